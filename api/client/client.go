@@ -1,12 +1,11 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
-	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -23,6 +22,8 @@ type Client struct {
 	accessToken string
 	userAgent   string
 	httpClient  HTTPClient
+	retryPolicy RetryPolicy
+	rateLimiter *rateLimiter
 
 	Sources      *sources
 	Destinations *destinations
@@ -34,9 +35,11 @@ type Client struct {
 const BASE_URL = "https://api.rudderstack.com"
 
 var (
-	ErrEmptyAccessToken  = fmt.Errorf("access token cannot be empty")
-	ErrInvalidBaseURL    = fmt.Errorf("base url cannot be empty")
-	ErrInvalidHTTPClient = fmt.Errorf("http client cannot be nil")
+	ErrEmptyAccessToken   = fmt.Errorf("access token cannot be empty")
+	ErrInvalidBaseURL     = fmt.Errorf("base url cannot be empty")
+	ErrInvalidHTTPClient  = fmt.Errorf("http client cannot be nil")
+	ErrInvalidRetryPolicy = fmt.Errorf("retry policy must allow at least one attempt")
+	ErrInvalidRateLimit   = fmt.Errorf("rate limit and burst must be positive")
 )
 
 func New(accessToken string, options ...Option) (*Client, error) {
@@ -45,6 +48,7 @@ func New(accessToken string, options ...Option) (*Client, error) {
 		httpClient:  &http.Client{},
 		accessToken: accessToken,
 		userAgent:   "rudder-api-go/1.0.0",
+		retryPolicy: DefaultRetryPolicy,
 	}
 
 	client.Sources = &sources{service: client.service("/v2/sources")}
@@ -103,32 +107,64 @@ func (c *Client) Do(ctx context.Context, method, path string, body io.Reader) ([
 }
 
 func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
-	attempts := 1
-	if isRetryableMethod(method) {
-		attempts = 3
+	// Buffer the body so that it can be replayed on every attempt.
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("reading request body: %w", err)
+		}
 	}
 
-	var err error
-	for attempt := range attempts {
-		req, reqErr := http.NewRequestWithContext(ctx, method, c.URL(path), body)
-		if reqErr != nil {
-			return nil, reqErr
+	attempts := max(c.retryPolicy.MaxAttempts, 1)
+	for attempt := 0; ; attempt++ {
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, c.URL(path), reqBody)
+		if err != nil {
+			return nil, err
 		}
 
 		req.Header.Add("Content-Type", "application/json")
 		req.Header.Add("User-Agent", c.userAgent)
 		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.accessToken))
 
-		var res *http.Response
-		res, err = c.httpClient.Do(req)
-		if err == nil {
+		res, err := c.httpClient.Do(req)
+		lastAttempt := attempt == attempts-1 || ctx.Err() != nil
+
+		var delay time.Duration
+		switch {
+		case err != nil:
+			if lastAttempt || !c.retryPolicy.retryableRequest(method) || !isRetryableTransportError(err) {
+				return nil, err
+			}
+			delay = c.retryPolicy.backoff(attempt)
+
+		case isRetryableStatus(res.StatusCode):
+			if lastAttempt || (res.StatusCode != http.StatusTooManyRequests && !c.retryPolicy.retryableRequest(method)) {
+				return res, nil
+			}
+			delay = c.retryPolicy.retryAfter(res.Header.Get("Retry-After"), time.Now())
+			if delay == 0 {
+				delay = c.retryPolicy.backoff(attempt)
+			}
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+
+		default:
 			return res, nil
 		}
-		if attempt == attempts-1 || ctx.Err() != nil || !isRetryableTransportError(err) {
-			return nil, err
-		}
 
-		timer := time.NewTimer(time.Duration(attempt+1) * 100 * time.Millisecond)
+		timer := time.NewTimer(delay)
 		select {
 		case <-ctx.Done():
 			timer.Stop()
@@ -136,37 +172,6 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 		case <-timer.C:
 		}
 	}
-
-	return nil, err
-}
-
-func isRetryableMethod(method string) bool {
-	switch strings.ToUpper(method) {
-	case http.MethodGet, http.MethodHead, http.MethodOptions:
-		return true
-	default:
-		return false
-	}
-}
-
-func isRetryableTransportError(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	var netErr net.Error
-	if errors.As(err, &netErr) && (netErr.Timeout() || netErr.Temporary()) {
-		return true
-	}
-
-	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
-		return true
-	}
-
-	msg := strings.ToLower(err.Error())
-	return strings.Contains(msg, "connection reset by peer") ||
-		strings.Contains(msg, "connection refused") ||
-		strings.Contains(msg, "unexpected eof")
 }
 
 func (c *Client) service(basePath string) *service {
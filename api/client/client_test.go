@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/rudderlabs/rudder-iac/api/client"
 	"github.com/rudderlabs/rudder-iac/api/internal/testutils"
@@ -87,3 +89,161 @@ func TestClientDoDoesNotRetryNonTransientSafeMethodErrors(t *testing.T) {
 	assert.Nil(t, data)
 	httpClient.AssertNumberOfCalls()
 }
+
+var fastRetries = client.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+func TestClientDoRetriesTransientStatuses(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{ResponseStatus: http.StatusServiceUnavailable},
+		testutils.Call{ResponseStatus: http.StatusBadGateway},
+		testutils.Call{ResponseStatus: http.StatusOK, ResponseBody: "ok"},
+	)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient), client.WithRetryPolicy(fastRetries))
+	require.NoError(t, err)
+
+	data, err := c.Do(context.Background(), http.MethodGet, "path", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(data))
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientDoReturnsAPIErrorWhenRetriesAreExhausted(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{ResponseStatus: http.StatusServiceUnavailable},
+		testutils.Call{ResponseStatus: http.StatusServiceUnavailable},
+		testutils.Call{ResponseStatus: http.StatusServiceUnavailable, ResponseBody: `{"message":"unavailable"}`},
+	)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient), client.WithRetryPolicy(fastRetries))
+	require.NoError(t, err)
+
+	_, err = c.Do(context.Background(), http.MethodGet, "path", nil)
+	var apiErr *client.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.HTTPStatusCode)
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientDoRetriesMutatingMethodsOnlyWhenEnabled(t *testing.T) {
+	validatePost := func(t *testing.T) func(req *http.Request) bool {
+		return func(req *http.Request) bool {
+			return testutils.ValidateRequest(t, req, "POST", "https://api.rudderstack.com/path", `{"name":"x"}`)
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		httpClient := testutils.NewMockHTTPClient(t,
+			testutils.Call{Validate: validatePost(t), ResponseStatus: http.StatusBadGateway},
+		)
+
+		c, err := client.New("some-access-token", client.WithHTTPClient(httpClient), client.WithRetryPolicy(fastRetries))
+		require.NoError(t, err)
+
+		_, err = c.Do(context.Background(), http.MethodPost, "path", strings.NewReader(`{"name":"x"}`))
+		var apiErr *client.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadGateway, apiErr.HTTPStatusCode)
+		httpClient.AssertNumberOfCalls()
+	})
+
+	t.Run("enabled replays the body", func(t *testing.T) {
+		httpClient := testutils.NewMockHTTPClient(t,
+			testutils.Call{Validate: validatePost(t), ResponseStatus: http.StatusBadGateway},
+			testutils.Call{Validate: validatePost(t), ResponseStatus: http.StatusOK, ResponseBody: "ok"},
+		)
+
+		policy := fastRetries
+		policy.RetryMutating = true
+		c, err := client.New("some-access-token", client.WithHTTPClient(httpClient), client.WithRetryPolicy(policy))
+		require.NoError(t, err)
+
+		data, err := c.Do(context.Background(), http.MethodPost, "path", strings.NewReader(`{"name":"x"}`))
+		require.NoError(t, err)
+		assert.Equal(t, "ok", string(data))
+		httpClient.AssertNumberOfCalls()
+	})
+}
+
+func TestClientDoRetriesRateLimitedMutatingMethods(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{ResponseStatus: http.StatusTooManyRequests, ResponseHeaders: http.Header{"Retry-After": []string{"0"}}},
+		testutils.Call{ResponseStatus: http.StatusCreated, ResponseBody: "ok"},
+	)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient), client.WithRetryPolicy(fastRetries))
+	require.NoError(t, err)
+
+	data, err := c.Do(context.Background(), http.MethodPost, "path", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(data))
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientDoHonoursRetryAfter(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{ResponseStatus: http.StatusTooManyRequests, ResponseHeaders: http.Header{"Retry-After": []string{"1"}}},
+		testutils.Call{ResponseStatus: http.StatusOK, ResponseBody: "ok"},
+	)
+
+	policy := fastRetries
+	policy.MaxDelay = 10 * time.Second
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient), client.WithRetryPolicy(policy))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// The backoff alone would be a few milliseconds; the header makes the
+	// client wait a full second, past the deadline.
+	_, err = c.Do(ctx, http.MethodGet, "path", nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClientDoCapsRetryAfterAtMaxDelay(t *testing.T) {
+	httpClient := testutils.NewMockHTTPClient(t,
+		testutils.Call{ResponseStatus: http.StatusTooManyRequests, ResponseHeaders: http.Header{"Retry-After": []string{"3600"}}},
+		testutils.Call{ResponseStatus: http.StatusOK, ResponseBody: "ok"},
+	)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient), client.WithRetryPolicy(fastRetries))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// An hour-long Retry-After is cut down to the policy's MaxDelay.
+	data, err := c.Do(ctx, http.MethodGet, "path", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(data))
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientRateLimit(t *testing.T) {
+	calls := make([]testutils.Call, 4)
+	for i := range calls {
+		calls[i] = testutils.Call{ResponseStatus: http.StatusOK}
+	}
+	httpClient := testutils.NewMockHTTPClient(t, calls...)
+
+	c, err := client.New("some-access-token", client.WithHTTPClient(httpClient), client.WithRateLimit(50, 1))
+	require.NoError(t, err)
+
+	start := time.Now()
+	for range calls {
+		_, err := c.Do(context.Background(), http.MethodGet, "path", nil)
+		require.NoError(t, err)
+	}
+
+	// The first request uses the burst; the other three wait 20ms each.
+	assert.GreaterOrEqual(t, time.Since(start), 55*time.Millisecond)
+	httpClient.AssertNumberOfCalls()
+}
+
+func TestClientRetryAndRateLimitOptionsValidation(t *testing.T) {
+	_, err := client.New("some-access-token", client.WithRetryPolicy(client.RetryPolicy{}))
+	assert.ErrorIs(t, err, client.ErrInvalidRetryPolicy)
+
+	_, err = client.New("some-access-token", client.WithRateLimit(0, 1))
+	assert.ErrorIs(t, err, client.ErrInvalidRateLimit)
+}
@@ -28,3 +28,27 @@ func WithUserAgent(userAgent string) Option {
 		return nil
 	}
 }
+
+// WithRetryPolicy overrides DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) error {
+		if policy.MaxAttempts < 1 {
+			return ErrInvalidRetryPolicy
+		}
+		c.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimit caps the requests the client sends to requestsPerSecond,
+// allowing bursts of up to burst requests. The limit is shared by every
+// request made through the client, including retries.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) error {
+		if requestsPerSecond <= 0 || burst < 1 {
+			return ErrInvalidRateLimit
+		}
+		c.rateLimiter = newRateLimiter(requestsPerSecond, burst)
+		return nil
+	}
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy configures how failed requests are retried. Transport errors
+// and 429, 502, 503 and 504 responses are retried with jittered exponential backoff,
+// waiting for the duration of a Retry-After header instead when the API
+// sends one, capped at MaxDelay.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request,
+	// including the first one. 1 disables retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry, doubled on every
+	// subsequent one.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff between attempts.
+	MaxDelay time.Duration
+	// RetryMutating extends retries to non-idempotent methods (POST, PATCH),
+	// which may then be applied more than once if a response is lost.
+	// Rate-limited (429) requests are retried regardless, since the API
+	// rejected them without processing them.
+	RetryMutating bool
+}
+
+// DefaultMaxAttempts is the number of attempts DefaultRetryPolicy makes for a
+// request, including the first one.
+const DefaultMaxAttempts = 3
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: DefaultMaxAttempts,
+	BaseDelay:   100 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// retryableRequest reports whether a request with the given method can be
+// retried after a transport error or a 502, 503 or 504 response.
+func (p RetryPolicy) retryableRequest(method string) bool {
+	return p.RetryMutating || isIdempotentMethod(method)
+}
+
+// backoff returns the delay before retry number attempt (starting at 0),
+// picked at random from the upper half of the exponential backoff so that
+// concurrent clients spread their retries out.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << attempt
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// retryAfter returns the delay a Retry-After header asks for, capped at
+// MaxDelay so that a server cannot stall the client indefinitely. It returns
+// 0 when the header is absent or invalid.
+func (p RetryPolicy) retryAfter(header string, now time.Time) time.Duration {
+	return min(retryAfter(header, now), p.MaxDelay)
+}
+
+func isIdempotentMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryableStatus reports whether a response status signals a transient
+// condition: rate limiting, or a gateway or upstream that is briefly
+// unavailable. Other 5xx responses are deterministic failures more often
+// than not, so they are returned straight away.
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter parses a Retry-After header, given either in seconds or as an
+// HTTP date. It returns 0 when the header is absent or invalid.
+func retryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(strings.TrimSpace(header)); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+func isRetryableTransportError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && (netErr.Timeout() || netErr.Temporary()) {
+		return true
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "connection reset by peer") ||
+		strings.Contains(msg, "connection refused") ||
+		strings.Contains(msg, "unexpected eof")
+}
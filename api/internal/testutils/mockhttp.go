@@ -13,10 +13,11 @@ import (
 
 type Call struct {
 	// Validate is an optional function that, if set, will validate an incoming request
-	Validate       func(req *http.Request) bool
-	ResponseStatus int
-	ResponseBody   string
-	ResponseError  error
+	Validate        func(req *http.Request) bool
+	ResponseStatus  int
+	ResponseBody    string
+	ResponseHeaders http.Header
+	ResponseError   error
 }
 
 type mockHTTPClient struct {
@@ -50,6 +51,7 @@ func (c *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
 
 	return &http.Response{
 		StatusCode: call.ResponseStatus,
+		Header:     call.ResponseHeaders,
 		Body:       io.NopCloser(strings.NewReader(call.ResponseBody)),
 	}, call.ResponseError
 }
@@ -166,6 +166,28 @@ func GenerateRuleCatalog(generatedAt string) (docs.DocumentedRules, []error, err
 	return ruledoc.Build(cp, GetVersion(), generatedAt)
 }
 
+// NewOfflineDeps builds the same dependencies as NewDeps without requiring
+// credentials, for commands that only work against the local project (e.g.
+// `validate --offline`). The client carries a placeholder token, so callers
+// must not issue API calls through it.
+func NewOfflineDeps() (Deps, error) {
+	c, err := newOfflineClient("offline")
+	if err != nil {
+		return nil, fmt.Errorf("setup client: %w", err)
+	}
+
+	cp, p, err := composeProviders(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deps{
+		client:            c,
+		providers:         p,
+		compositeProvider: cp,
+	}, nil
+}
+
 // newCompositeProvider builds the composite provider without requiring
 // credentials. It is used only by GenerateRuleCatalog: rule-doc generation
 // enumerates rules and reads authored fragments but makes no network calls, so
@@ -175,13 +197,7 @@ func GenerateRuleCatalog(generatedAt string) (docs.DocumentedRules, []error, err
 // composeProviders with NewDeps so the documented rule set stays identical to
 // the one project validation observes — they can't drift.
 func newCompositeProvider() (provider.Provider, error) {
-	cfg := config.GetConfig()
-
-	c, err := client.New(
-		"rule-doc-generation", // unused: generation makes no API calls
-		client.WithBaseURL(cfg.APIURL),
-		client.WithUserAgent("rudder-cli/"+v),
-	)
+	c, err := newOfflineClient("rule-doc-generation")
 	if err != nil {
 		return nil, fmt.Errorf("setup client: %w", err)
 	}
@@ -194,6 +210,17 @@ func newCompositeProvider() (provider.Provider, error) {
 	return cp, nil
 }
 
+// newOfflineClient returns a client authenticated with a placeholder token,
+// for callers that compose providers but never talk to the API.
+func newOfflineClient(placeholderToken string) (*client.Client, error) {
+	cfg := config.GetConfig()
+	return client.New(
+		placeholderToken, // unused: offline callers make no API calls
+		client.WithBaseURL(cfg.APIURL),
+		client.WithUserAgent("rudder-cli/"+v),
+	)
+}
+
 // composeProviders builds the provider set and aggregates it into a composite
 // provider. Shared by NewDeps and newCompositeProvider so every consumer
 // observes the same providers (and therefore the same registered rules).
@@ -213,11 +240,26 @@ func composeProviders(c *client.Client) (provider.Provider, *Providers, error) {
 
 func setupClient(version string) (*client.Client, error) {
 	cfg := config.GetConfig()
-	return client.New(
-		cfg.Auth.AccessToken,
+
+	options := []client.Option{
 		client.WithBaseURL(cfg.APIURL),
-		client.WithUserAgent("rudder-cli/"+version),
-	)
+		client.WithUserAgent("rudder-cli/" + version),
+	}
+
+	if cfg.API.MaxAttempts > 0 {
+		policy := client.DefaultRetryPolicy
+		policy.MaxAttempts = cfg.API.MaxAttempts
+		policy.RetryMutating = cfg.API.RetryMutating
+		options = append(options, client.WithRetryPolicy(policy))
+	}
+
+	// A single client backs every provider, so the limit applies to all of
+	// their requests combined.
+	if cfg.API.RateLimit > 0 {
+		options = append(options, client.WithRateLimit(cfg.API.RateLimit, max(cfg.API.RateLimitBurst, 1)))
+	}
+
+	return client.New(cfg.Auth.AccessToken, options...)
 }
 
 func setupProviders(c *client.Client) (*Providers, map[string]provider.Provider, error) {
@@ -397,6 +439,10 @@ func newDestinationRegistry(cfg config.Config) (*definitions.Registry, error) {
 }
 
 func SyncReporter() syncer.SyncReporter {
+	if config.GetConfig().Output == config.OutputJSON {
+		return &reporters.JSONSyncReporter{}
+	}
+
 	if ui.IsTerminal() {
 		return &reporters.ProgressSyncReporter{}
 	}
@@ -0,0 +1,22 @@
+// Package catalogloader loads a project's data catalog offline, for commands
+// that work on tracking plans without talking to the workspace.
+package catalogloader
+
+import (
+	"fmt"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/project"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog/localcatalog"
+)
+
+// Load loads and validates the data catalog specs at location offline. Specs
+// owned by other providers are skipped, since only the catalog is used.
+func Load(location string) (*localcatalog.DataCatalog, error) {
+	dcProvider := datacatalog.New(nil)
+	proj := project.New(dcProvider, project.WithIgnoreUnknownKinds())
+	if err := proj.Load(location); err != nil {
+		return nil, fmt.Errorf("loading and validating project: %w", err)
+	}
+	return dcProvider.GetLocalCatalog(), nil
+}
@@ -16,3 +16,20 @@ func (e *SilentError) Error() string {
 func (e *SilentError) Unwrap() error {
 	return e.Err
 }
+
+// ExitCodeError wraps an error that should terminate the process with a
+// specific exit code rather than the default of 1, for commands whose exit
+// status carries meaning to scripts (e.g. drift detection). Wrap a
+// SilentError in it to also suppress the error message.
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
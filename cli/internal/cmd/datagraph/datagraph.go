@@ -4,6 +4,7 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/spf13/cobra"
 
+	snapshotCmd "github.com/rudderlabs/rudder-iac/cli/internal/cmd/datagraph/snapshot"
 	validateCmd "github.com/rudderlabs/rudder-iac/cli/internal/cmd/datagraph/validate"
 )
 
@@ -16,10 +17,13 @@ func NewCmdDataGraph() *cobra.Command {
 			$ rudder-cli data-graphs validate --all
 			$ rudder-cli data-graphs validate --modified
 			$ rudder-cli data-graphs validate model my-model-id
+			$ rudder-cli data-graphs snapshot --file schema.json
+			$ rudder-cli data-graphs validate --all --snapshot schema.json
 		`),
 	}
 
 	cmd.AddCommand(validateCmd.NewCmdValidate())
+	cmd.AddCommand(snapshotCmd.NewCmdSnapshot())
 
 	return cmd
 }
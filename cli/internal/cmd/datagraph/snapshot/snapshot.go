@@ -0,0 +1,137 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/spf13/cobra"
+
+	client "github.com/rudderlabs/rudder-iac/api/client"
+	retlClient "github.com/rudderlabs/rudder-iac/api/client/retl"
+	"github.com/rudderlabs/rudder-iac/cli/internal/app"
+	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/telemetry"
+	"github.com/rudderlabs/rudder-iac/cli/internal/logger"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datagraph/validator"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+)
+
+var snapshotLog = logger.New("datagraph", logger.Attr{
+	Key:   "cmd",
+	Value: "snapshot",
+})
+
+// queryRowLimit caps the rows returned by each information_schema query,
+// one row per column of the schema's model tables.
+const queryRowLimit = 10000
+
+func NewCmdSnapshot() *cobra.Command {
+	var (
+		deps     app.Deps
+		p        project.Project
+		err      error
+		location string
+		file     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Capture the warehouse schema of data graph models",
+		Long: heredoc.Doc(`
+			Captures the columns of every data graph model table from the warehouse's
+			information_schema into a JSON snapshot file.
+
+			The snapshot can then be passed to 'data-graphs validate --snapshot' to validate
+			tables, primary_id, timestamp, column metadata and relationship join keys
+			without warehouse credentials, e.g. in CI. Model tables must be fully qualified
+			as catalog.schema.table.
+		`),
+		Example: heredoc.Doc(`
+			# Write the snapshot to a file
+			$ rudder-cli data-graphs snapshot --file schema.json
+
+			# Write the snapshot to stdout
+			$ rudder-cli data-graphs snapshot
+		`),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			deps, err = app.NewDeps()
+			if err != nil {
+				return fmt.Errorf("initialising dependencies: %w", err)
+			}
+
+			p = deps.NewProject()
+
+			if err := p.Load(location); err != nil {
+				return fmt.Errorf("loading and validating project: %w", err)
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defer func() {
+				telemetry.TrackCommand("data-graphs snapshot", err, []telemetry.KV{
+					{K: "location", V: location},
+				}...)
+			}()
+
+			snapshotLog.Debug("snapshot", "location", location, "file", file)
+
+			source := &warehouseSource{
+				client:  deps.Client(),
+				preview: retlClient.NewRudderRETLStore(deps.Client()),
+			}
+
+			var s *validator.Snapshot
+			s, err = validator.CaptureSnapshot(context.Background(), p, source)
+			if err != nil {
+				return fmt.Errorf("capturing snapshot: %w", err)
+			}
+
+			var data []byte
+			data, err = json.MarshalIndent(s, "", "  ")
+			if err != nil {
+				return fmt.Errorf("encoding snapshot: %w", err)
+			}
+			data = append(data, '\n')
+
+			if file == "" {
+				_, err = cmd.OutOrStdout().Write(data)
+				return err
+			}
+
+			if err = os.WriteFile(file, data, 0644); err != nil {
+				return fmt.Errorf("writing snapshot: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Captured %d columns to %s\n", len(s.Columns), file)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&location, "location", "l", ".", "Path to the directory containing the project files or a specific file")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Path of the snapshot file to write, stdout when not set")
+
+	return cmd
+}
+
+// warehouseSource captures schemas through the RETL preview API, which runs
+// queries against a warehouse account.
+type warehouseSource struct {
+	client  *client.Client
+	preview retlClient.PreviewStore
+}
+
+func (s *warehouseSource) WarehouseType(ctx context.Context, accountID string) (string, error) {
+	account, err := s.client.Accounts.Get(ctx, accountID)
+	if err != nil {
+		return "", err
+	}
+	return account.Definition.Type, nil
+}
+
+func (s *warehouseSource) Query(ctx context.Context, accountID, sql string) ([]map[string]any, error) {
+	return sqlmodel.RunPreview(ctx, s.preview, sql, accountID, queryRowLimit)
+}
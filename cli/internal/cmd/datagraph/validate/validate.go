@@ -31,6 +31,7 @@ func NewCmdValidate() *cobra.Command {
 		all        bool
 		modified   bool
 		jsonOutput bool
+		snapshot   string
 	)
 
 	cmd := &cobra.Command{
@@ -41,6 +42,10 @@ func NewCmdValidate() *cobra.Command {
 
 			Checks include table existence, column existence, type compatibility, and more.
 			You can validate all resources, only modified ones, or a specific resource by type and ID.
+
+			With --snapshot, resources are validated against a schema snapshot captured with
+			'data-graphs snapshot' or exported from information_schema.columns, without
+			workspace or warehouse credentials.
 		`),
 		Example: heredoc.Doc(`
 			# Validate all resources
@@ -57,18 +62,30 @@ func NewCmdValidate() *cobra.Command {
 
 			# Output as JSON
 			$ rudder-cli data-graphs validate --all --json
+
+			# Validate all resources against a schema snapshot
+			$ rudder-cli data-graphs validate --all --snapshot schema.json
 		`),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if err := validateFlags(args, all, modified); err != nil {
 				return err
 			}
 
-			deps, err = app.NewDeps()
+			var projectOpts []project.ProjectOption
+			if snapshot != "" {
+				if modified {
+					return fmt.Errorf("--modified compares against the workspace and cannot be combined with --snapshot")
+				}
+				deps, err = app.NewOfflineDeps()
+				projectOpts = append(projectOpts, project.WithOffline())
+			} else {
+				deps, err = app.NewDeps()
+			}
 			if err != nil {
 				return fmt.Errorf("initialising dependencies: %w", err)
 			}
 
-			p = deps.NewProject()
+			p = deps.NewProject(projectOpts...)
 
 			if err := p.Load(location); err != nil {
 				return fmt.Errorf("loading and validating project: %w", err)
@@ -83,17 +100,32 @@ func NewCmdValidate() *cobra.Command {
 					{K: "all", V: all},
 					{K: "modified", V: modified},
 					{K: "json", V: jsonOutput},
+					{K: "snapshot", V: snapshot != ""},
 				}...)
 			}()
 
-			validateLog.Debug("validate", "location", location, "all", all, "modified", modified, "json", jsonOutput)
+			validateLog.Debug("validate", "location", location, "all", all, "modified", modified, "json", jsonOutput, "snapshot", snapshot)
 
 			ctx := context.Background()
 
-			var workspace *client.Workspace
-			workspace, err = deps.Client().Workspaces.GetByAuthToken(ctx)
-			if err != nil {
-				return fmt.Errorf("fetching workspace information: %w", err)
+			// A snapshot replaces the warehouse, and the workspace is only
+			// needed to diff modified resources, which --snapshot rules out.
+			var (
+				workspaceID    string
+				schemaSnapshot *validator.Snapshot
+			)
+			if snapshot != "" {
+				schemaSnapshot, err = validator.LoadSnapshot(snapshot)
+				if err != nil {
+					return fmt.Errorf("loading snapshot: %w", err)
+				}
+			} else {
+				var workspace *client.Workspace
+				workspace, err = deps.Client().Workspaces.GetByAuthToken(ctx)
+				if err != nil {
+					return fmt.Errorf("fetching workspace information: %w", err)
+				}
+				workspaceID = workspace.ID
 			}
 
 			var mode validator.Mode
@@ -118,11 +150,12 @@ func NewCmdValidate() *cobra.Command {
 
 			err = validator.Validate(ctx, p, deps.Providers().DataGraph, validator.Config{
 				Mode:        mode,
-				WorkspaceID: workspace.ID,
+				WorkspaceID: workspaceID,
 				JSONOutput:  jsonOutput,
 				Writer:      w,
 				DisplayFunc: displayFunc,
 				Concurrency: config.GetConfig().Concurrency.DataGraph,
+				Snapshot:    schemaSnapshot,
 			})
 			if err != nil {
 				if jsonOutput && errors.Is(err, validator.ErrValidationFailed) {
@@ -139,6 +172,7 @@ func NewCmdValidate() *cobra.Command {
 	cmd.Flags().BoolVar(&all, "all", false, "Validate all data graph resources in the project")
 	cmd.Flags().BoolVar(&modified, "modified", false, "Validate only new or modified data graph resources")
 	cmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output results as JSON")
+	cmd.Flags().StringVar(&snapshot, "snapshot", "", "Validate against a schema snapshot file instead of the warehouse")
 
 	return cmd
 }
@@ -19,6 +19,19 @@ func TestNewCmdValidatePreRunValidatesModeBeforeDependencies(t *testing.T) {
 	assert.NotContains(t, err.Error(), "experimental flag")
 }
 
+func TestNewCmdValidatePreRunRejectsSnapshotWithModified(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewCmdValidate()
+	require.NoError(t, cmd.Flags().Set("modified", "true"))
+	require.NoError(t, cmd.Flags().Set("snapshot", "schema.json"))
+
+	err := cmd.PreRunE(cmd, []string{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be combined with --snapshot")
+}
+
 func TestValidateFlags(t *testing.T) {
 	t.Parallel()
 
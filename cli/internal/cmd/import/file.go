@@ -0,0 +1,136 @@
+package importcmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/catalogloader"
+	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/telemetry"
+	"github.com/rudderlabs/rudder-iac/cli/internal/logger"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/formatter"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/importer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/writer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog/importfile"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog/localcatalog"
+	"github.com/rudderlabs/rudder-iac/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fileImportLog = logger.New("import", logger.Attr{
+		Key:   "cmd",
+		Value: "file",
+	})
+)
+
+func NewCmdFileImport() *cobra.Command {
+	var (
+		format           string
+		location         string
+		trackingPlanName string
+		err              error
+	)
+
+	cmd := &cobra.Command{
+		Use:   "file <path>",
+		Short: "Import a tracking plan from a Segment Protocols or JSON Schema file",
+		Long: heredoc.Doc(`
+			Converts a tracking plan exported from another tool into data catalog specs:
+			properties, events, custom types, categories and a tracking plan with a rule
+			per event.
+
+			Supported formats:
+			  segment-protocols  a tracking plan exported from the Segment Config API or
+			                     Public API, as a single JSON file
+			  jsonschema         a JSON Schema file, or a directory with one schema per
+			                     event, such as Avo's JSON Schema export
+
+			Shared definitions referenced through $ref become custom types. Properties,
+			events, custom types and categories already defined in the project are
+			reused, and the ids of new ones never clash with existing ids.
+
+			The specs are written to imported/<tracking-plan-id> under --location and
+			validated along with the rest of the project. Parts of the file that have no
+			equivalent in the data catalog, such as conditional schemas, are reported as
+			warnings.
+
+			No authentication or network access is needed.
+		`),
+		Example: heredoc.Doc(`
+			$ rudder-cli import file --format segment-protocols ./protocols-export.json
+			$ rudder-cli import file --format jsonschema ./avo-schemas --location ./catalog
+			$ rudder-cli import file --format jsonschema ./checkout.schema.json --tracking-plan-name Checkout
+		`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			fileImportLog.Debug("import file", "format", format, "path", path, "location", location)
+
+			defer func() {
+				telemetry.TrackCommand("import file", err, []telemetry.KV{
+					{K: "format", V: format},
+				}...)
+			}()
+
+			var (
+				existing *localcatalog.DataCatalog
+				plan     *importfile.Plan
+				result   *importfile.Result
+			)
+
+			existing, err = catalogloader.Load(location)
+			if err != nil {
+				return fmt.Errorf("loading project: %w", err)
+			}
+
+			plan, err = importfile.Read(format, path)
+			if err != nil {
+				return fmt.Errorf("reading %s: %w", path, err)
+			}
+			if trackingPlanName != "" {
+				plan.Name = trackingPlanName
+			}
+
+			result, err = importfile.Convert(plan, existing)
+			if err != nil {
+				return fmt.Errorf("converting %s: %w", path, err)
+			}
+
+			importDir := filepath.Join(location, importer.ImportedDir, result.TrackingPlanID)
+			if _, err = os.Stat(importDir); err == nil {
+				err = fmt.Errorf("directory for import: %s already exists", importDir)
+				return err
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+
+			formatters := formatter.Setup(formatter.DefaultYAML)
+			if err = writer.Write(cmd.Context(), importDir, formatters, result.Entities); err != nil {
+				return fmt.Errorf("writing specs: %w", err)
+			}
+
+			for _, warning := range result.Warnings {
+				ui.PrintWarning(warning)
+			}
+
+			if _, err = catalogloader.Load(location); err != nil {
+				return fmt.Errorf("validating imported specs in %s, fix them before applying: %w", importDir, err)
+			}
+
+			ui.PrintSuccess(fmt.Sprintf("Imported tracking plan '%s' with %d specs", result.TrackingPlanID, len(result.Entities)))
+			fmt.Printf("Specs saved to: %s\n", importDir)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "", fmt.Sprintf("Format of the file to import, one of: %s", strings.Join(importfile.Formats, ", ")))
+	cmd.Flags().StringVarP(&location, "location", "l", ".", "Path to the directory containing the project files")
+	cmd.Flags().StringVar(&trackingPlanName, "tracking-plan-name", "", "Display name of the imported tracking plan (defaults to the name in the file)")
+	_ = cmd.MarkFlagRequired("format")
+
+	return cmd
+}
@@ -11,6 +11,7 @@ func NewCmdImport() *cobra.Command {
 		Long:  "Import remote resources from various providers into local YAML configuration files",
 	}
 
+	cmd.AddCommand(NewCmdFileImport())
 	cmd.AddCommand(NewCmdRetlSource())
 	cmd.AddCommand(NewCmdWorkspaceImport())
 
@@ -0,0 +1,87 @@
+package lsp
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rudderlabs/rudder-iac/cli/internal/app"
+	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/telemetry"
+	"github.com/rudderlabs/rudder-iac/cli/internal/config"
+	"github.com/rudderlabs/rudder-iac/cli/internal/lsp"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project"
+	"github.com/rudderlabs/rudder-iac/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdLSP() *cobra.Command {
+	var (
+		deps     app.Deps
+		err      error
+		varFiles []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "Run a language server for project spec files",
+		Long: heredoc.Doc(`
+			Runs a Language Server Protocol server over stdio for editors to use
+			with rudder spec YAML files.
+
+			The server validates the workspace with the same syntactic and semantic
+			rules as the validate command on every change, including unsaved editor
+			buffers, and publishes the results as diagnostics. It offers completion
+			for the kind and version fields and for #kind:id references, and
+			go-to-definition for references to resources in the project.
+
+			Validation runs offline: no access token is needed, and workspace-scoped
+			rules are skipped.
+		`),
+		Example: heredoc.Doc(`
+			$ rudder-cli lsp
+		`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defer func() {
+				telemetry.TrackCommand("lsp", err)
+			}()
+
+			// Only the patterns are needed up front; each validation run composes
+			// fresh providers since they accumulate loaded specs.
+			deps, err = app.NewOfflineDeps()
+			if err != nil {
+				return fmt.Errorf("initialising dependencies: %w", err)
+			}
+
+			var projectOpts []project.ProjectOption
+			projectOpts, err = app.NewProjectOptions(config.GetConfig(), varFiles)
+			if err != nil {
+				return err
+			}
+
+			// stdout carries the protocol, so anything printed through the ui
+			// package while loading specs must go elsewhere.
+			ui.SetWriter(os.Stderr)
+			defer ui.RestoreWriter()
+
+			server := lsp.NewServer(lsp.Config{
+				NewProject: func(opts ...project.ProjectOption) (project.Project, error) {
+					deps, err := app.NewOfflineDeps()
+					if err != nil {
+						return nil, err
+					}
+					opts = append(append([]project.ProjectOption{project.WithOffline()}, projectOpts...), opts...)
+					return deps.NewProject(opts...), nil
+				},
+				MatchPatterns: deps.CompositeProvider().SupportedMatchPatterns(),
+				Version:       app.GetVersion(),
+			})
+
+			err = server.Serve(os.Stdin, os.Stdout)
+			return err
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&varFiles, "var-file", nil, "Path to a variable file ending in .vars.yaml or .vars.yml (repeatable; later files take priority)")
+
+	return cmd
+}
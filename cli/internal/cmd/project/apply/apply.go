@@ -12,6 +12,7 @@ import (
 	"github.com/rudderlabs/rudder-iac/cli/internal/logger"
 	"github.com/rudderlabs/rudder-iac/cli/internal/project"
 	"github.com/rudderlabs/rudder-iac/cli/internal/syncer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/planner"
 	"github.com/rudderlabs/rudder-iac/cli/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -33,22 +34,62 @@ func NewCmdApply() *cobra.Command {
 		dryRun    bool
 		confirm   bool
 		varFiles  []string
+		planOut   string
+		savedPlan *planner.SavedPlan
+		targets   []string
 	)
 
 	cmd := &cobra.Command{
-		Use:   "apply",
+		Use:   "apply [plan-file]",
 		Short: "Apply project configuration changes",
 		Long: heredoc.Doc(`
 			Applies the project configuration changes to the RudderStack workspace associated with your access token.
 			This includes creating, updating, or deleting resources based on
 			the differences between local configuration and the workspace resources.
+
+			A plan computed with --dry-run can be saved with --out and applied later by
+			passing the plan file as an argument. Applying a saved plan fails if the
+			workspace resources or the local configuration have changed since the plan
+			was created.
+
+			Use --target to restrict the plan to specific resources, e.g. for an emergency fix.
+			Each target is a URN or a glob over URNs (kind:id), and pulls in the resources it
+			depends on. The rest of the project is ignored.
+
+			Resources declared in specs with metadata.lifecycle.prevent_destroy set, and
+			resources matching the prevent_destroy URNs or globs of the project's lifecycle
+			specs, are never deleted or replaced: a plan that would do so fails instead.
+			List resources in a lifecycle spec to keep them protected after their spec is
+			removed.
 		`),
 		Example: heredoc.Doc(`
 			$ rudder-cli apply --location </path/to/dir or file>
 			$ rudder-cli apply --location </path/to/dir or file> --dry-run
 			$ rudder-cli apply --location </path/to/dir or file> --confirm=false
+			$ rudder-cli apply --location </path/to/dir or file> --dry-run --out plan.json
+			$ rudder-cli apply plan.json --location </path/to/dir or file>
+			$ rudder-cli apply --location </path/to/dir or file> --target tracking-plan:checkout
+			$ rudder-cli apply --location </path/to/dir or file> --target 'destination:*'
 		`),
+		Args: cobra.MaximumNArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if planOut != "" && !dryRun {
+				return fmt.Errorf("--out can only be used together with --dry-run")
+			}
+
+			for _, target := range targets {
+				if err := planner.ValidateTargetPattern(target); err != nil {
+					return err
+				}
+			}
+
+			if len(args) == 1 {
+				savedPlan, err = planner.ReadSavedPlan(args[0])
+				if err != nil {
+					return fmt.Errorf("loading saved plan: %w", err)
+				}
+			}
+
 			deps, err = app.NewDeps()
 			if err != nil {
 				return fmt.Errorf("initialising dependencies: %w", err)
@@ -79,7 +120,7 @@ func NewCmdApply() *cobra.Command {
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			applyLog.Debug("apply", "location", location, "dryRun", dryRun, "confirm", confirm)
+			applyLog.Debug("apply", "location", location, "dryRun", dryRun, "confirm", confirm, "savedPlan", savedPlan != nil)
 			applyLog.Debug("identifying changes for the upstream catalog")
 
 			defer func() {
@@ -87,6 +128,8 @@ func NewCmdApply() *cobra.Command {
 					{K: "location", V: location},
 					{K: "dryRun", V: dryRun},
 					{K: "confirm", V: confirm},
+					{K: "savedPlan", V: savedPlan != nil},
+					{K: "targeted", V: len(targets) > 0},
 				}...)
 			}()
 
@@ -96,12 +139,33 @@ func NewCmdApply() *cobra.Command {
 				return fmt.Errorf("getting resource graph: %w", err)
 			}
 
+			protectedURNs, err := p.ProtectedURNs()
+			if err != nil {
+				return fmt.Errorf("reading lifecycle settings: %w", err)
+			}
+
 			options := []syncer.Option{
+				syncer.WithProtection(planner.Protection{
+					URNs:     protectedURNs,
+					Patterns: p.PreventDestroy(),
+				}),
 				syncer.WithDryRun(dryRun),
 				syncer.WithAskConfirmation(confirm),
 				syncer.WithReporter(app.SyncReporter()),
 			}
 
+			if planOut != "" {
+				options = append(options, syncer.WithPlanOutput(planOut))
+			}
+
+			if len(targets) > 0 {
+				options = append(options, syncer.WithTargets(planner.Targets{Patterns: targets}))
+			}
+
+			if savedPlan != nil {
+				options = append(options, syncer.WithSavedPlan(savedPlan))
+			}
+
 			if config.GetConfig().ExperimentalFlags.ConcurrentSyncs {
 				options = append(options, syncer.WithConcurrency(config.GetConfig().Concurrency.Syncer))
 			}
@@ -131,6 +195,8 @@ func NewCmdApply() *cobra.Command {
 	cmd.Flags().StringVarP(&location, "location", "l", ".", "Path to the directory containing the project files or a specific file")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only show the changes without applying them")
 	cmd.Flags().BoolVar(&confirm, "confirm", true, "Confirm changes before applying them")
+	cmd.Flags().StringVar(&planOut, "out", "", "Path to write the computed plan to, for applying later (requires --dry-run)")
+	cmd.Flags().StringArrayVar(&targets, "target", nil, "Only apply changes to resources matching this URN or kind:id glob, plus their dependencies (repeatable)")
 	cmd.Flags().StringArrayVar(&varFiles, "var-file", nil, "Path to a variable file ending in .vars.yaml or .vars.yml (repeatable; later files take priority)")
 
 	return cmd
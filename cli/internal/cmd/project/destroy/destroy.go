@@ -11,6 +11,7 @@ import (
 	"github.com/rudderlabs/rudder-iac/cli/internal/config"
 	"github.com/rudderlabs/rudder-iac/cli/internal/logger"
 	"github.com/rudderlabs/rudder-iac/cli/internal/syncer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/planner"
 	"github.com/spf13/cobra"
 )
 
@@ -23,10 +24,12 @@ var (
 
 func NewCmdDestroy() *cobra.Command {
 	var (
-		deps    app.Deps
-		err     error
-		dryRun  bool
-		confirm bool
+		deps     app.Deps
+		err      error
+		dryRun   bool
+		confirm  bool
+		targets  []string
+		location string
 	)
 
 	cmd := &cobra.Command{
@@ -37,13 +40,29 @@ func NewCmdDestroy() *cobra.Command {
 			This operation is destructive and will remove ALL resources managed
 			by the CLI, regardless of any configuration files.
 			Use with extreme caution.
+
+			Use --target to only delete specific resources. Each target is a URN or a glob
+			over URNs (kind:id), and pulls in the resources that depend on it, since they
+			can't outlive it. All other resources are left untouched.
+
+			Pass --location to protect the resources of a project from deletion: those
+			declared in its specs with metadata.lifecycle.prevent_destroy set, and those
+			matching the prevent_destroy URNs or globs of its lifecycle specs.
 		`),
 		Example: heredoc.Doc(`
 			$ rudder-cli destroy
 			$ rudder-cli destroy --dry-run
 			$ rudder-cli destroy --confirm=false
+			$ rudder-cli destroy --target 'event:legacy_*'
+			$ rudder-cli destroy --location </path/to/dir or file>
 		`),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			for _, target := range targets {
+				if err := planner.ValidateTargetPattern(target); err != nil {
+					return err
+				}
+			}
+
 			deps, err = app.NewDeps()
 			if err != nil {
 				return fmt.Errorf("initialising dependencies: %w", err)
@@ -59,15 +78,29 @@ func NewCmdDestroy() *cobra.Command {
 				telemetry.TrackCommand("destroy", err, []telemetry.KV{
 					{K: "dryRun", V: dryRun},
 					{K: "confirm", V: confirm},
+					{K: "targeted", V: len(targets) > 0},
 				}...)
 			}()
 
+			var protection planner.Protection
+			if location != "" {
+				protection, err = projectProtection(deps, location)
+				if err != nil {
+					return err
+				}
+			}
+
 			options := []syncer.Option{
+				syncer.WithProtection(protection),
 				syncer.WithDryRun(dryRun),
 				syncer.WithAskConfirmation(confirm),
 				syncer.WithReporter(app.SyncReporter()),
 			}
 
+			if len(targets) > 0 {
+				options = append(options, syncer.WithTargets(planner.Targets{Patterns: targets, Dependents: true}))
+			}
+
 			if config.GetConfig().ExperimentalFlags.ConcurrentSyncs {
 				options = append(options, syncer.WithConcurrency(config.GetConfig().Concurrency.Syncer))
 			}
@@ -95,6 +128,28 @@ func NewCmdDestroy() *cobra.Command {
 
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only show the resources that would be destroyed without actually destroying them")
 	cmd.Flags().BoolVar(&confirm, "confirm", true, "Confirm before destroying resources")
+	cmd.Flags().StringVarP(&location, "location", "l", "", "Path to the project whose lifecycle settings protect resources from deletion")
+	cmd.Flags().StringArrayVar(&targets, "target", nil, "Only destroy resources matching this URN or kind:id glob, plus the resources depending on them (repeatable)")
 
 	return cmd
 }
+
+// projectProtection loads the project at location for the resources its
+// specs and lifecycle specs protect.
+func projectProtection(deps app.Deps, location string) (planner.Protection, error) {
+	projectOpts, err := app.NewProjectOptions(config.GetConfig(), nil)
+	if err != nil {
+		return planner.Protection{}, err
+	}
+
+	p := deps.NewProject(projectOpts...)
+	if err := p.Load(location); err != nil {
+		return planner.Protection{}, fmt.Errorf("loading and validating project: %w", err)
+	}
+
+	urns, err := p.ProtectedURNs()
+	if err != nil {
+		return planner.Protection{}, fmt.Errorf("reading lifecycle settings: %w", err)
+	}
+	return planner.Protection{URNs: urns, Patterns: p.PreventDestroy()}, nil
+}
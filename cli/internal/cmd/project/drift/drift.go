@@ -0,0 +1,145 @@
+package drift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rudderlabs/rudder-iac/api/client"
+	"github.com/rudderlabs/rudder-iac/cli/internal/app"
+	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/cmderrors"
+	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/telemetry"
+	"github.com/rudderlabs/rudder-iac/cli/internal/config"
+	"github.com/rudderlabs/rudder-iac/cli/internal/logger"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/differ"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/drift"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/reporters"
+	"github.com/rudderlabs/rudder-iac/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// ExitCodeDrifted is the exit code when drift is detected, distinguishing it
+// from a clean workspace (0) and from a failure to determine drift (1).
+const ExitCodeDrifted = 2
+
+var (
+	driftLog = logger.New("root", logger.Attr{
+		Key:   "cmd",
+		Value: "drift",
+	})
+
+	// ErrDriftDetected is returned when the workspace diverges from the project.
+	ErrDriftDetected = errors.New("workspace resources have drifted from the project")
+)
+
+func NewCmdDrift() *cobra.Command {
+	var (
+		deps      app.Deps
+		p         project.Project
+		workspace *client.Workspace
+		err       error
+		location  string
+		varFiles  []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "drift",
+		Short: "Detect drift between the workspace and the project configuration",
+		Long: heredoc.Doc(`
+			Compares the resources in the RudderStack workspace associated with your access token
+			against the local project configuration, and reports the resources whose remote
+			configuration no longer matches the project. Nothing is changed in the workspace.
+			Only the project and the workspace are compared, so local changes that were not
+			applied yet are reported as well.
+
+			Resources that differ only in secret values are not reported, since secrets
+			can't be read back from the workspace.
+
+			Exit codes:
+			  0  no drift
+			  1  drift could not be determined (e.g. invalid project or API error)
+			  2  drift detected
+		`),
+		Example: heredoc.Doc(`
+			$ rudder-cli drift --location </path/to/dir or file>
+			$ rudder-cli drift --location </path/to/dir or file> --output-format json
+		`),
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			deps, err = app.NewDeps()
+			if err != nil {
+				return fmt.Errorf("initialising dependencies: %w", err)
+			}
+
+			workspace, err = deps.Client().Workspaces.GetByAuthToken(context.Background())
+			if err != nil {
+				return fmt.Errorf("fetching workspace information: %w", err)
+			}
+
+			projectOpts, err := app.NewProjectOptions(config.GetConfig(), varFiles)
+			if err != nil {
+				return err
+			}
+			projectOpts = append(projectOpts, project.WithWorkspaceID(workspace.ID))
+
+			p = deps.NewProject(projectOpts...)
+
+			if err := p.Load(location); err != nil {
+				return fmt.Errorf("loading and validating project: %w", err)
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			driftLog.Debug("drift", "location", location)
+
+			var drifted bool
+			defer func() {
+				telemetry.TrackCommand("drift", err, []telemetry.KV{
+					{K: "location", V: location},
+					{K: "drifted", V: drifted},
+				}...)
+			}()
+
+			var (
+				graph *resources.Graph
+				diff  *differ.Diff
+			)
+			graph, err = p.ResourceGraph()
+			if err != nil {
+				return fmt.Errorf("getting resource graph: %w", err)
+			}
+
+			diff, err = drift.Detect(context.Background(), deps.CompositeProvider(), graph, workspace.ID)
+			if err != nil {
+				return fmt.Errorf("detecting drift: %w", err)
+			}
+			drifted = diff.HasNonSecretDiff()
+
+			if config.GetConfig().Output == config.OutputJSON {
+				reporters.WriteJSONDrift(os.Stdout, diff)
+			} else if drifted {
+				reporters.WriteDrift(os.Stdout, diff)
+			} else {
+				ui.PrintSuccess("No drift detected")
+			}
+
+			if drifted {
+				return &cmderrors.ExitCodeError{
+					Code: ExitCodeDrifted,
+					Err:  &cmderrors.SilentError{Err: ErrDriftDetected},
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&location, "location", "l", ".", "Path to the directory containing the project files or a specific file")
+	cmd.Flags().StringArrayVar(&varFiles, "var-file", nil, "Path to a variable file ending in .vars.yaml or .vars.yml (repeatable; later files take priority)")
+
+	return cmd
+}
@@ -3,15 +3,17 @@ package validate
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc/v2"
-	"github.com/rudderlabs/rudder-iac/api/client"
 	"github.com/rudderlabs/rudder-iac/cli/internal/app"
 	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/telemetry"
 	"github.com/rudderlabs/rudder-iac/cli/internal/config"
 	"github.com/rudderlabs/rudder-iac/cli/internal/logger"
 	"github.com/rudderlabs/rudder-iac/cli/internal/project"
 	"github.com/rudderlabs/rudder-iac/cli/internal/ui"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/renderer"
 	"github.com/spf13/cobra"
 )
 
@@ -22,14 +24,24 @@ var (
 	})
 )
 
+// Supported values for the --format flag.
+const (
+	formatText  = "text"
+	formatJSON  = "json"
+	formatSARIF = "sarif"
+)
+
 func NewCmdValidate() *cobra.Command {
 	var (
-		deps      app.Deps
-		p         project.Project
-		workspace *client.Workspace
-		err       error
-		location  string
-		varFiles  []string
+		deps        app.Deps
+		p           project.Project
+		err         error
+		location    string
+		varFiles    []string
+		offline     bool
+		workspaceID string
+		format      string
+		r           renderer.Renderer
 	)
 
 	cmd := &cobra.Command{
@@ -39,29 +51,60 @@ func NewCmdValidate() *cobra.Command {
 			Validates the project configuration files for correctness and consistency.
 			This includes checking for valid syntax, required fields, and relationships
 			between resources.
+
+			With --offline, validation runs without an access token or network access.
+			Rules that depend on the targeted workspace (e.g. import-manifest
+			orphaned-urn) are skipped and listed, unless a workspace is given with
+			--workspace-id.
+
+			With --format json or --format sarif, diagnostics are written to stdout in
+			that format and all other messages go to stderr. SARIF output can be
+			uploaded to GitHub code scanning to annotate pull requests.
 		`),
 		Example: heredoc.Doc(`
 			$ rudder-cli validate --location </path/to/dir or file>
+			$ rudder-cli validate --location </path/to/dir or file> --offline
+			$ rudder-cli validate --location </path/to/dir or file> --offline --workspace-id <workspace-id>
+			$ rudder-cli validate --location </path/to/dir or file> --format sarif > results.sarif
 		`),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			deps, err = app.NewDeps()
+			if workspaceID != "" && !offline {
+				return fmt.Errorf("--workspace-id can only be used with --offline")
+			}
+
+			if offline {
+				deps, err = app.NewOfflineDeps()
+			} else {
+				deps, err = app.NewDeps()
+			}
 			if err != nil {
 				return fmt.Errorf("initialising dependencies: %w", err)
 			}
 
-			// Resolve the active workspace so validation scopes workspace-aware
-			// rules (e.g. import-manifest orphaned-urn) to the same workspace apply
-			// targets.
-			workspace, err = deps.Client().Workspaces.GetByAuthToken(context.Background())
+			r, err = newRenderer(format)
 			if err != nil {
-				return fmt.Errorf("fetching workspace information: %w", err)
+				return err
 			}
 
 			projectOpts, err := app.NewProjectOptions(config.GetConfig(), varFiles)
 			if err != nil {
 				return err
 			}
-			projectOpts = append(projectOpts, project.WithWorkspaceID(workspace.ID))
+			projectOpts = append(projectOpts, project.WithRenderer(r))
+
+			if offline {
+				projectOpts = append(projectOpts, project.WithOffline())
+			} else {
+				// Resolve the active workspace so validation scopes workspace-aware
+				// rules (e.g. import-manifest orphaned-urn) to the same workspace apply
+				// targets.
+				workspace, err := deps.Client().Workspaces.GetByAuthToken(context.Background())
+				if err != nil {
+					return fmt.Errorf("fetching workspace information: %w", err)
+				}
+				workspaceID = workspace.ID
+			}
+			projectOpts = append(projectOpts, project.WithWorkspaceID(workspaceID))
 
 			p = deps.NewProject(projectOpts...)
 			return nil
@@ -72,11 +115,25 @@ func NewCmdValidate() *cobra.Command {
 			defer func() {
 				telemetry.TrackCommand("validate", err, []telemetry.KV{
 					{K: "location", V: location},
+					{K: "offline", V: offline},
+					{K: "format", V: format},
 				}...)
 			}()
 
+			// Diagnostics own stdout in machine-readable formats, so the
+			// human-oriented messages move to stderr.
+			if format != formatText {
+				ui.SetWriter(cmd.ErrOrStderr())
+				defer ui.RestoreWriter()
+			}
+
 			// Load and validate the project (validation engine + RuleProvider rules)
-			if err := p.Load(location); err != nil {
+			err = p.Load(location)
+			if skipped := p.SkippedRules(); len(skipped) > 0 {
+				ui.PrintInfo(fmt.Sprintf("Skipped workspace-scoped rules (no workspace in offline mode): %s", strings.Join(skipped, ", ")))
+			}
+			if err != nil {
+				renderLoadError(r, err)
 				return fmt.Errorf("validating project: %w", err)
 			}
 
@@ -92,5 +149,43 @@ func NewCmdValidate() *cobra.Command {
 
 	cmd.Flags().StringVarP(&location, "location", "l", ".", "Path to the directory containing the project files or a specific file")
 	cmd.Flags().StringArrayVar(&varFiles, "var-file", nil, "Path to a variable file ending in .vars.yaml or .vars.yml (repeatable; later files take priority)")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Validate without an access token or network access, skipping workspace-scoped rules")
+	cmd.Flags().StringVar(&workspaceID, "workspace-id", "", "Workspace to scope workspace-scoped rules to in offline mode")
+	cmd.Flags().StringVar(&format, "format", formatText, "Diagnostics output format: text, json or sarif")
 	return cmd
 }
+
+// renderLoadError writes err as the output document of machine-readable
+// formats when loading stopped before any diagnostics were rendered, so that
+// consumers always receive a document they can parse.
+func renderLoadError(r renderer.Renderer, err error) {
+	er, ok := r.(renderer.ErrorRenderer)
+	if !ok || er.Rendered() {
+		return
+	}
+	if renderErr := er.RenderError(err); renderErr != nil {
+		validateLog.Error("rendering load error", "error", renderErr)
+	}
+}
+
+// newRenderer returns the diagnostics renderer for format. SARIF output
+// describes each reported rule using the documented rule catalog, which is
+// assembled from the same providers validation runs.
+func newRenderer(format string) (renderer.Renderer, error) {
+	switch format {
+	case formatText:
+		return renderer.NewTextRenderer(os.Stdout), nil
+	case formatJSON:
+		return renderer.NewJSONRenderer(os.Stdout), nil
+	case formatSARIF:
+		// Catalog validation errors (e.g. a rule without authored docs) only
+		// mean less help text, so they don't block validation.
+		catalog, _, err := app.GenerateRuleCatalog("")
+		if err != nil {
+			return nil, fmt.Errorf("building rule catalog: %w", err)
+		}
+		return renderer.NewSARIFRenderer(os.Stdout, app.GetVersion(), catalog.Rules), nil
+	default:
+		return nil, fmt.Errorf("invalid format %q, must be one of: %s, %s, %s", format, formatText, formatJSON, formatSARIF)
+	}
+}
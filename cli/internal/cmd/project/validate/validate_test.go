@@ -0,0 +1,48 @@
+package validate
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/renderer"
+)
+
+func TestRenderLoadError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes a document when nothing was rendered", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		renderLoadError(renderer.NewJSONRenderer(&buf), errors.New("failed to load specs"))
+
+		assert.JSONEq(t, `{"diagnostics": [], "summary": {"errors": 0, "warnings": 0}, "error": "failed to load specs"}`, buf.String())
+	})
+
+	t.Run("keeps rendered diagnostics as the only document", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		r := renderer.NewJSONRenderer(&buf)
+		require.NoError(t, r.Render(validation.Diagnostics{}))
+		buf.Reset()
+
+		renderLoadError(r, errors.New("syntax validation failed"))
+
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("ignores text output", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		renderLoadError(renderer.NewTextRenderer(&buf), errors.New("failed to load specs"))
+
+		assert.Empty(t, buf.String())
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/rudderlabs/rudder-iac/cli/internal/app"
 	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/telemetry"
+	"github.com/rudderlabs/rudder-iac/cli/internal/config"
 	"github.com/rudderlabs/rudder-iac/cli/internal/previewer"
 	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
 	"github.com/spf13/cobra"
@@ -16,16 +17,19 @@ func newCmdPreview() *cobra.Command {
 	var limit int
 	var jsonOutput bool
 	var interactive bool
+	var showSQL bool
+	var varFiles []string
 
 	cmd := &cobra.Command{
 		Use:   "preview <external-id>",
-		Short: "Preview a RETL source SQL model",
-		Long:  "Preview a RETL source SQL model to see the data structure and sample rows",
+		Short: "Preview a RETL source",
+		Long:  "Preview a RETL source (SQL model or warehouse table) to see the data structure and sample rows",
 		Example: heredoc.Doc(`
 			$ rudder-cli retl-sources preview my-model
 			$ rudder-cli retl-sources preview my-model --location ./project --limit 5
 			$ rudder-cli retl-sources preview my-model --interactive=false
 			$ rudder-cli retl-sources preview my-model --json
+			$ rudder-cli retl-sources preview my-model --show-sql --var-file staging.vars.yaml
 		`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 {
@@ -39,6 +43,7 @@ func newCmdPreview() *cobra.Command {
 					{K: "json", V: jsonOutput},
 					{K: "interactive", V: interactive},
 					{K: "limit", V: limit},
+					{K: "show_sql", V: showSQL},
 				}...)
 			}()
 
@@ -47,7 +52,12 @@ func newCmdPreview() *cobra.Command {
 				return err
 			}
 
-			p := d.NewProject()
+			projectOpts, err := app.NewProjectOptions(config.GetConfig(), varFiles)
+			if err != nil {
+				return err
+			}
+
+			p := d.NewProject(projectOpts...)
 			if err := p.Load(location); err != nil {
 				return fmt.Errorf("loading project: %w", err)
 			}
@@ -56,13 +66,24 @@ func newCmdPreview() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("getting resource graph: %w", err)
 			}
-			resource, ok := graph.GetResource(sqlmodel.ResourceType + ":" + externalID)
-			if !ok {
-				return fmt.Errorf("resource with external id '%s' not found in project", externalID)
+			resource, err := findSource(graph, externalID)
+			if err != nil {
+				return err
 			}
 			resourceData := resource.Data()
 			resourceType := resource.Type()
 
+			// The graph holds the SQL with parameters already rendered, so
+			// this is the exact query the preview runs. JSON output keeps
+			// stdout for the rows.
+			if showSQL && resourceType == sqlmodel.ResourceType {
+				out := cmd.OutOrStdout()
+				if jsonOutput {
+					out = cmd.ErrOrStderr()
+				}
+				fmt.Fprintf(out, "%s\n\n", resourceData[sqlmodel.SQLKey])
+			}
+
 			// Get the RETL provider
 			retlProvider := d.Providers().RETL
 			opts := []previewer.PreviewerOpts{}
@@ -79,6 +100,8 @@ func newCmdPreview() *cobra.Command {
 	cmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output preview rows as JSON")
 	cmd.Flags().IntVar(&limit, "limit", 10, "Number of rows to preview")
 	cmd.Flags().BoolVar(&interactive, "interactive", true, "Enable interactive table display")
+	cmd.Flags().BoolVar(&showSQL, "show-sql", false, "Print the SQL model query, with parameters rendered, before the preview")
+	cmd.Flags().StringArrayVar(&varFiles, "var-file", nil, "Path to a variable file ending in .vars.yaml or .vars.yml (repeatable; later files take priority)")
 
 	return cmd
 }
@@ -1,9 +1,19 @@
 package retlsource
 
 import (
+	"fmt"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/s3"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/table"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
 	"github.com/spf13/cobra"
 )
 
+// sourceTypes lists the RETL source resource types the commands look up an
+// external id in, in order.
+var sourceTypes = []string{sqlmodel.ResourceType, table.ResourceType, s3.ResourceType}
+
 func NewCmdRetlSources() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "retl-sources",
@@ -17,3 +27,14 @@ func NewCmdRetlSources() *cobra.Command {
 
 	return cmd
 }
+
+// findSource returns the RETL source with the given external id, whichever
+// source kind it is declared as.
+func findSource(graph *resources.Graph, externalID string) (*resources.Resource, error) {
+	for _, resourceType := range sourceTypes {
+		if resource, ok := graph.GetResource(resources.URN(externalID, resourceType)); ok {
+			return resource, nil
+		}
+	}
+	return nil, fmt.Errorf("resource with external id '%s' not found in project", externalID)
+}
@@ -1,22 +1,25 @@
 package retlsource
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/rudderlabs/rudder-iac/cli/internal/app"
 	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/telemetry"
-	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+	"github.com/rudderlabs/rudder-iac/cli/internal/config"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl"
 	"github.com/spf13/cobra"
 )
 
 func newCmdValidate() *cobra.Command {
 	var location string
+	var varFiles []string
 
 	cmd := &cobra.Command{
 		Use:   "validate <external-id>",
-		Short: "Validate a RETL source SQL model",
-		Long:  "Validate a RETL source SQL model by executing the query without returning data",
+		Short: "Validate a RETL source",
+		Long:  "Validate a RETL source (SQL model or warehouse table) by executing its query without returning data. S3 sources have no query and are skipped",
 		Example: heredoc.Doc(`
 			$ rudder-cli retl-sources validate my-model
 			$ rudder-cli retl-sources validate my-model --location ./project
@@ -36,7 +39,12 @@ func newCmdValidate() *cobra.Command {
 				return err
 			}
 
-			p := d.NewProject()
+			projectOpts, err := app.NewProjectOptions(config.GetConfig(), varFiles)
+			if err != nil {
+				return err
+			}
+
+			p := d.NewProject(projectOpts...)
 			if err := p.Load(location); err != nil {
 				return fmt.Errorf("loading project: %w", err)
 			}
@@ -45,9 +53,9 @@ func newCmdValidate() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("getting resource graph: %w", err)
 			}
-			resource, ok := graph.GetResource(sqlmodel.ResourceType + ":" + externalID)
-			if !ok {
-				return fmt.Errorf("resource with external id '%s' not found in project", externalID)
+			resource, err := findSource(graph, externalID)
+			if err != nil {
+				return err
 			}
 			resourceData := resource.Data()
 
@@ -55,7 +63,12 @@ func newCmdValidate() *cobra.Command {
 			retlProvider := d.Providers().RETL
 
 			// Validate by attempting to preview with limit=0
-			_, err = retlProvider.Preview(cmd.Context(), externalID, sqlmodel.ResourceType, resourceData, 0)
+			_, err = retlProvider.Preview(cmd.Context(), externalID, resource.Type(), resourceData, 0)
+			if errors.Is(err, retl.ErrPreviewUnsupported) {
+				fmt.Printf("⚠️ Skipped: %s\n", err.Error())
+				err = nil
+				return nil
+			}
 			if err != nil {
 				fmt.Printf("❌ SQL query failed to execute: %s\n", err.Error())
 				return err
@@ -67,6 +80,7 @@ func newCmdValidate() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&location, "location", "l", ".", "Path to the project directory")
+	cmd.Flags().StringArrayVar(&varFiles, "var-file", nil, "Path to a variable file ending in .vars.yaml or .vars.yml (repeatable; later files take priority)")
 
 	return cmd
 }
@@ -15,8 +15,10 @@ import (
 	d "github.com/rudderlabs/rudder-iac/cli/internal/cmd/debug"
 	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/experimental"
 	importcmd "github.com/rudderlabs/rudder-iac/cli/internal/cmd/import"
+	lspCmd "github.com/rudderlabs/rudder-iac/cli/internal/cmd/lsp"
 	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/project/apply"
 	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/project/destroy"
+	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/project/drift"
 	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/project/migrate"
 	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/project/validate"
 	retlsource "github.com/rudderlabs/rudder-iac/cli/internal/cmd/retl-sources"
@@ -27,6 +29,7 @@ import (
 	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/workspace"
 	"github.com/rudderlabs/rudder-iac/cli/internal/config"
 	"github.com/rudderlabs/rudder-iac/cli/internal/logger"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/reporters"
 	"github.com/rudderlabs/rudder-iac/cli/internal/telemetry"
 	"github.com/rudderlabs/rudder-iac/cli/internal/ui"
 	"github.com/spf13/cobra"
@@ -35,6 +38,7 @@ import (
 
 var (
 	cfgFile string
+	output  string
 	log     = logger.New("root")
 )
 
@@ -79,6 +83,14 @@ func init() {
 		fmt.Sprintf("config file (default is '%s')", config.DefaultConfigFile()),
 	)
 
+	rootCmd.PersistentFlags().StringVar(
+		&output,
+		"output-format",
+		config.OutputText,
+		fmt.Sprintf("output format of apply, destroy, drift and tp diff results and of command errors, one of: %s, %s", config.OutputText, config.OutputJSON),
+	)
+	_ = viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output-format"))
+
 	// Add subcommands to the root command
 	rootCmd.AddCommand(auth.NewCmdAuth())
 	rootCmd.AddCommand(trackingplan.NewCmdTrackingPlan())
@@ -90,7 +102,9 @@ func init() {
 	rootCmd.AddCommand(apply.NewCmdApply())
 	rootCmd.AddCommand(validate.NewCmdValidate())
 	rootCmd.AddCommand(destroy.NewCmdDestroy())
+	rootCmd.AddCommand(drift.NewCmdDrift())
 	rootCmd.AddCommand(migrate.NewCmdMigrate())
+	rootCmd.AddCommand(lspCmd.NewCmdLSP())
 
 	debugCmd = d.NewCmdDebug()
 	experimentalCmd = experimental.NewCmdExperimental()
@@ -107,6 +121,7 @@ func init() {
 
 func initConfig() {
 	config.InitConfig(cfgFile)
+	cobra.CheckErr(config.ValidateOutput(config.GetConfig().Output))
 
 	// only add debug command if enabled in config
 	if config.GetConfig().Debug {
@@ -152,15 +167,27 @@ var rootCmd = &cobra.Command{
 // Execute runs the root command. If the command returns an error, it is printed
 // to stderr and the process exits with code 1. Errors wrapped in SilentError
 // skip the stderr output — the command is expected to have already communicated
-// the failure through its primary output (e.g., JSON to stdout).
+// the failure through its primary output (e.g., JSON to stdout). With JSON output
+// selected, other errors are written to stderr as a structured error record, so
+// that they never corrupt a document the command already wrote to stdout.
 func Execute() {
 	defer recovery()
 
 	if err := rootCmd.Execute(); err != nil {
-		var silent *cmderrors.SilentError
-		if !errors.As(err, &silent) {
+		var (
+			silent   *cmderrors.SilentError
+			exitCode *cmderrors.ExitCodeError
+		)
+		switch {
+		case errors.As(err, &silent):
+		case config.GetConfig().Output == config.OutputJSON:
+			reporters.WriteJSONError(os.Stderr, err)
+		default:
 			ui.PrintError(err)
 		}
+		if errors.As(err, &exitCode) {
+			os.Exit(exitCode.Code)
+		}
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,143 @@
+package diff
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// resolveBase returns the location to load the base revision from. A base
+// naming an existing path is used as is; anything else is treated as a git
+// ref, whose copy of location is extracted into a temporary directory removed
+// by the returned cleanup function.
+func resolveBase(base, location string) (string, func(), error) {
+	noop := func() {}
+
+	if base == "" {
+		return "", noop, errors.New("base revision is required")
+	}
+	if _, err := os.Stat(base); err == nil {
+		return base, noop, nil
+	}
+
+	dir, err := os.MkdirTemp("", "rudder-tp-diff-")
+	if err != nil {
+		return "", noop, fmt.Errorf("creating temporary directory: %w", err)
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+
+	baseLocation, err := checkoutRef(base, location, dir)
+	if err != nil {
+		cleanup()
+		return "", noop, err
+	}
+	return baseLocation, cleanup, nil
+}
+
+// checkoutRef extracts the files under location, as of ref, into dir and
+// returns the path corresponding to location inside dir.
+func checkoutRef(ref, location, dir string) (string, error) {
+	if strings.HasPrefix(ref, "-") {
+		return "", fmt.Errorf("invalid git ref %q", ref)
+	}
+
+	absLocation, err := filepath.Abs(location)
+	if err != nil {
+		return "", fmt.Errorf("resolving location: %w", err)
+	}
+	info, err := os.Stat(absLocation)
+	if err != nil {
+		return "", fmt.Errorf("resolving location: %w", err)
+	}
+
+	workDir, name := absLocation, ""
+	if !info.IsDir() {
+		workDir, name = filepath.Dir(absLocation), filepath.Base(absLocation)
+	}
+
+	if _, err := git(workDir, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+		return "", fmt.Errorf("%q is neither an existing directory nor a git ref in %s", ref, workDir)
+	}
+
+	topLevel, err := git(workDir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	prefix, err := git(workDir, "rev-parse", "--show-prefix")
+	if err != nil {
+		return "", err
+	}
+	relPath := filepath.ToSlash(filepath.Join(strings.TrimSpace(prefix), name))
+
+	args := []string{"archive", "--format=tar", ref}
+	if relPath != "." {
+		args = append(args, "--", relPath)
+	}
+	archive, err := git(strings.TrimSpace(topLevel), args...)
+	if err != nil {
+		return "", fmt.Errorf("reading %s at %s: %w", relPath, ref, err)
+	}
+
+	if err := extractTar(strings.NewReader(archive), dir); err != nil {
+		return "", fmt.Errorf("extracting %s at %s: %w", relPath, ref, err)
+	}
+	return filepath.Join(dir, filepath.FromSlash(relPath)), nil
+}
+
+func git(dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
+
+// extractTar writes the regular files of a tar archive under dir, rejecting
+// entries that would land outside of it.
+func extractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if !filepath.IsLocal(hdr.Name) {
+			return fmt.Errorf("invalid path %q in archive", hdr.Name)
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(f, tr)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package diff
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/catalogloader"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog/catalogdiff"
+)
+
+const eventsSpec = `version: rudder/v0.1
+kind: events
+metadata:
+  name: app
+spec:
+  events:
+    - id: signed_up
+      name: User Signed Up
+      event_type: track
+`
+
+const trackingPlanSpec = `version: rudder/v0.1
+kind: tp
+metadata:
+  name: app
+spec:
+  id: app
+  display_name: App
+  rules:
+    - type: event_rule
+      id: signed_up_rule
+      event:
+        $ref: "#/events/app/signed_up"
+      properties:
+        - $ref: "#/properties/app/plan"
+          required: false
+`
+
+func propertiesSpec(planType string) string {
+	return `version: rudder/v0.1
+kind: properties
+metadata:
+  name: app
+spec:
+  properties:
+    - id: plan
+      name: plan
+      type: ` + planType + "\n"
+}
+
+func writeProject(t *testing.T, dir, planType string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	for name, content := range map[string]string{
+		"events.yaml":        eventsSpec,
+		"properties.yaml":    propertiesSpec(planType),
+		"tracking-plan.yaml": trackingPlanSpec,
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestResolveBaseDirectory(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "base")
+	writeProject(t, base, "string")
+
+	location, cleanup, err := resolveBase(base, ".")
+	require.NoError(t, err)
+	defer cleanup()
+
+	assert.Equal(t, base, location)
+}
+
+func TestResolveBaseGitRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	catalog := filepath.Join(repo, "catalog")
+	writeProject(t, catalog, "string")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("not a spec\n"), 0o644))
+
+	runGit(t, repo, "init", "--quiet")
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "--quiet", "-m", "initial catalog")
+	runGit(t, repo, "tag", "v1")
+
+	writeProject(t, catalog, "integer")
+
+	baseLocation, cleanup, err := resolveBase("v1", catalog)
+	require.NoError(t, err)
+
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(baseLocation), "README.md"))
+	content, err := os.ReadFile(filepath.Join(baseLocation, "properties.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "type: string")
+
+	baseCatalog, err := catalogloader.Load(baseLocation)
+	require.NoError(t, err)
+	headCatalog, err := catalogloader.Load(catalog)
+	require.NoError(t, err)
+
+	changes := catalogdiff.Compare(baseCatalog, headCatalog)
+	assert.Equal(t, catalogdiff.Changes{
+		{Entity: "property", ID: "plan", Path: "type", Severity: catalogdiff.Breaking, Message: "narrowed from [string] to [integer]"},
+	}, changes)
+
+	cleanup()
+	assert.NoDirExists(t, baseLocation)
+}
+
+func TestResolveBaseUnknownRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	writeProject(t, repo, "string")
+	runGit(t, repo, "init", "--quiet")
+
+	_, _, err := resolveBase("does-not-exist", repo)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "neither an existing directory nor a git ref")
+
+	_, _, err = resolveBase("--output=/tmp/x", repo)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid git ref")
+}
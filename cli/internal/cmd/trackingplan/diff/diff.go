@@ -0,0 +1,141 @@
+package diff
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/catalogloader"
+	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/cmderrors"
+	"github.com/rudderlabs/rudder-iac/cli/internal/cmd/telemetry"
+	"github.com/rudderlabs/rudder-iac/cli/internal/config"
+	"github.com/rudderlabs/rudder-iac/cli/internal/logger"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog/catalogdiff"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog/localcatalog"
+	"github.com/rudderlabs/rudder-iac/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// ExitCodeBreaking is the exit code when --fail-on-breaking is set and
+// breaking changes are found, distinguishing it from a failure to load either
+// revision (1).
+const ExitCodeBreaking = 2
+
+var (
+	diffLog = logger.New("trackingplan", logger.Attr{
+		Key:   "cmd",
+		Value: "diff",
+	})
+
+	// ErrBreakingChanges is returned when breaking changes are found and the
+	// command was asked to fail on them.
+	ErrBreakingChanges = errors.New("breaking changes detected")
+)
+
+func NewCmdTPDiff() *cobra.Command {
+	var (
+		location       string
+		base           string
+		failOnBreaking bool
+		err            error
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Detect breaking changes to the data catalog between two project revisions",
+		Long: heredoc.Doc(`
+			Compares the events, properties, custom types and tracking plans of the project
+			against a base revision and classifies each change as breaking or non-breaking.
+
+			A change is breaking when events that were valid against the base revision may be
+			rejected by the project, for example removing a property, narrowing its type,
+			tightening a constraint or making a property required.
+
+			The base is either a directory holding the previous revision of the project, or a
+			git ref (branch, tag or commit) of the repository the project lives in. A git ref
+			is resolved at the same path as --location.
+
+			No authentication or network access is needed.
+
+			Exit codes:
+			  0  no breaking changes, or breaking changes without --fail-on-breaking
+			  1  a revision could not be loaded (e.g. invalid specs or unknown git ref)
+			  2  breaking changes detected with --fail-on-breaking
+		`),
+		Example: heredoc.Doc(`
+			$ rudder-cli tp diff --base ../previous-release
+			$ rudder-cli tp diff --location ./catalog --base origin/main --fail-on-breaking
+			$ rudder-cli tp diff --base v1.2.0 --output-format json
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			diffLog.Debug("tp diff", "location", location, "base", base)
+
+			var changes catalogdiff.Changes
+			defer func() {
+				telemetry.TrackCommand("tp diff", err, []telemetry.KV{
+					{K: "failOnBreaking", V: failOnBreaking},
+					{K: "breaking", V: changes.Count(catalogdiff.Breaking)},
+					{K: "nonBreaking", V: changes.Count(catalogdiff.NonBreaking)},
+				}...)
+			}()
+
+			var (
+				baseLocation string
+				cleanup      func()
+				baseCatalog  *localcatalog.DataCatalog
+				headCatalog  *localcatalog.DataCatalog
+			)
+			baseLocation, cleanup, err = resolveBase(base, location)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			baseCatalog, err = catalogloader.Load(baseLocation)
+			if err != nil {
+				return fmt.Errorf("loading base revision %q: %w", base, err)
+			}
+
+			headCatalog, err = catalogloader.Load(location)
+			if err != nil {
+				return fmt.Errorf("loading project: %w", err)
+			}
+
+			changes = catalogdiff.Compare(baseCatalog, headCatalog)
+
+			if config.GetConfig().Output == config.OutputJSON {
+				if err = catalogdiff.WriteJSON(os.Stdout, changes); err != nil {
+					return err
+				}
+			} else if len(changes) > 0 {
+				catalogdiff.WriteText(os.Stdout, changes)
+			}
+
+			switch {
+			case len(changes) == 0:
+				if config.GetConfig().Output != config.OutputJSON {
+					ui.PrintSuccess("No changes to the data catalog")
+				}
+			case !changes.HasBreaking():
+				if config.GetConfig().Output != config.OutputJSON {
+					ui.PrintSuccess("No breaking changes")
+				}
+			case failOnBreaking:
+				return &cmderrors.ExitCodeError{
+					Code: ExitCodeBreaking,
+					Err:  &cmderrors.SilentError{Err: ErrBreakingChanges},
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&location, "location", "l", ".", "Path to the directory containing the project files or a specific file")
+	cmd.Flags().StringVar(&base, "base", "", "Directory or git ref holding the revision to compare against")
+	cmd.Flags().BoolVar(&failOnBreaking, "fail-on-breaking", false, "Exit with code 2 when breaking changes are found")
+	_ = cmd.MarkFlagRequired("base")
+
+	return cmd
+}
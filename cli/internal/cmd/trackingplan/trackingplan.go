@@ -5,21 +5,25 @@ import (
 
 	tpApplyCmd "github.com/rudderlabs/rudder-iac/cli/internal/cmd/trackingplan/apply"
 	tpDestroyCmd "github.com/rudderlabs/rudder-iac/cli/internal/cmd/trackingplan/destroy"
+	tpDiffCmd "github.com/rudderlabs/rudder-iac/cli/internal/cmd/trackingplan/diff"
 	tpValidateCmd "github.com/rudderlabs/rudder-iac/cli/internal/cmd/trackingplan/validate"
 )
 
+// NewCmdTrackingPlan groups the tracking plan commands. validate, apply and
+// destroy are deprecated in favour of the top-level commands and only remain
+// to point users at them.
 func NewCmdTrackingPlan() *cobra.Command {
 
 	cmd := &cobra.Command{
-		Use:        "tp <command>",
-		Short:      "[Deprecated] Manage datacatalog resources",
-		Long:       "[Deprecated] Manage the lifecycle of datacatalog resources using user defined state",
-		Deprecated: "all tp subcommands have been replaced by top-level commands (apply, validate, destroy)",
+		Use:   "tp <command>",
+		Short: "Inspect changes to tracking plans",
+		Long:  "Inspect changes to tracking plans and the data catalog resources they reference",
 	}
 
 	cmd.AddCommand(tpValidateCmd.NewCmdTPValidate())
 	cmd.AddCommand(tpApplyCmd.NewCmdTPApply())
 	cmd.AddCommand(tpDestroyCmd.NewCmdTPDestroy())
+	cmd.AddCommand(tpDiffCmd.NewCmdTPDiff())
 
 	return cmd
 }
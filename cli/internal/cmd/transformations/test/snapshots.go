@@ -0,0 +1,63 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/testorchestrator"
+	"github.com/rudderlabs/rudder-iac/cli/internal/ui"
+)
+
+// printSnapshotUpdates lists the expected output files changed by updating
+// snapshots, followed by a count of each kind of change.
+func printSnapshotUpdates(updates []testorchestrator.SnapshotUpdate) {
+	ui.Println()
+	ui.Println(ui.Bold("SNAPSHOTS"))
+
+	if len(updates) == 0 {
+		ui.Println("All expected outputs are up to date")
+		return
+	}
+
+	counts := make(map[testorchestrator.SnapshotChange]int)
+	for _, update := range updates {
+		counts[update.Change]++
+
+		line := fmt.Sprintf("  %-8s %s", update.Change, displayPath(update.Path))
+		switch update.Change {
+		case testorchestrator.SnapshotCreated:
+			line = ui.Color(line, ui.ColorGreen)
+		case testorchestrator.SnapshotDeleted:
+			line = ui.Color(line, ui.ColorRed)
+		case testorchestrator.SnapshotSkipped:
+			line = ui.GreyedOut(line + " (test errored)")
+		default:
+			line = ui.Color(line, ui.ColorYellow)
+		}
+		ui.Println(line)
+	}
+
+	ui.Println()
+	ui.Printf("Snapshots: %d created, %d updated, %d deleted, %d skipped\n",
+		counts[testorchestrator.SnapshotCreated],
+		counts[testorchestrator.SnapshotUpdated],
+		counts[testorchestrator.SnapshotDeleted],
+		counts[testorchestrator.SnapshotSkipped],
+	)
+}
+
+// displayPath shortens path relative to the working directory when it is
+// below it.
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return rel
+}
@@ -6,7 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/spf13/cobra"
@@ -17,6 +20,7 @@ import (
 	"github.com/rudderlabs/rudder-iac/cli/internal/project"
 	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/display"
 	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/testorchestrator"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
 	"github.com/rudderlabs/rudder-iac/cli/internal/ui"
 )
 
@@ -39,6 +43,10 @@ func NewCmdTest() *cobra.Command {
 		verbose  bool
 		output   string
 		force    bool
+		local    bool
+		watch    bool
+		report   string
+		update   bool
 	)
 
 	cmd := &cobra.Command{
@@ -50,6 +58,26 @@ func NewCmdTest() *cobra.Command {
 			You can test a single transformation by ID, all transformations, or only
 			modified transformations. Test results show pass/fail status with optional
 			verbose output showing diffs for failures.
+
+			With --local, JavaScript transformations run in an embedded engine instead
+			of the workspace, so no access token or network access is needed and no code
+			is uploaded. Library imports resolve to the libraries defined in the project;
+			RudderStack built-in libraries and Python transformations are not available.
+
+			With --watch, the command keeps running after the first test run and
+			re-runs the tests affected by every change to transformation or library
+			code, specs and test input or output files.
+
+			With --report, the results file is written as a JUnit XML or TAP report
+			for CI test dashboards instead of JSON. Every input file of every test
+			suite is reported as a test case of its transformation, with the output
+			diff as the failure message, and libraries are reported as a suite of
+			their own.
+
+			With --update-snapshots, the actual output of every test is written to the
+			expected output directory of its suite, creating missing output files and
+			deleting output files whose input file was removed. Review the changed files
+			before committing them.
 		`),
 		Example: heredoc.Doc(`
 			# Test a single transformation
@@ -72,29 +100,28 @@ func NewCmdTest() *cobra.Command {
 
 			# Overwrite an existing results file
 			$ rudder-cli transformations test --all --force
+
+			# Test without a workspace, e.g. in CI
+			$ rudder-cli transformations test --all --local
+
+			# Re-run tests as transformation code and test data change
+			$ rudder-cli transformations test my-transformation-id --local --watch
+
+			# Write a JUnit XML report for CI (default: test-results.xml)
+			$ rudder-cli transformations test --all --report junit
+
+			# Accept the current outputs as the expected outputs
+			$ rudder-cli transformations test my-transformation-id --update-snapshots
 		`),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			// Validate flags first
-			if err := validateFlags(args, all, modified, output, force); err != nil {
+			if err := validateFlags(args, all, modified, local, watch, update, output, report, force); err != nil {
 				return err
 			}
 
-			// Initialize dependencies
 			var err error
-			deps, err = app.NewDeps()
-			if err != nil {
-				return fmt.Errorf("initialising dependencies: %w", err)
-			}
-
-			// Create project
-			p = deps.NewProject()
-
-			// Load and validate the project configuration
-			if err := p.Load(location); err != nil {
-				return fmt.Errorf("loading and validating project: %w", err)
-			}
-
-			return nil
+			deps, p, err = loadProject(location, local)
+			return err
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
@@ -104,16 +131,20 @@ func NewCmdTest() *cobra.Command {
 					{K: "all", V: all},
 					{K: "modified", V: modified},
 					{K: "verbose", V: verbose},
+					{K: "local", V: local},
+					{K: "watch", V: watch},
+					{K: "report", V: report},
+					{K: "update_snapshots", V: update},
 				}...)
 			}()
 
-			testLog.Debug("test", "location", location, "all", all, "modified", modified, "verbose", verbose)
+			testLog.Debug("test", "location", location, "all", all, "modified", modified, "verbose", verbose, "local", local, "watch", watch, "report", report, "updateSnapshots", update)
 
 			ctx := context.Background()
-			// Get workspace information
-			workspace, err := deps.Client().Workspaces.GetByAuthToken(ctx)
-			if err != nil {
-				return fmt.Errorf("fetching workspace information: %w", err)
+			if watch {
+				var stop context.CancelFunc
+				ctx, stop = signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+				defer stop()
 			}
 
 			// Get resource graph
@@ -122,9 +153,6 @@ func NewCmdTest() *cobra.Command {
 				return fmt.Errorf("getting resource graph: %w", err)
 			}
 
-			// Create transformations provider
-			trProvider := deps.Providers().Transformations
-
 			// Determine test mode and target
 			var mode testorchestrator.Mode
 			var targetID string
@@ -141,8 +169,7 @@ func NewCmdTest() *cobra.Command {
 			spinner := ui.NewSpinner("Running tests...")
 			spinner.Start()
 
-			runner := testorchestrator.NewRunner(deps.Client(), trProvider, graph, workspace.ID)
-			results, err := runner.Run(ctx, mode, targetID)
+			results, err := runTests(ctx, deps, graph, local, mode, targetID)
 
 			spinner.Stop()
 
@@ -150,22 +177,48 @@ func NewCmdTest() *cobra.Command {
 				return fmt.Errorf("running tests: %w", err)
 			}
 
-			outputPath := output
-			if outputPath == "" {
-				outputPath = "test-results.json"
-			}
-
-			if err = writeResultsFile(outputPath, results); err != nil {
+			outputPath := resultsPath(output, report)
+			if err = writeResults(outputPath, report, results); err != nil {
 				return fmt.Errorf("writing results file: %w", err)
 			}
 
 			if results.Status == testorchestrator.RunStatusNoResources {
 				ui.Println("No resources to test")
+			} else {
+				displayer := display.NewResultDisplayer(verbose)
+				displayer.Display(results)
+			}
+
+			if update {
+				updates, err := testorchestrator.UpdateSnapshots(graph, results)
+				if err != nil {
+					return fmt.Errorf("updating snapshots: %w", err)
+				}
+				printSnapshotUpdates(updates)
+
+				// Mismatched outputs were just accepted, only errors remain.
+				if results.HasErrors() {
+					err = ErrTestsFailed
+					return err
+				}
 				return nil
 			}
 
-			displayer := display.NewResultDisplayer(verbose)
-			displayer.Display(results)
+			if watch {
+				session := &watchSession{
+					location:   location,
+					local:      local,
+					verbose:    verbose,
+					outputPath: outputPath,
+					report:     report,
+					mode:       mode,
+					targetID:   targetID,
+					graph:      graph,
+					specs:      p.Specs(),
+				}
+				err = session.Run(ctx)
+				return err
+			}
 
 			if results.HasFailures() {
 				err = ErrTestsFailed
@@ -180,14 +233,68 @@ func NewCmdTest() *cobra.Command {
 	cmd.Flags().BoolVar(&all, "all", false, "Test all transformations in the project")
 	cmd.Flags().BoolVar(&modified, "modified", false, "Test only new or modified transformations")
 	cmd.Flags().BoolVar(&verbose, "verbose", false, "Show detailed output including diffs for failures")
-	cmd.Flags().StringVarP(&output, "output", "o", "", "Path to write test results JSON file (default: test-results.json)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Path to write test results file (default: test-results.json, or test-results.xml and test-results.tap with --report)")
 	cmd.Flags().BoolVar(&force, "force", false, "Overwrite output file if it already exists")
+	cmd.Flags().BoolVar(&local, "local", false, "Run JavaScript tests in an embedded engine without an access token or network access")
+	cmd.Flags().BoolVar(&watch, "watch", false, "Keep running and re-run affected tests when code, specs or test files change")
+	cmd.Flags().BoolVar(&update, "update-snapshots", false, "Write actual outputs to the expected output directories of the test suites")
+	cmd.Flags().StringVar(&report, "report", "", "Write the results file as a test report in the given format: junit or tap")
 
 	return cmd
 }
 
+// loadProject initialises dependencies and loads and validates the project at
+// location. Local runs need neither an access token nor the workspace.
+func loadProject(location string, local bool) (app.Deps, project.Project, error) {
+	var (
+		deps app.Deps
+		err  error
+	)
+	if local {
+		deps, err = app.NewOfflineDeps()
+	} else {
+		deps, err = app.NewDeps()
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("initialising dependencies: %w", err)
+	}
+
+	var projectOpts []project.ProjectOption
+	if local {
+		projectOpts = append(projectOpts, project.WithOffline())
+	}
+	p := deps.NewProject(projectOpts...)
+
+	if err := p.Load(location); err != nil {
+		return nil, nil, fmt.Errorf("loading and validating project: %w", err)
+	}
+
+	return deps, p, nil
+}
+
+// runTests runs the tests selected by mode and targetID, in the embedded
+// engine when local is set and through the workspace otherwise.
+func runTests(ctx context.Context, deps app.Deps, graph *resources.Graph, local bool, mode testorchestrator.Mode, targetID string) (*testorchestrator.TestResults, error) {
+	if local {
+		return testorchestrator.NewLocalRunner(graph).Run(ctx, mode, targetID)
+	}
+	return runRemote(ctx, deps, graph, mode, targetID)
+}
+
+// runRemote runs the tests through the transformer API of the workspace the
+// access token belongs to.
+func runRemote(ctx context.Context, deps app.Deps, graph *resources.Graph, mode testorchestrator.Mode, targetID string) (*testorchestrator.TestResults, error) {
+	workspace, err := deps.Client().Workspaces.GetByAuthToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching workspace information: %w", err)
+	}
+
+	runner := testorchestrator.NewRunner(deps.Client(), deps.Providers().Transformations, graph, workspace.ID)
+	return runner.Run(ctx, mode, targetID)
+}
+
 // validateFlags validates the command flags and arguments
-func validateFlags(args []string, all, modified bool, output string, force bool) error {
+func validateFlags(args []string, all, modified, local, watch, updateSnapshots bool, output, report string, force bool) error {
 	// Count active modes
 	modes := 0
 	hasID := len(args) > 0
@@ -214,11 +321,22 @@ func validateFlags(args []string, all, modified bool, output string, force bool)
 		return fmt.Errorf("only one transformation/library ID allowed, got %d arguments", len(args))
 	}
 
-	outputPath := output
-	if outputPath == "" {
-		outputPath = "test-results.json"
+	if local && modified {
+		return fmt.Errorf("--modified cannot be used with --local: finding modified transformations requires the workspace")
 	}
 
+	if watch && updateSnapshots {
+		return fmt.Errorf("--update-snapshots cannot be used with --watch")
+	}
+
+	if report != "" {
+		if _, err := display.ParseReportFormat(report); err != nil {
+			return err
+		}
+	}
+
+	outputPath := resultsPath(output, report)
+
 	dir := filepath.Dir(outputPath)
 	info, err := os.Stat(dir)
 	if os.IsNotExist(err) {
@@ -237,6 +355,44 @@ func validateFlags(args []string, all, modified bool, output string, force bool)
 	return nil
 }
 
+// resultsPath returns the path to write results to, defaulting to a file named
+// after the report format in the working directory.
+func resultsPath(output, report string) string {
+	if output != "" {
+		return output
+	}
+
+	switch display.ReportFormat(strings.ToLower(report)) {
+	case display.ReportFormatJUnit:
+		return "test-results.xml"
+	case display.ReportFormatTAP:
+		return "test-results.tap"
+	default:
+		return "test-results.json"
+	}
+}
+
+// writeResults writes results to path as a report in the given format, or as
+// JSON when no report format is set.
+func writeResults(path, report string, results *testorchestrator.TestResults) error {
+	if report == "" {
+		return writeResultsFile(path, results)
+	}
+
+	format, err := display.ParseReportFormat(report)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating results file: %w", err)
+	}
+	defer f.Close()
+
+	return display.WriteReport(f, format, results)
+}
+
 func writeResultsFile(path string, results *testorchestrator.TestResults) error {
 	f, err := os.Create(path)
 	if err != nil {
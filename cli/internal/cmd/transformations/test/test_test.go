@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	transformations "github.com/rudderlabs/rudder-iac/api/client/transformations"
 	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/testorchestrator"
 )
 
@@ -22,37 +23,41 @@ func TestValidateFlags(t *testing.T) {
 	f.Close()
 
 	tests := []struct {
-		name          string
-		args          []string
-		all           bool
-		modified      bool
-		output        string
-		force         bool
-		expectedError bool
-		errorContains string
+		name            string
+		args            []string
+		all             bool
+		modified        bool
+		local           bool
+		watch           bool
+		updateSnapshots bool
+		output          string
+		report          string
+		force           bool
+		expectedError   bool
+		errorContains   string
 	}{
 		// Valid cases
 		{
-			name:          "valid single ID",
-			args:          []string{"my-transformation"},
-			all:           false,
-			modified:      false,
+			name:     "valid single ID",
+			args:     []string{"my-transformation"},
+			all:      false,
+			modified: false,
 
 			expectedError: false,
 		},
 		{
-			name:          "valid --all flag",
-			args:          []string{},
-			all:           true,
-			modified:      false,
+			name:     "valid --all flag",
+			args:     []string{},
+			all:      true,
+			modified: false,
 
 			expectedError: false,
 		},
 		{
-			name:          "valid --modified flag",
-			args:          []string{},
-			all:           false,
-			modified:      true,
+			name:     "valid --modified flag",
+			args:     []string{},
+			all:      false,
+			modified: true,
 
 			expectedError: false,
 		},
@@ -74,6 +79,14 @@ func TestValidateFlags(t *testing.T) {
 			expectedError: false,
 		},
 
+		{
+			name:          "valid --local with --all",
+			args:          []string{},
+			all:           true,
+			local:         true,
+			expectedError: false,
+		},
+
 		// Invalid cases
 		{
 			name:          "ID + --all",
@@ -123,6 +136,44 @@ func TestValidateFlags(t *testing.T) {
 			expectedError: true,
 			errorContains: "must specify either an ID, --all, or --modified",
 		},
+		{
+			name:          "--local + --modified",
+			args:          []string{},
+			modified:      true,
+			local:         true,
+			expectedError: true,
+			errorContains: "--modified cannot be used with --local",
+		},
+		{
+			name:          "valid --report junit",
+			args:          []string{},
+			all:           true,
+			report:        "junit",
+			expectedError: false,
+		},
+		{
+			name:          "invalid --report format",
+			args:          []string{},
+			all:           true,
+			report:        "html",
+			expectedError: true,
+			errorContains: "unsupported report format",
+		},
+		{
+			name:            "valid --update-snapshots",
+			args:            []string{"my-transformation"},
+			updateSnapshots: true,
+			expectedError:   false,
+		},
+		{
+			name:            "--update-snapshots + --watch",
+			args:            []string{},
+			all:             true,
+			watch:           true,
+			updateSnapshots: true,
+			expectedError:   true,
+			errorContains:   "--update-snapshots cannot be used with --watch",
+		},
 		{
 			name:          "invalid -o with non-existent base dir",
 			args:          []string{},
@@ -147,7 +198,7 @@ func TestValidateFlags(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			err := validateFlags(tt.args, tt.all, tt.modified, tt.output, tt.force)
+			err := validateFlags(tt.args, tt.all, tt.modified, tt.local, tt.watch, tt.updateSnapshots, tt.output, tt.report, tt.force)
 
 			if tt.expectedError {
 				require.Error(t, err)
@@ -198,3 +249,40 @@ func TestWriteResultsFile(t *testing.T) {
 		assert.Contains(t, err.Error(), "creating results file")
 	})
 }
+
+func TestResultsPath(t *testing.T) {
+	assert.Equal(t, "test-results.json", resultsPath("", ""))
+	assert.Equal(t, "test-results.xml", resultsPath("", "junit"))
+	assert.Equal(t, "test-results.tap", resultsPath("", "TAP"))
+	assert.Equal(t, "out/report.xml", resultsPath("out/report.xml", "junit"))
+}
+
+func TestWriteResults(t *testing.T) {
+	results := &testorchestrator.TestResults{
+		Status: testorchestrator.RunStatusExecuted,
+		Libraries: []transformations.LibraryTestResult{
+			{ID: "lib-1", HandleName: "utils", Pass: true},
+		},
+	}
+
+	t.Run("writes JSON without a report format", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "results.json")
+		require.NoError(t, writeResults(path, "", results))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		var got testorchestrator.TestResults
+		require.NoError(t, json.Unmarshal(data, &got))
+		assert.Equal(t, testorchestrator.RunStatusExecuted, got.Status)
+	})
+
+	t.Run("writes a report in the given format", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "results.tap")
+		require.NoError(t, writeResults(path, "tap", results))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "TAP version 13\n1..1\nok 1 - libraries: utils\n", string(data))
+	})
+}
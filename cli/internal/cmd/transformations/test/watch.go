@@ -0,0 +1,303 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/display"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/testorchestrator"
+	ttypes "github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/types"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/ui"
+)
+
+// settleDelay is how long the watcher waits for further changes before
+// re-running tests, so that saving several files at once triggers one run.
+const settleDelay = 300 * time.Millisecond
+
+// watchedExtensions are the files whose changes can affect test results:
+// specs, transformation and library code, and test input and output.
+var watchedExtensions = map[string]struct{}{
+	".yaml": {},
+	".yml":  {},
+	".js":   {},
+	".py":   {},
+	".json": {},
+}
+
+// watchSession re-runs the tests affected by changes to the project until
+// its context is done.
+type watchSession struct {
+	location   string
+	local      bool
+	verbose    bool
+	outputPath string
+	report     string
+	mode       testorchestrator.Mode
+	targetID   string
+
+	// graph and specs are from the last project load that succeeded.
+	graph *resources.Graph
+	specs map[string]*specs.Spec
+
+	watcher *fsnotify.Watcher
+	watched map[string]struct{}
+}
+
+// Run watches the project until ctx is done. Failing tests and projects that
+// fail to load while being edited are reported without ending the session.
+func (w *watchSession) Run(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("creating file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	w.watcher = watcher
+	w.watched = make(map[string]struct{})
+	w.watchProject()
+
+	for {
+		ui.Println(ui.GreyedOut("Watching for changes, press Ctrl+C to stop..."))
+
+		changed, err := w.nextChanges(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		}
+
+		w.rerun(ctx, changed)
+	}
+}
+
+// nextChanges blocks until relevant files change and returns their paths,
+// once no further change arrived for settleDelay.
+func (w *watchSession) nextChanges(ctx context.Context) ([]string, error) {
+	var (
+		changed = make(map[string]struct{})
+		settle  <-chan time.Time
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return nil, errors.New("file watcher closed")
+			}
+			testLog.Warn("file watcher error", "error", err)
+
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return nil, errors.New("file watcher closed")
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					w.watchTree(event.Name)
+				}
+			}
+			// Watches on removed directories are dropped by the watcher, so
+			// forget them to watch the directory again if it is re-created.
+			if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+				if abs, err := filepath.Abs(event.Name); err == nil {
+					delete(w.watched, abs)
+				}
+			}
+			if !w.relevant(event) {
+				continue
+			}
+			changed[event.Name] = struct{}{}
+			settle = time.After(settleDelay)
+
+		case <-settle:
+			paths := make([]string, 0, len(changed))
+			for path := range changed {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			return paths, nil
+		}
+	}
+}
+
+func (w *watchSession) relevant(event fsnotify.Event) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+	if _, ok := watchedExtensions[strings.ToLower(filepath.Ext(event.Name))]; !ok {
+		return false
+	}
+	// Writing the results file must not trigger another run.
+	return !samePath(event.Name, w.outputPath)
+}
+
+// rerun reloads the project and runs the tests affected by the changed paths.
+func (w *watchSession) rerun(ctx context.Context, changed []string) {
+	deps, p, err := loadProject(w.location, w.local)
+	if err != nil {
+		ui.PrintError(err)
+		return
+	}
+	graph, err := p.ResourceGraph()
+	if err != nil {
+		ui.PrintError(fmt.Errorf("getting resource graph: %w", err))
+		return
+	}
+
+	affected, err := testorchestrator.AffectedIDs(w.graph, graph, changed)
+	if err != nil {
+		ui.PrintError(err)
+		return
+	}
+	w.graph, w.specs = graph, p.Specs()
+	w.watchProject()
+
+	ids, err := w.scope(affected)
+	if err != nil {
+		ui.PrintError(err)
+		return
+	}
+	if len(ids) == 0 {
+		ui.Println("No tests affected by the changes")
+		return
+	}
+
+	ui.Println()
+	ui.Println(ui.Bold(fmt.Sprintf("Changes detected, re-running tests for: %s", strings.Join(ids, ", "))))
+
+	results := &testorchestrator.TestResults{Status: testorchestrator.RunStatusNoResources}
+	for _, id := range ids {
+		res, err := runTests(ctx, deps, graph, w.local, testorchestrator.ModeSingle, id)
+		if err != nil {
+			ui.PrintError(fmt.Errorf("running tests for %s: %w", id, err))
+			return
+		}
+		if res.Status == testorchestrator.RunStatusExecuted {
+			results.Status = testorchestrator.RunStatusExecuted
+		}
+		results.Libraries = append(results.Libraries, res.Libraries...)
+		results.Transformations = append(results.Transformations, res.Transformations...)
+	}
+
+	if err := writeResults(w.outputPath, w.report, results); err != nil {
+		ui.PrintError(fmt.Errorf("writing results file: %w", err))
+	}
+
+	if results.Status == testorchestrator.RunStatusNoResources {
+		ui.Println("No resources to test")
+		return
+	}
+	display.NewResultDisplayer(w.verbose).Display(results)
+}
+
+// scope narrows the affected resources to those the session was started for.
+// A session for a single transformation or library re-runs it whenever
+// anything it tests is affected.
+func (w *watchSession) scope(affected []string) ([]string, error) {
+	if w.mode != testorchestrator.ModeSingle || len(affected) == 0 {
+		return affected, nil
+	}
+
+	plan, err := testorchestrator.NewPlanner(w.graph).BuildPlan(resources.NewGraph(), testorchestrator.ModeSingle, w.targetID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	tested := map[string]struct{}{w.targetID: {}}
+	for _, unit := range plan.TestUnits {
+		tested[unit.Transformation.ID] = struct{}{}
+	}
+	for _, lib := range plan.StandaloneLibraries {
+		tested[lib.ID] = struct{}{}
+	}
+
+	for _, id := range affected {
+		if _, ok := tested[id]; ok {
+			return []string{w.targetID}, nil
+		}
+	}
+	return nil, nil
+}
+
+// watchProject watches the project location, the directories of code files
+// referenced by transformation and library specs, and test suite directories,
+// any of which may live outside the project location.
+func (w *watchSession) watchProject() {
+	w.watchTree(w.location)
+
+	for path, spec := range w.specs {
+		if spec.Kind != ttypes.TransformationSpecKind && spec.Kind != ttypes.LibrarySpecKind {
+			continue
+		}
+		file, ok := spec.Spec["file"].(string)
+		if !ok || file == "" {
+			continue
+		}
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(specs.DocumentFile(path)), file)
+		}
+		w.watchDir(filepath.Dir(file))
+	}
+
+	for _, dir := range testorchestrator.TestSuiteDirs(w.graph) {
+		w.watchDir(dir)
+	}
+}
+
+// watchTree watches root and every directory below it, skipping hidden ones.
+func (w *watchSession) watchTree(root string) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return
+	}
+	if !info.IsDir() {
+		w.watchDir(filepath.Dir(root))
+		return
+	}
+
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		w.watchDir(path)
+		return nil
+	})
+}
+
+func (w *watchSession) watchDir(dir string) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return
+	}
+	if _, ok := w.watched[abs]; ok {
+		return
+	}
+	if err := w.watcher.Add(abs); err != nil {
+		testLog.Debug("not watching directory", "dir", abs, "error", err)
+		return
+	}
+	w.watched[abs] = struct{}{}
+}
+
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
@@ -0,0 +1,89 @@
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/model"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/testorchestrator"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+func TestWatchSession_NextChanges(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0755))
+
+	watcher, err := fsnotify.NewWatcher()
+	require.NoError(t, err)
+	defer watcher.Close()
+
+	w := &watchSession{
+		location:   dir,
+		outputPath: filepath.Join(dir, "test-results.json"),
+		graph:      resources.NewGraph(),
+		watcher:    watcher,
+		watched:    make(map[string]struct{}),
+	}
+	w.watchProject()
+	assert.NotContains(t, w.watched, filepath.Join(dir, ".git"), "hidden directories are not watched")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go func() {
+		// Ignored: unrelated extension and the results file itself.
+		_ = os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0644)
+		_ = os.WriteFile(filepath.Join(dir, "test-results.json"), []byte("{}"), 0644)
+
+		_ = os.WriteFile(filepath.Join(dir, "code.js"), []byte("x"), 0644)
+		_ = os.WriteFile(filepath.Join(dir, "spec.yaml"), []byte("x"), 0644)
+	}()
+
+	changed, err := w.nextChanges(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "code.js"), filepath.Join(dir, "spec.yaml")}, changed)
+
+	cancel()
+	_, err = w.nextChanges(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWatchSession_Scope(t *testing.T) {
+	graph := resources.NewGraph()
+	lib := resources.NewResource("lib", "transformation-library", resources.ResourceData{}, nil,
+		resources.WithRawData(&model.LibraryResource{ID: "lib"}))
+	t1 := resources.NewResource("t1", "transformation", resources.ResourceData{}, nil,
+		resources.WithRawData(&model.TransformationResource{ID: "t1"}))
+	t2 := resources.NewResource("t2", "transformation", resources.ResourceData{}, nil,
+		resources.WithRawData(&model.TransformationResource{ID: "t2"}))
+	graph.AddResource(lib)
+	graph.AddResource(t1)
+	graph.AddResource(t2)
+	graph.AddDependency(t1.URN(), lib.URN())
+
+	t.Run("all mode re-runs everything affected", func(t *testing.T) {
+		w := &watchSession{mode: testorchestrator.ModeAll, graph: graph}
+		ids, err := w.scope([]string{"t1", "t2"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"t1", "t2"}, ids)
+	})
+
+	t.Run("single mode re-runs the target when it is affected", func(t *testing.T) {
+		w := &watchSession{mode: testorchestrator.ModeSingle, targetID: "lib", graph: graph}
+
+		ids, err := w.scope([]string{"t1"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"lib"}, ids)
+
+		ids, err = w.scope([]string{"t2"})
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+}
@@ -30,7 +30,7 @@ func newCmdOptions() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&platform, "platform", "", fmt.Sprintf("Platform to show options for (%s, %s, %s)", platformKotlin, platformSwift, platformTypeScript))
+	cmd.Flags().StringVar(&platform, "platform", "", fmt.Sprintf("Platform to show options for (%s, %s, %s, %s, %s, %s, %s)", platformKotlin, platformSwift, platformTypeScript, platformGo, platformPython, platformDart, platformJSONSchema))
 	cmd.MarkFlagRequired("platform")
 	return cmd
 }
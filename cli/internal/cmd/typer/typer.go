@@ -20,6 +20,10 @@ const (
 	platformKotlin     = "kotlin"
 	platformSwift      = "swift"
 	platformTypeScript = "typescript"
+	platformGo         = "go"
+	platformPython     = "python"
+	platformDart       = "dart"
+	platformJSONSchema = "jsonschema"
 )
 
 func NewCmdTyper() *cobra.Command {
@@ -51,9 +55,10 @@ func newCmdGenerate() *cobra.Command {
 		Example: heredoc.Doc(`
 			$ rudder-cli typer generate --tracking-plan-id <id> --platform kotlin
 			$ rudder-cli typer generate --local --location ./project --platform kotlin
+			$ rudder-cli typer generate --tracking-plan-id <id> --platform jsonschema --option openapi=true -o ./schemas
 		`),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			validPlatforms := map[string]bool{platformKotlin: true, platformSwift: true, platformTypeScript: true}
+			validPlatforms := map[string]bool{platformKotlin: true, platformSwift: true, platformTypeScript: true, platformGo: true, platformPython: true, platformDart: true, platformJSONSchema: true}
 			if !validPlatforms[platform] {
 				supported := make([]string, 0, len(validPlatforms))
 				for p := range validPlatforms {
@@ -105,7 +110,7 @@ func newCmdGenerate() *cobra.Command {
 
 	cmd.Flags().StringVar(&trackingPlanID, "tracking-plan-id", "", "Tracking plan ID to generate code from (remote), or local id of the plan in the specs (with --local)")
 
-	cmd.Flags().StringVar(&platform, "platform", platformKotlin, fmt.Sprintf("Platform to generate code for (%s, %s, %s)", platformKotlin, platformSwift, platformTypeScript))
+	cmd.Flags().StringVar(&platform, "platform", platformKotlin, fmt.Sprintf("Platform to generate code for (%s, %s, %s, %s, %s, %s, %s)", platformKotlin, platformSwift, platformTypeScript, platformGo, platformPython, platformDart, platformJSONSchema))
 	cmd.MarkFlagRequired("platform")
 
 	cmd.Flags().StringVarP(&outputDir, "output", "o", ".", "Output directory for generated files")
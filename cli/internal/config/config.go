@@ -22,10 +22,18 @@ var (
 	TelemetryDataplaneURL = ""
 )
 
+const (
+	// OutputText renders command results as human readable text.
+	OutputText = "text"
+	// OutputJSON renders command results as newline-delimited JSON records.
+	OutputJSON = "json"
+)
+
 type Config = struct {
 	Debug   bool   `mapstructure:"debug"`
 	Verbose bool   `mapstructure:"verbose"`
 	APIURL  string `mapstructure:"apiURL"`
+	Output  string `mapstructure:"output"`
 	Auth    struct {
 		AccessToken string `mapstructure:"accessToken"`
 	} `mapstructure:"auth"`
@@ -43,6 +51,17 @@ type Config = struct {
 		CatalogProvider   int `mapstructure:"catalogProvider"`
 		DataGraph         int `mapstructure:"dataGraph"`
 	}
+	API struct {
+		// MaxAttempts is the number of attempts made for a failed API request
+		// that is safe to retry, including the first one.
+		MaxAttempts int `mapstructure:"maxAttempts"`
+		// RetryMutating also retries POST and PATCH requests, which may then
+		// be applied twice if a response is lost.
+		RetryMutating bool `mapstructure:"retryMutating"`
+		// RateLimit caps the requests per second sent to the API; 0 disables it.
+		RateLimit      float64 `mapstructure:"rateLimit"`
+		RateLimitBurst int     `mapstructure:"rateLimitBurst"`
+	} `mapstructure:"api"`
 }
 
 func defaultConfigPath() string {
@@ -74,6 +93,7 @@ func InitConfig(cfgFile string) {
 	viper.SetDefault("debug", false)
 	viper.SetDefault("verbose", false)
 	viper.SetDefault("apiURL", client.BASE_URL)
+	viper.SetDefault("output", OutputText)
 	viper.SetDefault("telemetry.disabled", false)
 	viper.SetDefault("telemetry.writeKey", TelemetryWriteKey)
 	viper.SetDefault("telemetry.dataplaneURL", TelemetryDataplaneURL)
@@ -82,6 +102,10 @@ func InitConfig(cfgFile string) {
 	viper.SetDefault("concurrency.compositeProvider", 2)
 	viper.SetDefault("concurrency.catalogProvider", 4)
 	viper.SetDefault("concurrency.dataGraph", 4)
+	viper.SetDefault("api.maxAttempts", client.DefaultMaxAttempts)
+	viper.SetDefault("api.retryMutating", false)
+	viper.SetDefault("api.rateLimit", 0)
+	viper.SetDefault("api.rateLimitBurst", 10)
 
 	viper.BindEnv("auth.accessToken", "RUDDERSTACK_ACCESS_TOKEN")
 	viper.BindEnv("apiURL", "RUDDERSTACK_API_URL")
@@ -93,6 +117,9 @@ func InitConfig(cfgFile string) {
 	viper.BindEnv("concurrency.catalogClient", "RUDDERSTACK_CLI_CONCURRENCY_CATALOG_CLIENT")
 	viper.BindEnv("concurrency.compositeProvider", "RUDDERSTACK_CLI_CONCURRENCY_COMPOSITE_PROVIDER")
 	viper.BindEnv("concurrency.catalogProvider", "RUDDERSTACK_CLI_CONCURRENCY_CATALOG_PROVIDER")
+	viper.BindEnv("api.maxAttempts", "RUDDERSTACK_CLI_API_MAX_ATTEMPTS")
+	viper.BindEnv("api.retryMutating", "RUDDERSTACK_CLI_API_RETRY_MUTATING")
+	viper.BindEnv("api.rateLimit", "RUDDERSTACK_CLI_API_RATE_LIMIT")
 
 	// Automatically bind environment variables for all experimental flags
 	BindExperimentalFlags()
@@ -101,6 +128,16 @@ func InitConfig(cfgFile string) {
 	_ = viper.ReadInConfig()
 }
 
+// ValidateOutput checks that output is one of the supported output formats.
+func ValidateOutput(output string) error {
+	switch output {
+	case OutputText, OutputJSON:
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q, must be one of: %s, %s", output, OutputText, OutputJSON)
+	}
+}
+
 func createConfigFileIfNotExists(cfgFile string) error {
 	configPath := filepath.Dir(cfgFile)
 
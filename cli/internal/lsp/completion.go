@@ -0,0 +1,139 @@
+package lsp
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+var (
+	// kindValueRegex and versionValueRegex match a line being completed as
+	// the value of the top-level kind or version field.
+	kindValueRegex    = regexp.MustCompile(`^kind:\s*(\S*)$`)
+	versionValueRegex = regexp.MustCompile(`^version:\s*(\S*)$`)
+
+	// refPrefixRegex matches a "#<kind>:<id>" reference being typed at the
+	// end of the line; the second group is only present after the colon.
+	refPrefixRegex = regexp.MustCompile(`#([A-Za-z0-9_-]*)(?::([^\s"',\]}]*))?$`)
+)
+
+// completion offers the supported kinds and versions for the top-level kind
+// and version fields, and resource kinds and IDs from the resource graph for
+// references.
+func (s *Server) completion(params TextDocumentPositionParams) CompletionList {
+	list := CompletionList{Items: []CompletionItem{}}
+
+	line, ok := s.line(uriToPath(params.TextDocument.URI), params.Position.Line)
+	if !ok {
+		return list
+	}
+	before := line[:byteOffset(line, params.Position.Character)]
+
+	// replace builds an edit that substitutes typed with text, so clients
+	// whose word boundaries stop at '#' or ':' still replace the whole prefix.
+	replace := func(typed, text string) *TextEdit {
+		start := params.Position
+		start.Character -= utf16Len(typed)
+		return &TextEdit{Range: Range{Start: start, End: params.Position}, NewText: text}
+	}
+
+	switch {
+	case kindValueRegex.MatchString(before):
+		typed := kindValueRegex.FindStringSubmatch(before)[1]
+		for _, kind := range s.supportedKinds() {
+			list.Items = append(list.Items, CompletionItem{
+				Label:    kind,
+				Kind:     completionKindValue,
+				TextEdit: replace(typed, kind),
+			})
+		}
+
+	case versionValueRegex.MatchString(before):
+		typed := versionValueRegex.FindStringSubmatch(before)[1]
+		for _, version := range s.supportedVersions() {
+			list.Items = append(list.Items, CompletionItem{
+				Label:    version,
+				Kind:     completionKindValue,
+				TextEdit: replace(typed, version),
+			})
+		}
+
+	case refPrefixRegex.MatchString(before):
+		match := refPrefixRegex.FindStringSubmatch(before)
+		if !strings.Contains(match[0], ":") {
+			for _, kind := range s.referenceKinds() {
+				list.Items = append(list.Items, CompletionItem{
+					Label:    "#" + kind + ":",
+					Kind:     completionKindReference,
+					TextEdit: replace(match[0], "#"+kind+":"),
+				})
+			}
+			break
+		}
+		for _, id := range s.referenceIDs(match[1]) {
+			list.Items = append(list.Items, CompletionItem{
+				Label:    id,
+				Kind:     completionKindReference,
+				Detail:   resources.URN(id, match[1]),
+				TextEdit: replace(match[2], id),
+			})
+		}
+	}
+
+	return list
+}
+
+func (s *Server) supportedKinds() []string {
+	kinds := make(map[string]struct{})
+	for _, p := range s.cfg.MatchPatterns {
+		if p.Kind != "*" {
+			kinds[p.Kind] = struct{}{}
+		}
+	}
+	return sortedKeys(kinds)
+}
+
+func (s *Server) supportedVersions() []string {
+	versions := make(map[string]struct{})
+	for _, p := range s.cfg.MatchPatterns {
+		if p.Version != "*" {
+			versions[p.Version] = struct{}{}
+		}
+	}
+	return sortedKeys(versions)
+}
+
+// referenceKinds returns the resource types present in the graph, which are
+// the kinds a "#<kind>:<id>" reference can point at.
+func (s *Server) referenceKinds() []string {
+	kinds := make(map[string]struct{})
+	if s.graph != nil {
+		for _, r := range s.graph.Resources() {
+			kinds[r.Type()] = struct{}{}
+		}
+	}
+	return sortedKeys(kinds)
+}
+
+func (s *Server) referenceIDs(kind string) []string {
+	ids := make(map[string]struct{})
+	if s.graph != nil {
+		for _, r := range s.graph.Resources() {
+			if r.Type() == kind {
+				ids[r.ID()] = struct{}{}
+			}
+		}
+	}
+	return sortedKeys(ids)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,155 @@
+package lsp
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+var (
+	// refRegex matches a complete "#<kind>:<id>" reference.
+	refRegex = regexp.MustCompile(`#([A-Za-z0-9_-]+):([^\s"',\]}]+)`)
+
+	// legacyRefRegex matches a complete "#/<kind>/<group>/<id>" reference of
+	// a legacy spec, where kind is the kind of the declaring spec.
+	legacyRefRegex = regexp.MustCompile(`#/([A-Za-z0-9_-]+)/([^\s"',\]}/]+)/([^\s"',\]}/]+)`)
+)
+
+// definition resolves the reference under the cursor to the places its target
+// resource is declared. The reference must resolve to a resource in the graph;
+// the declaration is then located among the URNs the project specs declare.
+func (s *Server) definition(params TextDocumentPositionParams) []Location {
+	line, ok := s.line(uriToPath(params.TextDocument.URI), params.Position.Line)
+	if !ok {
+		return nil
+	}
+	cursor := byteOffset(line, params.Position.Character)
+
+	urn, ok := s.referenceAt(line, cursor)
+	if !ok || s.graph == nil {
+		return nil
+	}
+	if _, ok := s.graph.GetResource(urn); !ok {
+		return nil
+	}
+	return s.declarations(urn)
+}
+
+// referenceAt returns the URN of the reference spanning cursor in line.
+func (s *Server) referenceAt(line string, cursor int) (string, bool) {
+	for _, m := range refRegex.FindAllStringSubmatchIndex(line, -1) {
+		if cursor < m[0] || cursor > m[1] {
+			continue
+		}
+		kind, id := line[m[2]:m[3]], line[m[4]:m[5]]
+		return resources.URN(id, kind), true
+	}
+
+	for _, m := range legacyRefRegex.FindAllStringSubmatchIndex(line, -1) {
+		if cursor < m[0] || cursor > m[1] {
+			continue
+		}
+		kind, id := line[m[2]:m[3]], line[m[6]:m[7]]
+		return s.legacyURN(kind, id)
+	}
+
+	return "", false
+}
+
+// legacyURN resolves a legacy reference through the URNs declared by the
+// specs of its kind, as the group segment does not take part in resolution.
+func (s *Server) legacyURN(kind, id string) (string, bool) {
+	for _, key := range sortedKeys(s.declared) {
+		if spec, ok := s.specs[key]; !ok || spec.Kind != kind {
+			continue
+		}
+		for _, entry := range s.declared[key] {
+			if strings.HasSuffix(entry.URN, ":"+id) {
+				return entry.URN, true
+			}
+		}
+	}
+	return "", false
+}
+
+// declarations finds the `id` entries declaring urn across the loaded specs,
+// in file order.
+func (s *Server) declarations(urn string) []Location {
+	var locations []Location
+	for _, key := range sortedKeys(s.declared) {
+		rawSpec, ok := s.rawSpecs[key]
+		if !ok {
+			continue
+		}
+
+		for _, entry := range s.declared[key] {
+			if entry.URN != urn {
+				continue
+			}
+
+			valueNode := lookupPointer(rawSpec.Data, entry.JSONPointerPath)
+			if valueNode == nil {
+				continue
+			}
+
+			// Node lines are relative to the document, which may be one
+			// of several in its file.
+			start := Position{Line: valueNode.Line - 1 + rawSpec.LineOffset(), Character: valueNode.Column - 1}
+			end := Position{Line: start.Line, Character: start.Character + utf16Len(valueNode.Value)}
+			locations = append(locations, Location{
+				URI:   pathToURI(specs.DocumentFile(key)),
+				Range: Range{Start: start, End: end},
+			})
+		}
+	}
+	return locations
+}
+
+// lookupPointer returns the node a JSON pointer refers to within the YAML
+// document data, or nil when it does not exist.
+func lookupPointer(data []byte, pointer string) *yaml.Node {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return nil
+	}
+
+	node := doc.Content[0]
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		switch node.Kind {
+		case yaml.MappingNode:
+			var next *yaml.Node
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == token {
+					next = node.Content[i+1]
+					break
+				}
+			}
+			if next == nil {
+				return nil
+			}
+			node = next
+
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[i]
+
+		default:
+			return nil
+		}
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return nil
+	}
+	return node
+}
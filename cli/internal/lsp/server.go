@@ -0,0 +1,250 @@
+// Package lsp implements a Language Server Protocol server for rudder spec
+// YAML files. It runs the same validation pipeline as `rudder-cli validate`
+// on every change, over the saved project plus any unsaved editor buffers,
+// and uses the resulting resource graph to complete and resolve references.
+package lsp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/logger"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+)
+
+var log = logger.New("lsp")
+
+var (
+	errParse = errors.New("parse error")
+
+	// ErrExitWithoutShutdown is returned by Serve when the client sends exit
+	// without a preceding shutdown request; the protocol asks for a non-zero
+	// exit code in that case.
+	ErrExitWithoutShutdown = errors.New("exit received before shutdown")
+)
+
+// NewProjectFunc creates the project validated on every change. The server
+// appends its own loader and renderer options so that unsaved buffers are
+// overlaid on the files on disk and diagnostics are collected rather than
+// printed. Every call must return a project backed by fresh providers, since
+// providers accumulate the specs loaded into them.
+type NewProjectFunc func(opts ...project.ProjectOption) (project.Project, error)
+
+// Config configures a Server.
+type Config struct {
+	NewProject NewProjectFunc
+
+	// MatchPatterns are the supported (kind, version) pairs, offered as
+	// completions for the kind and version fields.
+	MatchPatterns []rules.MatchPattern
+
+	// Version is reported to the client in the initialize response.
+	Version string
+}
+
+// Server is a single-client LSP server. Messages are handled one at a time in
+// the order they arrive, so no state is shared across goroutines.
+type Server struct {
+	cfg  Config
+	conn *conn
+
+	root         string
+	initialized  bool
+	shuttingDown bool
+
+	// documents holds the text of every open document, keyed by file path.
+	documents map[string]string
+	// files holds the content of every spec the last validation run loaded,
+	// with open documents already overlaid.
+	files map[string][]byte
+	// rawSpecs, specs and declared hold, by spec key, the documents the last
+	// validation run loaded, their parsed form and the URNs they declare.
+	rawSpecs map[string]*specs.RawSpec
+	specs    map[string]*specs.Spec
+	declared map[string][]specs.URNEntry
+	// published tracks the files last sent non-empty diagnostics, so they can
+	// be cleared once their problems are fixed.
+	published map[string]struct{}
+	// graph is the last non-empty resource graph a validation run built. It is
+	// kept across runs that fail before graph construction so completion and
+	// go-to-definition keep working while a file is mid-edit.
+	graph *resources.Graph
+}
+
+func NewServer(cfg Config) *Server {
+	return &Server{
+		cfg:       cfg,
+		documents: make(map[string]string),
+		files:     make(map[string][]byte),
+		published: make(map[string]struct{}),
+	}
+}
+
+// Serve reads requests from r and writes responses and notifications to w
+// until the client sends exit or closes the stream.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	s.conn = newConn(r, w)
+
+	for {
+		msg, err := s.conn.read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if errors.Is(err, errParse) {
+			if err := s.conn.replyError(nil, codeParseError, err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if msg.Method == methodExit {
+			if !s.shuttingDown {
+				return ErrExitWithoutShutdown
+			}
+			return nil
+		}
+
+		if err := s.handle(msg); err != nil {
+			return fmt.Errorf("handling %s: %w", msg.Method, err)
+		}
+	}
+}
+
+// handle dispatches a single message. Only failures to write to the client
+// are returned; everything else is answered with an error response or, for
+// notifications, logged.
+func (s *Server) handle(msg *message) error {
+	isRequest := msg.ID != nil
+
+	if !s.initialized && msg.Method != methodInitialize {
+		if isRequest {
+			return s.conn.replyError(msg.ID, codeServerNotInitialized, "server not initialized")
+		}
+		return nil
+	}
+
+	if s.shuttingDown && isRequest {
+		return s.conn.replyError(msg.ID, codeInvalidRequest, "server is shutting down")
+	}
+
+	switch msg.Method {
+	case methodInitialize:
+		var params InitializeParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return s.conn.replyError(msg.ID, codeInvalidParams, err.Error())
+		}
+		return s.conn.reply(msg.ID, s.initialize(params))
+
+	case methodInitialized:
+		s.validate()
+		return nil
+
+	case methodShutdown:
+		s.shuttingDown = true
+		return s.conn.reply(msg.ID, nil)
+
+	case methodDidOpen:
+		var params DidOpenTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			log.Error("decoding didOpen params", "error", err)
+			return nil
+		}
+		s.documents[uriToPath(params.TextDocument.URI)] = params.TextDocument.Text
+		s.validate()
+		return nil
+
+	case methodDidChange:
+		var params DidChangeTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			log.Error("decoding didChange params", "error", err)
+			return nil
+		}
+		// Full document sync: the last change carries the whole text.
+		if n := len(params.ContentChanges); n > 0 {
+			s.documents[uriToPath(params.TextDocument.URI)] = params.ContentChanges[n-1].Text
+			s.validate()
+		}
+		return nil
+
+	case methodDidSave:
+		s.validate()
+		return nil
+
+	case methodDidClose:
+		var params DidCloseTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			log.Error("decoding didClose params", "error", err)
+			return nil
+		}
+		delete(s.documents, uriToPath(params.TextDocument.URI))
+		s.validate()
+		return nil
+
+	case methodCompletion:
+		var params TextDocumentPositionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return s.conn.replyError(msg.ID, codeInvalidParams, err.Error())
+		}
+		return s.conn.reply(msg.ID, s.completion(params))
+
+	case methodDefinition:
+		var params TextDocumentPositionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return s.conn.replyError(msg.ID, codeInvalidParams, err.Error())
+		}
+		locations := s.definition(params)
+		if len(locations) == 0 {
+			return s.conn.reply(msg.ID, nil)
+		}
+		return s.conn.reply(msg.ID, locations)
+
+	default:
+		if isRequest {
+			return s.conn.replyError(msg.ID, codeMethodNotFound, fmt.Sprintf("method not supported: %s", msg.Method))
+		}
+		return nil
+	}
+}
+
+func (s *Server) initialize(params InitializeParams) InitializeResult {
+	switch {
+	case params.RootURI != "":
+		s.root = uriToPath(params.RootURI)
+	case len(params.WorkspaceFolders) > 0:
+		s.root = uriToPath(params.WorkspaceFolders[0].URI)
+	case params.RootPath != "":
+		s.root = filepath.Clean(params.RootPath)
+	default:
+		s.root = "."
+	}
+	s.initialized = true
+
+	return InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync: textDocumentSyncFull,
+			CompletionProvider: &CompletionOptions{
+				TriggerCharacters: []string{"#", ":", " "},
+			},
+			DefinitionProvider: true,
+		},
+		ServerInfo: ServerInfo{Name: "rudder-cli", Version: s.cfg.Version},
+	}
+}
+
+// logError surfaces a failure that has no diagnostic to attach to, such as a
+// cycle in the resource graph, in the client's output log.
+func (s *Server) logError(message string) {
+	log.Error(message)
+	if err := s.conn.notify(methodLogMessage, LogMessageParams{Type: messageTypeError, Message: message}); err != nil {
+		log.Error("sending log message", "error", err)
+	}
+}
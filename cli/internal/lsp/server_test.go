@@ -0,0 +1,342 @@
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/project"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/testutils"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+)
+
+const propertiesSpec = `version: rudder/v1
+kind: properties
+metadata:
+  name: props
+spec:
+  properties:
+    - id: user_id
+      name: User ID
+`
+
+// eventsSpec declares an event sharing its id with the property above.
+const eventsSpec = `version: rudder/v1
+kind: events
+metadata:
+  name: tracked
+spec:
+  events:
+    - id: user_id
+      name: User ID
+`
+
+// testClient drives a Server over in-memory pipes the way an editor would.
+type testClient struct {
+	t        *testing.T
+	w        io.Writer
+	messages chan *message
+	nextID   int
+	done     chan error
+}
+
+func startServer(t *testing.T, cfg Config) *testClient {
+	t.Helper()
+
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+
+	c := &testClient{
+		t:        t,
+		w:        clientW,
+		messages: make(chan *message, 100),
+		done:     make(chan error, 1),
+	}
+
+	go func() {
+		c.done <- NewServer(cfg).Serve(serverR, serverW)
+		serverW.Close()
+	}()
+
+	// Read continuously so the server never blocks writing notifications
+	// while the client is busy writing requests.
+	go func() {
+		defer close(c.messages)
+		r := newConn(clientR, nil)
+		for {
+			msg, err := r.read()
+			if err != nil {
+				return
+			}
+			c.messages <- msg
+		}
+	}()
+
+	t.Cleanup(func() { clientW.Close() })
+
+	return c
+}
+
+func (c *testClient) send(msg map[string]any) {
+	c.t.Helper()
+	msg["jsonrpc"] = "2.0"
+	data, err := json.Marshal(msg)
+	require.NoError(c.t, err)
+	_, err = fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n%s", len(data), data)
+	require.NoError(c.t, err)
+}
+
+func (c *testClient) readMessage() *message {
+	c.t.Helper()
+	select {
+	case msg, ok := <-c.messages:
+		require.True(c.t, ok, "server closed the connection")
+		return msg
+	case <-time.After(10 * time.Second):
+		require.FailNow(c.t, "timed out waiting for a message")
+		return nil
+	}
+}
+
+// request sends a request and returns its raw result, skipping any
+// notifications that arrive first.
+func (c *testClient) request(method string, params any) json.RawMessage {
+	c.t.Helper()
+	c.nextID++
+	c.send(map[string]any{"id": c.nextID, "method": method, "params": params})
+
+	for {
+		msg := c.readMessage()
+		if msg.ID == nil {
+			continue
+		}
+		require.Nil(c.t, msg.Error)
+		data, err := json.Marshal(msg.Result)
+		require.NoError(c.t, err)
+		return data
+	}
+}
+
+func (c *testClient) notify(method string, params any) {
+	c.t.Helper()
+	c.send(map[string]any{"method": method, "params": params})
+}
+
+// diagnostics reads notifications until one publishes diagnostics for uri.
+func (c *testClient) diagnostics(uri string) []Diagnostic {
+	c.t.Helper()
+	for {
+		msg := c.readMessage()
+		if msg.Method != methodPublishDiagnostics {
+			continue
+		}
+		var params PublishDiagnosticsParams
+		require.NoError(c.t, json.Unmarshal(msg.Params, &params))
+		if params.URI == uri {
+			return params.Diagnostics
+		}
+	}
+}
+
+// specProvider declares the resources listed under the spec key named after
+// each kind, the way the data catalog provider does.
+type specProvider struct {
+	*testutils.MockProvider
+}
+
+var specResourceTypes = map[string]string{"properties": "property", "events": "event"}
+
+func (p *specProvider) ParseSpec(_ string, s *specs.Spec) (*specs.ParsedSpec, error) {
+	resourceType, ok := specResourceTypes[s.Kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown kind %s", s.Kind)
+	}
+
+	items, _ := s.Spec[s.Kind].([]any)
+	parsed := &specs.ParsedSpec{URNs: []specs.URNEntry{}}
+	for i, item := range items {
+		id, _ := item.(map[string]any)["id"].(string)
+		parsed.URNs = append(parsed.URNs, specs.URNEntry{
+			URN:             resources.URN(id, resourceType),
+			JSONPointerPath: fmt.Sprintf("/spec/%s/%d/id", s.Kind, i),
+		})
+	}
+	return parsed, nil
+}
+
+func testConfig() Config {
+	return Config{
+		NewProject: func(opts ...project.ProjectOption) (project.Project, error) {
+			provider := &specProvider{MockProvider: testutils.NewMockProvider(nil, nil)}
+			provider.MatchPatterns = []rules.MatchPattern{
+				rules.MatchKindVersion("properties", "rudder/v1"),
+				rules.MatchKindVersion("events", "rudder/v1"),
+			}
+			graph := resources.NewGraph()
+			graph.AddResource(resources.NewResource("user_id", "property", resources.ResourceData{}, nil))
+			graph.AddResource(resources.NewResource("signup", "event", resources.ResourceData{}, nil))
+			graph.AddResource(resources.NewResource("user_id", "event", resources.ResourceData{}, nil))
+			provider.GetResourceGraphVal = graph
+			return project.New(provider, append(opts, project.WithOffline())...), nil
+		},
+		MatchPatterns: []rules.MatchPattern{
+			rules.MatchKindVersion("properties", "rudder/v1"),
+			rules.MatchKindVersion("events", "rudder/v1"),
+		},
+	}
+}
+
+func TestServer(t *testing.T) {
+	root := t.TempDir()
+	propertiesPath := filepath.Join(root, "properties.yaml")
+	require.NoError(t, os.WriteFile(propertiesPath, []byte(propertiesSpec), 0644))
+	trackedPath := filepath.Join(root, "tracked.yaml")
+	require.NoError(t, os.WriteFile(trackedPath, []byte(eventsSpec), 0644))
+
+	eventsURI := pathToURI(filepath.Join(root, "events.yaml"))
+
+	c := startServer(t, testConfig())
+
+	var initResult InitializeResult
+	require.NoError(t, json.Unmarshal(c.request(methodInitialize, InitializeParams{RootURI: pathToURI(root)}), &initResult))
+	assert.Equal(t, textDocumentSyncFull, initResult.Capabilities.TextDocumentSync)
+	assert.True(t, initResult.Capabilities.DefinitionProvider)
+	c.notify(methodInitialized, struct{}{})
+
+	t.Run("publishes diagnostics for unsaved buffers", func(t *testing.T) {
+		c.notify(methodDidOpen, DidOpenTextDocumentParams{TextDocument: TextDocumentItem{
+			URI:  eventsURI,
+			Text: "version: rudder/v1\nkind: unknown\nmetadata:\n  name: events\nspec:\n  events: []\n",
+		}})
+
+		diags := c.diagnostics(eventsURI)
+		require.Len(t, diags, 1)
+		assert.Equal(t, "project/spec-syntax-valid", diags[0].Code)
+		assert.Equal(t, severityError, diags[0].Severity)
+		assert.Equal(t, Range{Start: Position{Line: 1, Character: 0}, End: Position{Line: 1, Character: 13}}, diags[0].Range)
+
+		c.notify(methodDidChange, DidChangeTextDocumentParams{
+			TextDocument: TextDocumentIdentifier{URI: eventsURI},
+			ContentChanges: []TextDocumentContentChangeEvent{{
+				Text: "version: rudder/v1\nkind: events\nmetadata:\n  name: events\nspec:\n  events:\n    - id: signup\n      property: \"#property:user_id\"\n",
+			}},
+		})
+		assert.Empty(t, c.diagnostics(eventsURI), "fixed file has its diagnostics cleared")
+	})
+
+	t.Run("completes kinds", func(t *testing.T) {
+		c.notify(methodDidChange, DidChangeTextDocumentParams{
+			TextDocument: TextDocumentIdentifier{URI: eventsURI},
+			ContentChanges: []TextDocumentContentChangeEvent{{
+				Text: "version: rudder/v1\nkind: ev\n",
+			}},
+		})
+
+		var list CompletionList
+		require.NoError(t, json.Unmarshal(c.request(methodCompletion, TextDocumentPositionParams{
+			TextDocument: TextDocumentIdentifier{URI: eventsURI},
+			Position:     Position{Line: 1, Character: 8},
+		}), &list))
+
+		require.Len(t, list.Items, 2)
+		assert.Equal(t, "events", list.Items[0].Label)
+		assert.Equal(t, &TextEdit{
+			Range:   Range{Start: Position{Line: 1, Character: 6}, End: Position{Line: 1, Character: 8}},
+			NewText: "events",
+		}, list.Items[0].TextEdit)
+		assert.Equal(t, "properties", list.Items[1].Label)
+	})
+
+	t.Run("completes references", func(t *testing.T) {
+		c.notify(methodDidChange, DidChangeTextDocumentParams{
+			TextDocument: TextDocumentIdentifier{URI: eventsURI},
+			ContentChanges: []TextDocumentContentChangeEvent{{
+				Text: "ref: \"#pro\"\nref: \"#property:us\"\n",
+			}},
+		})
+
+		var kinds CompletionList
+		require.NoError(t, json.Unmarshal(c.request(methodCompletion, TextDocumentPositionParams{
+			TextDocument: TextDocumentIdentifier{URI: eventsURI},
+			Position:     Position{Line: 0, Character: 10},
+		}), &kinds))
+		labels := make([]string, 0, len(kinds.Items))
+		for _, item := range kinds.Items {
+			labels = append(labels, item.Label)
+		}
+		assert.Equal(t, []string{"#event:", "#property:"}, labels)
+
+		var ids CompletionList
+		require.NoError(t, json.Unmarshal(c.request(methodCompletion, TextDocumentPositionParams{
+			TextDocument: TextDocumentIdentifier{URI: eventsURI},
+			Position:     Position{Line: 1, Character: 18},
+		}), &ids))
+		require.Len(t, ids.Items, 1)
+		assert.Equal(t, "user_id", ids.Items[0].Label)
+		assert.Equal(t, "property:user_id", ids.Items[0].Detail)
+	})
+
+	t.Run("resolves definitions through the resource graph", func(t *testing.T) {
+		c.notify(methodDidChange, DidChangeTextDocumentParams{
+			TextDocument: TextDocumentIdentifier{URI: eventsURI},
+			ContentChanges: []TextDocumentContentChangeEvent{{
+				Text: "property: \"#property:user_id\"\nmissing: \"#property:nope\"\nevent: \"#event:user_id\"\nlegacy: \"#/properties/props/user_id\"\n",
+			}},
+		})
+
+		definition := func(line, character int) []Location {
+			var locations []Location
+			require.NoError(t, json.Unmarshal(c.request(methodDefinition, TextDocumentPositionParams{
+				TextDocument: TextDocumentIdentifier{URI: eventsURI},
+				Position:     Position{Line: line, Character: character},
+			}), &locations))
+			return locations
+		}
+
+		propertyLocation := Location{
+			URI:   pathToURI(propertiesPath),
+			Range: Range{Start: Position{Line: 6, Character: 10}, End: Position{Line: 6, Character: 17}},
+		}
+		assert.Equal(t, []Location{propertyLocation}, definition(0, 15))
+		assert.Nil(t, definition(1, 15))
+
+		t.Run("only matches declarations of the referenced kind", func(t *testing.T) {
+			assert.Equal(t, []Location{{
+				URI:   pathToURI(trackedPath),
+				Range: Range{Start: Position{Line: 6, Character: 10}, End: Position{Line: 6, Character: 17}},
+			}}, definition(2, 12))
+		})
+
+		t.Run("resolves legacy path references by spec kind", func(t *testing.T) {
+			assert.Equal(t, []Location{propertyLocation}, definition(3, 15))
+		})
+	})
+
+	c.request(methodShutdown, nil)
+	c.notify(methodExit, nil)
+	assert.NoError(t, <-c.done)
+}
+
+func TestServer_ExitWithoutShutdown(t *testing.T) {
+	c := startServer(t, testConfig())
+	c.notify(methodExit, nil)
+	assert.ErrorIs(t, <-c.done, ErrExitWithoutShutdown)
+}
+
+func TestServer_RequestBeforeInitialize(t *testing.T) {
+	c := startServer(t, testConfig())
+	c.send(map[string]any{"id": 1, "method": methodCompletion, "params": struct{}{}})
+
+	msg := c.readMessage()
+	require.NotNil(t, msg.Error)
+	assert.Equal(t, codeServerNotInitialized, msg.Error.Code)
+}
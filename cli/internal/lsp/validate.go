@@ -0,0 +1,271 @@
+package lsp
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/project"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/loader"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+)
+
+const diagnosticSource = "rudder-cli"
+
+// overlayLoader loads the project from disk and replaces the content of any
+// spec open in the editor with its unsaved text. Open documents that do not
+// exist on disk yet are added, as long as they are spec files under root.
+type overlayLoader struct {
+	root      string
+	documents map[string]string
+
+	// files records what was loaded, for completion and definition lookups.
+	files map[string][]byte
+	// rawSpecs records the loaded documents by spec key, to locate the
+	// declarations within them.
+	rawSpecs map[string]*specs.RawSpec
+}
+
+func (l *overlayLoader) Load(location string) (map[string]*specs.RawSpec, error) {
+	rawSpecs, err := (&loader.Loader{}).Load(location)
+	if err != nil {
+		return nil, err
+	}
+
+	for path, text := range l.documents {
+		if !isSpecFile(path) || !isWithin(l.root, path) {
+			continue
+		}
+		for key := range rawSpecs {
+			if specs.DocumentFile(key) == path {
+				delete(rawSpecs, key)
+			}
+		}
+		for key, rawSpec := range specs.SplitDocuments(path, []byte(text)) {
+			rawSpecs[key] = rawSpec
+		}
+	}
+
+	l.rawSpecs = rawSpecs
+	l.files = make(map[string][]byte, len(rawSpecs))
+	for key, rawSpec := range rawSpecs {
+		path := specs.DocumentFile(key)
+		if _, ok := l.files[path]; ok {
+			continue
+		}
+		// Documents of a multi-document file only hold part of it.
+		if key == path {
+			l.files[path] = rawSpec.Data
+		} else if text, ok := l.documents[path]; ok {
+			l.files[path] = []byte(text)
+		} else if data, err := os.ReadFile(path); err == nil {
+			l.files[path] = data
+		}
+	}
+	return rawSpecs, nil
+}
+
+// collectingRenderer keeps diagnostics in memory so they can be published to
+// the client instead of printed.
+type collectingRenderer struct {
+	diagnostics validation.Diagnostics
+}
+
+func (r *collectingRenderer) Render(diagnostics validation.Diagnostics) error {
+	r.diagnostics = append(r.diagnostics, diagnostics...)
+	return nil
+}
+
+// validate runs the full syntactic and semantic validation over the project
+// and publishes the resulting diagnostics.
+func (s *Server) validate() {
+	var (
+		overlay   = &overlayLoader{root: s.root, documents: s.documents}
+		collector = &collectingRenderer{}
+	)
+
+	p, err := s.cfg.NewProject(project.WithLoader(overlay), project.WithRenderer(collector))
+	if err != nil {
+		s.logError(fmt.Sprintf("initialising project: %s", err))
+		return
+	}
+
+	loadErr := p.Load(s.root)
+	if overlay.files != nil {
+		s.files = overlay.files
+		s.rawSpecs = overlay.rawSpecs
+		s.specs = p.Specs()
+		s.declared = p.DeclaredURNs()
+	}
+
+	if graph, err := p.ResourceGraph(); err == nil && graph != nil && len(graph.Resources()) > 0 {
+		s.graph = graph
+	}
+
+	// Validation failures are already described by the diagnostics; only
+	// errors without any (e.g. a dependency cycle) need reporting separately.
+	if loadErr != nil && len(collector.diagnostics) == 0 {
+		s.logError(fmt.Sprintf("validating project: %s", loadErr))
+	}
+
+	s.publish(collector.diagnostics)
+}
+
+// publish sends diagnostics grouped per file, and clears diagnostics for
+// files that had some on the previous run but none now.
+func (s *Server) publish(diagnostics validation.Diagnostics) {
+	byFile := make(map[string][]Diagnostic)
+	for _, d := range diagnostics {
+		byFile[d.File] = append(byFile[d.File], s.toLSPDiagnostic(d))
+	}
+
+	for path := range s.published {
+		if _, ok := byFile[path]; !ok {
+			byFile[path] = []Diagnostic{}
+		}
+	}
+
+	paths := make([]string, 0, len(byFile))
+	for path := range byFile {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	s.published = make(map[string]struct{})
+	for _, path := range paths {
+		if len(byFile[path]) > 0 {
+			s.published[path] = struct{}{}
+		}
+		if err := s.conn.notify(methodPublishDiagnostics, PublishDiagnosticsParams{
+			URI:         pathToURI(path),
+			Diagnostics: byFile[path],
+		}); err != nil {
+			log.Error("publishing diagnostics", "file", path, "error", err)
+		}
+	}
+}
+
+// toLSPDiagnostic converts the engine's 1-based line and column into the
+// protocol's 0-based position, spanning to the end of the offending line.
+func (s *Server) toLSPDiagnostic(d validation.Diagnostic) Diagnostic {
+	var start Position
+	if d.Position.Line > 0 {
+		start.Line = d.Position.Line - 1
+	}
+	if d.Position.Column > 0 {
+		start.Character = d.Position.Column - 1
+	}
+
+	end := start
+	if line, ok := s.line(d.File, start.Line); ok {
+		end.Character = utf16Len(line)
+		if end.Character < start.Character {
+			end.Character = start.Character
+		}
+	}
+
+	return Diagnostic{
+		Range:    Range{Start: start, End: end},
+		Severity: lspSeverity(d.Severity),
+		Code:     d.RuleID,
+		Source:   diagnosticSource,
+		Message:  d.Message,
+	}
+}
+
+func lspSeverity(s rules.Severity) int {
+	switch s {
+	case rules.Error:
+		return severityError
+	case rules.Warning:
+		return severityWarning
+	default:
+		return severityInformation
+	}
+}
+
+// text returns the current content of path: the editor buffer when open,
+// otherwise what the last validation run loaded.
+func (s *Server) text(path string) (string, bool) {
+	if text, ok := s.documents[path]; ok {
+		return text, true
+	}
+	data, ok := s.files[path]
+	return string(data), ok
+}
+
+func (s *Server) line(path string, n int) (string, bool) {
+	text, ok := s.text(path)
+	if !ok {
+		return "", false
+	}
+	lines := strings.Split(text, "\n")
+	if n < 0 || n >= len(lines) {
+		return "", false
+	}
+	return strings.TrimSuffix(lines[n], "\r"), true
+}
+
+func isSpecFile(path string) bool {
+	ext := filepath.Ext(path)
+	if ext != loader.ExtensionYAML && ext != loader.ExtensionYML {
+		return false
+	}
+	return !strings.HasSuffix(path, loader.VarFileInfix+ext)
+}
+
+func isWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return filepath.Clean(uri)
+	}
+	return filepath.Clean(filepath.FromSlash(u.Path))
+}
+
+func pathToURI(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// utf16Len returns the length of s in UTF-16 code units, the unit LSP
+// positions are expressed in.
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// byteOffset converts a UTF-16 character offset within line into a byte
+// offset, clamped to the length of the line.
+func byteOffset(line string, character int) int {
+	n := 0
+	for i, r := range line {
+		if n >= character {
+			return i
+		}
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return len(line)
+}
@@ -2,6 +2,7 @@ package project
 
 import (
 	"github.com/rudderlabs/rudder-iac/cli/internal/project/importmanifest"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/lifecycle"
 	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
 )
 
@@ -16,7 +17,8 @@ const (
 // in package project, not specs, so it can route by the owning provider's kind
 // without making the spec data model depend on a provider.
 func classify(s *specs.Spec) SpecLevel {
-	if s.Kind == importmanifest.KindImportManifest {
+	switch s.Kind {
+	case importmanifest.KindImportManifest, lifecycle.KindLifecycle:
 		return ProjectSpec
 	}
 	return ResourceSpec
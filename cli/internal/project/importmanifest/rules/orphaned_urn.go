@@ -35,6 +35,10 @@ func (r *orphanedURNRule) AppliesTo() []vrules.MatchPattern {
 
 func (r *orphanedURNRule) Examples() vrules.Examples { return vrules.Examples{} }
 
+// WorkspaceScoped marks the rule as depending on the active workspace, so
+// offline validation without a workspace ID skips it.
+func (r *orphanedURNRule) WorkspaceScoped() bool { return true }
+
 func (r *orphanedURNRule) Validate(ctx *vrules.ValidationContext) []vrules.ValidationResult {
 	if !ctx.HasGraph() {
 		return nil
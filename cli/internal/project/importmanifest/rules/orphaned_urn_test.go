@@ -57,6 +57,7 @@ func TestOrphanedURNRule_Metadata(t *testing.T) {
 		[]vrules.MatchPattern{vrules.MatchKindVersion(manifestspec.KindImportManifest, specs.SpecVersionV1)},
 		r.AppliesTo(),
 	)
+	assert.True(t, vrules.IsWorkspaceScoped(r))
 }
 
 func TestOrphanedURNRule_Validate(t *testing.T) {
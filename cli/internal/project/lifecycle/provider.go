@@ -0,0 +1,133 @@
+// Package lifecycle provides a project-level provider for lifecycle specs,
+// which list the resources apply and destroy must never delete or replace.
+// Like import-manifest, it lives outside the CompositeProvider tree and
+// contributes no resource graph nodes.
+//
+// Protection declared here does not depend on the specs of the resources it
+// protects, so it still applies once a resource is removed from the project:
+//
+//	version: rudder/v1
+//	kind: lifecycle
+//	metadata:
+//	  name: protection
+//	spec:
+//	  prevent_destroy:
+//	    - "tracking-plan:*"
+//	    - "event:signup"
+package lifecycle
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/go-viper/mapstructure/v2"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/docs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+)
+
+// KindLifecycle is the spec kind this provider owns.
+const KindLifecycle = "lifecycle"
+
+// Spec is the payload of a lifecycle spec.
+type Spec struct {
+	// PreventDestroy lists URNs or globs over URNs, e.g. "tracking-plan:*",
+	// of the resources plans must not delete or replace.
+	PreventDestroy []string `yaml:"prevent_destroy"`
+}
+
+type Provider struct {
+	preventDestroy []string
+}
+
+func New() *Provider {
+	return &Provider{}
+}
+
+// MatchPatterns are the (kind, version) pairs of lifecycle specs.
+func MatchPatterns() []rules.MatchPattern {
+	return []rules.MatchPattern{
+		rules.MatchKindVersion(KindLifecycle, specs.SpecVersionV1),
+	}
+}
+
+func (p *Provider) SupportedKinds() []string {
+	return []string{KindLifecycle}
+}
+
+func (p *Provider) SupportedTypes() []string {
+	return nil
+}
+
+func (p *Provider) SupportedMatchPatterns() []rules.MatchPattern {
+	return MatchPatterns()
+}
+
+// LoadSpec adds the spec's prevent_destroy patterns to the ones already
+// loaded, so protection may be split across several lifecycle specs.
+func (p *Provider) LoadSpec(path string, s *specs.Spec) error {
+	spec, err := decodeSpec(s.Spec)
+	if err != nil {
+		return fmt.Errorf("parsing lifecycle spec %s: %w", path, err)
+	}
+	p.preventDestroy = append(p.preventDestroy, spec.PreventDestroy...)
+	return nil
+}
+
+func (p *Provider) LoadLegacySpec(path string, s *specs.Spec) error {
+	return fmt.Errorf("lifecycle spec %s does not support legacy version %s", path, s.Version)
+}
+
+// ParseSpec declares no URNs: a lifecycle spec refers to resources, it does
+// not define any.
+func (p *Provider) ParseSpec(_ string, _ *specs.Spec) (*specs.ParsedSpec, error) {
+	return &specs.ParsedSpec{URNs: []specs.URNEntry{}}, nil
+}
+
+func (p *Provider) ResourceGraph() (*resources.Graph, error) {
+	return resources.NewGraph(), nil
+}
+
+func (p *Provider) SyntacticRules() []rules.Rule {
+	return nil
+}
+
+func (p *Provider) SemanticRules() []rules.Rule {
+	return nil
+}
+
+func (p *Provider) RuleDocEntries() []docs.RuleDocEntry {
+	return nil
+}
+
+// PreventDestroy returns the prevent_destroy patterns of every loaded
+// lifecycle spec.
+func (p *Provider) PreventDestroy() []string {
+	return p.preventDestroy
+}
+
+// decodeSpec strict-decodes a lifecycle spec, rejecting unknown fields and
+// malformed patterns.
+func decodeSpec(spec map[string]any) (*Spec, error) {
+	var s Spec
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused: true,
+		Result:      &s,
+		TagName:     "yaml",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building decoder: %w", err)
+	}
+	if err := decoder.Decode(spec); err != nil {
+		return nil, fmt.Errorf("decoding spec: %w", err)
+	}
+
+	for _, pattern := range s.PreventDestroy {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid prevent_destroy pattern %q: %w", pattern, err)
+		}
+	}
+	return &s, nil
+}
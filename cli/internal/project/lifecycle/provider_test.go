@@ -0,0 +1,74 @@
+package lifecycle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+)
+
+func lifecycleSpec(spec map[string]any) *specs.Spec {
+	return &specs.Spec{
+		Kind:    KindLifecycle,
+		Version: specs.SpecVersionV1,
+		Spec:    spec,
+	}
+}
+
+func TestProvider_LoadSpec(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	require.NoError(t, p.LoadSpec("a.yaml", lifecycleSpec(map[string]any{
+		"prevent_destroy": []any{"tracking-plan:*"},
+	})))
+	require.NoError(t, p.LoadSpec("b.yaml", lifecycleSpec(map[string]any{
+		"prevent_destroy": []any{"event:signup"},
+	})))
+
+	assert.Equal(t, []string{"tracking-plan:*", "event:signup"}, p.PreventDestroy())
+}
+
+func TestProvider_LoadSpec_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		spec          map[string]any
+		errorContains string
+	}{
+		{
+			name:          "unknown field",
+			spec:          map[string]any{"prevent_destroy": []any{"event:*"}, "protect": true},
+			errorContains: "protect",
+		},
+		{
+			name:          "malformed pattern",
+			spec:          map[string]any{"prevent_destroy": []any{"event:["}},
+			errorContains: `invalid prevent_destroy pattern "event:["`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := New().LoadSpec("lifecycle.yaml", lifecycleSpec(tt.spec))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "parsing lifecycle spec lifecycle.yaml")
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestProvider_ParseSpec(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := New().ParseSpec("lifecycle.yaml", lifecycleSpec(map[string]any{
+		"prevent_destroy": []any{"event:signup"},
+	}))
+	require.NoError(t, err)
+	assert.Empty(t, parsed.URNs, "a lifecycle spec declares no resources")
+}
@@ -31,6 +31,8 @@ type Loader struct {
 // It walks the directory tree recursively to discover them, and parses them into Spec objects.
 // It returns a map of file paths to their corresponding Spec objects,
 // or an error if any file operation or spec parsing fails.
+// Files holding several YAML documents separated by `---` contribute one
+// entry per document, keyed by specs.DocumentKey.
 func (l *Loader) Load(location string) (map[string]*specs.RawSpec, error) {
 	var allRawSpecs map[string]*specs.RawSpec = make(map[string]*specs.RawSpec)
 
@@ -68,7 +70,9 @@ func (l *Loader) Load(location string) (map[string]*specs.RawSpec, error) {
 			return fmt.Errorf("reading file: %w", err)
 		}
 
-		allRawSpecs[path] = &specs.RawSpec{Data: data}
+		for key, rawSpec := range specs.SplitDocuments(path, data) {
+			allRawSpecs[key] = rawSpec
+		}
 		return nil
 	})
 
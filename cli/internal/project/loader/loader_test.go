@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/rudderlabs/rudder-iac/cli/internal/project/loader"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -78,6 +79,30 @@ func TestLoader_Load(t *testing.T) {
 		assert.Len(t, specs, 1)
 	})
 
+	t.Run("Splits multi-document files into one spec per document", func(t *testing.T) {
+		tmpDir := setupTestDir(t, map[string]string{
+			"single.yaml":   "---\n" + testContent,
+			"multiple.yaml": testContent + "\n---\nversion: rudder/0.1\nkind: destination\n",
+		})
+		defer os.RemoveAll(tmpDir)
+
+		l := &loader.Loader{}
+		loaded, err := l.Load(tmpDir)
+
+		require.NoError(t, err)
+		require.Len(t, loaded, 3)
+
+		multiple := filepath.Join(tmpDir, "multiple.yaml")
+		assert.Equal(t, "---\n"+testContent, string(loaded[filepath.Join(tmpDir, "single.yaml")].Data))
+		assert.Equal(t, testContent+"\n", string(loaded[specs.DocumentKey(multiple, 0)].Data))
+
+		second := loaded[specs.DocumentKey(multiple, 1)]
+		require.NotNil(t, second)
+		assert.Equal(t, "version: rudder/0.1\nkind: destination\n", string(second.Data))
+		assert.Equal(t, 1, second.Document())
+		assert.Equal(t, 3, second.LineOffset())
+	})
+
 	t.Run("Ignores non-YAML files", func(t *testing.T) {
 		tmpDir := setupTestDir(t, map[string]string{
 			"notes.txt":   "some notes",
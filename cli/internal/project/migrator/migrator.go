@@ -88,6 +88,12 @@ func (m *Migrator) MigrateSpecs(loadedSpecs map[string]*specs.Spec) (map[string]
 			continue
 		}
 
+		// Specs are written back one per file, which would drop the other
+		// documents of a multi-document file.
+		if specs.DocumentFile(path) != path {
+			return nil, fmt.Errorf("migrating file %s: multi-document files are not supported, split the documents into separate files first", specs.DocumentFile(path))
+		}
+
 		migratorLog.Info("migrating file", "path", path, "kind", spec.Kind, "version", spec.Version)
 		// Phase 1: Apply common migrations
 		if err := m.applyCommonMigrations(spec); err != nil {
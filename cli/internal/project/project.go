@@ -4,17 +4,19 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
+	"sort"
 
 	"github.com/rudderlabs/rudder-iac/cli/internal/config"
 	"github.com/rudderlabs/rudder-iac/cli/internal/logger"
 	"github.com/rudderlabs/rudder-iac/cli/internal/project/importmanifest"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/lifecycle"
 	"github.com/rudderlabs/rudder-iac/cli/internal/project/loader"
 	prules "github.com/rudderlabs/rudder-iac/cli/internal/project/rules"
 	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
 	"github.com/rudderlabs/rudder-iac/cli/internal/provider"
 	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation"
-	"github.com/rudderlabs/rudder-iac/cli/internal/validation/pathindex"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation/renderer"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
 	"github.com/rudderlabs/rudder-iac/cli/internal/varsubst"
@@ -48,12 +50,27 @@ type Project interface {
 	Load(location string) error
 	ResourceGraph() (*resources.Graph, error)
 	Specs() map[string]*specs.Spec
+	// SkippedRules returns the IDs of the rules the last Load skipped, e.g.
+	// workspace-scoped rules during offline validation.
+	SkippedRules() []string
+	// ProtectedURNs returns the URNs of the resources declared in specs with
+	// metadata.lifecycle.prevent_destroy set, which plans must not delete or
+	// replace. They are only protected while declared; PreventDestroy keeps
+	// protecting resources that have since been removed from the project.
+	ProtectedURNs() ([]string, error)
+	// PreventDestroy returns the URNs and URN globs the project's lifecycle
+	// specs protect from deletion and replacement.
+	PreventDestroy() []string
+	// DeclaredURNs returns the URNs each loaded resource spec declares, keyed
+	// like Specs. Specs the provider cannot parse are left out.
+	DeclaredURNs() map[string][]specs.URNEntry
 }
 
 type project struct {
 	location               string
 	provider               provider.Provider
 	importManifestProvider ImportManifestProvider
+	lifecycleProvider      *lifecycle.Provider
 	loader                 Loader
 	workspaceID            string
 	specs                  map[string]*specs.Spec
@@ -61,6 +78,8 @@ type project struct {
 	renderer               renderer.Renderer
 	substitutor            varsubst.Substitutor
 	ignoreUnknownKinds     bool
+	offline                bool
+	skippedRules           []string
 }
 
 // ProjectOption defines a functional option for configuring a Project.
@@ -118,12 +137,24 @@ func WithWorkspaceID(id string) ProjectOption {
 	}
 }
 
+// WithOffline marks the project as validated without access to a workspace.
+// Unless a workspace ID is also set via WithWorkspaceID, rules implementing
+// rules.WorkspaceScopedRule are left out of the registry and reported through
+// SkippedRules instead: with no workspace to scope them to they would check
+// entries that the eventual apply never considers.
+func WithOffline() ProjectOption {
+	return func(p *project) {
+		p.offline = true
+	}
+}
+
 // New creates a new Project instance.
 // By default, it uses a loader.Loader.
 func New(provider provider.Provider, opts ...ProjectOption) Project {
 	p := &project{
 		provider:               provider,
 		importManifestProvider: importmanifest.New(),
+		lifecycleProvider:      lifecycle.New(),
 		specs:                  make(map[string]*specs.Spec),
 	}
 
@@ -153,11 +184,67 @@ func (p *project) Specs() map[string]*specs.Spec {
 	return p.specs
 }
 
+func (p *project) SkippedRules() []string {
+	return p.skippedRules
+}
+
+func (p *project) ProtectedURNs() ([]string, error) {
+	var urns []string
+	for path, spec := range p.specs {
+		if classify(spec) == ProjectSpec || spec.IsLegacyVersion() {
+			continue
+		}
+
+		metadata, err := spec.CommonMetadata()
+		if err != nil {
+			return nil, fmt.Errorf("reading metadata of spec %s: %w", path, err)
+		}
+		if metadata.Lifecycle == nil || !metadata.Lifecycle.PreventDestroy {
+			continue
+		}
+
+		parsed, err := p.provider.ParseSpec(path, spec)
+		if err != nil {
+			return nil, fmt.Errorf("parsing spec %s: %w", path, err)
+		}
+		for _, entry := range parsed.URNs {
+			urns = append(urns, entry.URN)
+		}
+	}
+
+	sort.Strings(urns)
+	return urns, nil
+}
+
+func (p *project) PreventDestroy() []string {
+	return p.lifecycleProvider.PreventDestroy()
+}
+
+func (p *project) DeclaredURNs() map[string][]specs.URNEntry {
+	declared := make(map[string][]specs.URNEntry, len(p.specs))
+	for path, spec := range p.specs {
+		if classify(spec) == ProjectSpec {
+			continue
+		}
+
+		parsed, err := p.provider.ParseSpec(path, spec)
+		if err != nil {
+			log.Debug("skipping unparsable spec", "path", path, "error", err)
+			continue
+		}
+		declared[path] = parsed.URNs
+	}
+	return declared
+}
+
 func (p *project) loadSpec(path string, spec *specs.Spec) error {
-	// Project-level specs (e.g. import-manifest) are handled outside the resource
-	// provider tree by their dedicated provider; resource-level specs flow through
-	// the version-based dispatch below.
+	// Project-level specs (import-manifest, lifecycle) are handled outside the
+	// resource provider tree by their dedicated provider; resource-level specs
+	// flow through the version-based dispatch below.
 	if classify(spec) == ProjectSpec {
+		if spec.Kind == lifecycle.KindLifecycle {
+			return p.lifecycleProvider.LoadSpec(path, spec)
+		}
 		return p.importManifestProvider.LoadSpec(path, spec)
 	}
 
@@ -192,6 +279,10 @@ func (p *project) Load(location string) error {
 			return fmt.Errorf("variable substitution failed")
 		}
 		rawSpecs = substituted
+
+		if vc, ok := p.provider.(provider.VariableConsumer); ok {
+			vc.SetVariableResolver(p.substitutor)
+		}
 	}
 
 	return p.handleValidation(rawSpecs)
@@ -308,10 +399,10 @@ func (p *project) substituteSpecs(raw map[string]*specs.RawSpec) (map[string]*sp
 	for path, rawSpec := range raw {
 		data, subErrs := p.substitutor.SubstituteBytes(rawSpec.Data)
 		if len(subErrs) > 0 {
-			diags = append(diags, substitutionDiagnostics(path, subErrs)...)
+			diags = append(diags, substitutionDiagnostics(path, rawSpec, subErrs)...)
 			continue
 		}
-		substituted[path] = &specs.RawSpec{Data: data}
+		substituted[path] = rawSpec.WithData(data)
 	}
 	if diags.HasErrors() {
 		diags.Sort()
@@ -340,8 +431,8 @@ func (p *project) parseSpecs(raw map[string]*specs.RawSpec) (map[string]*specs.R
 				RuleID:   "project/spec-syntax-parse-valid",
 				Severity: rules.Error,
 				Message:  fmt.Sprintf("failed to parse spec from path %s: %s", path, err.Error()),
-				File:     path,
-				Position: pathindex.StartingPosition,
+				File:     specs.DocumentFile(path),
+				Position: documentStart(rawSpec),
 			})
 			// Continue to the next spec if the current one is not parsable
 			// preventing addition to the input specs map as we can't create specs
@@ -383,16 +474,60 @@ func (p *project) isKnownKind(kind string, importMergeEnabled bool) bool {
 }
 
 func (p *project) registry() (rules.Registry, error) {
-	return BuildRegistry(p.provider, p.importManifestProvider, config.GetConfig().ExperimentalFlags.ImportMerge)
+	importMergeEnabled := config.GetConfig().ExperimentalFlags.ImportMerge
+
+	registry, err := BuildRegistry(p.provider, p.importManifestProvider, importMergeEnabled)
+	if err != nil || !p.offline || p.workspaceID != "" {
+		return registry, err
+	}
+
+	registry, p.skippedRules, err = withoutWorkspaceScopedRules(
+		registry,
+		activePatterns(p.provider, p.importManifestProvider, importMergeEnabled),
+	)
+	return registry, err
+}
+
+// withoutWorkspaceScopedRules rebuilds registry without the rules that declare
+// themselves workspace-scoped, returning the IDs of the rules it dropped in
+// registration order.
+func withoutWorkspaceScopedRules(registry rules.Registry, active []rules.MatchPattern) (rules.Registry, []string, error) {
+	var (
+		filtered = rules.NewRegistry(active)
+		skipped  []string
+	)
+
+	for _, rule := range registry.AllSyntacticRules() {
+		if rules.IsWorkspaceScoped(rule) {
+			skipped = append(skipped, rule.ID())
+			continue
+		}
+		if err := filtered.RegisterSyntactic(rule); err != nil {
+			return nil, nil, fmt.Errorf("registering syntactic rule %s: %w", rule.ID(), err)
+		}
+	}
+
+	for _, rule := range registry.AllSemanticRules() {
+		if rules.IsWorkspaceScoped(rule) {
+			skipped = append(skipped, rule.ID())
+			continue
+		}
+		if err := filtered.RegisterSemantic(rule); err != nil {
+			return nil, nil, fmt.Errorf("registering semantic rule %s: %w", rule.ID(), err)
+		}
+	}
+
+	return filtered, skipped, nil
 }
 
 // activePatterns is the set of kind/version patterns the validation pipeline
-// treats as known: the resource provider's patterns, plus the import-manifest
-// provider's only when import-merge is enabled. Shared by BuildRegistry (which
+// treats as known: the resource provider's and the lifecycle spec's patterns,
+// plus the import-manifest provider's only when import-merge is enabled. Shared by BuildRegistry (which
 // rules fire) and isKnownKind (which specs the local typer may skip) so the two
 // never disagree about which kinds are known.
 func activePatterns(provider, manifestProvider ProjectProvider, importMergeEnabled bool) []rules.MatchPattern {
 	patterns := append([]rules.MatchPattern{}, provider.SupportedMatchPatterns()...)
+	patterns = append(patterns, lifecycle.MatchPatterns()...)
 	if importMergeEnabled {
 		patterns = append(patterns, manifestProvider.SupportedMatchPatterns()...)
 	}
@@ -404,10 +539,11 @@ func activePatterns(provider, manifestProvider ProjectProvider, importMergeEnabl
 // provider and the project-level import-manifest provider. It is shared by
 // project loading and the docs generator so both observe an identical rule set.
 //
-// The two providers' match patterns are unioned into the active set so the
-// gatekeeper rules treat both resource kinds and import-manifest as known. The
-// resource-scoped gatekeepers (metadata-syntax-valid, duplicate-urn) are scoped
-// to resourcePatterns alone so the engine never hands them a manifest spec.
+// The two providers' match patterns, and the lifecycle spec's, are unioned
+// into the active set so the gatekeeper rules treat resource kinds, lifecycle
+// and import-manifest as known. The resource-scoped gatekeepers
+// (metadata-syntax-valid, duplicate-urn) are scoped to resourcePatterns alone
+// so the engine never hands them a project-level spec.
 //
 // Import-manifest patterns and dedicated rules are only included when
 // importMergeEnabled is set — otherwise the kind is unknown and
@@ -434,9 +570,11 @@ func BuildRegistry(provider, manifestProvider ProjectProvider, importMergeEnable
 		prules.NewDuplicateURNRule(provider.ParseSpec, resourcePatterns),
 	}
 	// Cross-source conflict between import-manifest and inline metadata.import
-	// only applies when the import-manifest kind is recognized.
+	// only applies when the import-manifest kind is recognized. It is scoped to
+	// the manifest and resource patterns, leaving lifecycle specs out.
 	if importMergeEnabled {
-		syntactic = append(syntactic, prules.NewManifestInlineConflictRule(provider.ParseSpec, active))
+		conflictPatterns := append(slices.Clone(resourcePatterns), manifestProvider.SupportedMatchPatterns()...)
+		syntactic = append(syntactic, prules.NewManifestInlineConflictRule(provider.ParseSpec, conflictPatterns))
 	}
 	syntactic = append(syntactic, provider.SyntacticRules()...)
 	if importMergeEnabled {
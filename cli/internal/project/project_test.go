@@ -1,6 +1,7 @@
 package project_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"testing"
@@ -13,6 +14,7 @@ import (
 	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
 	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
 	"github.com/rudderlabs/rudder-iac/cli/internal/testutils"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/renderer"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
 	"github.com/rudderlabs/rudder-iac/cli/internal/varsubst"
 )
@@ -433,3 +435,176 @@ func TestProject_Load_WithSubstitutor(t *testing.T) {
 		})
 	}
 }
+
+// variableConsumerProvider records the resolver the project hands it.
+type variableConsumerProvider struct {
+	*testutils.MockProvider
+	resolver varsubst.Resolver
+}
+
+func (p *variableConsumerProvider) SetVariableResolver(r varsubst.Resolver) {
+	p.resolver = r
+}
+
+func TestProject_Load_HandsResolverToVariableConsumers(t *testing.T) {
+	t.Parallel()
+
+	newProvider := func() *variableConsumerProvider {
+		mockProvider := testutils.NewMockProvider(nil, nil)
+		mockProvider.MatchPatterns = fixtureMatchPatterns
+		return &variableConsumerProvider{MockProvider: mockProvider}
+	}
+	mockLoader := &MockLoader{LoadFunc: func(string) (map[string]*specs.RawSpec, error) {
+		return map[string]*specs.RawSpec{
+			"path/to/spec.yaml": {Data: []byte("kind: Source\nversion: rudder/0.1\nmetadata:\n  name: db\nspec:\n  k: v")},
+		}, nil
+	}}
+
+	t.Run("with a substitutor", func(t *testing.T) {
+		t.Parallel()
+
+		sub := varsubst.NewSubstitutor(mapResolver{"SCHEMA": "analytics"})
+		p := newProvider()
+		proj := project.New(p, project.WithLoader(mockLoader), project.WithSubstitutor(sub))
+		require.NoError(t, proj.Load("test_dir"))
+
+		require.NotNil(t, p.resolver)
+		value, found := p.resolver.Resolve("SCHEMA")
+		assert.True(t, found)
+		assert.Equal(t, "analytics", value)
+	})
+
+	t.Run("without a substitutor", func(t *testing.T) {
+		t.Parallel()
+
+		p := newProvider()
+		proj := project.New(p, project.WithLoader(mockLoader))
+		require.NoError(t, proj.Load("test_dir"))
+
+		assert.Nil(t, p.resolver)
+	})
+}
+
+func TestProject_Load_OfflineSkipsWorkspaceScopedRules(t *testing.T) {
+	enableImportMerge(t)
+
+	manifestYAML := "version: rudder/v1\n" +
+		"kind: import-manifest\n" +
+		"metadata:\n  name: import-manifest\n" +
+		"spec:\n" +
+		"  workspaces:\n" +
+		"    - workspace_id: ws-a\n" +
+		"      resources:\n" +
+		"        - urn: event:missing\n" +
+		"          remote_id: rem-1\n"
+	loader := &MockLoader{LoadFunc: func(string) (map[string]*specs.RawSpec, error) {
+		return map[string]*specs.RawSpec{
+			"import-manifest.yaml": {Data: []byte(manifestYAML)},
+		}, nil
+	}}
+
+	newProvider := func() *testutils.MockProvider {
+		p := testutils.NewMockProvider(nil, nil)
+		p.GetResourceGraphVal = resources.NewGraph()
+		return p
+	}
+
+	t.Run("without workspace id", func(t *testing.T) {
+		proj := project.New(
+			newProvider(),
+			project.WithLoader(loader),
+			project.WithRenderer(renderer.NewTextRenderer(&bytes.Buffer{})),
+			project.WithOffline(),
+		)
+		require.NoError(t, proj.Load("test_dir"))
+		assert.Equal(t, []string{"import-manifest/orphaned-urn"}, proj.SkippedRules())
+	})
+
+	t.Run("with workspace id", func(t *testing.T) {
+		proj := project.New(
+			newProvider(),
+			project.WithLoader(loader),
+			project.WithRenderer(renderer.NewTextRenderer(&bytes.Buffer{})),
+			project.WithOffline(),
+			project.WithWorkspaceID("ws-a"),
+		)
+		err := proj.Load("test_dir")
+		assert.ErrorContains(t, err, "semantic validation failed")
+		assert.Empty(t, proj.SkippedRules())
+	})
+}
+
+func TestProject_ProtectedURNs(t *testing.T) {
+	protectedYAML := "version: rudder/v1\n" +
+		"kind: properties\n" +
+		"metadata:\n  name: protected\n  lifecycle:\n    prevent_destroy: true\n" +
+		"spec:\n  properties: []\n"
+	unprotectedYAML := "version: rudder/v1\n" +
+		"kind: properties\n" +
+		"metadata:\n  name: unprotected\n" +
+		"spec:\n  properties: []\n"
+
+	loader := &MockLoader{LoadFunc: func(string) (map[string]*specs.RawSpec, error) {
+		return map[string]*specs.RawSpec{
+			"protected.yaml":   {Data: []byte(protectedYAML)},
+			"unprotected.yaml": {Data: []byte(unprotectedYAML)},
+		}, nil
+	}}
+
+	provider := testutils.NewMockProvider(nil, nil)
+	provider.MatchPatterns = []rules.MatchPattern{rules.MatchKindVersion("properties", "rudder/v1")}
+	provider.GetResourceGraphVal = resources.NewGraph()
+
+	proj := project.New(
+		provider,
+		project.WithLoader(loader),
+		project.WithRenderer(renderer.NewTextRenderer(&bytes.Buffer{})),
+	)
+	require.NoError(t, proj.Load("test_dir"))
+
+	// The mock parses every spec into the same URNs, so they are only set
+	// after loading to keep the duplicate URN rule quiet.
+	provider.ParseSpecVal = &specs.ParsedSpec{URNs: []specs.URNEntry{
+		{URN: "property:user_id"},
+		{URN: "property:email"},
+	}}
+	provider.ParseSpecCalledWithArgs = nil
+	urns, err := proj.ProtectedURNs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"property:email", "property:user_id"}, urns)
+
+	require.Len(t, provider.ParseSpecCalledWithArgs, 1)
+	assert.Equal(t, "protected.yaml", provider.ParseSpecCalledWithArgs[0].Path)
+}
+
+func TestProject_PreventDestroy(t *testing.T) {
+	// The protected event has no spec left in the project; the lifecycle spec
+	// keeps protecting it.
+	lifecycleYAML := "version: rudder/v1\n" +
+		"kind: lifecycle\n" +
+		"metadata:\n  name: protection\n" +
+		"spec:\n  prevent_destroy:\n    - \"tracking-plan:*\"\n    - \"event:signup\"\n"
+
+	loader := &MockLoader{LoadFunc: func(string) (map[string]*specs.RawSpec, error) {
+		return map[string]*specs.RawSpec{
+			"lifecycle.yaml": {Data: []byte(lifecycleYAML)},
+		}, nil
+	}}
+
+	provider := testutils.NewMockProvider(nil, nil)
+	provider.GetResourceGraphVal = resources.NewGraph()
+
+	proj := project.New(
+		provider,
+		project.WithLoader(loader),
+		project.WithRenderer(renderer.NewTextRenderer(&bytes.Buffer{})),
+	)
+	require.NoError(t, proj.Load("test_dir"))
+
+	assert.Equal(t, []string{"tracking-plan:*", "event:signup"}, proj.PreventDestroy())
+	assert.Empty(t, provider.LoadSpecCalledWithArgs, "lifecycle specs are not handed to resource providers")
+
+	urns, err := proj.ProtectedURNs()
+	require.NoError(t, err)
+	assert.Empty(t, urns)
+}
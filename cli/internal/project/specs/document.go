@@ -0,0 +1,109 @@
+package specs
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+)
+
+var (
+	// documentSeparatorRegex matches a YAML document start marker on a line
+	// of its own, optionally followed by a comment.
+	documentSeparatorRegex = regexp.MustCompile(`^---\s*(#.*)?$`)
+
+	// documentKeyRegex matches the document suffix DocumentKey appends.
+	documentKeyRegex = regexp.MustCompile(`#[0-9]+$`)
+)
+
+// DocumentKey returns the key identifying the document at index within the
+// file at path, e.g. "destinations.yaml#1".
+func DocumentKey(path string, index int) string {
+	return fmt.Sprintf("%s#%d", path, index)
+}
+
+// DocumentFile returns the file a spec key refers to, stripping the document
+// suffix added by DocumentKey if present.
+func DocumentFile(key string) string {
+	return documentKeyRegex.ReplaceAllString(key, "")
+}
+
+// SplitDocuments splits the content of the file at path into one RawSpec per
+// YAML document, keyed by spec key.
+//
+// Files holding a single document, as well as empty files, are returned as a
+// single RawSpec keyed by path with the content untouched. Files holding
+// several documents are keyed by DocumentKey, where the index is the position
+// of the document among the non-empty documents of the file, so leading
+// separators and empty documents do not shift it.
+func SplitDocuments(path string, data []byte) map[string]*RawSpec {
+	var (
+		lines     = bytes.SplitAfter(data, []byte("\n"))
+		documents []*RawSpec
+		start     int
+	)
+
+	flush := func(end int) {
+		content := bytes.Join(lines[start:end], nil)
+		if isBlankDocument(content) {
+			return
+		}
+		documents = append(documents, &RawSpec{
+			Data:       content,
+			document:   len(documents),
+			lineOffset: start,
+		})
+	}
+
+	for i, line := range lines {
+		if !documentSeparatorRegex.Match(bytes.TrimRight(line, "\r\n")) {
+			continue
+		}
+		flush(i)
+		start = i + 1
+	}
+	flush(len(lines))
+
+	if len(documents) <= 1 {
+		return map[string]*RawSpec{path: {Data: data}}
+	}
+
+	result := make(map[string]*RawSpec, len(documents))
+	for _, doc := range documents {
+		result[DocumentKey(path, doc.document)] = doc
+	}
+	return result
+}
+
+// isBlankDocument reports whether content holds nothing but whitespace and
+// comments.
+func isBlankDocument(content []byte) bool {
+	for _, line := range bytes.Split(content, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 && line[0] != '#' {
+			return false
+		}
+	}
+	return true
+}
+
+// Document returns the index of the document within its file, as used in its
+// DocumentKey. It is 0 for specs loaded from single document files.
+func (r *RawSpec) Document() int {
+	return r.document
+}
+
+// LineOffset returns the number of lines preceding the document in its file.
+// Adding it to a line number within Data gives the line number in the file.
+func (r *RawSpec) LineOffset() int {
+	return r.lineOffset
+}
+
+// WithData returns a new RawSpec holding data in place of the original
+// content, keeping its position within the file.
+func (r *RawSpec) WithData(data []byte) *RawSpec {
+	return &RawSpec{
+		Data:       data,
+		document:   r.document,
+		lineOffset: r.lineOffset,
+	}
+}
@@ -0,0 +1,106 @@
+package specs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitDocuments(t *testing.T) {
+	t.Run("single document keeps the file path and content", func(t *testing.T) {
+		data := []byte("---\nversion: rudder/v1\nkind: properties\n")
+
+		got := SplitDocuments("props.yaml", data)
+
+		require.Len(t, got, 1)
+		require.Contains(t, got, "props.yaml")
+		assert.Equal(t, data, got["props.yaml"].Data)
+		assert.Equal(t, 0, got["props.yaml"].LineOffset())
+	})
+
+	t.Run("empty file is kept as is", func(t *testing.T) {
+		got := SplitDocuments("empty.yaml", []byte(""))
+
+		require.Len(t, got, 1)
+		assert.Empty(t, got["empty.yaml"].Data)
+	})
+
+	t.Run("multiple documents get one entry each", func(t *testing.T) {
+		data := []byte(`# destination and its account
+---
+kind: destination
+---   # the account
+kind: account
+
+---
+
+# nothing here
+---
+kind: connection
+`)
+
+		got := SplitDocuments("dest.yaml", data)
+
+		require.Len(t, got, 3)
+
+		dest := got["dest.yaml#0"]
+		require.NotNil(t, dest)
+		assert.Equal(t, "kind: destination\n", string(dest.Data))
+		assert.Equal(t, 0, dest.Document())
+		assert.Equal(t, 2, dest.LineOffset())
+
+		account := got["dest.yaml#1"]
+		require.NotNil(t, account)
+		assert.Equal(t, "kind: account\n\n", string(account.Data))
+		assert.Equal(t, 1, account.Document())
+		assert.Equal(t, 4, account.LineOffset())
+
+		connection := got["dest.yaml#2"]
+		require.NotNil(t, connection)
+		assert.Equal(t, "kind: connection\n", string(connection.Data))
+		assert.Equal(t, 2, connection.Document())
+		assert.Equal(t, 10, connection.LineOffset())
+	})
+
+	t.Run("handles CRLF line endings", func(t *testing.T) {
+		got := SplitDocuments("dest.yaml", []byte("kind: a\r\n---\r\nkind: b\r\n"))
+
+		require.Len(t, got, 2)
+		assert.Equal(t, "kind: b\r\n", string(got["dest.yaml#1"].Data))
+		assert.Equal(t, 2, got["dest.yaml#1"].LineOffset())
+	})
+
+	t.Run("ignores separators that are not on their own line", func(t *testing.T) {
+		got := SplitDocuments("dest.yaml", []byte("description: |\n  ---- not a separator\nkind: a\n"))
+
+		assert.Len(t, got, 1)
+	})
+}
+
+func TestDocumentFile(t *testing.T) {
+	assert.Equal(t, "/specs/dest.yaml", DocumentFile("/specs/dest.yaml"))
+	assert.Equal(t, "/specs/dest.yaml", DocumentFile(DocumentKey("/specs/dest.yaml", 3)))
+	assert.Equal(t, "/specs/#notes.yaml", DocumentFile("/specs/#notes.yaml"))
+}
+
+func TestRawSpec_DocumentPosition(t *testing.T) {
+	got := SplitDocuments("dest.yaml", []byte("version: rudder/v1\n---\nversion: rudder/v1\nkind: account\n"))
+	rawSpec := got[DocumentKey("dest.yaml", 1)]
+	require.NotNil(t, rawSpec)
+
+	pi, err := rawSpec.PathIndexer()
+	require.NoError(t, err)
+
+	pos, err := pi.PositionLookup("/kind")
+	require.NoError(t, err)
+	assert.Equal(t, 4, pos.Line)
+
+	substituted := rawSpec.WithData([]byte("version: rudder/v1\nkind: account\n"))
+	assert.Equal(t, rawSpec.Document(), substituted.Document())
+	assert.Equal(t, rawSpec.LineOffset(), substituted.LineOffset())
+
+	parsed, err := rawSpec.Parse()
+	require.NoError(t, err)
+	assert.Equal(t, "account", parsed.Kind)
+}
@@ -10,8 +10,15 @@ import (
 
 // Metadata represents the common metadata fields for all specs
 type Metadata struct {
-	Name   string                    `yaml:"name" json:"name,omitempty" validate:"required"`
-	Import *WorkspacesImportMetadata `yaml:"import" json:"import,omitempty"`
+	Name      string                    `yaml:"name" json:"name,omitempty" validate:"required"`
+	Import    *WorkspacesImportMetadata `yaml:"import" json:"import,omitempty"`
+	Lifecycle *Lifecycle                `yaml:"lifecycle" json:"lifecycle,omitempty"`
+}
+
+// Lifecycle holds settings that govern how the resources of a spec may change
+type Lifecycle struct {
+	// PreventDestroy makes any plan that would delete one of the spec's resources fail
+	PreventDestroy bool `yaml:"prevent_destroy" json:"prevent_destroy,omitempty"`
 }
 
 // WorkspacesImportMetadata holds import spec metadata for a set of workspaces
@@ -22,6 +22,11 @@ type RawSpec struct {
 	errored   error
 	pathIndex pathindex.PathIndexer
 	piErr     error
+
+	// document and lineOffset locate the spec within a file holding several
+	// YAML documents; see SplitDocuments.
+	document   int
+	lineOffset int
 }
 
 func (r *RawSpec) Parse() (*Spec, error) {
@@ -47,7 +52,7 @@ func (r *RawSpec) PathIndexer() (pathindex.PathIndexer, error) {
 		return r.pathIndex, r.piErr
 	}
 
-	pi, err := pathindex.NewPathIndexer(r.Data)
+	pi, err := pathindex.NewPathIndexer(r.Data, pathindex.WithLineOffset(r.lineOffset))
 	if err != nil {
 		r.piErr = fmt.Errorf("building path indexer: %w", err)
 		return nil, r.piErr
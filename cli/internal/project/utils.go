@@ -3,6 +3,7 @@ package project
 import (
 	"fmt"
 
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation/pathindex"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
@@ -12,16 +13,17 @@ import (
 // substitutionDiagnostics converts variable-substitution errors into project
 // validation diagnostics. Lives here (not in varsubst) so the substitution
 // engine stays free of any dependency on the validation/diagnostics layer.
-func substitutionDiagnostics(filePath string, errs []varsubst.SubstitutionError) validation.Diagnostics {
+// Lines are reported relative to the file the spec was loaded from.
+func substitutionDiagnostics(path string, rawSpec *specs.RawSpec, errs []varsubst.SubstitutionError) validation.Diagnostics {
 	diagnostics := make(validation.Diagnostics, 0, len(errs))
 	for _, e := range errs {
 		diagnostics = append(diagnostics, validation.Diagnostic{
 			RuleID:   "project/var-substitution",
 			Severity: rules.Error,
 			Message:  fmt.Sprintf("%s %q", e.Err, e.Name),
-			File:     filePath,
+			File:     specs.DocumentFile(path),
 			Position: pathindex.Position{
-				Line:     e.Line,
+				Line:     e.Line + rawSpec.LineOffset(),
 				Column:   e.Column,
 				LineText: e.LineText,
 			},
@@ -29,3 +31,11 @@ func substitutionDiagnostics(filePath string, errs []varsubst.SubstitutionError)
 	}
 	return diagnostics
 }
+
+// documentStart returns the position of the first line of the spec within
+// its file.
+func documentStart(rawSpec *specs.RawSpec) pathindex.Position {
+	pos := pathindex.StartingPosition
+	pos.Line += rawSpec.LineOffset()
+	return pos
+}
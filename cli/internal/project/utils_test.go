@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation/pathindex"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
@@ -36,7 +37,7 @@ func TestSubstitutionDiagnostics(t *testing.T) {
 		},
 	}
 
-	got := substitutionDiagnostics("specs/dest.yaml", errs)
+	got := substitutionDiagnostics("specs/dest.yaml", &specs.RawSpec{}, errs)
 
 	assert.Equal(t, validation.Diagnostics{
 		{
@@ -74,3 +75,28 @@ func TestSubstitutionDiagnostics(t *testing.T) {
 		},
 	}, got)
 }
+
+func TestSubstitutionDiagnostics_MultiDocumentFile(t *testing.T) {
+	raw := specs.SplitDocuments("specs/dest.yaml", []byte("kind: a\n---\nkind: b\nvalue: {{ .MISSING }}\n"))
+	rawSpec := raw[specs.DocumentKey("specs/dest.yaml", 1)]
+
+	got := substitutionDiagnostics(specs.DocumentKey("specs/dest.yaml", 1), rawSpec, []varsubst.SubstitutionError{{
+		Name:     "MISSING",
+		Line:     2,
+		Column:   8,
+		LineText: "value: {{ .MISSING }}",
+		Err:      varsubst.ErrUndefinedVariable,
+	}})
+
+	assert.Equal(t, validation.Diagnostics{{
+		RuleID:   "project/var-substitution",
+		Severity: rules.Error,
+		Message:  `undefined variable "MISSING"`,
+		File:     "specs/dest.yaml",
+		Position: pathindex.Position{
+			Line:     4,
+			Column:   8,
+			LineText: "value: {{ .MISSING }}",
+		},
+	}}, got)
+}
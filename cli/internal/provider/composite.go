@@ -16,6 +16,7 @@ import (
 	"github.com/rudderlabs/rudder-iac/cli/internal/resources/state"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation/docs"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/varsubst"
 	"github.com/rudderlabs/rudder-iac/cli/pkg/tasker"
 	"golang.org/x/exp/maps"
 )
@@ -290,6 +291,29 @@ func (p *CompositeProvider) LoadImportManifest(m *specs.WorkspaceImportMetadata)
 	return nil
 }
 
+// SetVariableResolver hands the resolver to every sub-provider implementing
+// VariableConsumer.
+func (p *CompositeProvider) SetVariableResolver(r varsubst.Resolver) {
+	for _, sub := range p.Providers {
+		if vc, ok := sub.(VariableConsumer); ok {
+			vc.SetVariableResolver(r)
+		}
+	}
+}
+
+// ReplaceProperties asks the sub-provider managing resourceType, if it
+// implements Replacer.
+func (p *CompositeProvider) ReplaceProperties(resourceType string) []string {
+	provider, err := p.providerForType(resourceType)
+	if err != nil {
+		return nil
+	}
+	if r, ok := provider.(Replacer); ok {
+		return r.ReplaceProperties(resourceType)
+	}
+	return nil
+}
+
 // Helper methods
 func (p *CompositeProvider) providerForKind(kind string) (Provider, error) {
 	provider, ok := p.registeredKinds[kind]
@@ -18,6 +18,7 @@ import (
 	"github.com/rudderlabs/rudder-iac/cli/internal/testutils"
 	vrules "github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation/docs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/varsubst"
 )
 
 func TestNewCompositeProvider(t *testing.T) {
@@ -516,6 +517,30 @@ func TestCompositeProvider_LoadImportManifest(t *testing.T) {
 	})
 }
 
+// variableConsumerProvider records the resolver handed to it.
+type variableConsumerProvider struct {
+	*testutils.MockProvider
+	got varsubst.Resolver
+}
+
+func (m *variableConsumerProvider) SetVariableResolver(r varsubst.Resolver) {
+	m.got = r
+}
+
+func TestCompositeProvider_SetVariableResolver(t *testing.T) {
+	t.Parallel()
+
+	consumer := &variableConsumerProvider{MockProvider: testutils.NewMockProvider([]string{"kindA"}, nil)}
+	// plain does not implement VariableConsumer and is skipped.
+	plain := testutils.NewMockProvider([]string{"kindB"}, nil)
+	cp, err := provider.NewCompositeProvider(map[string]provider.Provider{"a": consumer, "b": plain})
+	require.NoError(t, err)
+
+	sub := varsubst.NewSubstitutor()
+	cp.(*provider.CompositeProvider).SetVariableResolver(sub)
+	assert.Equal(t, sub, consumer.got)
+}
+
 // matcherMockProvider overrides the MockProvider's nil-default ResourceMatchers.
 type matcherMockProvider struct {
 	*testutils.MockProvider
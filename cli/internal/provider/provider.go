@@ -13,6 +13,7 @@ import (
 	"github.com/rudderlabs/rudder-iac/cli/internal/resources/state"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation/docs"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/varsubst"
 )
 
 // TypeProvider defines the interface for providers to declare what resource types
@@ -233,6 +234,26 @@ type ResourceMatcherProvider interface {
 	ResourceMatchers() []importmatcher.Matcher
 }
 
+// VariableConsumer is an optional interface for providers that render
+// variables into content the project does not substitute itself, such as the
+// SQL files referenced by RETL SQL models. When variable substitution is
+// enabled, the project hands its resolver chain to the provider before any
+// spec is loaded, so that content honours the same env vars and --var-file
+// values as the specs.
+type VariableConsumer interface {
+	SetVariableResolver(r varsubst.Resolver)
+}
+
+// Replacer is an optional interface for providers with resources that can't
+// change some properties in place: updating any of them deletes and recreates
+// the resource. Planning uses it to keep protected resources from being
+// replaced.
+type Replacer interface {
+	// ReplaceProperties returns the properties of resources of resourceType
+	// whose change replaces the resource, as keyed in their resource data.
+	ReplaceProperties(resourceType string) []string
+}
+
 // Provider is the complete interface that all providers must implement.
 // It combines all the individual capabilities required for full resource lifecycle management:
 //
@@ -0,0 +1,311 @@
+// Package catalogdiff compares two revisions of a data catalog and classifies
+// each change by whether it can break instrumented applications.
+//
+// A change is breaking when a payload that was valid against the base revision
+// may be rejected by the head revision: removing an event, property, custom type
+// or rule, narrowing a type, tightening a constraint or making a property
+// required. Changes that only loosen validation or touch descriptive fields are
+// reported as non-breaking.
+package catalogdiff
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog/localcatalog"
+	"github.com/rudderlabs/rudder-iac/cli/internal/utils"
+)
+
+// Severity classifies a change by its impact on instrumented applications.
+type Severity string
+
+const (
+	Breaking    Severity = "breaking"
+	NonBreaking Severity = "non-breaking"
+)
+
+// Entity kinds a change can be reported against.
+const (
+	EntityEvent        = "event"
+	EntityProperty     = "property"
+	EntityCustomType   = "custom-type"
+	EntityTrackingPlan = "tracking-plan"
+)
+
+// Change is a single difference between the base and head catalogs.
+type Change struct {
+	Entity   string   `json:"entity"`
+	ID       string   `json:"id"`
+	Path     string   `json:"path,omitempty"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (c Change) String() string {
+	subject := c.Entity + " " + c.ID
+	if c.Path != "" {
+		subject += " " + c.Path
+	}
+	return fmt.Sprintf("%s: %s", subject, c.Message)
+}
+
+// Changes is the ordered list of changes between two catalogs.
+type Changes []Change
+
+// HasBreaking reports whether any of the changes is breaking.
+func (cs Changes) HasBreaking() bool {
+	for _, c := range cs {
+		if c.Severity == Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the number of changes with the given severity.
+func (cs Changes) Count(s Severity) int {
+	n := 0
+	for _, c := range cs {
+		if c.Severity == s {
+			n++
+		}
+	}
+	return n
+}
+
+// Compare returns the changes needed to go from base to head, ordered by
+// entity kind, entity id and path. Property definitions are compared once, at
+// the catalog level, rather than at every rule referencing them.
+func Compare(base, head *localcatalog.DataCatalog) Changes {
+	d := &differ{}
+
+	d.compareEvents(base.Events, head.Events)
+	d.compareProperties(base.Properties, head.Properties)
+	d.compareCustomTypes(base.CustomTypes, head.CustomTypes)
+	d.compareTrackingPlans(base.TrackingPlans, head.TrackingPlans)
+
+	entityOrder := map[string]int{EntityEvent: 0, EntityProperty: 1, EntityCustomType: 2, EntityTrackingPlan: 3}
+	sort.SliceStable(d.changes, func(i, j int) bool {
+		a, b := d.changes[i], d.changes[j]
+		if a.Entity != b.Entity {
+			return entityOrder[a.Entity] < entityOrder[b.Entity]
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Path < b.Path
+	})
+
+	return d.changes
+}
+
+type differ struct {
+	changes Changes
+}
+
+func (d *differ) add(entity, id, path string, severity Severity, format string, args ...any) {
+	d.changes = append(d.changes, Change{
+		Entity:   entity,
+		ID:       id,
+		Path:     path,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (d *differ) compareEvents(base, head []localcatalog.EventV1) {
+	headByID := make(map[string]localcatalog.EventV1, len(head))
+	for _, e := range head {
+		headByID[e.LocalID] = e
+	}
+
+	baseIDs := make(map[string]bool, len(base))
+	for _, b := range base {
+		baseIDs[b.LocalID] = true
+
+		h, ok := headByID[b.LocalID]
+		if !ok {
+			d.add(EntityEvent, b.LocalID, "", Breaking, "removed")
+			continue
+		}
+		if b.Name != h.Name {
+			d.add(EntityEvent, b.LocalID, "name", Breaking, "renamed from %q to %q", b.Name, h.Name)
+		}
+		if b.Type != h.Type {
+			d.add(EntityEvent, b.LocalID, "event_type", Breaking, "event type changed from %q to %q", b.Type, h.Type)
+		}
+		if b.Description != h.Description {
+			d.add(EntityEvent, b.LocalID, "description", NonBreaking, "description changed")
+		}
+		if refValue(b.CategoryRef) != refValue(h.CategoryRef) {
+			d.add(EntityEvent, b.LocalID, "category", NonBreaking, "category changed from %q to %q", refValue(b.CategoryRef), refValue(h.CategoryRef))
+		}
+	}
+
+	for _, h := range head {
+		if !baseIDs[h.LocalID] {
+			d.add(EntityEvent, h.LocalID, "", NonBreaking, "added")
+		}
+	}
+}
+
+func (d *differ) compareProperties(base, head []localcatalog.PropertyV1) {
+	headByID := make(map[string]localcatalog.PropertyV1, len(head))
+	for _, p := range head {
+		headByID[p.LocalID] = p
+	}
+
+	baseIDs := make(map[string]bool, len(base))
+	for _, b := range base {
+		baseIDs[b.LocalID] = true
+
+		h, ok := headByID[b.LocalID]
+		if !ok {
+			d.add(EntityProperty, b.LocalID, "", Breaking, "removed")
+			continue
+		}
+		if b.Name != h.Name {
+			d.add(EntityProperty, b.LocalID, "name", Breaking, "renamed from %q to %q", b.Name, h.Name)
+		}
+		if b.Description != h.Description {
+			d.add(EntityProperty, b.LocalID, "description", NonBreaking, "description changed")
+		}
+		d.compareTypes(EntityProperty, b.LocalID, "type", typeSet(b.Type, b.Types), typeSet(h.Type, h.Types))
+		d.compareTypes(EntityProperty, b.LocalID, "item_type", typeSet(b.ItemType, b.ItemTypes), typeSet(h.ItemType, h.ItemTypes))
+		d.compareConfig(EntityProperty, b.LocalID, b.Config, h.Config)
+	}
+
+	for _, h := range head {
+		if !baseIDs[h.LocalID] {
+			d.add(EntityProperty, h.LocalID, "", NonBreaking, "added")
+		}
+	}
+}
+
+func (d *differ) compareCustomTypes(base, head []localcatalog.CustomTypeV1) {
+	headByID := make(map[string]localcatalog.CustomTypeV1, len(head))
+	for _, ct := range head {
+		headByID[ct.LocalID] = ct
+	}
+
+	baseIDs := make(map[string]bool, len(base))
+	for _, b := range base {
+		baseIDs[b.LocalID] = true
+
+		h, ok := headByID[b.LocalID]
+		if !ok {
+			d.add(EntityCustomType, b.LocalID, "", Breaking, "removed")
+			continue
+		}
+		// Custom types are referenced by id, so a new name doesn't change the
+		// payloads they describe.
+		if b.Name != h.Name {
+			d.add(EntityCustomType, b.LocalID, "name", NonBreaking, "renamed from %q to %q", b.Name, h.Name)
+		}
+		if b.Description != h.Description {
+			d.add(EntityCustomType, b.LocalID, "description", NonBreaking, "description changed")
+		}
+		d.compareTypes(EntityCustomType, b.LocalID, "type", typeSet(b.Type, nil), typeSet(h.Type, nil))
+		d.compareTypes(EntityCustomType, b.LocalID, "item_type", typeSet(b.ItemType, b.ItemTypes), typeSet(h.ItemType, h.ItemTypes))
+		d.compareConfig(EntityCustomType, b.LocalID, b.Config, h.Config)
+		d.comparePropertyRefs(EntityCustomType, b.LocalID, "properties",
+			customTypePropertyRefs(b.Properties), customTypePropertyRefs(h.Properties), false)
+		d.compareVariants(EntityCustomType, b.LocalID, "variants", b.Variants, h.Variants)
+	}
+
+	for _, h := range head {
+		if !baseIDs[h.LocalID] {
+			d.add(EntityCustomType, h.LocalID, "", NonBreaking, "added")
+		}
+	}
+}
+
+// compareTypes reports narrowing and widening of a set of allowed types. An
+// empty set allows any type. A number accepts every integer, so integer ->
+// number widens while number -> integer narrows.
+func (d *differ) compareTypes(entity, id, path string, base, head []string) {
+	if slices.Equal(base, head) {
+		return
+	}
+
+	switch {
+	case len(base) == 0:
+		d.add(entity, id, path, Breaking, "restricted to %s", formatTypes(head))
+		return
+	case len(head) == 0:
+		d.add(entity, id, path, NonBreaking, "no longer restricted (was %s)", formatTypes(base))
+		return
+	}
+
+	var removed, added []string
+	for _, t := range base {
+		if !acceptsType(head, t) {
+			removed = append(removed, t)
+		}
+	}
+	for _, t := range head {
+		if !acceptsType(base, t) {
+			added = append(added, t)
+		}
+	}
+
+	if len(removed) > 0 {
+		d.add(entity, id, path, Breaking, "narrowed from %s to %s", formatTypes(base), formatTypes(head))
+	} else if len(added) > 0 {
+		d.add(entity, id, path, NonBreaking, "widened from %s to %s", formatTypes(base), formatTypes(head))
+	} else {
+		d.add(entity, id, path, NonBreaking, "changed from %s to %s", formatTypes(base), formatTypes(head))
+	}
+}
+
+func acceptsType(types []string, t string) bool {
+	for _, candidate := range types {
+		if candidate == t || (t == "integer" && candidate == "number") {
+			return true
+		}
+	}
+	return false
+}
+
+// typeSet normalises the single and multi-type forms into a sorted list.
+func typeSet(single string, multiple []string) []string {
+	var types []string
+	switch {
+	case len(multiple) > 0:
+		types = append(types, multiple...)
+	case strings.Contains(single, ","):
+		types = utils.SplitMultiTypeString(single)
+	case single != "":
+		types = []string{single}
+	}
+	sort.Strings(types)
+	return types
+}
+
+func formatTypes(types []string) string {
+	return "[" + strings.Join(types, ", ") + "]"
+}
+
+func refValue(ref *string) string {
+	if ref == nil {
+		return ""
+	}
+	return *ref
+}
+
+// refID strips the kind prefix from a reference such as #property:<id>.
+func refID(ref string) string {
+	if i := strings.LastIndex(ref, ":"); i >= 0 {
+		return ref[i+1:]
+	}
+	return ref
+}
+
+func joinPath(parent, child string) string {
+	if parent == "" {
+		return child
+	}
+	return parent + "." + child
+}
@@ -0,0 +1,391 @@
+package catalogdiff_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog/catalogdiff"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog/localcatalog"
+)
+
+func baseCatalog() *localcatalog.DataCatalog {
+	return &localcatalog.DataCatalog{
+		Events: []localcatalog.EventV1{
+			{LocalID: "signed_up", Name: "User Signed Up", Type: "track"},
+			{LocalID: "logged_in", Name: "User Logged In", Type: "track"},
+		},
+		Properties: []localcatalog.PropertyV1{
+			{LocalID: "email", Name: "email", Type: "string", Config: map[string]any{"format": "email"}},
+			{LocalID: "age", Name: "age", Type: "integer", Config: map[string]any{"minimum": 0, "maximum": 150}},
+			{LocalID: "plan", Name: "plan", Type: "string", Config: map[string]any{"enum": []any{"free", "pro"}}},
+			{LocalID: "tags", Name: "tags", Type: "array", ItemType: "string"},
+			{LocalID: "kind", Name: "kind", Type: "string"},
+		},
+		CustomTypes: []localcatalog.CustomTypeV1{
+			{
+				LocalID: "address",
+				Name:    "Address",
+				Type:    "object",
+				Properties: []localcatalog.CustomTypePropertyV1{
+					{Property: "#property:email", Required: true},
+				},
+			},
+		},
+		TrackingPlans: []*localcatalog.TrackingPlanV1{
+			{
+				LocalID: "web",
+				Name:    "Web",
+				Rules: []*localcatalog.TPRuleV1{
+					{
+						LocalID: "signed_up_rule",
+						Event:   "#event:signed_up",
+						Properties: []*localcatalog.TPRulePropertyV1{
+							{Property: "#property:email", Required: true},
+							{Property: "#property:age"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCompareIdentical(t *testing.T) {
+	changes := catalogdiff.Compare(baseCatalog(), baseCatalog())
+
+	assert.Empty(t, changes)
+	assert.False(t, changes.HasBreaking())
+}
+
+func TestCompareEntities(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(dc *localcatalog.DataCatalog)
+		want   []catalogdiff.Change
+	}{
+		{
+			name: "event removed",
+			mutate: func(dc *localcatalog.DataCatalog) {
+				dc.Events = dc.Events[:1]
+			},
+			want: []catalogdiff.Change{
+				{Entity: "event", ID: "logged_in", Severity: catalogdiff.Breaking, Message: "removed"},
+			},
+		},
+		{
+			name: "event added and description changed",
+			mutate: func(dc *localcatalog.DataCatalog) {
+				dc.Events[0].Description = "Sent after sign up"
+				dc.Events = append(dc.Events, localcatalog.EventV1{LocalID: "logged_out", Type: "track"})
+			},
+			want: []catalogdiff.Change{
+				{Entity: "event", ID: "logged_out", Severity: catalogdiff.NonBreaking, Message: "added"},
+				{Entity: "event", ID: "signed_up", Path: "description", Severity: catalogdiff.NonBreaking, Message: "description changed"},
+			},
+		},
+		{
+			name: "event renamed",
+			mutate: func(dc *localcatalog.DataCatalog) {
+				dc.Events[0].Name = "Signed Up"
+			},
+			want: []catalogdiff.Change{
+				{Entity: "event", ID: "signed_up", Path: "name", Severity: catalogdiff.Breaking, Message: `renamed from "User Signed Up" to "Signed Up"`},
+			},
+		},
+		{
+			name: "property type widened",
+			mutate: func(dc *localcatalog.DataCatalog) {
+				dc.Properties[4].Type = ""
+				dc.Properties[4].Types = []string{"string", "null"}
+				dc.Properties[1].Type = "number"
+			},
+			want: []catalogdiff.Change{
+				{Entity: "property", ID: "age", Path: "type", Severity: catalogdiff.NonBreaking, Message: "widened from [integer] to [number]"},
+				{Entity: "property", ID: "kind", Path: "type", Severity: catalogdiff.NonBreaking, Message: "widened from [string] to [null, string]"},
+			},
+		},
+		{
+			name: "property type narrowed",
+			mutate: func(dc *localcatalog.DataCatalog) {
+				dc.Properties[1].Type = "string"
+				dc.Properties[3].ItemType = "#custom-type:address"
+			},
+			want: []catalogdiff.Change{
+				{Entity: "property", ID: "age", Path: "type", Severity: catalogdiff.Breaking, Message: "narrowed from [integer] to [string]"},
+				{Entity: "property", ID: "tags", Path: "item_type", Severity: catalogdiff.Breaking, Message: "narrowed from [string] to [#custom-type:address]"},
+			},
+		},
+		{
+			name: "property constraints",
+			mutate: func(dc *localcatalog.DataCatalog) {
+				dc.Properties[0].Config = map[string]any{"format": "uri", "max_length": 64}
+				dc.Properties[1].Config = map[string]any{"minimum": 18.0, "maximum": 200}
+				dc.Properties[2].Config = map[string]any{"enum": []any{"pro", "enterprise"}}
+			},
+			want: []catalogdiff.Change{
+				{Entity: "property", ID: "age", Path: "config.maximum", Severity: catalogdiff.NonBreaking, Message: "relaxed from 150 to 200"},
+				{Entity: "property", ID: "age", Path: "config.minimum", Severity: catalogdiff.Breaking, Message: "tightened from 0 to 18"},
+				{Entity: "property", ID: "email", Path: "config.format", Severity: catalogdiff.Breaking, Message: "changed from email to uri"},
+				{Entity: "property", ID: "email", Path: "config.max_length", Severity: catalogdiff.Breaking, Message: "constraint added: 64"},
+				{Entity: "property", ID: "plan", Path: "config.enum", Severity: catalogdiff.Breaking, Message: "values removed: free"},
+				{Entity: "property", ID: "plan", Path: "config.enum", Severity: catalogdiff.NonBreaking, Message: "values added: enterprise"},
+			},
+		},
+		{
+			name: "property constraint removed",
+			mutate: func(dc *localcatalog.DataCatalog) {
+				dc.Properties[2].Config = nil
+			},
+			want: []catalogdiff.Change{
+				{Entity: "property", ID: "plan", Path: "config.enum", Severity: catalogdiff.NonBreaking, Message: "constraint removed (was [free pro])"},
+			},
+		},
+		{
+			name: "custom type property made optional and renamed",
+			mutate: func(dc *localcatalog.DataCatalog) {
+				dc.CustomTypes[0].Name = "PostalAddress"
+				dc.CustomTypes[0].Properties[0].Required = false
+				dc.CustomTypes[0].Properties = append(dc.CustomTypes[0].Properties,
+					localcatalog.CustomTypePropertyV1{Property: "#property:kind", Required: true})
+			},
+			want: []catalogdiff.Change{
+				{Entity: "custom-type", ID: "address", Path: "name", Severity: catalogdiff.NonBreaking, Message: `renamed from "Address" to "PostalAddress"`},
+				{Entity: "custom-type", ID: "address", Path: "properties.email", Severity: catalogdiff.NonBreaking, Message: "property made optional"},
+				{Entity: "custom-type", ID: "address", Path: "properties.kind", Severity: catalogdiff.Breaking, Message: "required property added"},
+			},
+		},
+		{
+			name: "custom type removed",
+			mutate: func(dc *localcatalog.DataCatalog) {
+				dc.CustomTypes = nil
+			},
+			want: []catalogdiff.Change{
+				{Entity: "custom-type", ID: "address", Severity: catalogdiff.Breaking, Message: "removed"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head := baseCatalog()
+			tt.mutate(head)
+
+			changes := catalogdiff.Compare(baseCatalog(), head)
+			assert.Equal(t, catalogdiff.Changes(tt.want), changes)
+		})
+	}
+}
+
+func TestCompareTrackingPlanRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(tp *localcatalog.TrackingPlanV1)
+		want   []catalogdiff.Change
+	}{
+		{
+			name: "property made required",
+			mutate: func(tp *localcatalog.TrackingPlanV1) {
+				tp.Rules[0].Properties[1].Required = true
+			},
+			want: []catalogdiff.Change{
+				{Entity: "tracking-plan", ID: "web", Path: "rules.signed_up.properties.age", Severity: catalogdiff.Breaking, Message: "property made required"},
+			},
+		},
+		{
+			name: "property removed without additional properties",
+			mutate: func(tp *localcatalog.TrackingPlanV1) {
+				tp.Rules[0].Properties = tp.Rules[0].Properties[:1]
+			},
+			want: []catalogdiff.Change{
+				{Entity: "tracking-plan", ID: "web", Path: "rules.signed_up.properties.age", Severity: catalogdiff.Breaking, Message: "property removed"},
+			},
+		},
+		{
+			name: "property removed with additional properties",
+			mutate: func(tp *localcatalog.TrackingPlanV1) {
+				tp.Rules[0].AdditionalProperties = true
+				tp.Rules[0].Properties = tp.Rules[0].Properties[:1]
+			},
+			want: []catalogdiff.Change{
+				{Entity: "tracking-plan", ID: "web", Path: "rules.signed_up.additional_properties", Severity: catalogdiff.NonBreaking, Message: "additional properties allowed"},
+				{Entity: "tracking-plan", ID: "web", Path: "rules.signed_up.properties.age", Severity: catalogdiff.NonBreaking, Message: "property removed"},
+			},
+		},
+		{
+			name: "rule renamed and nested properties tightened",
+			mutate: func(tp *localcatalog.TrackingPlanV1) {
+				tp.Rules[0].LocalID = "signed_up_rule_v2"
+				tp.Rules[0].Properties[0].AdditionalProperties = boolPtr(false)
+				tp.Rules[0].Properties[0].Properties = []*localcatalog.TPRulePropertyV1{{Property: "#property:kind"}}
+			},
+			want: []catalogdiff.Change{
+				{Entity: "tracking-plan", ID: "web", Path: "rules.signed_up.properties.email.additional_properties", Severity: catalogdiff.Breaking, Message: "additional properties no longer allowed"},
+				{Entity: "tracking-plan", ID: "web", Path: "rules.signed_up.properties.email.properties.kind", Severity: catalogdiff.NonBreaking, Message: "optional property added"},
+			},
+		},
+		{
+			name: "rules added and removed",
+			mutate: func(tp *localcatalog.TrackingPlanV1) {
+				tp.Rules = []*localcatalog.TPRuleV1{
+					{LocalID: "logged_in_rule", Event: "#event:logged_in", Properties: []*localcatalog.TPRulePropertyV1{
+						{Property: "#property:email", Required: true},
+					}},
+				}
+			},
+			want: []catalogdiff.Change{
+				{Entity: "tracking-plan", ID: "web", Path: "rules.logged_in", Severity: catalogdiff.Breaking, Message: "rule added with required properties"},
+				{Entity: "tracking-plan", ID: "web", Path: "rules.signed_up", Severity: catalogdiff.Breaking, Message: "rule removed"},
+			},
+		},
+		{
+			name: "identity section changed",
+			mutate: func(tp *localcatalog.TrackingPlanV1) {
+				tp.Rules[0].IdentitySection = "context.traits"
+			},
+			want: []catalogdiff.Change{
+				{Entity: "tracking-plan", ID: "web", Path: "rules.signed_up.identity_section", Severity: catalogdiff.Breaking, Message: `identity section changed from "" to "context.traits"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head := baseCatalog()
+			tt.mutate(head.TrackingPlans[0])
+
+			changes := catalogdiff.Compare(baseCatalog(), head)
+			assert.Equal(t, catalogdiff.Changes(tt.want), changes)
+		})
+	}
+}
+
+func TestCompareVariants(t *testing.T) {
+	variants := func() localcatalog.VariantsV1 {
+		return localcatalog.VariantsV1{{
+			Type:          "discriminator",
+			Discriminator: "#property:kind",
+			Cases: []localcatalog.VariantCaseV1{
+				{DisplayName: "Home", Match: []any{"home"}, Properties: []localcatalog.PropertyReferenceV1{{Property: "#property:email"}}},
+				{DisplayName: "Work", Match: []any{"work", "office"}, Properties: []localcatalog.PropertyReferenceV1{{Property: "#property:age"}}},
+			},
+		}}
+	}
+
+	tests := []struct {
+		name   string
+		base   localcatalog.VariantsV1
+		mutate func(v localcatalog.VariantsV1) localcatalog.VariantsV1
+		want   []catalogdiff.Change
+	}{
+		{
+			name:   "variants added",
+			mutate: func(localcatalog.VariantsV1) localcatalog.VariantsV1 { return variants() },
+			want: []catalogdiff.Change{
+				{Entity: "custom-type", ID: "address", Path: "variants", Severity: catalogdiff.Breaking, Message: "variants added on kind"},
+			},
+		},
+		{
+			name:   "variants removed",
+			base:   variants(),
+			mutate: func(localcatalog.VariantsV1) localcatalog.VariantsV1 { return nil },
+			want: []catalogdiff.Change{
+				{Entity: "custom-type", ID: "address", Path: "variants", Severity: catalogdiff.NonBreaking, Message: "variants removed"},
+			},
+		},
+		{
+			name: "discriminator changed",
+			base: variants(),
+			mutate: func(v localcatalog.VariantsV1) localcatalog.VariantsV1 {
+				v[0].Discriminator = "#property:plan"
+				return v
+			},
+			want: []catalogdiff.Change{
+				{Entity: "custom-type", ID: "address", Path: "variants.discriminator", Severity: catalogdiff.Breaking, Message: "discriminator changed from kind to plan"},
+			},
+		},
+		{
+			name: "cases changed",
+			base: variants(),
+			mutate: func(v localcatalog.VariantsV1) localcatalog.VariantsV1 {
+				v[0].Cases[0].Match = []any{"home", "house"}
+				v[0].Cases[0].Properties[0].Required = true
+				v[0].Cases[1].Match = []any{"work"}
+				v[0].Cases = append(v[0].Cases, localcatalog.VariantCaseV1{
+					DisplayName: "Other", Match: []any{"other"}, Properties: []localcatalog.PropertyReferenceV1{{Property: "#property:plan"}},
+				})
+				v[0].Default.Properties = []localcatalog.PropertyReferenceV1{{Property: "#property:plan", Required: true}}
+				return v
+			},
+			want: []catalogdiff.Change{
+				{Entity: "custom-type", ID: "address", Path: "variants.cases[Home].match", Severity: catalogdiff.Breaking, Message: "now also matches house"},
+				{Entity: "custom-type", ID: "address", Path: "variants.cases[Home].properties.email", Severity: catalogdiff.Breaking, Message: "property made required"},
+				{Entity: "custom-type", ID: "address", Path: "variants.cases[Other]", Severity: catalogdiff.NonBreaking, Message: "case added"},
+				{Entity: "custom-type", ID: "address", Path: "variants.cases[Work].match", Severity: catalogdiff.Breaking, Message: "no longer matches office"},
+				{Entity: "custom-type", ID: "address", Path: "variants.default.plan", Severity: catalogdiff.Breaking, Message: "required property added"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := baseCatalog()
+			base.CustomTypes[0].Variants = tt.base
+
+			head := baseCatalog()
+			head.CustomTypes[0].Variants = tt.mutate(variants())
+
+			changes := catalogdiff.Compare(base, head)
+			assert.Equal(t, catalogdiff.Changes(tt.want), changes)
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := catalogdiff.WriteJSON(&buf, catalogdiff.Changes{
+		{Entity: "property", ID: "age", Path: "type", Severity: catalogdiff.Breaking, Message: "narrowed from [number] to [integer]"},
+		{Entity: "event", ID: "logged_out", Severity: catalogdiff.NonBreaking, Message: "added"},
+	})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"breaking": true,
+		"summary": {"breaking": 1, "nonBreaking": 1},
+		"changes": [
+			{"entity": "property", "id": "age", "path": "type", "severity": "breaking", "message": "narrowed from [number] to [integer]"},
+			{"entity": "event", "id": "logged_out", "severity": "non-breaking", "message": "added"}
+		]
+	}`, buf.String())
+}
+
+func TestWriteJSONNoChanges(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, catalogdiff.WriteJSON(&buf, nil))
+	assert.JSONEq(t, `{"breaking": false, "summary": {"breaking": 0, "nonBreaking": 0}, "changes": []}`, buf.String())
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+
+	catalogdiff.WriteText(&buf, catalogdiff.Changes{
+		{Entity: "property", ID: "age", Path: "type", Severity: catalogdiff.Breaking, Message: "narrowed from [number] to [integer]"},
+		{Entity: "event", ID: "logged_out", Severity: catalogdiff.NonBreaking, Message: "added"},
+	})
+
+	assert.Equal(t, `Breaking changes (1):
+  - property age type: narrowed from [number] to [integer]
+
+Non-breaking changes (1):
+  - event logged_out: added
+
+`, buf.String())
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,136 @@
+package catalogdiff
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Config keywords bounding a value from below or above. Raising a lower bound
+// or lowering an upper bound rejects values that used to be valid.
+var (
+	lowerBounds = map[string]bool{
+		"minimum":           true,
+		"exclusive_minimum": true,
+		"min_length":        true,
+		"min_items":         true,
+	}
+	upperBounds = map[string]bool{
+		"maximum":           true,
+		"exclusive_maximum": true,
+		"max_length":        true,
+		"max_items":         true,
+	}
+)
+
+// compareConfig reports changes to the validation keywords of a property or
+// custom type. Keywords without a known ordering (pattern, format, ...) are
+// breaking when added or changed and non-breaking when removed.
+func (d *differ) compareConfig(entity, id string, base, head map[string]any) {
+	keys := make(map[string]bool, len(base)+len(head))
+	for k := range base {
+		keys[k] = true
+	}
+	for k := range head {
+		keys[k] = true
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		path := joinPath("config", key)
+		b, inBase := base[key]
+		h, inHead := head[key]
+
+		switch {
+		case !inBase:
+			d.add(entity, id, path, Breaking, "constraint added: %v", h)
+		case !inHead:
+			d.add(entity, id, path, NonBreaking, "constraint removed (was %v)", b)
+		case key == "enum":
+			d.compareEnum(entity, id, path, b, h)
+		case lowerBounds[key] || upperBounds[key]:
+			d.compareBound(entity, id, path, lowerBounds[key], b, h)
+		case !reflect.DeepEqual(b, h):
+			d.add(entity, id, path, Breaking, "changed from %v to %v", b, h)
+		}
+	}
+}
+
+func (d *differ) compareEnum(entity, id, path string, base, head any) {
+	baseValues, headValues := enumValues(base), enumValues(head)
+
+	var removed, added []string
+	for _, v := range baseValues {
+		if !slices.Contains(headValues, v) {
+			removed = append(removed, v)
+		}
+	}
+	for _, v := range headValues {
+		if !slices.Contains(baseValues, v) {
+			added = append(added, v)
+		}
+	}
+
+	if len(removed) > 0 {
+		d.add(entity, id, path, Breaking, "values removed: %s", strings.Join(removed, ", "))
+	}
+	if len(added) > 0 {
+		d.add(entity, id, path, NonBreaking, "values added: %s", strings.Join(added, ", "))
+	}
+}
+
+func (d *differ) compareBound(entity, id, path string, lower bool, base, head any) {
+	b, bok := toFloat(base)
+	h, hok := toFloat(head)
+	if !bok || !hok {
+		if !reflect.DeepEqual(base, head) {
+			d.add(entity, id, path, Breaking, "changed from %v to %v", base, head)
+		}
+		return
+	}
+
+	switch {
+	case b == h:
+		return
+	case (lower && h > b) || (!lower && h < b):
+		d.add(entity, id, path, Breaking, "tightened from %v to %v", base, head)
+	default:
+		d.add(entity, id, path, NonBreaking, "relaxed from %v to %v", base, head)
+	}
+}
+
+// enumValues renders enum values as strings so that values decoded as
+// different numeric types still compare equal.
+func enumValues(v any) []string {
+	items, ok := v.([]any)
+	if !ok {
+		return []string{fmt.Sprint(v)}
+	}
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		values = append(values, fmt.Sprint(item))
+	}
+	return values
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
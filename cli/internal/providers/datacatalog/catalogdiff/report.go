@@ -0,0 +1,64 @@
+package catalogdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type jsonReport struct {
+	Breaking bool        `json:"breaking"`
+	Summary  jsonSummary `json:"summary"`
+	Changes  Changes     `json:"changes"`
+}
+
+type jsonSummary struct {
+	Breaking    int `json:"breaking"`
+	NonBreaking int `json:"nonBreaking"`
+}
+
+// WriteText renders the changes grouped by severity, breaking changes first.
+func WriteText(w io.Writer, changes Changes) {
+	for _, group := range []struct {
+		title    string
+		severity Severity
+	}{
+		{"Breaking changes", Breaking},
+		{"Non-breaking changes", NonBreaking},
+	} {
+		count := changes.Count(group.severity)
+		if count == 0 {
+			continue
+		}
+
+		fmt.Fprintf(w, "%s (%d):\n", group.title, count)
+		for _, c := range changes {
+			if c.Severity == group.severity {
+				fmt.Fprintf(w, "  - %s\n", c)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// WriteJSON writes the changes as a single JSON object for CI pipelines.
+func WriteJSON(w io.Writer, changes Changes) error {
+	if changes == nil {
+		changes = Changes{}
+	}
+
+	report := jsonReport{
+		Breaking: changes.HasBreaking(),
+		Summary: jsonSummary{
+			Breaking:    changes.Count(Breaking),
+			NonBreaking: changes.Count(NonBreaking),
+		},
+		Changes: changes,
+	}
+
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("encoding changes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package catalogdiff
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog/localcatalog"
+)
+
+// propertyRef is the common shape of a property referenced from a tracking
+// plan rule, a custom type or a variant case.
+type propertyRef struct {
+	id       string
+	required bool
+	// additionalProperties is only set on nested object properties of rules;
+	// nil leaves additional properties allowed.
+	additionalProperties *bool
+	properties           []propertyRef
+}
+
+func (d *differ) compareTrackingPlans(base, head []*localcatalog.TrackingPlanV1) {
+	headByID := make(map[string]*localcatalog.TrackingPlanV1, len(head))
+	for _, tp := range head {
+		headByID[tp.LocalID] = tp
+	}
+
+	baseIDs := make(map[string]bool, len(base))
+	for _, b := range base {
+		baseIDs[b.LocalID] = true
+
+		h, ok := headByID[b.LocalID]
+		if !ok {
+			d.add(EntityTrackingPlan, b.LocalID, "", Breaking, "removed")
+			continue
+		}
+		if b.Name != h.Name {
+			d.add(EntityTrackingPlan, b.LocalID, "display_name", NonBreaking, "renamed from %q to %q", b.Name, h.Name)
+		}
+		if b.Description != h.Description {
+			d.add(EntityTrackingPlan, b.LocalID, "description", NonBreaking, "description changed")
+		}
+		d.compareRules(b.LocalID, b.Rules, h.Rules)
+	}
+
+	for _, h := range head {
+		if !baseIDs[h.LocalID] {
+			d.add(EntityTrackingPlan, h.LocalID, "", NonBreaking, "added")
+		}
+	}
+}
+
+// compareRules matches rules by the event they apply to, or by the rule set
+// they include, rather than by rule id, so that renaming a rule isn't reported
+// as removing and re-adding it.
+func (d *differ) compareRules(tpID string, base, head []*localcatalog.TPRuleV1) {
+	headByKey := make(map[string]*localcatalog.TPRuleV1, len(head))
+	for _, r := range head {
+		headByKey[ruleKey(r)] = r
+	}
+
+	baseKeys := make(map[string]bool, len(base))
+	for _, b := range base {
+		key := ruleKey(b)
+		baseKeys[key] = true
+		path := joinPath("rules", key)
+
+		h, ok := headByKey[key]
+		if !ok {
+			d.add(EntityTrackingPlan, tpID, path, Breaking, "rule removed")
+			continue
+		}
+		if b.IdentitySection != h.IdentitySection {
+			d.add(EntityTrackingPlan, tpID, joinPath(path, "identity_section"), Breaking,
+				"identity section changed from %q to %q", b.IdentitySection, h.IdentitySection)
+		}
+		d.compareAdditionalProperties(EntityTrackingPlan, tpID, path, b.AdditionalProperties, h.AdditionalProperties)
+		d.comparePropertyRefs(EntityTrackingPlan, tpID, joinPath(path, "properties"),
+			rulePropertyRefs(b.Properties), rulePropertyRefs(h.Properties), h.AdditionalProperties)
+		d.compareVariants(EntityTrackingPlan, tpID, joinPath(path, "variants"), b.Variants, h.Variants)
+	}
+
+	for _, h := range head {
+		key := ruleKey(h)
+		if baseKeys[key] {
+			continue
+		}
+		// A new rule starts validating an event that used to be accepted as is,
+		// which only rejects payloads if it requires something.
+		if hasRequired(rulePropertyRefs(h.Properties)) {
+			d.add(EntityTrackingPlan, tpID, joinPath("rules", key), Breaking, "rule added with required properties")
+		} else {
+			d.add(EntityTrackingPlan, tpID, joinPath("rules", key), NonBreaking, "rule added")
+		}
+	}
+}
+
+func ruleKey(r *localcatalog.TPRuleV1) string {
+	if r.Event != "" {
+		return refID(r.Event)
+	}
+	if r.Includes != nil {
+		return "includes(" + r.Includes.Ref + ")"
+	}
+	return r.LocalID
+}
+
+func (d *differ) compareAdditionalProperties(entity, id, path string, base, head bool) {
+	switch {
+	case base && !head:
+		d.add(entity, id, joinPath(path, "additional_properties"), Breaking, "additional properties no longer allowed")
+	case !base && head:
+		d.add(entity, id, joinPath(path, "additional_properties"), NonBreaking, "additional properties allowed")
+	}
+}
+
+// comparePropertyRefs compares the properties an object accepts. Removing a
+// property only breaks payloads still sending it when the object doesn't allow
+// additional properties.
+func (d *differ) comparePropertyRefs(entity, id, path string, base, head []propertyRef, allowAdditional bool) {
+	headByID := make(map[string]propertyRef, len(head))
+	for _, p := range head {
+		headByID[p.id] = p
+	}
+
+	baseIDs := make(map[string]bool, len(base))
+	for _, b := range base {
+		baseIDs[b.id] = true
+		propPath := joinPath(path, b.id)
+
+		h, ok := headByID[b.id]
+		if !ok {
+			if allowAdditional {
+				d.add(entity, id, propPath, NonBreaking, "property removed")
+			} else {
+				d.add(entity, id, propPath, Breaking, "property removed")
+			}
+			continue
+		}
+
+		switch {
+		case !b.required && h.required:
+			d.add(entity, id, propPath, Breaking, "property made required")
+		case b.required && !h.required:
+			d.add(entity, id, propPath, NonBreaking, "property made optional")
+		}
+
+		if len(b.properties) > 0 || len(h.properties) > 0 || b.additionalProperties != nil || h.additionalProperties != nil {
+			baseAllows, headAllows := allowsAdditional(b.additionalProperties), allowsAdditional(h.additionalProperties)
+			d.compareAdditionalProperties(entity, id, propPath, baseAllows, headAllows)
+			d.comparePropertyRefs(entity, id, joinPath(propPath, "properties"), b.properties, h.properties, headAllows)
+		}
+	}
+
+	for _, h := range head {
+		if baseIDs[h.id] {
+			continue
+		}
+		if h.required {
+			d.add(entity, id, joinPath(path, h.id), Breaking, "required property added")
+		} else {
+			d.add(entity, id, joinPath(path, h.id), NonBreaking, "optional property added")
+		}
+	}
+}
+
+func allowsAdditional(v *bool) bool {
+	return v == nil || *v
+}
+
+// compareVariants compares discriminated variants. Cases are matched by
+// display name; a payload whose discriminator no longer matches a case is
+// validated against the default properties instead, so changing which values
+// a case matches is treated as breaking.
+func (d *differ) compareVariants(entity, id, path string, base, head localcatalog.VariantsV1) {
+	switch {
+	case len(base) == 0 && len(head) == 0:
+		return
+	case len(base) == 0:
+		d.add(entity, id, path, Breaking, "variants added on %s", refID(head[0].Discriminator))
+		return
+	case len(head) == 0:
+		d.add(entity, id, path, NonBreaking, "variants removed")
+		return
+	}
+
+	b, h := base[0], head[0]
+	if b.Discriminator != h.Discriminator {
+		d.add(entity, id, joinPath(path, "discriminator"), Breaking,
+			"discriminator changed from %s to %s", refID(b.Discriminator), refID(h.Discriminator))
+		return
+	}
+
+	headCases := make(map[string]localcatalog.VariantCaseV1, len(h.Cases))
+	for _, c := range h.Cases {
+		headCases[c.DisplayName] = c
+	}
+
+	baseCases := make(map[string]bool, len(b.Cases))
+	for _, bc := range b.Cases {
+		baseCases[bc.DisplayName] = true
+		casePath := joinPath(path, fmt.Sprintf("cases[%s]", bc.DisplayName))
+
+		hc, ok := headCases[bc.DisplayName]
+		if !ok {
+			d.add(entity, id, casePath, Breaking, "case removed")
+			continue
+		}
+
+		baseMatch, headMatch := enumValues(bc.Match), enumValues(hc.Match)
+		var removed, added []string
+		for _, v := range baseMatch {
+			if !slices.Contains(headMatch, v) {
+				removed = append(removed, v)
+			}
+		}
+		for _, v := range headMatch {
+			if !slices.Contains(baseMatch, v) {
+				added = append(added, v)
+			}
+		}
+		if len(removed) > 0 {
+			d.add(entity, id, joinPath(casePath, "match"), Breaking, "no longer matches %s", strings.Join(removed, ", "))
+		}
+		if len(added) > 0 {
+			headRefs := variantPropertyRefs(hc.Properties)
+			severity := NonBreaking
+			if hasRequired(headRefs) {
+				severity = Breaking
+			}
+			d.add(entity, id, joinPath(casePath, "match"), severity, "now also matches %s", strings.Join(added, ", "))
+		}
+
+		d.comparePropertyRefs(entity, id, joinPath(casePath, "properties"),
+			variantPropertyRefs(bc.Properties), variantPropertyRefs(hc.Properties), true)
+	}
+
+	for _, hc := range h.Cases {
+		if baseCases[hc.DisplayName] {
+			continue
+		}
+		casePath := joinPath(path, fmt.Sprintf("cases[%s]", hc.DisplayName))
+		if hasRequired(variantPropertyRefs(hc.Properties)) {
+			d.add(entity, id, casePath, Breaking, "case added with required properties")
+		} else {
+			d.add(entity, id, casePath, NonBreaking, "case added")
+		}
+	}
+
+	d.comparePropertyRefs(entity, id, joinPath(path, "default"),
+		variantPropertyRefs(b.Default.Properties), variantPropertyRefs(h.Default.Properties), true)
+}
+
+func hasRequired(refs []propertyRef) bool {
+	for _, r := range refs {
+		if r.required {
+			return true
+		}
+	}
+	return false
+}
+
+func rulePropertyRefs(props []*localcatalog.TPRulePropertyV1) []propertyRef {
+	refs := make([]propertyRef, 0, len(props))
+	for _, p := range props {
+		refs = append(refs, propertyRef{
+			id:                   refID(p.Property),
+			required:             p.Required,
+			additionalProperties: p.AdditionalProperties,
+			properties:           rulePropertyRefs(p.Properties),
+		})
+	}
+	return refs
+}
+
+func customTypePropertyRefs(props []localcatalog.CustomTypePropertyV1) []propertyRef {
+	refs := make([]propertyRef, 0, len(props))
+	for _, p := range props {
+		refs = append(refs, propertyRef{id: refID(p.Property), required: p.Required})
+	}
+	return refs
+}
+
+func variantPropertyRefs(props []localcatalog.PropertyReferenceV1) []propertyRef {
+	refs := make([]propertyRef, 0, len(props))
+	for _, p := range props {
+		refs = append(refs, propertyRef{id: refID(p.Property), required: p.Required})
+	}
+	return refs
+}
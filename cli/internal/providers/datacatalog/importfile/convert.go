@@ -0,0 +1,805 @@
+package importfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/namer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/writer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog/localcatalog"
+	catalogRules "github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog/types"
+)
+
+// Paths of the generated spec files, relative to the import directory.
+const (
+	PropertiesRelativePath    = "properties/properties.yaml"
+	EventsRelativePath        = "events/events.yaml"
+	CustomTypesRelativePath   = "custom-types/custom-types.yaml"
+	CategoriesRelativePath    = "categories/categories.yaml"
+	TrackingPlansRelativePath = "tracking-plans"
+)
+
+const defaultTrackingPlanName = "Imported Tracking Plan"
+
+var (
+	displayNameRegex    = regexp.MustCompile(`^[A-Z_a-z][ \w,.-]{1,63}[\w,.-]$`)
+	customTypeNameRegex = regexp.MustCompile(`^[A-Z][A-Za-z0-9_-]*$`)
+	idRegex             = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+	nonWordRegex        = regexp.MustCompile(`[^A-Za-z0-9]+`)
+	nonDisplayNameRegex = regexp.MustCompile(`[^ \w,.-]+`)
+)
+
+// configKeys lists the config keys each type accepts, in the order they are
+// read from a schema.
+var configKeys = map[string][]string{
+	"string":  {"enum", "min_length", "max_length", "pattern", "format"},
+	"integer": {"enum", "minimum", "maximum", "exclusive_minimum", "exclusive_maximum", "multiple_of"},
+	"number":  {"enum", "minimum", "maximum", "exclusive_minimum", "exclusive_maximum", "multiple_of"},
+	"boolean": {"enum"},
+	"array":   {"min_items", "max_items", "unique_items"},
+}
+
+// Result is a Plan converted into data catalog specs.
+type Result struct {
+	// TrackingPlanID is the id of the tracking plan the plan was converted to.
+	TrackingPlanID string
+	// Entities are the spec files to write, relative to the import directory.
+	Entities []writer.FormattableEntity
+	// Warnings lists the parts of the plan that were dropped or adjusted to
+	// fit the data catalog.
+	Warnings []string
+}
+
+// Convert turns plan into data catalog specs. Properties, events, custom
+// types and categories already defined in existing are referenced instead of
+// duplicated, and the ids of new entities never clash with existing ones.
+func Convert(plan *Plan, existing *localcatalog.DataCatalog) (*Result, error) {
+	if existing == nil {
+		existing = &localcatalog.DataCatalog{}
+	}
+
+	c, err := newConverter(plan, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	tp, err := c.trackingPlan()
+	if err != nil {
+		return nil, err
+	}
+
+	entities, err := c.entities(tp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		TrackingPlanID: tp.LocalID,
+		Entities:       entities,
+		Warnings:       append(slices.Clone(plan.Warnings), c.warnings...),
+	}, nil
+}
+
+type converter struct {
+	plan  *Plan
+	namer namer.Namer
+
+	properties  []localcatalog.PropertyV1
+	events      []localcatalog.EventV1
+	customTypes []localcatalog.CustomTypeV1
+	categories  []localcatalog.CategoryV1
+
+	// The ids below cover both existing entities and the ones created by
+	// the conversion, so that each is only defined once.
+	propertyIDs     map[string]string
+	eventIDs        map[string]string
+	customTypeIDs   map[string]string
+	customTypeNames map[string]string
+	categoryIDs     map[string]string
+
+	// created maps the ids of properties created by the conversion to their
+	// index in properties.
+	created map[string]int
+	// ruled holds the events a rule was already created for.
+	ruled map[string]bool
+
+	warnings []string
+}
+
+func newConverter(plan *Plan, existing *localcatalog.DataCatalog) (*converter, error) {
+	c := &converter{
+		plan:            plan,
+		namer:           namer.NewExternalIdNamer(namer.NewKebabCase()),
+		propertyIDs:     make(map[string]string),
+		eventIDs:        make(map[string]string),
+		customTypeIDs:   make(map[string]string),
+		customTypeNames: make(map[string]string),
+		categoryIDs:     make(map[string]string),
+		created:         make(map[string]int),
+		ruled:           make(map[string]bool),
+	}
+
+	var names []namer.ScopeName
+	for _, p := range existing.Properties {
+		c.propertyIDs[propertyKey(p)] = p.LocalID
+		names = append(names, namer.ScopeName{Name: p.LocalID, Scope: types.PropertyResourceType})
+	}
+	for _, e := range existing.Events {
+		c.eventIDs[eventKey(e.Type, e.Name)] = e.LocalID
+		names = append(names, namer.ScopeName{Name: e.LocalID, Scope: types.EventResourceType})
+	}
+	for _, ct := range existing.CustomTypes {
+		c.customTypeNames[ct.Name] = ct.LocalID
+		names = append(names, namer.ScopeName{Name: ct.LocalID, Scope: types.CustomTypeResourceType})
+	}
+	for _, cat := range existing.Categories {
+		c.categoryIDs[cat.Name] = cat.LocalID
+		names = append(names, namer.ScopeName{Name: cat.LocalID, Scope: types.CategoryResourceType})
+	}
+	for _, tp := range existing.TrackingPlans {
+		names = append(names, namer.ScopeName{Name: tp.LocalID, Scope: types.TrackingPlanResourceType})
+	}
+
+	if err := c.namer.Load(names); err != nil {
+		return nil, fmt.Errorf("loading existing ids: %w", err)
+	}
+	return c, nil
+}
+
+func (c *converter) warnf(format string, args ...any) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+// id returns a new id for name that is unique within scope. Names without
+// any character usable in an id fall back to the scope itself.
+func (c *converter) id(scope, name string) (string, error) {
+	candidate := namer.NewKebabCase().Name(name)
+	if !idRegex.MatchString(candidate) {
+		candidate = scope
+	}
+
+	id, err := c.namer.Name(namer.ScopeName{Name: candidate, Scope: scope})
+	if err != nil {
+		return "", fmt.Errorf("generating id for %s %q: %w", scope, name, err)
+	}
+	return id, nil
+}
+
+func (c *converter) trackingPlan() (*localcatalog.TrackingPlanV1, error) {
+	name := displayName(c.plan.Name)
+	if name == "" {
+		if c.plan.Name != "" {
+			c.warnf("tracking plan name %q is not a valid display name, using %q", c.plan.Name, defaultTrackingPlanName)
+		}
+		name = defaultTrackingPlanName
+	}
+
+	id, err := c.id(types.TrackingPlanResourceType, name)
+	if err != nil {
+		return nil, err
+	}
+
+	tp := &localcatalog.TrackingPlanV1{
+		Name:        name,
+		LocalID:     id,
+		Description: c.description(c.plan.Description, true, "tracking plan "+name),
+	}
+
+	for _, e := range c.plan.Events {
+		rule, err := c.rule(e)
+		if err != nil {
+			return nil, err
+		}
+		if rule != nil {
+			tp.Rules = append(tp.Rules, rule)
+		}
+	}
+
+	return tp, nil
+}
+
+// rule converts an event to a tracking plan rule, or returns nil when the
+// event was already ruled on.
+func (c *converter) rule(e Event) (*localcatalog.TPRuleV1, error) {
+	label := e.Type
+	if e.Name != "" {
+		label = fmt.Sprintf("%s event %q", e.Type, e.Name)
+	}
+
+	key := eventKey(e.Type, e.Name)
+	if c.ruled[key] {
+		c.warnf("%s is defined more than once, keeping the first definition", label)
+		return nil, nil
+	}
+	c.ruled[key] = true
+
+	eventID, err := c.event(e, label)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := c.resolve(e.Schema)
+	if schema.If != nil {
+		c.warnf("%s: conditional schemas (if/then/else) are not imported", label)
+	}
+
+	properties, err := c.ruleProperties(schema, label)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleName := e.Name
+	if ruleName == "" {
+		ruleName = e.Type
+	}
+	ruleID, err := c.id("event_rule", ruleName+"-rule")
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &localcatalog.TPRuleV1{
+		Type:                 "event_rule",
+		LocalID:              ruleID,
+		Event:                fmt.Sprintf("#%s:%s", types.EventResourceType, eventID),
+		AdditionalProperties: schema.allowsAdditional(),
+		Properties:           properties,
+	}
+	if e.Section != "" && e.Section != SectionProperties {
+		rule.IdentitySection = e.Section
+	}
+	return rule, nil
+}
+
+// event returns the id of the event, creating it unless it already exists.
+func (c *converter) event(e Event, label string) (string, error) {
+	key := eventKey(e.Type, e.Name)
+	if id, ok := c.eventIDs[key]; ok {
+		return id, nil
+	}
+
+	name := e.Name
+	if name == "" {
+		name = e.Type
+	}
+	id, err := c.id(types.EventResourceType, name)
+	if err != nil {
+		return "", err
+	}
+
+	event := localcatalog.EventV1{
+		LocalID:     id,
+		Name:        e.Name,
+		Type:        e.Type,
+		Description: c.description(e.Description, true, label),
+	}
+	if e.Category != "" {
+		categoryID, err := c.category(e.Category)
+		if err != nil {
+			return "", err
+		}
+		if categoryID != "" {
+			ref := fmt.Sprintf("#%s:%s", types.CategoryResourceType, categoryID)
+			event.CategoryRef = &ref
+		}
+	}
+
+	c.eventIDs[key] = id
+	c.events = append(c.events, event)
+	return id, nil
+}
+
+// category returns the id of the named category, creating it unless it
+// already exists, or an empty id when the name can't be used.
+func (c *converter) category(name string) (string, error) {
+	if id, ok := c.categoryIDs[name]; ok {
+		return id, nil
+	}
+	if !displayNameRegex.MatchString(name) {
+		c.warnf("category %q is not a valid display name and is skipped", name)
+		c.categoryIDs[name] = ""
+		return "", nil
+	}
+
+	id, err := c.id(types.CategoryResourceType, name)
+	if err != nil {
+		return "", err
+	}
+
+	c.categoryIDs[name] = id
+	c.categories = append(c.categories, localcatalog.CategoryV1{LocalID: id, Name: name})
+	return id, nil
+}
+
+// ruleProperties converts the properties of an object schema, in name order.
+func (c *converter) ruleProperties(s *Schema, label string) ([]*localcatalog.TPRulePropertyV1, error) {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []*localcatalog.TPRulePropertyV1
+	for _, name := range names {
+		ref, nested, err := c.property(name, s.Properties[name], label)
+		if err != nil {
+			return nil, err
+		}
+
+		rp := &localcatalog.TPRulePropertyV1{
+			Property: ref,
+			Required: s.isRequired(name),
+		}
+		if nested != nil {
+			allowed := nested.allowsAdditional()
+			rp.AdditionalProperties = &allowed
+			rp.Properties, err = c.ruleProperties(nested, label)
+			if err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, rp)
+	}
+	return result, nil
+}
+
+// property returns a reference to the property described by s, creating it
+// unless an identical one exists. Objects declaring properties of their own
+// are returned as nested, since their structure is described by the rule.
+func (c *converter) property(name string, s *Schema, label string) (string, *Schema, error) {
+	if s == nil {
+		s = &Schema{}
+	}
+
+	p := localcatalog.PropertyV1{
+		Name:        name,
+		Description: c.description(s.Description, false, fmt.Sprintf("%s: property %q", label, name)),
+	}
+
+	var nested *Schema
+	if s.Ref != "" {
+		id, err := c.customType(refName(s.Ref))
+		if err != nil {
+			return "", nil, err
+		}
+		if id != "" {
+			p.Type = fmt.Sprintf("#%s:%s", types.CustomTypeResourceType, id)
+		}
+	} else {
+		propertyTypes, err := c.shape(s, fmt.Sprintf("%s: property %q", label, name))
+		if err != nil {
+			return "", nil, err
+		}
+		if len(propertyTypes.types) == 1 {
+			p.Type = propertyTypes.types[0]
+		} else {
+			p.Types = propertyTypes.types
+		}
+		if len(propertyTypes.itemTypes) == 1 {
+			p.ItemType = propertyTypes.itemTypes[0]
+		} else {
+			p.ItemTypes = propertyTypes.itemTypes
+		}
+		p.Config = propertyTypes.config
+
+		if slices.Contains(propertyTypes.types, "object") && len(s.Properties) > 0 {
+			nested = s
+		}
+	}
+
+	key := propertyKey(p)
+	if id, ok := c.propertyIDs[key]; ok {
+		if i, ok := c.created[id]; ok && !reflect.DeepEqual(c.properties[i].Config, p.Config) {
+			c.warnf("%s: property %q is defined differently elsewhere, keeping the first definition", label, name)
+		}
+		return fmt.Sprintf("#%s:%s", types.PropertyResourceType, id), nested, nil
+	}
+
+	id, err := c.id(types.PropertyResourceType, name)
+	if err != nil {
+		return "", nil, err
+	}
+	p.LocalID = id
+
+	c.propertyIDs[key] = id
+	c.created[id] = len(c.properties)
+	c.properties = append(c.properties, p)
+	return fmt.Sprintf("#%s:%s", types.PropertyResourceType, id), nested, nil
+}
+
+// customType returns the id of the custom type the named definition converts
+// to, or an empty id when it can't be converted.
+func (c *converter) customType(defName string) (string, error) {
+	if id, ok := c.customTypeIDs[defName]; ok {
+		return id, nil
+	}
+
+	def := c.plan.Definitions[defName]
+	if def == nil {
+		c.warnf("definition %q is not found, properties referencing it are imported without a type", defName)
+		c.customTypeIDs[defName] = ""
+		return "", nil
+	}
+
+	name := customTypeName(def.Title)
+	if name == "" {
+		name = customTypeName(defName)
+	}
+	if name == "" {
+		name = "CustomType"
+	}
+	if id, ok := c.customTypeNames[name]; ok {
+		c.customTypeIDs[defName] = id
+		return id, nil
+	}
+
+	label := fmt.Sprintf("custom type %q", name)
+	shape, err := c.shape(def, label)
+	if err != nil {
+		return "", err
+	}
+	if len(shape.types) != 1 {
+		c.warnf("%s must have exactly one type, properties referencing it are imported without a type", label)
+		c.customTypeIDs[defName] = ""
+		return "", nil
+	}
+
+	id, err := c.id(types.CustomTypeResourceType, name)
+	if err != nil {
+		return "", err
+	}
+	// Registered before converting its properties, so that definitions
+	// referencing themselves resolve.
+	c.customTypeIDs[defName] = id
+	c.customTypeNames[name] = id
+
+	ct := localcatalog.CustomTypeV1{
+		LocalID:     id,
+		Name:        name,
+		Description: c.description(def.Description, true, label),
+		Type:        shape.types[0],
+		Config:      shape.config,
+	}
+	if len(shape.itemTypes) == 1 {
+		ct.ItemType = shape.itemTypes[0]
+	} else {
+		ct.ItemTypes = shape.itemTypes
+	}
+
+	if ct.Type == "object" {
+		names := make([]string, 0, len(def.Properties))
+		for propertyName := range def.Properties {
+			names = append(names, propertyName)
+		}
+		sort.Strings(names)
+
+		for _, propertyName := range names {
+			ref, nested, err := c.property(propertyName, def.Properties[propertyName], label)
+			if err != nil {
+				return "", err
+			}
+			if nested != nil {
+				c.warnf("%s: nested properties of %q are not imported, as custom types can't nest them", label, propertyName)
+			}
+			ct.Properties = append(ct.Properties, localcatalog.CustomTypePropertyV1{
+				Property: ref,
+				Required: def.isRequired(propertyName),
+			})
+		}
+	}
+
+	c.customTypes = append(c.customTypes, ct)
+	return id, nil
+}
+
+// shape holds the types and config a schema converts to.
+type shape struct {
+	types     []string
+	itemTypes []string
+	config    map[string]any
+}
+
+func (c *converter) shape(s *Schema, label string) (shape, error) {
+	var result shape
+
+	switch {
+	case len(s.Type) > 0:
+		result.types = slices.Clone(s.Type)
+	case len(s.AnyOf) > 0 || len(s.OneOf) > 0:
+		for _, branch := range append(slices.Clone(s.AnyOf), s.OneOf...) {
+			if branch.Ref != "" || len(branch.Type) == 0 {
+				c.warnf("%s: alternatives that aren't plain types are not supported, imported without a type", label)
+				result.types = nil
+				break
+			}
+			for _, t := range branch.Type {
+				if !slices.Contains(result.types, t) {
+					result.types = append(result.types, t)
+				}
+			}
+		}
+	case len(s.Properties) > 0:
+		result.types = []string{"object"}
+	}
+
+	result.types = slices.DeleteFunc(result.types, func(t string) bool {
+		if slices.Contains(catalogRules.ValidPrimitiveTypes, t) {
+			return false
+		}
+		c.warnf("%s: type %q is not supported and is dropped", label, t)
+		return true
+	})
+
+	if slices.Contains(result.types, "array") && s.Items != nil {
+		if s.Items.Ref != "" {
+			id, err := c.customType(refName(s.Items.Ref))
+			if err != nil {
+				return shape{}, err
+			}
+			if id != "" {
+				result.itemTypes = []string{fmt.Sprintf("#%s:%s", types.CustomTypeResourceType, id)}
+			}
+		} else {
+			items, err := c.shape(s.Items, label+" items")
+			if err != nil {
+				return shape{}, err
+			}
+			result.itemTypes = items.types
+		}
+	}
+
+	result.config = c.config(s, result.types, label)
+	return result, nil
+}
+
+// config maps the validation keywords of s to the config keys of the given
+// types. Keywords none of the types accept are dropped.
+func (c *converter) config(s *Schema, propertyTypes []string, label string) map[string]any {
+	values := make(map[string]any)
+
+	switch {
+	case len(s.Enum) > 0:
+		values["enum"] = s.Enum
+	case s.Const != nil:
+		values["enum"] = []any{s.Const}
+	}
+
+	if s.MinLength != nil {
+		values["min_length"] = *s.MinLength
+	}
+	if s.MaxLength != nil {
+		values["max_length"] = *s.MaxLength
+	}
+	if s.Pattern != "" {
+		values["pattern"] = s.Pattern
+	}
+	if s.Format != "" {
+		if slices.Contains(catalogRules.ValidFormatValues, s.Format) {
+			values["format"] = s.Format
+		} else {
+			c.warnf("%s: format %q is not supported and is dropped", label, s.Format)
+		}
+	}
+
+	if s.Minimum != nil {
+		values["minimum"] = *s.Minimum
+	}
+	if s.Maximum != nil {
+		values["maximum"] = *s.Maximum
+	}
+	if s.MultipleOf != nil {
+		values["multiple_of"] = *s.MultipleOf
+	}
+	exclusiveBound(values, s.ExclusiveMinimum, "minimum")
+	exclusiveBound(values, s.ExclusiveMaximum, "maximum")
+
+	if s.MinItems != nil {
+		values["min_items"] = *s.MinItems
+	}
+	if s.MaxItems != nil {
+		values["max_items"] = *s.MaxItems
+	}
+	if s.UniqueItems {
+		values["unique_items"] = true
+	}
+
+	allowed := map[string]bool{}
+	if len(propertyTypes) == 0 {
+		// Untyped properties accept any value, which only an enum can narrow.
+		allowed["enum"] = true
+	}
+	for _, t := range propertyTypes {
+		for _, key := range configKeys[t] {
+			allowed[key] = true
+		}
+	}
+
+	config := make(map[string]any)
+	for key, value := range values {
+		if allowed[key] {
+			config[key] = value
+		}
+	}
+	if len(config) == 0 {
+		return nil
+	}
+	return config
+}
+
+// exclusiveBound sets the exclusive variant of bound, which draft 4 expresses
+// as a boolean modifying the inclusive bound and later drafts as a number.
+func exclusiveBound(values map[string]any, exclusive any, bound string) {
+	switch v := exclusive.(type) {
+	case bool:
+		if inclusive, ok := values[bound]; ok && v {
+			values["exclusive_"+bound] = inclusive
+			delete(values, bound)
+		}
+	case float64:
+		values["exclusive_"+bound] = v
+	}
+}
+
+// resolve follows the $ref of a schema describing a whole message section.
+func (c *converter) resolve(s *Schema) *Schema {
+	seen := map[string]bool{}
+	for s != nil && s.Ref != "" && !seen[s.Ref] {
+		seen[s.Ref] = true
+		def := c.plan.Definitions[refName(s.Ref)]
+		if def == nil {
+			break
+		}
+		s = def
+	}
+	if s == nil {
+		return &Schema{}
+	}
+	return s
+}
+
+// description returns d when it passes the description rules, warning and
+// dropping it otherwise.
+func (c *converter) description(d string, letterStart bool, label string) string {
+	d = strings.TrimSpace(d)
+	if d == "" {
+		return ""
+	}
+
+	valid := len(d) >= 3 && len(d) <= 2000
+	if letterStart {
+		valid = valid && (d[0] >= 'a' && d[0] <= 'z' || d[0] >= 'A' && d[0] <= 'Z')
+	}
+	if !valid {
+		c.warnf("%s: description %q doesn't meet the description rules and is dropped", label, d)
+		return ""
+	}
+	return d
+}
+
+func (c *converter) entities(tp *localcatalog.TrackingPlanV1) ([]writer.FormattableEntity, error) {
+	type file struct {
+		kind, path, key string
+		items           any
+		empty           bool
+	}
+
+	files := []file{
+		{localcatalog.KindProperties, PropertiesRelativePath, "properties", c.properties, len(c.properties) == 0},
+		{localcatalog.KindEvents, EventsRelativePath, "events", c.events, len(c.events) == 0},
+		{localcatalog.KindCustomTypes, CustomTypesRelativePath, "types", c.customTypes, len(c.customTypes) == 0},
+		{localcatalog.KindCategories, CategoriesRelativePath, "categories", c.categories, len(c.categories) == 0},
+	}
+
+	var entities []writer.FormattableEntity
+	for _, f := range files {
+		if f.empty {
+			continue
+		}
+		spec, err := newSpec(f.kind, tp.LocalID, map[string]any{f.key: f.items})
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, writer.FormattableEntity{Content: spec, RelativePath: f.path})
+	}
+
+	spec, err := newSpec(localcatalog.KindTrackingPlansV1, tp.LocalID, tp)
+	if err != nil {
+		return nil, err
+	}
+	entities = append(entities, writer.FormattableEntity{
+		Content:      spec,
+		RelativePath: filepath.Join(TrackingPlansRelativePath, tp.LocalID+".yaml"),
+	})
+
+	return entities, nil
+}
+
+// newSpec builds a V1 spec, going through JSON so that the content follows
+// the json tags of the catalog models.
+func newSpec(kind, name string, content any) (*specs.Spec, error) {
+	byt, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling %s: %w", kind, err)
+	}
+
+	data := make(map[string]any)
+	if err := json.Unmarshal(byt, &data); err != nil {
+		return nil, fmt.Errorf("unmarshalling %s: %w", kind, err)
+	}
+
+	metadata := specs.Metadata{Name: name}
+	metadataMap, err := metadata.ToMap()
+	if err != nil {
+		return nil, fmt.Errorf("converting metadata to map: %w", err)
+	}
+
+	return &specs.Spec{
+		Version:  specs.SpecVersionV1,
+		Kind:     kind,
+		Metadata: metadataMap,
+		Spec:     data,
+	}, nil
+}
+
+// propertyKey identifies a property by what makes it unique in a catalog:
+// its name, types and item types.
+func propertyKey(p localcatalog.PropertyV1) string {
+	propertyTypes := slices.Clone(p.Types)
+	if p.Type != "" {
+		propertyTypes = append(propertyTypes, p.Type)
+	}
+	itemTypes := slices.Clone(p.ItemTypes)
+	if p.ItemType != "" {
+		itemTypes = append(itemTypes, p.ItemType)
+	}
+	slices.Sort(propertyTypes)
+	slices.Sort(itemTypes)
+
+	return strings.Join([]string{p.Name, strings.Join(propertyTypes, ","), strings.Join(itemTypes, ",")}, "|")
+}
+
+func eventKey(eventType, name string) string {
+	return eventType + "|" + name
+}
+
+// displayName adapts name to the display name rules, returning an empty
+// string when nothing usable is left.
+func displayName(name string) string {
+	name = strings.Join(strings.Fields(nonDisplayNameRegex.ReplaceAllString(name, " ")), " ")
+	name = strings.TrimLeft(name, " ,.-0123456789")
+	if len(name) > 65 {
+		name = strings.TrimRight(name[:65], " ")
+	}
+	if !displayNameRegex.MatchString(name) {
+		return ""
+	}
+	return name
+}
+
+// customTypeName adapts name to the custom type name rules by joining its
+// words in PascalCase, returning an empty string when nothing usable is left.
+func customTypeName(name string) string {
+	var b strings.Builder
+	for _, word := range nonWordRegex.Split(name, -1) {
+		if word == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+
+	result := strings.TrimLeft(b.String(), "0123456789")
+	if len(result) > 65 {
+		result = result[:65]
+	}
+	if len(result) < 2 || !customTypeNameRegex.MatchString(result) {
+		return ""
+	}
+	return result
+}
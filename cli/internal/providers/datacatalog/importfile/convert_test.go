@@ -0,0 +1,238 @@
+package importfile
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/project"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/formatter"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/writer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog/localcatalog"
+)
+
+func convertFile(t *testing.T, format, path string, existing *localcatalog.DataCatalog) *Result {
+	t.Helper()
+
+	plan, err := Read(format, path)
+	require.NoError(t, err)
+	result, err := Convert(plan, existing)
+	require.NoError(t, err)
+	return result
+}
+
+// specAt returns the spec section of the entity written to relativePath.
+func specAt(t *testing.T, result *Result, relativePath string) map[string]any {
+	t.Helper()
+
+	for _, e := range result.Entities {
+		if e.RelativePath == relativePath {
+			return e.Content.(*specs.Spec).Spec
+		}
+	}
+	require.Failf(t, "entity not found", "no entity at %s", relativePath)
+	return nil
+}
+
+// byID indexes the items of a spec list by their id.
+func byID(items any) map[string]map[string]any {
+	result := make(map[string]map[string]any)
+	for _, item := range items.([]any) {
+		m := item.(map[string]any)
+		result[m["id"].(string)] = m
+	}
+	return result
+}
+
+func TestConvertSegmentProtocols(t *testing.T) {
+	result := convertFile(t, FormatSegmentProtocols, "testdata/segment-config.json", nil)
+
+	assert.Equal(t, "checkout-plan", result.TrackingPlanID)
+	assert.Empty(t, result.Warnings)
+
+	var paths []string
+	for _, e := range result.Entities {
+		paths = append(paths, e.RelativePath)
+	}
+	assert.Equal(t, []string{
+		PropertiesRelativePath,
+		EventsRelativePath,
+		CustomTypesRelativePath,
+		filepath.Join(TrackingPlansRelativePath, "checkout-plan.yaml"),
+	}, paths)
+
+	properties := byID(specAt(t, result, PropertiesRelativePath)["properties"])
+	assert.Equal(t, map[string]any{"pattern": "^ord_"}, properties["order-id"]["config"])
+	assert.Equal(t, map[string]any{"exclusive_minimum": float64(0)}, properties["total"]["config"])
+	assert.Equal(t, []any{"string", "null"}, properties["coupon"]["types"])
+	assert.Equal(t, "#custom-type:address", properties["shipping"]["type"])
+	assert.Equal(t, map[string]any{"format": "email"}, properties["email"]["config"])
+	assert.Equal(t, map[string]any{"max_length": float64(10)}, properties["zip"]["config"])
+
+	customTypes := byID(specAt(t, result, CustomTypesRelativePath)["types"])
+	assert.Equal(t, map[string]any{
+		"id":          "address",
+		"name":        "Address",
+		"description": "Postal address",
+		"type":        "object",
+		"properties": []any{
+			map[string]any{"property": "#property:city", "required": true},
+			map[string]any{"property": "#property:zip", "required": false},
+		},
+	}, customTypes["address"])
+
+	tp := specAt(t, result, filepath.Join(TrackingPlansRelativePath, "checkout-plan.yaml"))
+	assert.Equal(t, "Checkout Plan", tp["display_name"])
+	assert.Equal(t, []any{
+		map[string]any{
+			"type":                  "event_rule",
+			"id":                    "identify-rule",
+			"event":                 "#event:identify",
+			"identity_section":      "traits",
+			"additional_properties": true,
+			"properties": []any{
+				map[string]any{"property": "#property:email", "required": true},
+			},
+		},
+		map[string]any{
+			"type":                  "event_rule",
+			"id":                    "order-completed-rule",
+			"event":                 "#event:order-completed",
+			"additional_properties": true,
+			"properties": []any{
+				map[string]any{"property": "#property:coupon", "required": false},
+				map[string]any{"property": "#property:order-id", "required": true},
+				map[string]any{"property": "#property:shipping", "required": false},
+				map[string]any{"property": "#property:total", "required": true},
+			},
+		},
+	}, tp["rules"])
+}
+
+func TestConvertJSONSchema(t *testing.T) {
+	result := convertFile(t, FormatJSONSchema, "testdata/jsonschema", nil)
+
+	assert.Equal(t, "web-plan", result.TrackingPlanID)
+	assert.Equal(t, []string{
+		`track event "Product Added": property "currency": format "iso-4217" is not supported and is dropped`,
+	}, result.Warnings)
+
+	categories := byID(specAt(t, result, CategoriesRelativePath)["categories"])
+	assert.Equal(t, map[string]any{"id": "ecommerce", "name": "Ecommerce"}, categories["ecommerce"])
+
+	events := byID(specAt(t, result, EventsRelativePath)["events"])
+	assert.Equal(t, "#category:ecommerce", events["product-added"]["category"])
+	assert.Equal(t, "group", events["group"]["event_type"])
+	assert.NotContains(t, events["group"], "name")
+
+	customTypes := byID(specAt(t, result, CustomTypesRelativePath)["types"])
+	assert.Equal(t, "ProductItem", customTypes["productitem"]["name"])
+
+	tp := specAt(t, result, filepath.Join(TrackingPlansRelativePath, "web-plan.yaml"))
+	rules := tp["rules"].([]any)
+	require.Len(t, rules, 3)
+
+	group := rules[0].(map[string]any)
+	assert.Equal(t, "context.traits", group["identity_section"])
+
+	productAdded := rules[1].(map[string]any)
+	assert.Equal(t, map[string]any{
+		"property":              "#property:cart",
+		"required":              false,
+		"additional_properties": false,
+		"properties": []any{
+			map[string]any{"property": "#property:size", "required": false},
+		},
+	}, productAdded["properties"].([]any)[0])
+
+	signedUp := rules[2].(map[string]any)
+	assert.NotContains(t, signedUp, "additional_properties")
+}
+
+func TestConvertReusesExistingEntities(t *testing.T) {
+	existing := &localcatalog.DataCatalog{
+		Properties: []localcatalog.PropertyV1{
+			{LocalID: "order_id", Name: "order_id", Type: "string"},
+			// A different type makes it a different property.
+			{LocalID: "total", Name: "total", Type: "integer"},
+		},
+		Events: []localcatalog.EventV1{
+			{LocalID: "order_completed", Name: "Order Completed", Type: "track"},
+		},
+		TrackingPlans: []*localcatalog.TrackingPlanV1{
+			{LocalID: "checkout-plan", Name: "Checkout Plan"},
+		},
+	}
+
+	result := convertFile(t, FormatSegmentProtocols, "testdata/segment-config.json", existing)
+	assert.Equal(t, "checkout-plan-1", result.TrackingPlanID)
+
+	properties := byID(specAt(t, result, PropertiesRelativePath)["properties"])
+	assert.NotContains(t, properties, "order-id")
+	assert.NotContains(t, properties, "order_id")
+	assert.Equal(t, "number", properties["total-1"]["type"])
+
+	events := byID(specAt(t, result, EventsRelativePath)["events"])
+	assert.NotContains(t, events, "order-completed")
+
+	tp := specAt(t, result, filepath.Join(TrackingPlansRelativePath, "checkout-plan-1.yaml"))
+	orderCompleted := tp["rules"].([]any)[1].(map[string]any)
+	assert.Equal(t, "#event:order_completed", orderCompleted["event"])
+	assert.Contains(t, orderCompleted["properties"], map[string]any{"property": "#property:order_id", "required": true})
+	assert.Contains(t, orderCompleted["properties"], map[string]any{"property": "#property:total-1", "required": true})
+}
+
+func TestConvertAdaptsNames(t *testing.T) {
+	plan := &Plan{
+		Name: "2024: Growth / Web!",
+		Events: []Event{
+			{Name: "Viewed", Type: EventTypeTrack, Description: "42 is not a description", Section: SectionProperties, Schema: &Schema{}},
+			{Type: EventTypePage, Section: SectionProperties, Schema: &Schema{}},
+			{Type: EventTypePage, Section: SectionProperties, Schema: &Schema{}},
+		},
+	}
+
+	result, err := Convert(plan, nil)
+	require.NoError(t, err)
+
+	tp := specAt(t, result, filepath.Join(TrackingPlansRelativePath, result.TrackingPlanID+".yaml"))
+	assert.Equal(t, "Growth Web", tp["display_name"])
+	assert.Len(t, tp["rules"], 2)
+
+	events := byID(specAt(t, result, EventsRelativePath)["events"])
+	assert.NotContains(t, events["viewed"], "description")
+
+	assert.Equal(t, []string{
+		`track event "Viewed": description "42 is not a description" doesn't meet the description rules and is dropped`,
+		"page is defined more than once, keeping the first definition",
+	}, result.Warnings)
+}
+
+// TestConvertedSpecsAreValid runs the converted specs through the same
+// loading and validation as any other project.
+func TestConvertedSpecsAreValid(t *testing.T) {
+	inputs := []struct{ format, path string }{
+		{FormatSegmentProtocols, "testdata/segment-config.json"},
+		{FormatSegmentProtocols, "testdata/segment-public.json"},
+		{FormatJSONSchema, "testdata/jsonschema"},
+	}
+
+	for _, input := range inputs {
+		t.Run(input.path, func(t *testing.T) {
+			result := convertFile(t, input.format, input.path, nil)
+
+			dir := t.TempDir()
+			err := writer.Write(context.Background(), dir, formatter.Setup(formatter.DefaultYAML), result.Entities)
+			require.NoError(t, err)
+
+			dcProvider := datacatalog.New(nil)
+			require.NoError(t, project.New(dcProvider).Load(dir))
+			assert.Len(t, dcProvider.GetLocalCatalog().TrackingPlans, 1)
+		})
+	}
+}
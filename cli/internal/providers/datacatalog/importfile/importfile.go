@@ -0,0 +1,91 @@
+// Package importfile converts tracking plan definitions exported from other
+// tools into data catalog specs, so that teams migrating onto RudderStack
+// don't have to rewrite their events and properties by hand.
+//
+// Each supported format is read into a Plan, a format-independent description
+// of the events and their JSON Schemas, which Convert then turns into
+// properties, events, custom types, categories and a tracking plan.
+package importfile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Supported input formats.
+const (
+	FormatSegmentProtocols = "segment-protocols"
+	FormatJSONSchema       = "jsonschema"
+)
+
+// Formats lists the supported input formats.
+var Formats = []string{FormatSegmentProtocols, FormatJSONSchema}
+
+// Event types, matching the event_type values of event specs.
+const (
+	EventTypeTrack    = "track"
+	EventTypeIdentify = "identify"
+	EventTypeGroup    = "group"
+	EventTypePage     = "page"
+	EventTypeScreen   = "screen"
+)
+
+// Identity sections of a tracking plan rule.
+const (
+	SectionProperties    = "properties"
+	SectionTraits        = "traits"
+	SectionContextTraits = "context.traits"
+)
+
+// Plan is a tracking plan read from an export, before conversion.
+type Plan struct {
+	Name        string
+	Description string
+	Events      []Event
+	// Definitions holds the shared schemas events reference through $ref,
+	// keyed by definition name.
+	Definitions map[string]*Schema
+	// Warnings lists the parts of the export that couldn't be represented.
+	Warnings []string
+}
+
+// Event is a single event of a Plan along with the schema of the section of
+// the message it validates.
+type Event struct {
+	Name        string
+	Type        string
+	Description string
+	Category    string
+	Section     string
+	Schema      *Schema
+}
+
+// Read parses the export at path in the given format.
+func Read(format, path string) (*Plan, error) {
+	var (
+		plan *Plan
+		err  error
+	)
+
+	switch format {
+	case FormatSegmentProtocols:
+		plan, err = readSegmentProtocols(path)
+	case FormatJSONSchema:
+		plan, err = readJSONSchema(path)
+	default:
+		return nil, fmt.Errorf("unsupported format %q, must be one of: %s", format, strings.Join(Formats, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(plan.Events, func(i, j int) bool {
+		a, b := plan.Events[i], plan.Events[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Name < b.Name
+	})
+	return plan, nil
+}
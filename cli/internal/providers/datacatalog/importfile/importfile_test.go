@@ -0,0 +1,162 @@
+package importfile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type eventSummary struct {
+	Name, Type, Section string
+	Properties          []string
+}
+
+func summarize(plan *Plan) []eventSummary {
+	var summaries []eventSummary
+	for _, e := range plan.Events {
+		s := eventSummary{Name: e.Name, Type: e.Type, Section: e.Section}
+		for name := range e.Schema.Properties {
+			s.Properties = append(s.Properties, name)
+		}
+		summaries = append(summaries, s)
+	}
+	return summaries
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name            string
+		format          string
+		path            string
+		planName        string
+		events          []eventSummary
+		definitions     []string
+		warningContains string
+	}{
+		{
+			name:     "segment config api export",
+			format:   FormatSegmentProtocols,
+			path:     "testdata/segment-config.json",
+			planName: "Checkout Plan",
+			events: []eventSummary{
+				{Type: EventTypeIdentify, Section: SectionTraits, Properties: []string{"email"}},
+				{Name: "Order Completed", Type: EventTypeTrack, Section: SectionProperties, Properties: []string{"order_id", "total", "coupon", "shipping"}},
+			},
+			definitions: []string{"address"},
+		},
+		{
+			name:     "segment public api export",
+			format:   FormatSegmentProtocols,
+			path:     "testdata/segment-public.json",
+			planName: "Mobile App",
+			events: []eventSummary{
+				{Type: EventTypeIdentify, Section: SectionTraits, Properties: []string{"plan"}},
+				{Name: "Song Played", Type: EventTypeTrack, Section: SectionProperties, Properties: []string{"song_id", "duration", "tags"}},
+			},
+			warningContains: "skipping COMMON rule",
+		},
+		{
+			name:     "json schema directory",
+			format:   FormatJSONSchema,
+			path:     "testdata/jsonschema",
+			planName: "Web Plan",
+			events: []eventSummary{
+				{Type: EventTypeGroup, Section: SectionContextTraits, Properties: []string{"company"}},
+				{Name: "Product Added", Type: EventTypeTrack, Section: SectionProperties, Properties: []string{"product", "cart", "currency"}},
+				{Name: "Signed Up", Type: EventTypeTrack, Section: SectionProperties, Properties: []string{"method"}},
+			},
+			definitions: []string{"Product"},
+		},
+		{
+			name:     "single json schema file",
+			format:   FormatJSONSchema,
+			path:     "testdata/jsonschema/signup.json",
+			planName: "signup",
+			events: []eventSummary{
+				{Name: "Signed Up", Type: EventTypeTrack, Section: SectionProperties, Properties: []string{"method"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := Read(tt.format, tt.path)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.planName, plan.Name)
+
+			summaries := summarize(plan)
+			require.Len(t, summaries, len(tt.events))
+			for i, want := range tt.events {
+				got := summaries[i]
+				assert.Equal(t, want.Name, got.Name)
+				assert.Equal(t, want.Type, got.Type)
+				assert.Equal(t, want.Section, got.Section)
+				assert.ElementsMatch(t, want.Properties, got.Properties)
+			}
+
+			for _, def := range tt.definitions {
+				assert.Contains(t, plan.Definitions, def)
+			}
+
+			if tt.warningContains != "" {
+				require.Len(t, plan.Warnings, 1)
+				assert.Contains(t, plan.Warnings[0], tt.warningContains)
+			} else {
+				assert.Empty(t, plan.Warnings)
+			}
+		})
+	}
+}
+
+func TestReadKeepsLatestSegmentVersion(t *testing.T) {
+	plan, err := Read(FormatSegmentProtocols, "testdata/segment-config.json")
+	require.NoError(t, err)
+
+	var orderCompleted *Event
+	for i := range plan.Events {
+		if plan.Events[i].Name == "Order Completed" {
+			orderCompleted = &plan.Events[i]
+		}
+	}
+	require.NotNil(t, orderCompleted)
+	assert.Equal(t, "^ord_", orderCompleted.Schema.Properties["order_id"].Pattern)
+	assert.Equal(t, []string{"order_id", "total"}, orderCompleted.Schema.Required)
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		path     string
+		contains string
+	}{
+		{
+			name:     "unsupported format",
+			format:   "avo",
+			path:     "testdata/segment-config.json",
+			contains: `unsupported format "avo"`,
+		},
+		{
+			name:     "missing file",
+			format:   FormatSegmentProtocols,
+			path:     "testdata/does-not-exist.json",
+			contains: "reading testdata/does-not-exist.json",
+		},
+		{
+			name:     "not a segment export",
+			format:   FormatSegmentProtocols,
+			path:     "testdata/jsonschema/signup.json",
+			contains: "rules not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(tt.format, tt.path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.contains)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package importfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	catalogRules "github.com/rudderlabs/rudder-iac/cli/internal/providers/datacatalog/rules"
+)
+
+// readJSONSchema reads a JSON Schema file, or a directory of them, with one
+// schema per event. A schema either describes a whole message, pinning the
+// event through "type" and "event" consts, or directly describes the
+// properties of a track event named after its title or file. Definitions
+// shared through $ref may live in any of the files.
+func readJSONSchema(path string) (*Plan, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	files := []string{path}
+	name := schemaBaseName(path)
+	if info.IsDir() {
+		files, err = schemaFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no JSON Schema files found in %s", path)
+		}
+		name = filepath.Base(filepath.Clean(path))
+	}
+
+	plan := &Plan{
+		Name:        name,
+		Definitions: make(map[string]*Schema),
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", file, err)
+		}
+
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", file, err)
+		}
+		if _, ok := raw["openapi"]; ok {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("skipping %s: OpenAPI documents are not supported", filepath.Base(file)))
+			continue
+		}
+
+		var schema Schema
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", file, err)
+		}
+
+		for defName, def := range schema.definitions() {
+			plan.Definitions[defName] = def
+		}
+
+		if len(schema.Properties) == 0 {
+			// A definitions-only document, titled after the tracking plan
+			// when exported from one.
+			if len(files) > 1 && schema.Title != "" {
+				plan.Name = schema.Title
+			}
+			continue
+		}
+
+		event, err := jsonSchemaEvent(&schema, file)
+		if err != nil {
+			return nil, err
+		}
+		plan.Events = append(plan.Events, event)
+	}
+
+	return plan, nil
+}
+
+// jsonSchemaEvent turns the schema of a single file into an event.
+func jsonSchemaEvent(schema *Schema, file string) (Event, error) {
+	event := Event{
+		Name:        schema.Title,
+		Type:        EventTypeTrack,
+		Description: schema.Description,
+		Category:    schema.Category,
+		Section:     SectionProperties,
+		Schema:      schema,
+	}
+	if event.Name == "" {
+		event.Name = schemaBaseName(file)
+	}
+
+	typ := schema.Properties["type"].constString()
+	name := schema.Properties["event"].constString()
+	if typ == "" && name == "" {
+		return event, nil
+	}
+
+	if typ != "" {
+		if !slices.Contains(catalogRules.ValidEventTypes, typ) {
+			return Event{}, fmt.Errorf("%s: unsupported event type %q", file, typ)
+		}
+		event.Type = typ
+	}
+	if name != "" {
+		event.Name = name
+	}
+	if event.Type != EventTypeTrack {
+		event.Name = ""
+	}
+
+	switch {
+	case schema.Properties[SectionProperties] != nil:
+		event.Section = SectionProperties
+		event.Schema = schema.Properties[SectionProperties]
+	case schema.Properties[SectionTraits] != nil:
+		event.Section = SectionTraits
+		event.Schema = schema.Properties[SectionTraits]
+	case schema.Properties["context"] != nil && schema.Properties["context"].Properties[SectionTraits] != nil:
+		event.Section = SectionContextTraits
+		event.Schema = schema.Properties["context"].Properties[SectionTraits]
+	default:
+		if event.Type == EventTypeIdentify || event.Type == EventTypeGroup {
+			event.Section = SectionTraits
+		}
+		event.Schema = &Schema{}
+	}
+	return event, nil
+}
+
+func schemaFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.EqualFold(filepath.Ext(path), ".json") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing JSON Schema files in %s: %w", dir, err)
+	}
+
+	slices.Sort(files)
+	return files, nil
+}
+
+// schemaBaseName returns the file name without its .schema.json or .json
+// extension.
+func schemaBaseName(path string) string {
+	base := filepath.Base(path)
+	for _, ext := range []string{".schema.json", ".json"} {
+		if strings.HasSuffix(strings.ToLower(base), ext) {
+			return base[:len(base)-len(ext)]
+		}
+	}
+	return base
+}
@@ -0,0 +1,127 @@
+package importfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Schema is the subset of JSON Schema that both Segment Protocols and plain
+// JSON Schema documents use to describe event properties.
+type Schema struct {
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	Type  SchemaType `json:"type,omitempty"`
+	Enum  []any      `json:"enum,omitempty"`
+	Const any        `json:"const,omitempty"`
+	AnyOf []*Schema  `json:"anyOf,omitempty"`
+	OneOf []*Schema  `json:"oneOf,omitempty"`
+	// If is only kept to report conditional schemas, which have no
+	// equivalent that can be imported.
+	If json.RawMessage `json:"if,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties,omitempty"`
+
+	Items       *Schema `json:"items,omitempty"`
+	MinItems    *int    `json:"minItems,omitempty"`
+	MaxItems    *int    `json:"maxItems,omitempty"`
+	UniqueItems bool    `json:"uniqueItems,omitempty"`
+
+	Format    string `json:"format,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+
+	Minimum    *float64 `json:"minimum,omitempty"`
+	Maximum    *float64 `json:"maximum,omitempty"`
+	MultipleOf *float64 `json:"multipleOf,omitempty"`
+	// Draft 4 spells exclusive bounds as booleans modifying minimum and
+	// maximum, later drafts as numbers, so both are accepted.
+	ExclusiveMinimum any `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum any `json:"exclusiveMaximum,omitempty"`
+
+	Defs        map[string]*Schema `json:"$defs,omitempty"`
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+
+	// Category is read from the x-category annotation, since JSON Schema has
+	// no notion of event categories.
+	Category string `json:"x-category,omitempty"`
+}
+
+// SchemaType holds the type keyword, which is either a single type name or a
+// list of them.
+type SchemaType []string
+
+func (t *SchemaType) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = SchemaType{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("type must be a string or a list of strings: %w", err)
+	}
+	*t = multiple
+	return nil
+}
+
+// allowsAdditional reports whether additionalProperties is absent, true or a
+// schema, all of which accept properties that aren't listed.
+func (s *Schema) allowsAdditional() bool {
+	var allowed bool
+	if err := json.Unmarshal(s.AdditionalProperties, &allowed); err != nil {
+		return true
+	}
+	return allowed
+}
+
+func (s *Schema) isRequired(name string) bool {
+	return slices.Contains(s.Required, name)
+}
+
+// definitions returns the schemas declared under $defs and definitions.
+func (s *Schema) definitions() map[string]*Schema {
+	defs := make(map[string]*Schema, len(s.Defs)+len(s.Definitions))
+	for name, def := range s.Definitions {
+		defs[name] = def
+	}
+	for name, def := range s.Defs {
+		defs[name] = def
+	}
+	return defs
+}
+
+// refName returns the definition name a $ref points at, ignoring the document
+// it lives in, so that "#/$defs/Address", "#/definitions/Address" and
+// "defs.schema.json#/$defs/Address" all resolve to Address.
+func refName(ref string) string {
+	_, fragment, _ := strings.Cut(ref, "#")
+	if i := strings.LastIndex(fragment, "/"); i >= 0 {
+		return fragment[i+1:]
+	}
+	return fragment
+}
+
+// constString returns the value of a const or single-valued enum string
+// keyword, as used to pin the event name and type in message schemas.
+func (s *Schema) constString() string {
+	if s == nil {
+		return ""
+	}
+	if v, ok := s.Const.(string); ok {
+		return v
+	}
+	if len(s.Enum) == 1 {
+		if v, ok := s.Enum[0].(string); ok {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,221 @@
+package importfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// segmentExport accepts both shapes Segment exports a tracking plan in: the
+// Config API, where rules is an object with events, identify and group
+// entries, and the Public API, where rules is a list of typed rules,
+// optionally wrapped in a data envelope.
+type segmentExport struct {
+	Name         string          `json:"name"`
+	DisplayName  string          `json:"display_name"`
+	Description  string          `json:"description"`
+	Rules        json.RawMessage `json:"rules"`
+	TrackingPlan *segmentExport  `json:"trackingPlan"`
+	Data         *segmentExport  `json:"data"`
+}
+
+type segmentConfigRules struct {
+	Events   []segmentConfigEvent `json:"events"`
+	Identify *Schema              `json:"identify"`
+	Group    *Schema              `json:"group"`
+}
+
+type segmentConfigEvent struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Version     int     `json:"version"`
+	Rules       *Schema `json:"rules"`
+}
+
+type segmentPublicRule struct {
+	Type       string  `json:"type"`
+	Key        string  `json:"key"`
+	Version    int     `json:"version"`
+	JSONSchema *Schema `json:"jsonSchema"`
+}
+
+func readSegmentProtocols(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var export segmentExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("parsing Segment Protocols export %s: %w", path, err)
+	}
+
+	// Unwrap the Public API envelopes, {"data": {"trackingPlan": {...}, "rules": [...]}}.
+	for export.Data != nil {
+		export = *export.Data
+	}
+	if export.TrackingPlan != nil {
+		rules := export.Rules
+		export = *export.TrackingPlan
+		if len(rules) > 0 {
+			export.Rules = rules
+		}
+	}
+
+	plan := &Plan{
+		Name:        export.DisplayName,
+		Description: export.Description,
+	}
+	if plan.Name == "" {
+		// Config API names are resource paths such as
+		// workspaces/<id>/tracking-plans/<id>; keep the last segment.
+		plan.Name = export.Name[strings.LastIndex(export.Name, "/")+1:]
+	}
+
+	rules := strings.TrimSpace(string(export.Rules))
+	switch {
+	case strings.HasPrefix(rules, "["):
+		var list []segmentPublicRule
+		if err := json.Unmarshal(export.Rules, &list); err != nil {
+			return nil, fmt.Errorf("parsing rules of %s: %w", path, err)
+		}
+		if err := plan.addPublicRules(list); err != nil {
+			return nil, fmt.Errorf("reading rules of %s: %w", path, err)
+		}
+	case strings.HasPrefix(rules, "{"):
+		var config segmentConfigRules
+		if err := json.Unmarshal(export.Rules, &config); err != nil {
+			return nil, fmt.Errorf("parsing rules of %s: %w", path, err)
+		}
+		plan.addConfigRules(config)
+	default:
+		return nil, fmt.Errorf("%s is not a Segment Protocols tracking plan export: rules not found", path)
+	}
+
+	plan.Definitions = make(map[string]*Schema)
+	for _, e := range plan.Events {
+		for name, def := range e.Schema.definitions() {
+			plan.Definitions[name] = def
+		}
+	}
+
+	return plan, nil
+}
+
+func (p *Plan) addConfigRules(rules segmentConfigRules) {
+	latest := make(map[string]segmentConfigEvent)
+	var order []string
+	for _, e := range rules.Events {
+		prev, ok := latest[e.Name]
+		if !ok {
+			order = append(order, e.Name)
+		}
+		if !ok || e.Version > prev.Version {
+			latest[e.Name] = e
+		}
+	}
+
+	for _, name := range order {
+		e := latest[name]
+		p.Events = append(p.Events, Event{
+			Name:        e.Name,
+			Type:        EventTypeTrack,
+			Description: e.Description,
+			Section:     SectionProperties,
+			Schema:      messageSection(e.Rules, SectionProperties),
+		})
+	}
+
+	if rules.Identify != nil {
+		p.Events = append(p.Events, Event{
+			Type:    EventTypeIdentify,
+			Section: SectionTraits,
+			Schema:  messageSection(rules.Identify, SectionTraits),
+		})
+	}
+	if rules.Group != nil {
+		p.Events = append(p.Events, Event{
+			Type:    EventTypeGroup,
+			Section: SectionTraits,
+			Schema:  messageSection(rules.Group, SectionTraits),
+		})
+	}
+}
+
+func (p *Plan) addPublicRules(rules []segmentPublicRule) error {
+	type key struct{ typ, name string }
+	latest := make(map[key]segmentPublicRule)
+	var order []key
+
+	for _, r := range rules {
+		k := key{strings.ToLower(r.Type), r.Key}
+		prev, ok := latest[k]
+		if !ok {
+			order = append(order, k)
+		}
+		if !ok || r.Version > prev.Version {
+			latest[k] = r
+		}
+	}
+
+	for _, k := range order {
+		r := latest[k]
+		switch k.typ {
+		case EventTypeTrack:
+			if r.Key == "" {
+				return fmt.Errorf("track rule without a key")
+			}
+			p.Events = append(p.Events, Event{
+				Name:    r.Key,
+				Type:    EventTypeTrack,
+				Section: SectionProperties,
+				Schema:  messageSection(r.JSONSchema, SectionProperties),
+			})
+		case EventTypeIdentify, EventTypeGroup:
+			p.Events = append(p.Events, Event{
+				Type:    k.typ,
+				Section: SectionTraits,
+				Schema:  messageSection(r.JSONSchema, SectionTraits),
+			})
+		case EventTypePage, EventTypeScreen:
+			p.Events = append(p.Events, Event{
+				Type:    k.typ,
+				Section: SectionProperties,
+				Schema:  messageSection(r.JSONSchema, SectionProperties),
+			})
+		default:
+			p.Warnings = append(p.Warnings, fmt.Sprintf("skipping %s rule %q: not supported by tracking plans", r.Type, r.Key))
+		}
+	}
+	return nil
+}
+
+// messageSection returns the schema of a message section, such as
+// properties.properties of a track message. A schema that doesn't describe a
+// message is taken to describe the section directly.
+func messageSection(message *Schema, section string) *Schema {
+	if message == nil {
+		return &Schema{}
+	}
+
+	isMessage := false
+	for _, key := range []string{SectionProperties, SectionTraits, "context"} {
+		if _, ok := message.Properties[key]; ok {
+			isMessage = true
+		}
+	}
+	if !isMessage {
+		return message
+	}
+
+	s := message.Properties[section]
+	if s == nil {
+		s = &Schema{}
+	}
+	// Definitions are declared at the root of the message schema.
+	if s.Defs == nil && s.Definitions == nil {
+		s.Defs = message.definitions()
+	}
+	return s
+}
@@ -0,0 +1,194 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	dgModel "github.com/rudderlabs/rudder-iac/cli/internal/providers/datagraph/model"
+)
+
+// SchemaSource runs the information_schema queries that capture a snapshot.
+type SchemaSource interface {
+	// WarehouseType returns the account definition type of a warehouse
+	// account, which decides how its information_schema is queried.
+	WarehouseType(ctx context.Context, accountID string) (string, error)
+	// Query runs a read-only query against a warehouse account.
+	Query(ctx context.Context, accountID, sql string) ([]map[string]any, error)
+}
+
+// tableRefPartRegex bounds the catalog, schema and table names written into
+// the capture queries. Hyphens are allowed for BigQuery project IDs.
+var tableRefPartRegex = regexp.MustCompile(`^[A-Za-z0-9_$-]+$`)
+
+// captureScope is a schema of a warehouse account and the model tables that
+// live in it.
+type captureScope struct {
+	accountID string
+	catalog   string
+	schema    string
+	tables    []string
+}
+
+// CaptureSnapshot queries the information_schema of the warehouse accounts
+// referenced by the project's data graphs for the columns of every model
+// table. Model tables must be fully qualified as catalog.schema.table. One
+// query is run per schema.
+func CaptureSnapshot(ctx context.Context, project Project, source SchemaSource) (*Snapshot, error) {
+	graph, err := project.ResourceGraph()
+	if err != nil {
+		return nil, fmt.Errorf("getting resource graph: %w", err)
+	}
+
+	plan, err := PlanAll(graph)
+	if err != nil {
+		return nil, fmt.Errorf("building validation plan: %w", err)
+	}
+
+	runner := &Runner{graph: graph}
+	if err := runner.resolveAccountIDs(plan); err != nil {
+		return nil, err
+	}
+
+	scopes, err := captureScopes(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	warehouseTypes := make(map[string]string)
+	snapshot := &Snapshot{Columns: []SnapshotColumn{}}
+	for _, scope := range scopes {
+		warehouseType, ok := warehouseTypes[scope.accountID]
+		if !ok {
+			warehouseType, err = source.WarehouseType(ctx, scope.accountID)
+			if err != nil {
+				return nil, fmt.Errorf("getting warehouse type of account %s: %w", scope.accountID, err)
+			}
+			warehouseTypes[scope.accountID] = warehouseType
+		}
+
+		rows, err := source.Query(ctx, scope.accountID, columnsQuery(warehouseType, scope))
+		if err != nil {
+			return nil, fmt.Errorf("querying columns of %s.%s: %w", scope.catalog, scope.schema, err)
+		}
+
+		for _, row := range rows {
+			c := SnapshotColumn{
+				AccountID:    scope.accountID,
+				TableCatalog: rowValue(row, "table_catalog"),
+				TableSchema:  rowValue(row, "table_schema"),
+				TableName:    rowValue(row, "table_name"),
+				ColumnName:   rowValue(row, "column_name"),
+				DataType:     rowValue(row, "data_type"),
+			}
+			if c.TableName == "" || c.ColumnName == "" {
+				return nil, fmt.Errorf("querying columns of %s.%s: result rows must include table_name and column_name", scope.catalog, scope.schema)
+			}
+			snapshot.Columns = append(snapshot.Columns, c)
+		}
+	}
+
+	now := time.Now().UTC()
+	snapshot.CapturedAt = &now
+
+	return snapshot, nil
+}
+
+// captureScopes groups the model tables of the plan by account and schema,
+// in a stable order.
+func captureScopes(plan *ValidationPlan) ([]*captureScope, error) {
+	byKey := make(map[string]*captureScope)
+	var keys []string
+
+	for _, unit := range plan.Units {
+		modelRes, ok := unit.Resource.(*dgModel.ModelResource)
+		if !ok {
+			continue
+		}
+
+		parts := splitTableRef(modelRes.Table)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("model %s: table '%s' must be fully qualified as catalog.schema.table to be captured", unit.ID, modelRes.Table)
+		}
+		for _, p := range parts {
+			if !tableRefPartRegex.MatchString(p) {
+				return nil, fmt.Errorf("model %s: table '%s' contains characters that cannot be captured", unit.ID, modelRes.Table)
+			}
+		}
+
+		key := strings.ToLower(unit.AccountID + "/" + parts[0] + "." + parts[1])
+		scope, ok := byKey[key]
+		if !ok {
+			scope = &captureScope{accountID: unit.AccountID, catalog: parts[0], schema: parts[1]}
+			byKey[key] = scope
+			keys = append(keys, key)
+		}
+		if !slices.ContainsFunc(scope.tables, func(t string) bool { return strings.EqualFold(t, parts[2]) }) {
+			scope.tables = append(scope.tables, parts[2])
+		}
+	}
+
+	slices.Sort(keys)
+	scopes := make([]*captureScope, 0, len(keys))
+	for _, key := range keys {
+		scopes = append(scopes, byKey[key])
+	}
+	return scopes, nil
+}
+
+// columnsQuery builds the information_schema query for the columns of a
+// scope's tables, in the form the warehouse supports:
+//   - BigQuery keeps an INFORMATION_SCHEMA per dataset.
+//   - Snowflake and Databricks can read the information_schema of any catalog.
+//   - Postgres and Redshift only expose the connected database, which is
+//     filtered on table_catalog.
+//   - MySQL has no catalog level, so only the schema is filtered.
+//
+// Names are compared upper-cased, as warehouses differ in how they fold
+// unquoted identifiers.
+func columnsQuery(warehouseType string, scope *captureScope) string {
+	tables := make([]string, 0, len(scope.tables))
+	for _, t := range scope.tables {
+		tables = append(tables, sqlString(strings.ToUpper(t)))
+	}
+
+	columns := "table_catalog, table_schema, table_name, column_name, data_type"
+	schemaFilter := fmt.Sprintf("UPPER(table_schema) = %s", sqlString(strings.ToUpper(scope.schema)))
+	tableFilter := fmt.Sprintf("UPPER(table_name) IN (%s)", strings.Join(tables, ", "))
+	orderBy := "ORDER BY table_name, ordinal_position"
+
+	switch strings.ToUpper(warehouseType) {
+	case "BQ", "BIGQUERY":
+		return fmt.Sprintf("SELECT %s FROM `%s.%s`.INFORMATION_SCHEMA.COLUMNS WHERE %s %s",
+			columns, scope.catalog, scope.schema, tableFilter, orderBy)
+	case "POSTGRES", "RS", "REDSHIFT":
+		catalogFilter := fmt.Sprintf("UPPER(table_catalog) = %s", sqlString(strings.ToUpper(scope.catalog)))
+		return fmt.Sprintf("SELECT %s FROM information_schema.columns WHERE %s AND %s AND %s %s",
+			columns, catalogFilter, schemaFilter, tableFilter, orderBy)
+	case "MYSQL":
+		return fmt.Sprintf("SELECT %s FROM information_schema.columns WHERE %s AND %s %s",
+			columns, schemaFilter, tableFilter, orderBy)
+	default:
+		return fmt.Sprintf("SELECT %s FROM %s.information_schema.columns WHERE %s AND %s %s",
+			columns, scope.catalog, schemaFilter, tableFilter, orderBy)
+	}
+}
+
+// sqlString quotes s as a SQL string literal.
+func sqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// rowValue reads a column of a query result row, whose keys may be upper-cased
+// by the warehouse.
+func rowValue(row map[string]any, key string) string {
+	for k, v := range row {
+		if strings.EqualFold(k, key) && v != nil {
+			return fmt.Sprint(v)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,195 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datagraph/handlers/datagraph"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/datagraph/handlers/model"
+	dgModel "github.com/rudderlabs/rudder-iac/cli/internal/providers/datagraph/model"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type graphProject struct {
+	graph *resources.Graph
+}
+
+func (p *graphProject) ResourceGraph() (*resources.Graph, error) {
+	return p.graph, nil
+}
+
+// fakeSchemaSource returns results in the order queries are run
+type fakeSchemaSource struct {
+	types   map[string]string
+	results [][]map[string]any
+	queries []string
+}
+
+func (s *fakeSchemaSource) WarehouseType(_ context.Context, accountID string) (string, error) {
+	return s.types[accountID], nil
+}
+
+func (s *fakeSchemaSource) Query(_ context.Context, accountID, sql string) ([]map[string]any, error) {
+	s.queries = append(s.queries, accountID+": "+sql)
+	if len(s.results) == 0 {
+		return nil, nil
+	}
+	rows := s.results[0]
+	s.results = s.results[1:]
+	return rows, nil
+}
+
+func newCaptureGraph(accountID string, models ...*dgModel.ModelResource) *resources.Graph {
+	graph := resources.NewGraph()
+
+	dgURN := resources.URN("my-dg", datagraph.HandlerMetadata.ResourceType)
+	graph.AddResource(resources.NewResource("my-dg", datagraph.HandlerMetadata.ResourceType,
+		resources.ResourceData{"AccountID": accountID}, nil,
+		resources.WithRawData(&dgModel.DataGraphResource{ID: "my-dg", AccountID: accountID})))
+
+	for _, m := range models {
+		m.DataGraphRef = &resources.PropertyRef{URN: dgURN}
+		graph.AddResource(resources.NewResource(m.ID, model.HandlerMetadata.ResourceType,
+			resources.ResourceData{"Type": m.Type, "Table": m.Table}, nil, resources.WithRawData(m)))
+	}
+
+	return graph
+}
+
+func TestCaptureSnapshot(t *testing.T) {
+	t.Parallel()
+
+	graph := newCaptureGraph("acc-1",
+		&dgModel.ModelResource{ID: "user", Type: "entity", Table: "cat.sch.users", PrimaryID: "id"},
+		&dgModel.ModelResource{ID: "order", Type: "event", Table: "cat.sch.orders", Timestamp: "ts"},
+		&dgModel.ModelResource{ID: "account", Type: "entity", Table: "cat.crm.accounts", PrimaryID: "id"},
+	)
+
+	source := &fakeSchemaSource{
+		types: map[string]string{"acc-1": "SNOWFLAKE"},
+		results: [][]map[string]any{
+			{
+				{"TABLE_CATALOG": "CAT", "TABLE_SCHEMA": "CRM", "TABLE_NAME": "ACCOUNTS", "COLUMN_NAME": "ID", "DATA_TYPE": "NUMBER"},
+			},
+			{
+				{"TABLE_CATALOG": "CAT", "TABLE_SCHEMA": "SCH", "TABLE_NAME": "ORDERS", "COLUMN_NAME": "TS", "DATA_TYPE": "TIMESTAMP_NTZ"},
+				{"TABLE_CATALOG": "CAT", "TABLE_SCHEMA": "SCH", "TABLE_NAME": "USERS", "COLUMN_NAME": "ID", "DATA_TYPE": "NUMBER"},
+			},
+		},
+	}
+
+	s, err := CaptureSnapshot(context.Background(), &graphProject{graph: graph}, source)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"acc-1: SELECT table_catalog, table_schema, table_name, column_name, data_type FROM cat.information_schema.columns WHERE UPPER(table_schema) = 'CRM' AND UPPER(table_name) IN ('ACCOUNTS') ORDER BY table_name, ordinal_position",
+		"acc-1: SELECT table_catalog, table_schema, table_name, column_name, data_type FROM cat.information_schema.columns WHERE UPPER(table_schema) = 'SCH' AND UPPER(table_name) IN ('ORDERS', 'USERS') ORDER BY table_name, ordinal_position",
+	}, source.queries)
+
+	require.NotNil(t, s.CapturedAt)
+	assert.Equal(t, []SnapshotColumn{
+		{AccountID: "acc-1", TableCatalog: "CAT", TableSchema: "CRM", TableName: "ACCOUNTS", ColumnName: "ID", DataType: "NUMBER"},
+		{AccountID: "acc-1", TableCatalog: "CAT", TableSchema: "SCH", TableName: "ORDERS", ColumnName: "TS", DataType: "TIMESTAMP_NTZ"},
+		{AccountID: "acc-1", TableCatalog: "CAT", TableSchema: "SCH", TableName: "USERS", ColumnName: "ID", DataType: "NUMBER"},
+	}, s.Columns)
+}
+
+func TestCaptureSnapshot_BigQuery(t *testing.T) {
+	t.Parallel()
+
+	graph := newCaptureGraph("acc-bq",
+		&dgModel.ModelResource{ID: "user", Type: "entity", Table: "my-project.analytics.users", PrimaryID: "id"},
+	)
+	source := &fakeSchemaSource{types: map[string]string{"acc-bq": "BQ"}}
+
+	_, err := CaptureSnapshot(context.Background(), &graphProject{graph: graph}, source)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"acc-bq: SELECT table_catalog, table_schema, table_name, column_name, data_type FROM `my-project.analytics`.INFORMATION_SCHEMA.COLUMNS WHERE UPPER(table_name) IN ('USERS') ORDER BY table_name, ordinal_position",
+	}, source.queries)
+}
+
+func TestCaptureSnapshot_InvalidTableRefs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		table         string
+		errorContains string
+	}{
+		{table: "sch.users", errorContains: "model user: table 'sch.users' must be fully qualified as catalog.schema.table to be captured"},
+		{table: "cat.sch.users'; DROP", errorContains: "contains characters that cannot be captured"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.table, func(t *testing.T) {
+			t.Parallel()
+
+			graph := newCaptureGraph("acc-1",
+				&dgModel.ModelResource{ID: "user", Type: "entity", Table: tt.table, PrimaryID: "id"},
+			)
+			source := &fakeSchemaSource{}
+
+			_, err := CaptureSnapshot(context.Background(), &graphProject{graph: graph}, source)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+			assert.Empty(t, source.queries)
+		})
+	}
+}
+
+func TestCaptureSnapshot_RowsWithoutColumnName(t *testing.T) {
+	t.Parallel()
+
+	graph := newCaptureGraph("acc-1",
+		&dgModel.ModelResource{ID: "user", Type: "entity", Table: "cat.sch.users", PrimaryID: "id"},
+	)
+	source := &fakeSchemaSource{results: [][]map[string]any{
+		{{"table_name": "users"}},
+	}}
+
+	_, err := CaptureSnapshot(context.Background(), &graphProject{graph: graph}, source)
+	require.Error(t, err)
+	assert.Equal(t, "querying columns of cat.sch: result rows must include table_name and column_name", err.Error())
+}
+
+func TestColumnsQuery(t *testing.T) {
+	t.Parallel()
+
+	scope := &captureScope{accountID: "acc-1", catalog: "cat", schema: "sch", tables: []string{"users", "o'rders"}}
+
+	tests := []struct {
+		warehouseType string
+		expected      string
+	}{
+		{
+			warehouseType: "SNOWFLAKE",
+			expected:      "SELECT table_catalog, table_schema, table_name, column_name, data_type FROM cat.information_schema.columns WHERE UPPER(table_schema) = 'SCH' AND UPPER(table_name) IN ('USERS', 'O''RDERS') ORDER BY table_name, ordinal_position",
+		},
+		{
+			warehouseType: "POSTGRES",
+			expected:      "SELECT table_catalog, table_schema, table_name, column_name, data_type FROM information_schema.columns WHERE UPPER(table_catalog) = 'CAT' AND UPPER(table_schema) = 'SCH' AND UPPER(table_name) IN ('USERS', 'O''RDERS') ORDER BY table_name, ordinal_position",
+		},
+		{
+			warehouseType: "RS",
+			expected:      "SELECT table_catalog, table_schema, table_name, column_name, data_type FROM information_schema.columns WHERE UPPER(table_catalog) = 'CAT' AND UPPER(table_schema) = 'SCH' AND UPPER(table_name) IN ('USERS', 'O''RDERS') ORDER BY table_name, ordinal_position",
+		},
+		{
+			warehouseType: "MYSQL",
+			expected:      "SELECT table_catalog, table_schema, table_name, column_name, data_type FROM information_schema.columns WHERE UPPER(table_schema) = 'SCH' AND UPPER(table_name) IN ('USERS', 'O''RDERS') ORDER BY table_name, ordinal_position",
+		},
+		{
+			warehouseType: "BQ",
+			expected:      "SELECT table_catalog, table_schema, table_name, column_name, data_type FROM `cat.sch`.INFORMATION_SCHEMA.COLUMNS WHERE UPPER(table_name) IN ('USERS', 'O''RDERS') ORDER BY table_name, ordinal_position",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.warehouseType, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, columnsQuery(tt.warehouseType, scope))
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	dgClient "github.com/rudderlabs/rudder-iac/api/client/datagraph"
 	"github.com/rudderlabs/rudder-iac/cli/internal/logger"
 	"github.com/rudderlabs/rudder-iac/cli/internal/provider"
 	dgModel "github.com/rudderlabs/rudder-iac/cli/internal/providers/datagraph/model"
@@ -31,7 +30,7 @@ type remoteStateLoader interface {
 // Runner orchestrates data graph validation
 type Runner struct {
 	loader      remoteStateLoader
-	client      dgClient.DataGraphClient
+	client      WarehouseValidator
 	graph       *resources.Graph
 	reporter    ValidationReporter
 	concurrency int
@@ -39,7 +38,7 @@ type Runner struct {
 
 // NewRunner creates a new validation runner.
 // When concurrency is 0, it defaults to 1.
-func NewRunner(client dgClient.DataGraphClient, loader remoteStateLoader, graph *resources.Graph, reporter ValidationReporter, concurrency int) *Runner {
+func NewRunner(client WarehouseValidator, loader remoteStateLoader, graph *resources.Graph, reporter ValidationReporter, concurrency int) *Runner {
 	if reporter == nil {
 		reporter = noopReporter{}
 	}
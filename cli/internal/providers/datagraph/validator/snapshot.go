@@ -0,0 +1,289 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	dgClient "github.com/rudderlabs/rudder-iac/api/client/datagraph"
+)
+
+// Snapshot is a captured copy of the warehouse schema the data graph models
+// point at. Validating against it checks tables and columns without access
+// to the warehouse, e.g. in CI.
+type Snapshot struct {
+	CapturedAt *time.Time       `json:"captured_at,omitempty"`
+	Columns    []SnapshotColumn `json:"columns"`
+
+	tables map[string]*snapshotTable
+}
+
+// SnapshotColumn is one row of the snapshot, shaped like a row of
+// information_schema.columns so that an exported query result can be used as
+// a snapshot as is. AccountID is set by capture and is empty for exported
+// rows, which then match models of any account.
+type SnapshotColumn struct {
+	AccountID    string `json:"account_id,omitempty"`
+	TableCatalog string `json:"table_catalog,omitempty"`
+	TableSchema  string `json:"table_schema,omitempty"`
+	TableName    string `json:"table_name"`
+	ColumnName   string `json:"column_name"`
+	DataType     string `json:"data_type"`
+}
+
+// snapshotTable groups the snapshot columns of one table.
+type snapshotTable struct {
+	accountID string
+	parts     []string // catalog, schema and table, as far as they are known
+	columns   map[string]SnapshotColumn
+}
+
+func (t *snapshotTable) name() string {
+	return strings.Join(t.parts, ".")
+}
+
+// column looks up a column case-insensitively, as warehouses fold unquoted
+// identifiers.
+func (t *snapshotTable) column(name string) (SnapshotColumn, bool) {
+	c, ok := t.columns[strings.ToLower(name)]
+	return c, ok
+}
+
+// LoadSnapshot reads a snapshot written by `data-graphs snapshot`, or a JSON
+// array of information_schema.columns rows exported from the warehouse.
+// Keys are matched case-insensitively, so upper-case exports work too.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot: %w", err)
+	}
+
+	s := &Snapshot{}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(trimmed, &s.Columns)
+	} else {
+		err = json.Unmarshal(data, s)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing snapshot %s: %w", path, err)
+	}
+
+	if err := s.index(); err != nil {
+		return nil, fmt.Errorf("snapshot %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// index groups the columns by table. It is called once the columns are
+// complete and before the snapshot is used for validation.
+func (s *Snapshot) index() error {
+	if len(s.Columns) == 0 {
+		return fmt.Errorf("contains no columns")
+	}
+
+	s.tables = make(map[string]*snapshotTable)
+	for i, c := range s.Columns {
+		if c.TableName == "" || c.ColumnName == "" {
+			return fmt.Errorf("column %d: table_name and column_name are required", i)
+		}
+
+		parts := []string{}
+		for _, p := range []string{c.TableCatalog, c.TableSchema, c.TableName} {
+			if p != "" {
+				parts = append(parts, p)
+			}
+		}
+
+		key := strings.ToLower(c.AccountID + "/" + strings.Join(parts, "."))
+		t, ok := s.tables[key]
+		if !ok {
+			t = &snapshotTable{accountID: c.AccountID, parts: parts, columns: make(map[string]SnapshotColumn)}
+			s.tables[key] = t
+		}
+		t.columns[strings.ToLower(c.ColumnName)] = c
+	}
+
+	return nil
+}
+
+// lookupTable finds the table a model's table ref points at. The ref matches
+// a snapshot table when its parts equal the trailing parts of the table's
+// name, so schema.table matches a snapshot captured with catalogs. An issue
+// is returned when no table or more than one table matches.
+func (s *Snapshot) lookupTable(accountID, tableRef string) (*snapshotTable, string) {
+	ref := splitTableRef(tableRef)
+
+	var matches []*snapshotTable
+	for _, t := range s.tables {
+		if t.accountID != "" && accountID != "" && t.accountID != accountID {
+			continue
+		}
+		if len(ref) > len(t.parts) {
+			continue
+		}
+		if slices.EqualFunc(ref, t.parts[len(t.parts)-len(ref):], strings.EqualFold) {
+			matches = append(matches, t)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Sprintf("table '%s' does not exist in the schema snapshot", tableRef)
+	case 1:
+		return matches[0], ""
+	default:
+		names := make([]string, 0, len(matches))
+		for _, t := range matches {
+			names = append(names, t.name())
+		}
+		slices.Sort(names)
+		return nil, fmt.Sprintf("table '%s' matches several tables in the schema snapshot (%s), use its fully qualified name", tableRef, strings.Join(names, ", "))
+	}
+}
+
+// splitTableRef splits a dotted table ref into its parts, dropping the
+// quotes around quoted identifiers.
+func splitTableRef(ref string) []string {
+	parts := strings.Split(ref, ".")
+	for i, p := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(p), "\"`")
+	}
+	return parts
+}
+
+// ValidateModel checks that the model's table exists in the snapshot, along
+// with its primary_id column for entity models and its timestamp column for
+// event models.
+func (s *Snapshot) ValidateModel(_ context.Context, req *dgClient.ValidateModelRequest) (*dgClient.ValidationReport, error) {
+	report := &dgClient.ValidationReport{Issues: []dgClient.ValidationIssue{}}
+
+	table, msg := s.lookupTable(req.AccountID, req.TableRef)
+	if table == nil {
+		report.Issues = append(report.Issues, errorIssue("model/table-exists", msg))
+		return report, nil
+	}
+
+	switch req.Type {
+	case "entity":
+		if req.PrimaryID != "" {
+			if _, ok := table.column(req.PrimaryID); !ok {
+				report.Issues = append(report.Issues, errorIssue("model/primary-id-exists",
+					fmt.Sprintf("primary_id column '%s' does not exist in table '%s'", req.PrimaryID, req.TableRef)))
+			}
+		}
+	case "event":
+		if req.Timestamp != "" {
+			c, ok := table.column(req.Timestamp)
+			if !ok {
+				report.Issues = append(report.Issues, errorIssue("model/timestamp-exists",
+					fmt.Sprintf("timestamp column '%s' does not exist in table '%s'", req.Timestamp, req.TableRef)))
+			} else if family := typeFamily(c.DataType); family != "" && family != "temporal" {
+				report.Issues = append(report.Issues, warningIssue("model/timestamp-type",
+					fmt.Sprintf("timestamp column '%s' has type '%s', expected a date or timestamp type", req.Timestamp, c.DataType)))
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// validateColumns checks that every column listed in a model's columns
+// block exists in its table. A missing table is reported by ValidateModel,
+// so it yields no issues here.
+func (s *Snapshot) validateColumns(accountID, tableRef string, columns []string) []dgClient.ValidationIssue {
+	table, _ := s.lookupTable(accountID, tableRef)
+	if table == nil {
+		return nil
+	}
+
+	var issues []dgClient.ValidationIssue
+	for _, name := range columns {
+		if _, ok := table.column(name); !ok {
+			issues = append(issues, errorIssue("model/column-exists",
+				fmt.Sprintf("column '%s' in columns does not exist in table '%s'", name, tableRef)))
+		}
+	}
+	return issues
+}
+
+// ValidateRelationship checks that the tables of both models exist in the
+// snapshot and contain the join keys, and warns when the join keys have
+// types that do not compare.
+func (s *Snapshot) ValidateRelationship(_ context.Context, req *dgClient.ValidateRelationshipRequest) (*dgClient.ValidationReport, error) {
+	report := &dgClient.ValidationReport{Issues: []dgClient.ValidationIssue{}}
+
+	sourceKey, sourceOK := s.joinKey(report, req.AccountID, "source", req.SourceModel)
+	targetKey, targetOK := s.joinKey(report, req.AccountID, "target", req.TargetModel)
+
+	if sourceOK && targetOK {
+		sourceFamily, targetFamily := typeFamily(sourceKey.DataType), typeFamily(targetKey.DataType)
+		if sourceFamily != "" && targetFamily != "" && sourceFamily != targetFamily {
+			report.Issues = append(report.Issues, warningIssue("relationship/join-key-type",
+				fmt.Sprintf("source_join_key '%s' (%s) and target_join_key '%s' (%s) have incompatible types",
+					req.SourceModel.JoinKey, sourceKey.DataType, req.TargetModel.JoinKey, targetKey.DataType)))
+		}
+	}
+
+	return report, nil
+}
+
+// joinKey looks up one side's join key, adding an issue to report when its
+// table or column is missing.
+func (s *Snapshot) joinKey(report *dgClient.ValidationReport, accountID, side string, ref dgClient.ValidationModelRef) (SnapshotColumn, bool) {
+	table, msg := s.lookupTable(accountID, ref.TableRef)
+	if table == nil {
+		report.Issues = append(report.Issues, errorIssue("relationship/table-exists", fmt.Sprintf("%s model: %s", side, msg)))
+		return SnapshotColumn{}, false
+	}
+
+	c, ok := table.column(ref.JoinKey)
+	if !ok {
+		report.Issues = append(report.Issues, errorIssue("relationship/join-key-exists",
+			fmt.Sprintf("%s_join_key '%s' does not exist in table '%s'", side, ref.JoinKey, ref.TableRef)))
+		return SnapshotColumn{}, false
+	}
+
+	return c, true
+}
+
+// typeFamily groups warehouse data types into families whose values compare
+// with each other. It returns "" for types it does not know, which are never
+// reported.
+func typeFamily(dataType string) string {
+	t := strings.ToUpper(strings.TrimSpace(dataType))
+	if i := strings.IndexAny(t, "(<"); i >= 0 {
+		t = strings.TrimSpace(t[:i])
+	}
+
+	switch {
+	case t == "":
+		return ""
+	case strings.Contains(t, "CHAR"), t == "TEXT", t == "STRING":
+		return "string"
+	case t == "INTERVAL":
+		return ""
+	case strings.HasPrefix(t, "INT"), strings.HasSuffix(t, "INT"), t == "NUMBER", t == "NUMERIC", t == "DECIMAL", t == "BIGNUMERIC",
+		strings.HasPrefix(t, "FLOAT"), strings.HasPrefix(t, "DOUBLE"), t == "REAL":
+		return "number"
+	case t == "DATE", t == "DATETIME", strings.HasPrefix(t, "TIME"):
+		return "temporal"
+	case t == "BOOL", t == "BOOLEAN":
+		return "boolean"
+	default:
+		return ""
+	}
+}
+
+func errorIssue(rule, message string) dgClient.ValidationIssue {
+	return dgClient.ValidationIssue{Rule: rule, Severity: "error", Message: message}
+}
+
+func warningIssue(rule, message string) dgClient.ValidationIssue {
+	return dgClient.ValidationIssue{Rule: rule, Severity: "warning", Message: message}
+}
@@ -0,0 +1,317 @@
+package validator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	dgClient "github.com/rudderlabs/rudder-iac/api/client/datagraph"
+	dgModel "github.com/rudderlabs/rudder-iac/cli/internal/providers/datagraph/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSnapshot(t *testing.T, columns ...SnapshotColumn) *Snapshot {
+	t.Helper()
+	s := &Snapshot{Columns: columns}
+	require.NoError(t, s.index())
+	return s
+}
+
+func testSnapshot(t *testing.T) *Snapshot {
+	return newTestSnapshot(t,
+		SnapshotColumn{TableCatalog: "CAT", TableSchema: "SCH", TableName: "USERS", ColumnName: "ID", DataType: "NUMBER"},
+		SnapshotColumn{TableCatalog: "CAT", TableSchema: "SCH", TableName: "USERS", ColumnName: "EMAIL", DataType: "VARCHAR"},
+		SnapshotColumn{TableCatalog: "CAT", TableSchema: "SCH", TableName: "ORDERS", ColumnName: "USER_ID", DataType: "NUMBER(38,0)"},
+		SnapshotColumn{TableCatalog: "CAT", TableSchema: "SCH", TableName: "ORDERS", ColumnName: "USER_EMAIL", DataType: "TEXT"},
+		SnapshotColumn{TableCatalog: "CAT", TableSchema: "SCH", TableName: "ORDERS", ColumnName: "CREATED_AT", DataType: "TIMESTAMP_NTZ"},
+		SnapshotColumn{TableCatalog: "CAT", TableSchema: "SCH", TableName: "ORDERS", ColumnName: "STATUS", DataType: "VARCHAR"},
+		SnapshotColumn{TableCatalog: "CAT", TableSchema: "OTHER", TableName: "USERS", ColumnName: "ID", DataType: "NUMBER"},
+	)
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		content       string
+		expected      []SnapshotColumn
+		errorContains string
+	}{
+		{
+			name:    "captured snapshot",
+			content: `{"captured_at": "2026-01-02T03:04:05Z", "columns": [{"account_id": "acc-1", "table_catalog": "cat", "table_schema": "sch", "table_name": "users", "column_name": "id", "data_type": "NUMBER"}]}`,
+			expected: []SnapshotColumn{
+				{AccountID: "acc-1", TableCatalog: "cat", TableSchema: "sch", TableName: "users", ColumnName: "id", DataType: "NUMBER"},
+			},
+		},
+		{
+			name:    "exported information_schema rows with upper-case keys",
+			content: `[{"TABLE_CATALOG": "CAT", "TABLE_SCHEMA": "SCH", "TABLE_NAME": "USERS", "COLUMN_NAME": "ID", "DATA_TYPE": "NUMBER", "ORDINAL_POSITION": 1}]`,
+			expected: []SnapshotColumn{
+				{TableCatalog: "CAT", TableSchema: "SCH", TableName: "USERS", ColumnName: "ID", DataType: "NUMBER"},
+			},
+		},
+		{
+			name:          "no columns",
+			content:       `{"columns": []}`,
+			errorContains: "contains no columns",
+		},
+		{
+			name:          "missing column name",
+			content:       `[{"table_name": "users"}]`,
+			errorContains: "column 0: table_name and column_name are required",
+		},
+		{
+			name:          "invalid json",
+			content:       `{"columns": [`,
+			errorContains: "parsing snapshot",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "schema.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			s, err := LoadSnapshot(path)
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, s.Columns)
+		})
+	}
+}
+
+func TestLoadSnapshot_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadSnapshot(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reading snapshot")
+}
+
+func TestSnapshot_ValidateModel(t *testing.T) {
+	t.Parallel()
+
+	s := testSnapshot(t)
+
+	tests := []struct {
+		name     string
+		req      *dgClient.ValidateModelRequest
+		expected []dgClient.ValidationIssue
+	}{
+		{
+			name:     "entity model matching case-insensitively",
+			req:      &dgClient.ValidateModelRequest{Type: "entity", TableRef: "cat.sch.users", PrimaryID: "id"},
+			expected: []dgClient.ValidationIssue{},
+		},
+		{
+			name:     "quoted table ref",
+			req:      &dgClient.ValidateModelRequest{Type: "entity", TableRef: `"CAT"."SCH"."USERS"`, PrimaryID: "ID"},
+			expected: []dgClient.ValidationIssue{},
+		},
+		{
+			name: "missing table",
+			req:  &dgClient.ValidateModelRequest{Type: "entity", TableRef: "cat.sch.accounts", PrimaryID: "id"},
+			expected: []dgClient.ValidationIssue{
+				{Rule: "model/table-exists", Severity: "error", Message: "table 'cat.sch.accounts' does not exist in the schema snapshot"},
+			},
+		},
+		{
+			name: "ambiguous partial table ref",
+			req:  &dgClient.ValidateModelRequest{Type: "entity", TableRef: "users", PrimaryID: "id"},
+			expected: []dgClient.ValidationIssue{
+				{Rule: "model/table-exists", Severity: "error", Message: "table 'users' matches several tables in the schema snapshot (CAT.OTHER.USERS, CAT.SCH.USERS), use its fully qualified name"},
+			},
+		},
+		{
+			name:     "unique partial table ref",
+			req:      &dgClient.ValidateModelRequest{Type: "entity", TableRef: "sch.users", PrimaryID: "id"},
+			expected: []dgClient.ValidationIssue{},
+		},
+		{
+			name: "missing primary id",
+			req:  &dgClient.ValidateModelRequest{Type: "entity", TableRef: "cat.sch.users", PrimaryID: "user_id"},
+			expected: []dgClient.ValidationIssue{
+				{Rule: "model/primary-id-exists", Severity: "error", Message: "primary_id column 'user_id' does not exist in table 'cat.sch.users'"},
+			},
+		},
+		{
+			name:     "event model",
+			req:      &dgClient.ValidateModelRequest{Type: "event", TableRef: "cat.sch.orders", Timestamp: "created_at"},
+			expected: []dgClient.ValidationIssue{},
+		},
+		{
+			name: "missing timestamp",
+			req:  &dgClient.ValidateModelRequest{Type: "event", TableRef: "cat.sch.orders", Timestamp: "ts"},
+			expected: []dgClient.ValidationIssue{
+				{Rule: "model/timestamp-exists", Severity: "error", Message: "timestamp column 'ts' does not exist in table 'cat.sch.orders'"},
+			},
+		},
+		{
+			name: "timestamp of a non-temporal type",
+			req:  &dgClient.ValidateModelRequest{Type: "event", TableRef: "cat.sch.orders", Timestamp: "status"},
+			expected: []dgClient.ValidationIssue{
+				{Rule: "model/timestamp-type", Severity: "warning", Message: "timestamp column 'status' has type 'VARCHAR', expected a date or timestamp type"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := s.ValidateModel(context.Background(), tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, report.Issues)
+		})
+	}
+}
+
+func TestSnapshot_ValidateModel_FiltersByAccount(t *testing.T) {
+	t.Parallel()
+
+	s := newTestSnapshot(t,
+		SnapshotColumn{AccountID: "acc-1", TableCatalog: "cat", TableSchema: "sch", TableName: "users", ColumnName: "id"},
+		SnapshotColumn{AccountID: "acc-2", TableCatalog: "cat", TableSchema: "sch", TableName: "users", ColumnName: "user_id"},
+	)
+
+	report, err := s.ValidateModel(context.Background(), &dgClient.ValidateModelRequest{
+		AccountID: "acc-2", Type: "entity", TableRef: "cat.sch.users", PrimaryID: "user_id",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, report.Issues)
+
+	report, err = s.ValidateModel(context.Background(), &dgClient.ValidateModelRequest{
+		AccountID: "acc-3", Type: "entity", TableRef: "cat.sch.users", PrimaryID: "id",
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, "model/table-exists", report.Issues[0].Rule)
+}
+
+func TestSnapshot_ValidateRelationship(t *testing.T) {
+	t.Parallel()
+
+	s := testSnapshot(t)
+
+	tests := []struct {
+		name     string
+		source   dgClient.ValidationModelRef
+		target   dgClient.ValidationModelRef
+		expected []dgClient.ValidationIssue
+	}{
+		{
+			name:     "compatible join keys",
+			source:   dgClient.ValidationModelRef{TableRef: "cat.sch.users", JoinKey: "id"},
+			target:   dgClient.ValidationModelRef{TableRef: "cat.sch.orders", JoinKey: "user_id"},
+			expected: []dgClient.ValidationIssue{},
+		},
+		{
+			name:   "incompatible join key types",
+			source: dgClient.ValidationModelRef{TableRef: "cat.sch.users", JoinKey: "id"},
+			target: dgClient.ValidationModelRef{TableRef: "cat.sch.orders", JoinKey: "user_email"},
+			expected: []dgClient.ValidationIssue{
+				{Rule: "relationship/join-key-type", Severity: "warning", Message: "source_join_key 'id' (NUMBER) and target_join_key 'user_email' (TEXT) have incompatible types"},
+			},
+		},
+		{
+			name:   "missing join keys",
+			source: dgClient.ValidationModelRef{TableRef: "cat.sch.users", JoinKey: "uid"},
+			target: dgClient.ValidationModelRef{TableRef: "cat.sch.orders", JoinKey: "uid"},
+			expected: []dgClient.ValidationIssue{
+				{Rule: "relationship/join-key-exists", Severity: "error", Message: "source_join_key 'uid' does not exist in table 'cat.sch.users'"},
+				{Rule: "relationship/join-key-exists", Severity: "error", Message: "target_join_key 'uid' does not exist in table 'cat.sch.orders'"},
+			},
+		},
+		{
+			name:   "missing target table",
+			source: dgClient.ValidationModelRef{TableRef: "cat.sch.users", JoinKey: "id"},
+			target: dgClient.ValidationModelRef{TableRef: "cat.sch.payments", JoinKey: "user_id"},
+			expected: []dgClient.ValidationIssue{
+				{Rule: "relationship/table-exists", Severity: "error", Message: "target model: table 'cat.sch.payments' does not exist in the schema snapshot"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := s.ValidateRelationship(context.Background(), &dgClient.ValidateRelationshipRequest{
+				Cardinality: "one-to-many",
+				SourceModel: tt.source,
+				TargetModel: tt.target,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, report.Issues)
+		})
+	}
+}
+
+func TestExecuteValidation_SnapshotChecksModelColumns(t *testing.T) {
+	t.Parallel()
+
+	unit := &ValidationUnit{
+		ResourceType: "model",
+		ID:           "user",
+		URN:          "data-graph-model:user",
+		Resource: &dgModel.ModelResource{
+			ID:          "user",
+			DisplayName: "User",
+			Type:        "entity",
+			Table:       "cat.sch.users",
+			PrimaryID:   "id",
+			Columns: []map[string]any{
+				{"name": "email", "display_name": "Email"},
+				{"name": "phone", "display_name": "Phone"},
+			},
+		},
+	}
+
+	result := executeValidation(context.Background(), testSnapshot(t), nil, unit)
+
+	assert.Equal(t, &ResourceValidation{
+		ID:           "user",
+		URN:          "data-graph-model:user",
+		DisplayName:  "User",
+		ResourceType: "model",
+		Issues: []dgClient.ValidationIssue{
+			{Rule: "model/column-exists", Severity: "error", Message: "column 'phone' in columns does not exist in table 'cat.sch.users'"},
+		},
+	}, result)
+}
+
+func TestTypeFamily(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"VARCHAR(255)":             "string",
+		"character varying":        "string",
+		"STRING":                   "string",
+		"NUMBER(38,0)":             "number",
+		"INT64":                    "number",
+		"bigint":                   "number",
+		"DOUBLE PRECISION":         "number",
+		"TIMESTAMP_NTZ(9)":         "temporal",
+		"timestamp with time zone": "temporal",
+		"DATE":                     "temporal",
+		"BOOLEAN":                  "boolean",
+		"INTERVAL":                 "",
+		"ARRAY<INT64>":             "",
+		"VARIANT":                  "",
+	}
+
+	for dataType, expected := range tests {
+		assert.Equal(t, expected, typeFamily(dataType), dataType)
+	}
+}
@@ -16,6 +16,12 @@ type ValidationReporter interface {
 	TaskCompleted(id, description string, err error)
 }
 
+// columnValidator is an optional interface for warehouse validators that can
+// also check the columns listed in a model's columns block.
+type columnValidator interface {
+	validateColumns(accountID, tableRef string, columns []string) []dgClient.ValidationIssue
+}
+
 // noopReporter is a no-op implementation of ValidationReporter
 type noopReporter struct{}
 
@@ -38,7 +44,7 @@ func (t *validateTask) Dependencies() []string {
 // runValidationTasks executes all validation tasks concurrently
 func runValidationTasks(
 	ctx context.Context,
-	client dgClient.DataGraphClient,
+	client WarehouseValidator,
 	graph *resources.Graph,
 	units []*ValidationUnit,
 	reporter ValidationReporter,
@@ -77,7 +83,7 @@ func runValidationTasks(
 // executeValidation runs a single validation and returns the result
 func executeValidation(
 	ctx context.Context,
-	client dgClient.DataGraphClient,
+	client WarehouseValidator,
 	graph *resources.Graph,
 	unit *ValidationUnit,
 ) *ResourceValidation {
@@ -96,7 +102,7 @@ func executeValidation(
 	}
 }
 
-func validateModel(ctx context.Context, client dgClient.DataGraphClient, unit *ValidationUnit) *ResourceValidation {
+func validateModel(ctx context.Context, client WarehouseValidator, unit *ValidationUnit) *ResourceValidation {
 	modelRes := unit.Resource.(*dgModel.ModelResource)
 
 	req := &dgClient.ValidateModelRequest{
@@ -119,16 +125,32 @@ func validateModel(ctx context.Context, client dgClient.DataGraphClient, unit *V
 		}
 	}
 
+	issues := report.Issues
+	if cv, ok := client.(columnValidator); ok {
+		issues = append(issues, cv.validateColumns(unit.AccountID, modelRes.Table, modelColumnNames(modelRes))...)
+	}
+
 	return &ResourceValidation{
 		ID:           unit.ID,
 		URN:          unit.URN,
 		DisplayName:  modelRes.DisplayName,
 		ResourceType: "model",
-		Issues:       report.Issues,
+		Issues:       issues,
+	}
+}
+
+// modelColumnNames returns the names of the columns in a model's columns block
+func modelColumnNames(modelRes *dgModel.ModelResource) []string {
+	names := make([]string, 0, len(modelRes.Columns))
+	for _, col := range modelRes.Columns {
+		if name, ok := col["name"].(string); ok && name != "" {
+			names = append(names, name)
+		}
 	}
+	return names
 }
 
-func validateRelationship(ctx context.Context, client dgClient.DataGraphClient, graph *resources.Graph, unit *ValidationUnit) *ResourceValidation {
+func validateRelationship(ctx context.Context, client WarehouseValidator, graph *resources.Graph, unit *ValidationUnit) *ResourceValidation {
 	relRes := unit.Resource.(*dgModel.RelationshipResource)
 
 	sourceTableRef, err := resolveModelTableRef(graph, relRes.SourceModelRef)
@@ -38,6 +38,14 @@ type ValidatorProvider interface {
 	Client() dgClient.DataGraphClient
 }
 
+// WarehouseValidator checks models and relationships against a warehouse.
+// The data graph client checks the live warehouse and a Snapshot checks a
+// captured schema offline.
+type WarehouseValidator interface {
+	ValidateModel(ctx context.Context, req *dgClient.ValidateModelRequest) (*dgClient.ValidationReport, error)
+	ValidateRelationship(ctx context.Context, req *dgClient.ValidateRelationshipRequest) (*dgClient.ValidationReport, error)
+}
+
 // Project abstracts the project methods needed for validation
 type Project interface {
 	ResourceGraph() (*resources.Graph, error)
@@ -54,6 +62,8 @@ type Config struct {
 	Writer      io.Writer
 	DisplayFunc DisplayFunc
 	Concurrency int
+	// Snapshot, when set, is validated against instead of the live warehouse.
+	Snapshot *Snapshot
 }
 
 // Validate orchestrates a complete validation run: builds a resource graph,
@@ -71,7 +81,12 @@ func Validate(ctx context.Context, project Project, p ValidatorProvider, cfg Con
 		reporter = newProgressReporterIfTerminal()
 	}
 
-	runner := NewRunner(p.Client(), p, graph, reporter, cfg.Concurrency)
+	var warehouse WarehouseValidator = p.Client()
+	if cfg.Snapshot != nil {
+		warehouse = cfg.Snapshot
+	}
+
+	runner := NewRunner(warehouse, p, graph, reporter, cfg.Concurrency)
 	report, err := runner.Run(ctx, cfg.Mode, cfg.WorkspaceID)
 	if err != nil {
 		return fmt.Errorf("running validations: %w", err)
@@ -31,6 +31,10 @@ const (
 	ImportPath = "connections.yaml"
 )
 
+// ReplaceProperties are the resource data keys whose change replaces the
+// connection, see Handler.Update.
+var ReplaceProperties = []string{SourceKey, DestinationKey}
+
 // ConnectionsSpec mirrors the YAML spec structure: the body is a list of
 // connection entries. JSON tags enable the typed rule engine's
 // json.Marshal/Unmarshal round-trip; validate tags drive
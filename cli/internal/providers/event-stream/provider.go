@@ -144,6 +144,15 @@ func (p *Provider) SupportedTypes() []string {
 	return types
 }
 
+// ReplaceProperties implements provider.Replacer: a connection is replaced
+// when its endpoints change.
+func (p *Provider) ReplaceProperties(resourceType string) []string {
+	if resourceType == connectionHandler.EventStreamConnectionResourceType {
+		return connectionHandler.ReplaceProperties
+	}
+	return nil
+}
+
 // ResourceMatchers overrides the EmptyProvider default to opt into import
 // --merge smart linking for event stream sources and connections. The
 // connection matcher is listed after the source matcher so its endpoint
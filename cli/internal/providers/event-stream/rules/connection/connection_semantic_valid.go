@@ -11,6 +11,7 @@ import (
 	"github.com/rudderlabs/rudder-iac/cli/internal/providers/destination/definitions/common"
 	esConnection "github.com/rudderlabs/rudder-iac/cli/internal/providers/event-stream/connection"
 	esSource "github.com/rudderlabs/rudder-iac/cli/internal/providers/event-stream/source"
+	retlConnection "github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/connection"
 	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
 )
@@ -320,13 +321,11 @@ type connectionEdge struct {
 	destinationURN string
 }
 
-// projectConnectionEdges reduces every event stream connection in the graph
-// to its endpoint URN pair for the topology checks (V-C3, V-E1). Event
-// stream connections are the only project-managed connections today; when
-// the retl-connections kind lands, its validations must fold its connections
-// into these checks. Known limitation: only project-managed connections are
-// visible — a connection that exists remotely but is not in the project is
-// invisible at validate time.
+// projectConnectionEdges reduces every project connection in the graph —
+// event stream connections and rETL connections alike — to its endpoint URN
+// pair for the topology checks (V-C3, V-E1). Known limitation: only
+// project-managed connections are visible — a connection that exists remotely
+// but is not in the project is invisible at validate time.
 //
 // Edges stay a slice with one entry per declared connection rather than a
 // count keyed by pair: V-E1 must fire once for every place the offending
@@ -343,6 +342,13 @@ func projectConnectionEdges(graph *resources.Graph) []connectionEdge {
 		}
 		edges = append(edges, connectionEdge{sourceURN: src.URN, destinationURN: dst.URN})
 	}
+	for _, res := range graph.ResourcesByType(retlConnection.ResourceType) {
+		data, ok := res.RawData().(*retlConnection.ConnectionResource)
+		if !ok || data.Source == nil || data.Destination == nil {
+			continue
+		}
+		edges = append(edges, connectionEdge{sourceURN: data.Source.URN, destinationURN: data.Destination.URN})
+	}
 	return edges
 }
 
@@ -8,6 +8,7 @@ import (
 	"github.com/rudderlabs/rudder-iac/cli/internal/providers/destination/definitions"
 	esConnection "github.com/rudderlabs/rudder-iac/cli/internal/providers/event-stream/connection"
 	esSource "github.com/rudderlabs/rudder-iac/cli/internal/providers/event-stream/source"
+	retlConnection "github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/connection"
 	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
 	"github.com/stretchr/testify/assert"
@@ -433,8 +434,7 @@ func TestConnectionSemanticValid_DestinationFamily(t *testing.T) {
 	t.Run("destination shared with a rETL source", func(t *testing.T) {
 		t.Parallel()
 
-		// A project connection whose source URN belongs to another family
-		// (as the retl-connections kind will produce once it lands).
+		// A project connection whose source URN belongs to another family.
 		graph := compatibleGraph()
 		addConnectionResource(graph, "conn-retl",
 			resources.URN("my-model", "retl-source-sql-model"),
@@ -452,6 +452,27 @@ func TestConnectionSemanticValid_DestinationFamily(t *testing.T) {
 		assert.Contains(t, results[0].Message, "cannot receive from both event stream and rETL sources")
 	})
 
+	t.Run("destination shared with a retl-connection", func(t *testing.T) {
+		t.Parallel()
+
+		graph := compatibleGraph()
+		graph.AddResource(resources.NewResource("users-sync", retlConnection.ResourceType, resources.ResourceData{}, nil,
+			resources.WithRawData(&retlConnection.ConnectionResource{
+				ID:          "users-sync",
+				Source:      &resources.PropertyRef{URN: resources.URN("my-model", "retl-source-sql-model"), Property: "id"},
+				Destination: &resources.PropertyRef{URN: resources.URN("dest-1", destination.DestinationResourceType), Property: "id"},
+			}),
+		))
+
+		spec := esConnection.ConnectionsSpec{
+			Connections: []esConnection.ConnectionSpec{connectionEntry("conn-1", "src-1", "dest-1")},
+		}
+
+		results := validateConnectionsSemantic(registry, spec, graph)
+		require.Len(t, results, 1)
+		assert.Contains(t, results[0].Message, "destination 'dest-1' is also connected to rETL source 'my-model'")
+	})
+
 	t.Run("rETL connection to a different destination is fine", func(t *testing.T) {
 		t.Parallel()
 
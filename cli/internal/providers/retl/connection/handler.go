@@ -0,0 +1,621 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	retlClient "github.com/rudderlabs/rudder-iac/api/client/retl"
+	"github.com/rudderlabs/rudder-iac/cli/internal/namer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/importmanifest"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/writer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/provider/handler"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/destination"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/handlers"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resolver"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+// ConnectionHandler is the BaseHandler instantiation for RETL connections.
+type ConnectionHandler = handler.BaseHandler[
+	ConnectionSpec,
+	ConnectionResource,
+	ConnectionState,
+	RemoteConnection,
+]
+
+// HandlerMetadata is the static metadata describing the RETL connection
+// handler for the BaseHandler framework.
+var HandlerMetadata = handler.HandlerMetadata{
+	ResourceType:     ResourceType,
+	SpecKind:         ResourceKind,
+	SpecMetadataName: MetadataName,
+}
+
+// RefRegex matches a well-formed scalar reference "#<kind>:<id>". The handler
+// parses endpoint refs with it and the spec syntax rule matches against it, so
+// parsing and validation cannot drift apart.
+var RefRegex = regexp.MustCompile(`^#([a-zA-Z0-9_-]+):(.+)$`)
+
+// listPageSize is the page size used when walking the paginated connections
+// list.
+const listPageSize = 100
+
+// HandlerImpl owns RETL connection CRUD against the RETL API client.
+type HandlerImpl struct {
+	client    retlClient.RETLStore
+	importDir string
+}
+
+// NewHandler builds a *ConnectionHandler wired to the given client. Exported
+// specs are written under importDir.
+func NewHandler(client retlClient.RETLStore, importDir string) *ConnectionHandler {
+	return handler.NewHandler(&HandlerImpl{
+		client:    client,
+		importDir: importDir,
+	})
+}
+
+func (h *HandlerImpl) Metadata() handler.HandlerMetadata {
+	return HandlerMetadata
+}
+
+func (h *HandlerImpl) NewSpec() *ConnectionSpec {
+	return &ConnectionSpec{}
+}
+
+// ExtractResourcesFromSpec decodes a parsed spec into a ConnectionResource,
+// parsing the "#retl-source-sql-model:<id>" and "#destination:<id>" references
+// into PropertyRefs. enabled defaults to true when omitted.
+func (h *HandlerImpl) ExtractResourcesFromSpec(_ string, spec *ConnectionSpec) (map[string]*ConnectionResource, error) {
+	source, err := parseSourceRef(spec.Source)
+	if err != nil {
+		return nil, fmt.Errorf("connection %q: %w", spec.ID, err)
+	}
+
+	destinationRef, err := parseDestinationRef(spec.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("connection %q: %w", spec.ID, err)
+	}
+
+	if spec.Schedule == nil {
+		return nil, fmt.Errorf("connection %q: schedule is required", spec.ID)
+	}
+
+	enabled := true
+	if spec.Enabled != nil {
+		enabled = *spec.Enabled
+	}
+
+	resource := &ConnectionResource{
+		ID:          spec.ID,
+		Source:      source,
+		Destination: destinationRef,
+		Enabled:     enabled,
+		Schedule: retlClient.Schedule{
+			Type:           retlClient.ScheduleType(spec.Schedule.Type),
+			EveryMinutes:   spec.Schedule.EveryMinutes,
+			CronExpression: spec.Schedule.CronExpression,
+		},
+		SyncBehaviour: retlClient.SyncBehaviour(spec.SyncBehaviour),
+		Identifiers:   toMappings(spec.Identifiers),
+		Mappings:      toMappings(spec.Mappings),
+		Constants:     toConstants(spec.Constants),
+		CursorColumn:  spec.CursorColumn,
+		Object:        spec.Object,
+	}
+	if spec.Event != nil {
+		resource.Event = &retlClient.Event{
+			Type:       retlClient.EventType(spec.Event.Type),
+			Name:       spec.Event.Name,
+			NameColumn: spec.Event.NameColumn,
+		}
+	}
+
+	return map[string]*ConnectionResource{spec.ID: resource}, nil
+}
+
+// Create provisions the connection remotely, claiming the spec id as its
+// external id in the same call. The apply framework resolves both endpoint
+// refs before Create is called.
+func (h *HandlerImpl) Create(ctx context.Context, data *ConnectionResource) (*ConnectionState, error) {
+	sourceID, destinationID, err := resolveEndpoints(data)
+	if err != nil {
+		return nil, err
+	}
+
+	syncBehaviour := data.SyncBehaviour
+	created, err := h.client.CreateConnection(ctx, &retlClient.CreateRETLConnectionRequest{
+		SourceID:      sourceID,
+		DestinationID: destinationID,
+		Enabled:       &data.Enabled,
+		ExternalID:    data.ID,
+		Schedule:      data.Schedule,
+		SyncBehaviour: &syncBehaviour,
+		Identifiers:   data.Identifiers,
+		Mappings:      data.Mappings,
+		Event:         data.Event,
+		Constants:     data.Constants,
+		CursorColumn:  data.CursorColumn,
+		Object:        data.Object,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating RETL connection %q: %w", data.ID, err)
+	}
+
+	return &ConnectionState{
+		ID:            created.ID,
+		SourceID:      sourceID,
+		DestinationID: destinationID,
+	}, nil
+}
+
+// Update pushes the mutable settings of the connection. A connection cannot be
+// moved to another source or destination, so an endpoint change replaces it,
+// mirroring event stream connections. The remaining settings the API treats as
+// immutable are rejected rather than silently replacing a sync and its history.
+func (h *HandlerImpl) Update(
+	ctx context.Context,
+	newData *ConnectionResource,
+	oldData *ConnectionResource,
+	oldState *ConnectionState,
+) (*ConnectionState, error) {
+	sourceID, destinationID, err := resolveEndpoints(newData)
+	if err != nil {
+		return nil, err
+	}
+
+	if sourceID != oldState.SourceID || destinationID != oldState.DestinationID {
+		if err := h.Delete(ctx, newData.ID, oldData, oldState); err != nil {
+			return nil, err
+		}
+		// The delete already happened, so a failure here leaves the connection
+		// gone while state still carries its remote id; say so in the error
+		// rather than reporting a bare create failure.
+		created, err := h.Create(ctx, newData)
+		if err != nil {
+			return nil, fmt.Errorf("recreating RETL connection %q after endpoint change (the previous connection was deleted): %w", newData.ID, err)
+		}
+		return created, nil
+	}
+
+	if field := immutableChange(newData, oldData); field != "" {
+		return nil, fmt.Errorf("RETL connection %q: %s cannot be changed, create a connection with a new id instead", newData.ID, field)
+	}
+
+	req := &retlClient.UpdateRETLConnectionRequest{
+		Enabled:     &newData.Enabled,
+		Schedule:    newData.Schedule,
+		Identifiers: newData.Identifiers,
+	}
+	// Mappings and constants are only sent when either side has them, so flows
+	// that do not support them never see the fields, while removing the last
+	// one still clears them remotely.
+	if len(newData.Mappings) > 0 || len(oldData.Mappings) > 0 {
+		mappings := append([]retlClient.Mapping{}, newData.Mappings...)
+		req.Mappings = &mappings
+	}
+	if len(newData.Constants) > 0 || len(oldData.Constants) > 0 {
+		constants := append([]retlClient.Constant{}, newData.Constants...)
+		req.Constants = &constants
+	}
+
+	if _, err := h.client.UpdateConnection(ctx, oldState.ID, req); err != nil {
+		return nil, fmt.Errorf("updating RETL connection %q: %w", newData.ID, err)
+	}
+
+	return &ConnectionState{
+		ID:            oldState.ID,
+		SourceID:      sourceID,
+		DestinationID: destinationID,
+	}, nil
+}
+
+// Delete removes the remote connection only — the SQL model and destination
+// it links are their own resources and are never touched from here.
+func (h *HandlerImpl) Delete(ctx context.Context, ID string, _ *ConnectionResource, oldState *ConnectionState) error {
+	if err := h.client.DeleteConnection(ctx, oldState.ID); err != nil {
+		return fmt.Errorf("deleting RETL connection %q: %w", ID, err)
+	}
+	return nil
+}
+
+// Import adopts an existing remote connection: it pushes the spec's settings
+// via Update, then sets the external ID last so a failed Update never leaves a
+// partially-adopted connection behind. The spec must link the same endpoints
+// as the remote connection — adopting a connection never replaces it.
+func (h *HandlerImpl) Import(ctx context.Context, data *ConnectionResource, remoteId string) (*ConnectionState, error) {
+	remote, err := h.client.GetConnection(ctx, remoteId)
+	if err != nil {
+		return nil, fmt.Errorf("getting RETL connection during import: %w", err)
+	}
+
+	sourceID, destinationID, err := resolveEndpoints(data)
+	if err != nil {
+		return nil, err
+	}
+	if sourceID != remote.SourceID || destinationID != remote.DestinationID {
+		return nil, fmt.Errorf(
+			"RETL connection %q links source %s and destination %s, but the spec links source %s and destination %s",
+			remoteId, remote.SourceID, remote.DestinationID, sourceID, destinationID,
+		)
+	}
+
+	oldData := toResource(remote, nil, nil)
+	oldState := &ConnectionState{
+		ID:            remoteId,
+		SourceID:      remote.SourceID,
+		DestinationID: remote.DestinationID,
+	}
+
+	newState, err := h.Update(ctx, data, oldData, oldState)
+	if err != nil {
+		return nil, fmt.Errorf("updating RETL connection during import: %w", err)
+	}
+
+	if err := h.client.SetConnectionExternalId(ctx, &retlClient.SetRETLConnectionExternalIDRequest{
+		ID:         remoteId,
+		ExternalID: data.ID,
+	}); err != nil {
+		return nil, fmt.Errorf("setting external ID for RETL connection during import: %w", err)
+	}
+
+	return newState, nil
+}
+
+// MapRemoteToState converts a managed remote connection into the spec-side
+// resource and the persisted state. Both endpoints are resolved back to URNs
+// through the urnResolver; a managed connection whose endpoints are not both
+// managed cannot be expressed as a spec and is reported as an error.
+func (h *HandlerImpl) MapRemoteToState(
+	remote *RemoteConnection,
+	urnResolver handler.URNResolver,
+) (*ConnectionResource, *ConnectionState, error) {
+	if remote.ExternalID == "" {
+		return nil, nil, fmt.Errorf("managed RETL connection %s has empty external ID", remote.ID)
+	}
+
+	sourceURN, err := urnResolver.GetURNByID(sqlmodel.ResourceType, remote.SourceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving source %s of RETL connection %s: %w", remote.SourceID, remote.ID, err)
+	}
+
+	destinationURN, err := urnResolver.GetURNByID(destination.DestinationResourceType, remote.DestinationID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving destination %s of RETL connection %s: %w", remote.DestinationID, remote.ID, err)
+	}
+
+	resource := toResource(remote.RETLConnection, sourceRef(sourceURN), destinationRefFor(destinationURN))
+	state := &ConnectionState{
+		ID:            remote.ID,
+		SourceID:      remote.SourceID,
+		DestinationID: remote.DestinationID,
+	}
+	return resource, state, nil
+}
+
+// LoadRemoteResources returns the managed connections (ExternalID set) whose
+// source is a managed SQL model — MapRemoteToState can only express a
+// connection whose endpoints are managed as well.
+func (h *HandlerImpl) LoadRemoteResources(ctx context.Context) ([]*RemoteConnection, error) {
+	return h.listConnections(ctx, true, true)
+}
+
+// LoadImportableResources returns the unmanaged connections (no ExternalID)
+// whose source is a SQL model, managed or not; connections from other source
+// types cannot be expressed as a spec yet.
+func (h *HandlerImpl) LoadImportableResources(ctx context.Context) ([]*RemoteConnection, error) {
+	return h.listConnections(ctx, false, false, true)
+}
+
+// FormatForExport converts unmanaged remote connections into importable YAML
+// specs, one per connection. Both endpoints resolve to references, failing the
+// export when either cannot be resolved — a connection can only be imported
+// along with, or after, the SQL model and destination it links.
+func (h *HandlerImpl) FormatForExport(
+	collection map[string]*RemoteConnection,
+	_ namer.Namer,
+	inputResolver resolver.ReferenceResolver,
+) ([]writer.FormattableEntity, []importmanifest.ImportEntry, error) {
+	if len(collection) == 0 {
+		return nil, nil, nil
+	}
+
+	var (
+		entities []writer.FormattableEntity
+		entries  []importmanifest.ImportEntry
+	)
+
+	for externalID, remote := range collection {
+		specMap, err := toExportSpecMap(externalID, remote, inputResolver)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		workspaceMetadata := specs.WorkspaceImportMetadata{
+			WorkspaceID: remote.WorkspaceID,
+			Resources: []specs.ImportIds{
+				{
+					URN:      resources.URN(externalID, ResourceType),
+					RemoteID: remote.ID,
+				},
+			},
+		}
+		entries = append(entries, handlers.ImportEntriesFromWorkspace(workspaceMetadata)...)
+
+		spec, err := specs.ToImportSpec(ResourceKind, MetadataName, workspaceMetadata, specMap)
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating spec for RETL connection %s: %w", remote.ID, err)
+		}
+
+		entities = append(entities, writer.FormattableEntity{
+			Content:      spec,
+			RelativePath: filepath.Join(h.importDir, ImportPath, fmt.Sprintf("%s.yaml", externalID)),
+		})
+	}
+
+	return entities, entries, nil
+}
+
+// listConnections walks every page of connections matching hasExternalID and
+// keeps those whose source is a SQL model listed with one of the given
+// sourceStates, attaching the workspace id and name of that source.
+func (h *HandlerImpl) listConnections(ctx context.Context, hasExternalID bool, sourceStates ...bool) ([]*RemoteConnection, error) {
+	sqlModels := make(map[string]retlClient.RETLSource)
+	for _, managed := range sourceStates {
+		sources, err := h.client.ListRetlSources(
+			ctx,
+			retlClient.WithSourceType(string(retlClient.ModelSourceType)),
+			retlClient.WithHasExternalId(&managed),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("listing RETL sources: %w", err)
+		}
+		for _, source := range sources.Data {
+			sqlModels[source.ID] = source
+		}
+	}
+
+	var result []*RemoteConnection
+	for page := 1; ; page++ {
+		resp, err := h.client.ListConnections(ctx, &retlClient.ListRETLConnectionsRequest{
+			HasExternalID: &hasExternalID,
+			Page:          page,
+			PageSize:      listPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing RETL connections: %w", err)
+		}
+
+		for i := range resp.Data {
+			c := &resp.Data[i]
+			source, ok := sqlModels[c.SourceID]
+			if !ok {
+				continue
+			}
+			result = append(result, &RemoteConnection{
+				RETLConnection: c,
+				WorkspaceID:    source.WorkspaceID,
+				SourceName:     source.Name,
+			})
+		}
+
+		if resp.Paging.Next == "" {
+			break
+		}
+	}
+	return result, nil
+}
+
+// toExportSpecMap builds the "spec" section of an importable connection's
+// YAML, leaving out settings the remote connection does not use.
+func toExportSpecMap(externalID string, remote *RemoteConnection, inputResolver resolver.ReferenceResolver) (map[string]any, error) {
+	sourceRef, err := inputResolver.ResolveToReference(sqlmodel.ResourceType, remote.SourceID)
+	if err != nil {
+		return nil, fmt.Errorf("resolving source reference for RETL connection %s: %w", remote.ID, err)
+	}
+	destinationRef, err := inputResolver.ResolveToReference(destination.DestinationResourceType, remote.DestinationID)
+	if err != nil {
+		return nil, fmt.Errorf("resolving destination reference for RETL connection %s: %w", remote.ID, err)
+	}
+
+	schedule := map[string]any{"type": string(remote.Schedule.Type)}
+	if remote.Schedule.EveryMinutes != nil {
+		schedule["every_minutes"] = *remote.Schedule.EveryMinutes
+	}
+	if remote.Schedule.CronExpression != nil {
+		schedule["cron_expression"] = *remote.Schedule.CronExpression
+	}
+
+	specMap := map[string]any{
+		"id":             externalID,
+		"source":         sourceRef,
+		"destination":    destinationRef,
+		"enabled":        remote.Enabled,
+		"schedule":       schedule,
+		"sync_behaviour": string(remote.SyncBehaviour),
+		"identifiers":    exportMappings(remote.Identifiers),
+	}
+	if len(remote.Mappings) > 0 {
+		specMap["mappings"] = exportMappings(remote.Mappings)
+	}
+	if remote.Event != nil {
+		event := map[string]any{"type": string(remote.Event.Type)}
+		if remote.Event.Name != "" {
+			event["name"] = remote.Event.Name
+		}
+		if remote.Event.NameColumn != "" {
+			event["name_column"] = remote.Event.NameColumn
+		}
+		specMap["event"] = event
+	}
+	if len(remote.Constants) > 0 {
+		constants := make([]map[string]any, 0, len(remote.Constants))
+		for _, c := range remote.Constants {
+			constants = append(constants, map[string]any{"key": c.Key, "value": c.Value})
+		}
+		specMap["constants"] = constants
+	}
+	if remote.CursorColumn != "" {
+		specMap["cursor_column"] = remote.CursorColumn
+	}
+	if remote.Object != "" {
+		specMap["object"] = remote.Object
+	}
+
+	return specMap, nil
+}
+
+func exportMappings(mappings []retlClient.Mapping) []map[string]any {
+	result := make([]map[string]any, 0, len(mappings))
+	for _, m := range mappings {
+		result = append(result, map[string]any{"from": m.From, "to": m.To})
+	}
+	return result
+}
+
+// toResource builds the comparable resource from a remote connection. Empty
+// lists are normalised to nil so they compare equal to omitted spec fields.
+func toResource(remote *retlClient.RETLConnection, source, destination *resources.PropertyRef) *ConnectionResource {
+	return &ConnectionResource{
+		ID:            remote.ExternalID,
+		Source:        source,
+		Destination:   destination,
+		Enabled:       remote.Enabled,
+		Schedule:      remote.Schedule,
+		SyncBehaviour: remote.SyncBehaviour,
+		Identifiers:   nilIfEmpty(remote.Identifiers),
+		Mappings:      nilIfEmpty(remote.Mappings),
+		Event:         remote.Event,
+		Constants:     nilIfEmpty(remote.Constants),
+		CursorColumn:  remote.CursorColumn,
+		Object:        remote.Object,
+	}
+}
+
+// immutableChange returns the spec field of the first setting that differs
+// between newData and oldData and that the API does not allow to update, or an
+// empty string when there is none.
+func immutableChange(newData, oldData *ConnectionResource) string {
+	switch {
+	case newData.SyncBehaviour != oldData.SyncBehaviour:
+		return "sync_behaviour"
+	case !reflect.DeepEqual(newData.Event, oldData.Event):
+		return "event"
+	case newData.CursorColumn != oldData.CursorColumn:
+		return "cursor_column"
+	case newData.Object != oldData.Object:
+		return "object"
+	default:
+		return ""
+	}
+}
+
+func toMappings(specs []MappingSpec) []retlClient.Mapping {
+	if len(specs) == 0 {
+		return nil
+	}
+	result := make([]retlClient.Mapping, 0, len(specs))
+	for _, m := range specs {
+		result = append(result, retlClient.Mapping{From: m.From, To: m.To})
+	}
+	return result
+}
+
+func toConstants(specs []ConstantSpec) []retlClient.Constant {
+	if len(specs) == 0 {
+		return nil
+	}
+	result := make([]retlClient.Constant, 0, len(specs))
+	for _, c := range specs {
+		result = append(result, retlClient.Constant{Key: c.Key, Value: c.Value})
+	}
+	return result
+}
+
+func nilIfEmpty[T any](items []T) []T {
+	if len(items) == 0 {
+		return nil
+	}
+	return items
+}
+
+// resolveEndpoints reads the remote ids of both endpoints from their resolved
+// PropertyRefs.
+func resolveEndpoints(data *ConnectionResource) (string, string, error) {
+	sourceID, err := resolvedValue(data.Source)
+	if err != nil {
+		return "", "", fmt.Errorf("connection %q: source %w", data.ID, err)
+	}
+	destinationID, err := resolvedValue(data.Destination)
+	if err != nil {
+		return "", "", fmt.Errorf("connection %q: destination %w", data.ID, err)
+	}
+	return sourceID, destinationID, nil
+}
+
+func resolvedValue(ref *resources.PropertyRef) (string, error) {
+	if ref == nil || !ref.IsResolved || ref.Value == "" {
+		return "", errors.New("reference is not resolved or has empty value")
+	}
+	return ref.Value, nil
+}
+
+// refID extracts <id> from a scalar "#<kind>:<id>" reference.
+func refID(ref string, kind string) (string, error) {
+	matches := RefRegex.FindStringSubmatch(strings.TrimSpace(ref))
+	if matches == nil || matches[1] != kind {
+		return "", fmt.Errorf("invalid reference %q: expected format #%s:<id>", ref, kind)
+	}
+	return matches[2], nil
+}
+
+// parseSourceRef parses a scalar "#retl-source-sql-model:<id>" reference. SQL
+// models are legacy resources, so the ref reads the "id" key of their state.
+func parseSourceRef(ref string) (*resources.PropertyRef, error) {
+	id, err := refID(ref, sqlmodel.ResourceKind)
+	if err != nil {
+		return nil, err
+	}
+	return sourceRef(resources.URN(id, sqlmodel.ResourceType)), nil
+}
+
+func sourceRef(urn string) *resources.PropertyRef {
+	return &resources.PropertyRef{
+		URN:      urn,
+		Property: sqlmodel.IDKey,
+	}
+}
+
+// parseDestinationRef parses a scalar "#destination:<id>" reference into a
+// PropertyRef whose Resolve function reads DestinationState.ID.
+func parseDestinationRef(ref string) (*resources.PropertyRef, error) {
+	id, err := refID(ref, destination.DestinationSpecKind)
+	if err != nil {
+		return nil, err
+	}
+	return destinationRefFor(resources.URN(id, destination.DestinationResourceType)), nil
+}
+
+// destinationRefFor stamps the "id" property on the resolving ref so the
+// differ's comparePropertyRefs sees a stable shape on both the spec and state
+// sides, as the event stream connection handler does.
+func destinationRefFor(urn string) *resources.PropertyRef {
+	ref := handler.CreatePropertyRef(
+		urn,
+		func(state *destination.DestinationState) (string, error) {
+			if state.ID == "" {
+				return "", fmt.Errorf("destination state has empty ID")
+			}
+			return state.ID, nil
+		},
+	)
+	ref.Property = "id"
+	return ref
+}
@@ -0,0 +1,417 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/api/client"
+	retlClient "github.com/rudderlabs/rudder-iac/api/client/retl"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/importmanifest"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/destination"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+// mockStore implements only the RETL calls the connection handler makes; any
+// other call panics on the nil embedded interface.
+type mockStore struct {
+	retlClient.RETLStore
+
+	sources     []retlClient.RETLSource
+	pages       []retlClient.RETLConnectionsPage
+	connections map[string]*retlClient.RETLConnection
+
+	createReqs  []*retlClient.CreateRETLConnectionRequest
+	updateReqs  map[string]*retlClient.UpdateRETLConnectionRequest
+	deletedIDs  []string
+	externalIDs []*retlClient.SetRETLConnectionExternalIDRequest
+	listReqs    []*retlClient.ListRETLConnectionsRequest
+	calls       []string
+}
+
+func newMockStore() *mockStore {
+	return &mockStore{
+		connections: make(map[string]*retlClient.RETLConnection),
+		updateReqs:  make(map[string]*retlClient.UpdateRETLConnectionRequest),
+	}
+}
+
+func (m *mockStore) CreateConnection(_ context.Context, req *retlClient.CreateRETLConnectionRequest) (*retlClient.RETLConnection, error) {
+	m.calls = append(m.calls, "create")
+	m.createReqs = append(m.createReqs, req)
+	return &retlClient.RETLConnection{ID: "conn-new", SourceID: req.SourceID, DestinationID: req.DestinationID}, nil
+}
+
+func (m *mockStore) UpdateConnection(_ context.Context, id string, req *retlClient.UpdateRETLConnectionRequest) (*retlClient.RETLConnection, error) {
+	m.calls = append(m.calls, "update")
+	m.updateReqs[id] = req
+	return &retlClient.RETLConnection{ID: id}, nil
+}
+
+func (m *mockStore) DeleteConnection(_ context.Context, id string) error {
+	m.calls = append(m.calls, "delete")
+	m.deletedIDs = append(m.deletedIDs, id)
+	return nil
+}
+
+func (m *mockStore) GetConnection(_ context.Context, id string) (*retlClient.RETLConnection, error) {
+	if c, ok := m.connections[id]; ok {
+		return c, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (m *mockStore) ListConnections(_ context.Context, req *retlClient.ListRETLConnectionsRequest) (*retlClient.RETLConnectionsPage, error) {
+	m.listReqs = append(m.listReqs, req)
+	if req.Page > len(m.pages) {
+		return &retlClient.RETLConnectionsPage{}, nil
+	}
+	page := m.pages[req.Page-1]
+	return &page, nil
+}
+
+func (m *mockStore) SetConnectionExternalId(_ context.Context, req *retlClient.SetRETLConnectionExternalIDRequest) error {
+	m.calls = append(m.calls, "set-external-id")
+	m.externalIDs = append(m.externalIDs, req)
+	return nil
+}
+
+func (m *mockStore) ListRetlSources(_ context.Context, _ ...retlClient.ListRetlSourcesOption) (*retlClient.RETLSources, error) {
+	return &retlClient.RETLSources{Data: m.sources}, nil
+}
+
+// urnResolver resolves remote ids from a canned "type/id" map.
+type urnResolver map[string]string
+
+func (r urnResolver) GetURNByID(resourceType string, remoteID string) (string, error) {
+	if urn, ok := r[resourceType+"/"+remoteID]; ok {
+		return urn, nil
+	}
+	return "", resources.ErrRemoteResourceExternalIdNotFound
+}
+
+// refResolver resolves remote ids to references from a canned "type/id" map.
+type refResolver map[string]string
+
+func (r refResolver) ResolveToReference(entityType string, remoteID string) (string, error) {
+	if ref, ok := r[entityType+"/"+remoteID]; ok {
+		return ref, nil
+	}
+	return "", errors.New("resource not present in resources collection")
+}
+
+func intPtr(i int) *int { return &i }
+
+func validSpec() *ConnectionSpec {
+	return &ConnectionSpec{
+		ID:            "users-to-webhook",
+		Source:        "#retl-source-sql-model:users",
+		Destination:   "#destination:webhook",
+		Schedule:      &ScheduleSpec{Type: "basic", EveryMinutes: intPtr(30)},
+		SyncBehaviour: "upsert",
+		Identifiers:   []MappingSpec{{From: "email", To: "email"}},
+		Event:         &EventSpec{Type: "identify"},
+	}
+}
+
+// resolved returns a ConnectionResource whose endpoint refs have been resolved
+// by the apply framework.
+func resolved(t *testing.T, sourceID, destinationID string) *ConnectionResource {
+	t.Helper()
+
+	h := &HandlerImpl{}
+	extracted, err := h.ExtractResourcesFromSpec("", validSpec())
+	require.NoError(t, err)
+	r := extracted["users-to-webhook"]
+	r.Source.IsResolved, r.Source.Value = true, sourceID
+	r.Destination.IsResolved, r.Destination.Value = true, destinationID
+	return r
+}
+
+func TestExtractResourcesFromSpec(t *testing.T) {
+	t.Run("parses refs and defaults enabled", func(t *testing.T) {
+		h := &HandlerImpl{}
+
+		extracted, err := h.ExtractResourcesFromSpec("", validSpec())
+		require.NoError(t, err)
+		require.Contains(t, extracted, "users-to-webhook")
+
+		r := extracted["users-to-webhook"]
+		assert.Equal(t, &resources.PropertyRef{
+			URN:      resources.URN("users", sqlmodel.ResourceType),
+			Property: "id",
+		}, r.Source)
+		assert.Equal(t, resources.URN("webhook", destination.DestinationResourceType), r.Destination.URN)
+		assert.Equal(t, "id", r.Destination.Property)
+		assert.True(t, r.Enabled)
+		assert.Equal(t, retlClient.Schedule{Type: retlClient.ScheduleTypeBasic, EveryMinutes: intPtr(30)}, r.Schedule)
+		assert.Equal(t, []retlClient.Mapping{{From: "email", To: "email"}}, r.Identifiers)
+		assert.Nil(t, r.Mappings)
+		assert.Nil(t, r.Constants)
+	})
+
+	t.Run("rejects a source that is not a sql model", func(t *testing.T) {
+		spec := validSpec()
+		spec.Source = "#destination:webhook"
+
+		_, err := (&HandlerImpl{}).ExtractResourcesFromSpec("", spec)
+		assert.ErrorContains(t, err, "expected format #retl-source-sql-model:<id>")
+	})
+}
+
+func TestCreate(t *testing.T) {
+	store := newMockStore()
+	h := &HandlerImpl{client: store}
+
+	state, err := h.Create(context.Background(), resolved(t, "src-1", "dst-1"))
+	require.NoError(t, err)
+
+	assert.Equal(t, &ConnectionState{ID: "conn-new", SourceID: "src-1", DestinationID: "dst-1"}, state)
+	require.Len(t, store.createReqs, 1)
+	req := store.createReqs[0]
+	assert.Equal(t, "users-to-webhook", req.ExternalID)
+	assert.Equal(t, "src-1", req.SourceID)
+	assert.Equal(t, "dst-1", req.DestinationID)
+	require.NotNil(t, req.Enabled)
+	assert.True(t, *req.Enabled)
+	require.NotNil(t, req.SyncBehaviour)
+	assert.Equal(t, retlClient.SyncBehaviourUpsert, *req.SyncBehaviour)
+}
+
+func TestCreate_UnresolvedRef(t *testing.T) {
+	extracted, err := (&HandlerImpl{}).ExtractResourcesFromSpec("", validSpec())
+	require.NoError(t, err)
+
+	_, err = (&HandlerImpl{client: newMockStore()}).Create(context.Background(), extracted["users-to-webhook"])
+	assert.ErrorContains(t, err, "source reference is not resolved")
+}
+
+func TestUpdate(t *testing.T) {
+	oldState := &ConnectionState{ID: "conn-1", SourceID: "src-1", DestinationID: "dst-1"}
+
+	t.Run("pushes mutable settings", func(t *testing.T) {
+		store := newMockStore()
+		h := &HandlerImpl{client: store}
+
+		oldData := resolved(t, "src-1", "dst-1")
+		newData := resolved(t, "src-1", "dst-1")
+		newData.Enabled = false
+		newData.Schedule = retlClient.Schedule{Type: retlClient.ScheduleTypeManual}
+
+		state, err := h.Update(context.Background(), newData, oldData, oldState)
+		require.NoError(t, err)
+
+		assert.Equal(t, oldState, state)
+		assert.Equal(t, []string{"update"}, store.calls)
+		req := store.updateReqs["conn-1"]
+		require.NotNil(t, req)
+		assert.False(t, *req.Enabled)
+		assert.Equal(t, retlClient.ScheduleTypeManual, req.Schedule.Type)
+		assert.Nil(t, req.Mappings, "mappings are not sent when neither side has any")
+		assert.Nil(t, req.Constants)
+	})
+
+	t.Run("clears removed mappings", func(t *testing.T) {
+		store := newMockStore()
+		h := &HandlerImpl{client: store}
+
+		oldData := resolved(t, "src-1", "dst-1")
+		oldData.Mappings = []retlClient.Mapping{{From: "name", To: "firstName"}}
+		newData := resolved(t, "src-1", "dst-1")
+
+		_, err := h.Update(context.Background(), newData, oldData, oldState)
+		require.NoError(t, err)
+
+		req := store.updateReqs["conn-1"]
+		require.NotNil(t, req.Mappings)
+		assert.Empty(t, *req.Mappings)
+	})
+
+	t.Run("recreates on endpoint change", func(t *testing.T) {
+		store := newMockStore()
+		h := &HandlerImpl{client: store}
+
+		state, err := h.Update(context.Background(), resolved(t, "src-1", "dst-2"), resolved(t, "src-1", "dst-1"), oldState)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"delete", "create"}, store.calls)
+		assert.Equal(t, []string{"conn-1"}, store.deletedIDs)
+		assert.Equal(t, &ConnectionState{ID: "conn-new", SourceID: "src-1", DestinationID: "dst-2"}, state)
+	})
+
+	t.Run("rejects immutable changes", func(t *testing.T) {
+		store := newMockStore()
+		h := &HandlerImpl{client: store}
+
+		newData := resolved(t, "src-1", "dst-1")
+		newData.SyncBehaviour = retlClient.SyncBehaviourMirror
+
+		_, err := h.Update(context.Background(), newData, resolved(t, "src-1", "dst-1"), oldState)
+		assert.ErrorContains(t, err, "sync_behaviour cannot be changed")
+		assert.Empty(t, store.calls)
+	})
+}
+
+func TestImport(t *testing.T) {
+	t.Run("updates then sets the external id", func(t *testing.T) {
+		store := newMockStore()
+		store.connections["conn-1"] = &retlClient.RETLConnection{
+			ID:            "conn-1",
+			SourceID:      "src-1",
+			DestinationID: "dst-1",
+			Schedule:      retlClient.Schedule{Type: retlClient.ScheduleTypeManual},
+			SyncBehaviour: retlClient.SyncBehaviourUpsert,
+			Event:         &retlClient.Event{Type: retlClient.EventTypeIdentify},
+		}
+		h := &HandlerImpl{client: store}
+
+		state, err := h.Import(context.Background(), resolved(t, "src-1", "dst-1"), "conn-1")
+		require.NoError(t, err)
+
+		assert.Equal(t, &ConnectionState{ID: "conn-1", SourceID: "src-1", DestinationID: "dst-1"}, state)
+		assert.Equal(t, []string{"update", "set-external-id"}, store.calls)
+		assert.Equal(t, "users-to-webhook", store.externalIDs[0].ExternalID)
+	})
+
+	t.Run("rejects different endpoints", func(t *testing.T) {
+		store := newMockStore()
+		store.connections["conn-1"] = &retlClient.RETLConnection{ID: "conn-1", SourceID: "src-1", DestinationID: "dst-other"}
+		h := &HandlerImpl{client: store}
+
+		_, err := h.Import(context.Background(), resolved(t, "src-1", "dst-1"), "conn-1")
+		assert.ErrorContains(t, err, "links source src-1 and destination dst-other")
+		assert.Empty(t, store.calls)
+	})
+}
+
+func TestLoadRemoteResources(t *testing.T) {
+	store := newMockStore()
+	store.sources = []retlClient.RETLSource{{ID: "src-1", Name: "Users", WorkspaceID: "ws-1"}}
+	store.pages = []retlClient.RETLConnectionsPage{
+		{
+			Data:   []retlClient.RETLConnection{{ID: "conn-1", SourceID: "src-1", ExternalID: "a"}},
+			Paging: client.Paging{Next: "/connections?page=2"},
+		},
+		{
+			Data: []retlClient.RETLConnection{
+				{ID: "conn-2", SourceID: "src-1", ExternalID: "b"},
+				{ID: "conn-3", SourceID: "table-src", ExternalID: "c"},
+			},
+		},
+	}
+	h := &HandlerImpl{client: store}
+
+	remotes, err := h.LoadRemoteResources(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, store.listReqs, 2)
+	require.NotNil(t, store.listReqs[0].HasExternalID)
+	assert.True(t, *store.listReqs[0].HasExternalID)
+
+	require.Len(t, remotes, 2, "connections from sources that are not sql models are skipped")
+	assert.Equal(t, "conn-1", remotes[0].ID)
+	assert.Equal(t, "ws-1", remotes[0].WorkspaceID)
+	assert.Equal(t, "Users", remotes[0].Metadata().Name)
+	assert.Equal(t, "conn-2", remotes[1].ID)
+}
+
+func TestMapRemoteToState(t *testing.T) {
+	h := &HandlerImpl{}
+	resolver := urnResolver{
+		sqlmodel.ResourceType + "/src-1":               resources.URN("users", sqlmodel.ResourceType),
+		destination.DestinationResourceType + "/dst-1": resources.URN("webhook", destination.DestinationResourceType),
+	}
+	remote := &RemoteConnection{RETLConnection: &retlClient.RETLConnection{
+		ID:            "conn-1",
+		ExternalID:    "users-to-webhook",
+		SourceID:      "src-1",
+		DestinationID: "dst-1",
+		Enabled:       true,
+		Schedule:      retlClient.Schedule{Type: retlClient.ScheduleTypeBasic, EveryMinutes: intPtr(30)},
+		SyncBehaviour: retlClient.SyncBehaviourUpsert,
+		Identifiers:   []retlClient.Mapping{{From: "email", To: "email"}},
+		Mappings:      []retlClient.Mapping{},
+		Event:         &retlClient.Event{Type: retlClient.EventTypeIdentify},
+	}}
+
+	t.Run("maps to the same shape as the spec", func(t *testing.T) {
+		resource, state, err := h.MapRemoteToState(remote, resolver)
+		require.NoError(t, err)
+
+		extracted, err := h.ExtractResourcesFromSpec("", validSpec())
+		require.NoError(t, err)
+		expected := extracted["users-to-webhook"]
+
+		assert.Equal(t, expected.Source, resource.Source)
+		assert.Equal(t, expected.Destination.URN, resource.Destination.URN)
+		expected.Source, expected.Destination = nil, nil
+		resource.Source, resource.Destination = nil, nil
+		assert.Equal(t, expected, resource)
+		assert.Equal(t, &ConnectionState{ID: "conn-1", SourceID: "src-1", DestinationID: "dst-1"}, state)
+	})
+
+	t.Run("errors when the destination is not managed", func(t *testing.T) {
+		_, _, err := h.MapRemoteToState(remote, urnResolver{
+			sqlmodel.ResourceType + "/src-1": resources.URN("users", sqlmodel.ResourceType),
+		})
+		assert.ErrorContains(t, err, "resolving destination dst-1")
+	})
+}
+
+func TestFormatForExport(t *testing.T) {
+	h := &HandlerImpl{importDir: "retl"}
+	collection := map[string]*RemoteConnection{
+		"users": {
+			RETLConnection: &retlClient.RETLConnection{
+				ID:            "conn-1",
+				SourceID:      "src-1",
+				DestinationID: "dst-1",
+				Enabled:       true,
+				Schedule:      retlClient.Schedule{Type: retlClient.ScheduleTypeBasic, EveryMinutes: intPtr(30)},
+				SyncBehaviour: retlClient.SyncBehaviourUpsert,
+				Identifiers:   []retlClient.Mapping{{From: "email", To: "email"}},
+				Event:         &retlClient.Event{Type: retlClient.EventTypeTrack, Name: "Synced"},
+			},
+			WorkspaceID: "ws-1",
+		},
+	}
+	resolver := refResolver{
+		sqlmodel.ResourceType + "/src-1":               "#retl-source-sql-model:users",
+		destination.DestinationResourceType + "/dst-1": "#destination:webhook",
+	}
+
+	entities, entries, err := h.FormatForExport(collection, nil, resolver)
+	require.NoError(t, err)
+
+	require.Len(t, entities, 1)
+	assert.Equal(t, filepath.Join("retl", ImportPath, "users.yaml"), entities[0].RelativePath)
+	assert.Equal(t, []importmanifest.ImportEntry{{
+		WorkspaceID: "ws-1",
+		URN:         resources.URN("users", ResourceType),
+		RemoteID:    "conn-1",
+	}}, entries)
+
+	spec := entities[0].Content.(*specs.Spec)
+	assert.Equal(t, ResourceKind, spec.Kind)
+	assert.Equal(t, map[string]any{
+		"id":             "users",
+		"source":         "#retl-source-sql-model:users",
+		"destination":    "#destination:webhook",
+		"enabled":        true,
+		"schedule":       map[string]any{"type": "basic", "every_minutes": 30},
+		"sync_behaviour": "upsert",
+		"identifiers":    []map[string]any{{"from": "email", "to": "email"}},
+		"event":          map[string]any{"type": "track", "name": "Synced"},
+	}, spec.Spec)
+
+	t.Run("fails when an endpoint cannot be resolved", func(t *testing.T) {
+		_, _, err := h.FormatForExport(collection, nil, refResolver{})
+		assert.ErrorContains(t, err, "resolving source reference")
+	})
+}
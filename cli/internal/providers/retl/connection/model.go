@@ -0,0 +1,119 @@
+package connection
+
+import (
+	retlClient "github.com/rudderlabs/rudder-iac/api/client/retl"
+	"github.com/rudderlabs/rudder-iac/cli/internal/provider/handler"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+const (
+	ResourceType = "retl-connection"
+	ResourceKind = "retl-connection"
+	MetadataName = "retl-connection"
+
+	// ImportPath is the directory, relative to the provider's import
+	// directory, exported connections are written to.
+	ImportPath = "connections"
+)
+
+// ConnectionSpec is the user-authored YAML representation of a RETL
+// connection: a sync from a SQL model into a destination. JSON tags enable the
+// typed rule engine's json.Marshal/Unmarshal round-trip; validate tags drive
+// go-playground/validator checks. Cross-field constraints on the schedule,
+// event and mappings live in the spec syntax rule.
+type ConnectionSpec struct {
+	ID            string         `json:"id"             mapstructure:"id"             validate:"required"`
+	Source        string         `json:"source"         mapstructure:"source"         validate:"required"`
+	Destination   string         `json:"destination"    mapstructure:"destination"    validate:"required"`
+	Enabled       *bool          `json:"enabled"        mapstructure:"enabled"`
+	Schedule      *ScheduleSpec  `json:"schedule"       mapstructure:"schedule"       validate:"required"`
+	SyncBehaviour string         `json:"sync_behaviour" mapstructure:"sync_behaviour" validate:"required,oneof=upsert mirror full"`
+	Identifiers   []MappingSpec  `json:"identifiers"    mapstructure:"identifiers"    validate:"required,min=1,dive"`
+	Mappings      []MappingSpec  `json:"mappings"       mapstructure:"mappings"       validate:"dive"`
+	Event         *EventSpec     `json:"event"          mapstructure:"event"`
+	Constants     []ConstantSpec `json:"constants"      mapstructure:"constants"      validate:"dive"`
+	CursorColumn  string         `json:"cursor_column"  mapstructure:"cursor_column"`
+	Object        string         `json:"object"         mapstructure:"object"`
+}
+
+// ScheduleSpec defines when the connection syncs: every_minutes for a basic
+// schedule, cron_expression for a cron one and neither for a manual one.
+type ScheduleSpec struct {
+	Type           string  `json:"type"            mapstructure:"type"            validate:"required,oneof=basic manual cron"`
+	EveryMinutes   *int    `json:"every_minutes"   mapstructure:"every_minutes"   validate:"omitempty,gte=1"`
+	CronExpression *string `json:"cron_expression" mapstructure:"cron_expression"`
+}
+
+// MappingSpec maps a column of the SQL model to a destination field or
+// identifier.
+type MappingSpec struct {
+	From string `json:"from" mapstructure:"from" validate:"required"`
+	To   string `json:"to"   mapstructure:"to"   validate:"required"`
+}
+
+// EventSpec is the event sent for every synced record when the destination
+// receives events. Name and NameColumn are mutually exclusive.
+type EventSpec struct {
+	Type       string `json:"type"        mapstructure:"type"        validate:"required,oneof=identify track"`
+	Name       string `json:"name"        mapstructure:"name"`
+	NameColumn string `json:"name_column" mapstructure:"name_column"`
+}
+
+// ConstantSpec is a fixed key/value added to every synced record.
+type ConstantSpec struct {
+	Key   string `json:"key"   mapstructure:"key"   validate:"required"`
+	Value string `json:"value" mapstructure:"value"`
+}
+
+// ReplaceProperties are the ConnectionResource fields whose change replaces
+// the connection, see Update.
+var ReplaceProperties = []string{"Source", "Destination"}
+
+// ConnectionResource is the resolved in-memory representation compared by the
+// differ. The source and destination PropertyRefs give the resource graph its
+// dependency edges, so connections are created after and deleted before the
+// resources they link.
+type ConnectionResource struct {
+	ID            string
+	Source        *resources.PropertyRef
+	Destination   *resources.PropertyRef
+	Enabled       bool
+	Schedule      retlClient.Schedule
+	SyncBehaviour retlClient.SyncBehaviour
+	Identifiers   []retlClient.Mapping
+	Mappings      []retlClient.Mapping
+	Event         *retlClient.Event
+	Constants     []retlClient.Constant
+	CursorColumn  string
+	Object        string
+}
+
+// ConnectionState is the persisted apply-cycle state: the remote connection ID
+// and the remote ids of the endpoints it links, so Update can tell an endpoint
+// change apart from a settings change.
+type ConnectionState struct {
+	ID            string
+	SourceID      string
+	DestinationID string
+}
+
+// RemoteConnection wraps retlClient.RETLConnection together with identity the
+// connections API row does not include: the workspace id and the name both
+// come from the connection's SQL model source.
+type RemoteConnection struct {
+	*retlClient.RETLConnection
+	WorkspaceID string
+	SourceName  string
+}
+
+// Metadata exposes the identifying fields BaseHandler uses to key the remote
+// collection and to name importable resources. Connections have no name of
+// their own, so importable ones are named after their source.
+func (r RemoteConnection) Metadata() handler.RemoteResourceMetadata {
+	return handler.RemoteResourceMetadata{
+		ID:          r.ID,
+		ExternalID:  r.ExternalID,
+		WorkspaceID: r.WorkspaceID,
+		Name:        r.SourceName,
+	}
+}
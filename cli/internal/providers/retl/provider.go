@@ -2,7 +2,9 @@ package retl
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 
 	retlClient "github.com/rudderlabs/rudder-iac/api/client/retl"
@@ -15,27 +17,48 @@ import (
 	"github.com/rudderlabs/rudder-iac/cli/internal/provider"
 	"github.com/rudderlabs/rudder-iac/cli/internal/provider/importmatcher"
 	prules "github.com/rudderlabs/rudder-iac/cli/internal/provider/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/connection"
 	retldocs "github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/docs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/s3"
 	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/table"
 	"github.com/rudderlabs/rudder-iac/cli/internal/resolver"
 	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
 	"github.com/rudderlabs/rudder-iac/cli/internal/resources/state"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation/docs"
 	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/varsubst"
 
+	connectionRules "github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/rules/connection"
+	s3Rules "github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/rules/s3"
+	sourceRules "github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/rules/source"
 	sqlmodelRules "github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/rules/sqlmodel"
+	tableRules "github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/rules/table"
 )
 
-// Provider implements the provider interface for RETL resources
+// Provider implements the provider interface for RETL resources. SQL models go
+// through the legacy resourceHandler map; kinds built on handler.BaseHandler
+// (warehouse tables, S3 sources and connections) are served by base and routed
+// to it by kind and type.
 type Provider struct {
 	provider.EmptyProvider
 	client     retlClient.RETLStore
 	handlers   map[string]resourceHandler
 	kindToType map[string]string
+	base       *provider.BaseProvider
+	tables     *table.TableHandler
+
+	// handlerOrder holds the handler types in registration order, so that
+	// remote loading and export walk the handlers deterministically.
+	handlerOrder []string
 }
 
 const importDir = "retl"
 
+// ErrPreviewUnsupported is returned by Preview for sources that cannot be
+// previewed.
+var ErrPreviewUnsupported = errors.New("preview is not supported")
+
 // New creates a new RETL provider instance
 func New(client retlClient.RETLStore) *Provider {
 	p := &Provider{
@@ -44,14 +67,25 @@ func New(client retlClient.RETLStore) *Provider {
 		kindToType: map[string]string{
 			"retl-source-sql-model": sqlmodel.ResourceType,
 		},
+		tables: table.NewHandler(client, importDir),
 	}
 
 	// Register handlers
-	p.handlers[sqlmodel.ResourceType] = sqlmodel.NewHandler(client, importDir)
+	p.registerHandler(sqlmodel.ResourceType, sqlmodel.NewHandler(client, importDir))
+	p.base = provider.NewBaseProvider([]provider.Handler{
+		p.tables,
+		s3.NewHandler(client, importDir),
+		connection.NewHandler(client, importDir),
+	})
 
 	return p
 }
 
+func (p *Provider) registerHandler(resourceType string, h resourceHandler) {
+	p.handlers[resourceType] = h
+	p.handlerOrder = append(p.handlerOrder, resourceType)
+}
+
 // LoadImportManifest fans the active workspace's manifest out to every registered
 // handler so URN → remote-ID mappings from a central import-manifest file
 // populate the same import-metadata map that inline metadata.import does.
@@ -62,7 +96,17 @@ func (p *Provider) LoadImportManifest(m *specs.WorkspaceImportMetadata) error {
 			return fmt.Errorf("loading import manifest into handler %s: %w", resourceType, err)
 		}
 	}
-	return nil
+	return p.base.LoadImportManifest(m)
+}
+
+// SetVariableResolver hands the resolver to the handlers that render
+// variables, such as the SQL model handler for parameters.
+func (p *Provider) SetVariableResolver(r varsubst.Resolver) {
+	for _, resourceType := range p.handlerOrder {
+		if vc, ok := p.handlers[resourceType].(provider.VariableConsumer); ok {
+			vc.SetVariableResolver(r)
+		}
+	}
 }
 
 func (p *Provider) SupportedKinds() []string {
@@ -70,7 +114,7 @@ func (p *Provider) SupportedKinds() []string {
 	for kind := range p.kindToType {
 		kinds = append(kinds, kind)
 	}
-	return kinds
+	return append(kinds, p.base.SupportedKinds()...)
 }
 
 func (p *Provider) SupportedMatchPatterns() []rules.MatchPattern {
@@ -79,6 +123,11 @@ func (p *Provider) SupportedMatchPatterns() []rules.MatchPattern {
 		patterns = append(patterns, prules.LegacyVersionPatterns(kind)...)
 		patterns = append(patterns, prules.V1VersionPatterns(kind)...)
 	}
+	// The BaseHandler kinds postdate rudder/v1 and have no legacy version to
+	// match.
+	for _, kind := range p.base.SupportedKinds() {
+		patterns = append(patterns, prules.V1VersionPatterns(kind)...)
+	}
 	return patterns
 }
 
@@ -88,7 +137,16 @@ func (p *Provider) SupportedTypes() []string {
 	for resourceType := range p.handlers {
 		types = append(types, resourceType)
 	}
-	return types
+	return append(types, p.base.SupportedTypes()...)
+}
+
+// ReplaceProperties implements provider.Replacer: a connection is replaced
+// when its endpoints change.
+func (p *Provider) ReplaceProperties(resourceType string) []string {
+	if resourceType == connection.ResourceType {
+		return connection.ReplaceProperties
+	}
+	return nil
 }
 
 // ResourceMatchers overrides the EmptyProvider default to opt into import
@@ -98,6 +156,10 @@ func (p *Provider) ResourceMatchers() []importmatcher.Matcher {
 }
 
 func (p *Provider) ParseSpec(path string, s *specs.Spec) (*specs.ParsedSpec, error) {
+	if p.isBaseKind(s.Kind) {
+		return p.base.ParseSpec(path, s)
+	}
+
 	resourceType, ok := p.kindToType[s.Kind]
 	if !ok {
 		return nil, fmt.Errorf("unsupported kind: %s", s.Kind)
@@ -112,6 +174,10 @@ func (p *Provider) ParseSpec(path string, s *specs.Spec) (*specs.ParsedSpec, err
 
 // LoadSpec loads a spec for the given kind
 func (p *Provider) LoadSpec(path string, s *specs.Spec) error {
+	if p.isBaseKind(s.Kind) {
+		return p.base.LoadSpec(path, s)
+	}
+
 	resourceType, ok := p.kindToType[s.Kind]
 	if !ok {
 		return fmt.Errorf("unsupported kind: %s", s.Kind)
@@ -149,12 +215,20 @@ func (p *Provider) MigrateSpec(s *specs.Spec) (*specs.Spec, error) {
 func (p *Provider) SyntacticRules() []rules.Rule {
 	return []rules.Rule{
 		sqlmodelRules.NewSQLModelSpecSyntaxValidRule(),
+		sqlmodelRules.NewSQLModelSQLValidRule(),
+		tableRules.NewTableSpecSyntaxValidRule(),
+		s3Rules.NewS3SpecSyntaxValidRule(),
+		connectionRules.NewConnectionSpecSyntaxValidRule(),
 	}
 }
 
 func (p *Provider) SemanticRules() []rules.Rule {
 	return []rules.Rule{
 		sqlmodelRules.NewSQLModelSemanticValidRule(),
+		sqlmodelRules.NewSQLModelPrimaryKeySelectedRule(),
+		sourceRules.NewSourceSemanticValidRule("table", "warehouse table", table.ResourceKind, table.ResourceType),
+		sourceRules.NewSourceSemanticValidRule("s3", "s3 source", s3.ResourceKind, s3.ResourceType),
+		connectionRules.NewConnectionSemanticValidRule(),
 	}
 }
 
@@ -169,7 +243,8 @@ func (p *Provider) RuleDocEntries() []docs.RuleDocEntry {
 func (p *Provider) ResourceGraph() (*resources.Graph, error) {
 	graph := resources.NewGraph()
 
-	for resourceType, handler := range p.handlers {
+	for _, resourceType := range p.handlerOrder {
+		handler := p.handlers[resourceType]
 		resources, err := handler.GetResources()
 		if err != nil {
 			return nil, fmt.Errorf("getting resources for %s: %w", resourceType, err)
@@ -180,9 +255,33 @@ func (p *Provider) ResourceGraph() (*resources.Graph, error) {
 		}
 	}
 
+	baseGraph, err := p.base.ResourceGraph()
+	if err != nil {
+		return nil, err
+	}
+	graph.Merge(baseGraph)
+
 	return graph, nil
 }
 
+// CreateRaw, UpdateRaw, DeleteRaw and ImportRaw serve the BaseHandler-backed
+// kinds; SQL models keep going through the ResourceData methods below.
+func (p *Provider) CreateRaw(ctx context.Context, resource *resources.Resource) (any, error) {
+	return p.base.CreateRaw(ctx, resource)
+}
+
+func (p *Provider) UpdateRaw(ctx context.Context, resource *resources.Resource, oldData any, oldState any) (any, error) {
+	return p.base.UpdateRaw(ctx, resource, oldData, oldState)
+}
+
+func (p *Provider) DeleteRaw(ctx context.Context, ID string, resourceType string, oldData any, oldState any) error {
+	return p.base.DeleteRaw(ctx, ID, resourceType, oldData, oldState)
+}
+
+func (p *Provider) ImportRaw(ctx context.Context, resource *resources.Resource, remoteId string) (any, error) {
+	return p.base.ImportRaw(ctx, resource, remoteId)
+}
+
 // Create creates a new resource
 func (p *Provider) Create(ctx context.Context, ID string, resourceType string, data resources.ResourceData) (*resources.ResourceData, error) {
 	handler, ok := p.handlers[resourceType]
@@ -258,7 +357,8 @@ func (p *Provider) FetchImportData(ctx context.Context, resourceType string, imp
 // LoadResourcesFromRemote loads all RETL resources from remote (no-op implementation)
 func (p *Provider) LoadResourcesFromRemote(ctx context.Context) (*resources.RemoteResources, error) {
 	collection := resources.NewRemoteResources()
-	for resourceType, handler := range p.handlers {
+	for _, resourceType := range p.handlerOrder {
+		handler := p.handlers[resourceType]
 		c, err := handler.LoadResourcesFromRemote(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("loading %s: %w", resourceType, err)
@@ -268,27 +368,64 @@ func (p *Provider) LoadResourcesFromRemote(ctx context.Context) (*resources.Remo
 			return nil, fmt.Errorf("merging collection for %s: %w", resourceType, err)
 		}
 	}
+
+	c, err := p.base.LoadResourcesFromRemote(ctx)
+	if err != nil {
+		return nil, err
+	}
+	collection, err = collection.Merge(c)
+	if err != nil {
+		return nil, fmt.Errorf("merging connections collection: %w", err)
+	}
 	return collection, nil
 }
 
 // MapRemoteToState reconstructs RETL state from loaded resources (no-op implementation)
 func (p *Provider) MapRemoteToState(collection *resources.RemoteResources) (*state.State, error) {
 	s := state.EmptyState()
-	for sqlmodelResourceType, handler := range p.handlers {
+	for _, resourceType := range p.handlerOrder {
+		handler := p.handlers[resourceType]
 		providerState, err := handler.MapRemoteToState(collection)
 		if err != nil {
-			return nil, fmt.Errorf("loading state from provider handler %s: %w", sqlmodelResourceType, err)
+			return nil, fmt.Errorf("loading state from provider handler %s: %w", resourceType, err)
 		}
 		s, err = s.Merge(providerState)
 		if err != nil {
 			return nil, fmt.Errorf("merging provider states: %w", err)
 		}
 	}
+
+	baseState, err := p.base.MapRemoteToState(collection)
+	if err != nil {
+		return nil, err
+	}
+	s, err = s.Merge(baseState)
+	if err != nil {
+		return nil, fmt.Errorf("merging provider states: %w", err)
+	}
 	return s, nil
 }
 
-// Preview returns the preview results for a resource
+// Preview returns the preview results for a resource. Warehouse tables are
+// previewed from the loaded specs; S3 sources cannot be previewed and return
+// ErrPreviewUnsupported.
 func (p *Provider) Preview(ctx context.Context, ID string, resourceType string, data resources.ResourceData, limit int) ([]map[string]any, error) {
+	switch resourceType {
+	case table.ResourceType:
+		tables, err := p.tables.Resources()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range tables {
+			if res, ok := r.RawData().(*table.TableResource); ok && r.ID() == ID {
+				return table.Preview(ctx, p.client, res, limit)
+			}
+		}
+		return nil, fmt.Errorf("table source %s not found", ID)
+	case s3.ResourceType:
+		return nil, fmt.Errorf("%w for S3 source %s: previews run SQL against a warehouse", ErrPreviewUnsupported, ID)
+	}
+
 	handler, ok := p.handlers[resourceType]
 	if !ok {
 		return nil, fmt.Errorf("no handler for resource type: %s", resourceType)
@@ -299,7 +436,8 @@ func (p *Provider) Preview(ctx context.Context, ID string, resourceType string,
 
 func (p *Provider) LoadImportable(ctx context.Context, idNamer namer.Namer) (*resources.RemoteResources, error) {
 	collection := resources.NewRemoteResources()
-	for _, handler := range p.handlers {
+	for _, resourceType := range p.handlerOrder {
+		handler := p.handlers[resourceType]
 		resources, err := handler.LoadImportable(ctx, idNamer)
 		if err != nil {
 			return nil, fmt.Errorf("loading importable resources from handler %w", err)
@@ -309,13 +447,23 @@ func (p *Provider) LoadImportable(ctx context.Context, idNamer namer.Namer) (*re
 			return nil, fmt.Errorf("merging importable resource collection for handler %w", err)
 		}
 	}
+
+	importable, err := p.base.LoadImportable(ctx, idNamer)
+	if err != nil {
+		return nil, err
+	}
+	collection, err = collection.Merge(importable)
+	if err != nil {
+		return nil, fmt.Errorf("merging importable connections collection: %w", err)
+	}
 	return collection, nil
 }
 
 func (p *Provider) FormatForExport(collection *resources.RemoteResources, idNamer namer.Namer, inputResolver resolver.ReferenceResolver) ([]writer.FormattableEntity, []importmanifest.ImportEntry, error) {
 	allEntities := make([]writer.FormattableEntity, 0)
 	var entries []importmanifest.ImportEntry
-	for _, handler := range p.handlers {
+	for _, resourceType := range p.handlerOrder {
+		handler := p.handlers[resourceType]
 		entities, handlerEntries, err := handler.FormatForExport(collection, idNamer, inputResolver)
 		if err != nil {
 			return nil, nil, fmt.Errorf("formatting for export for handler %w", err)
@@ -323,5 +471,18 @@ func (p *Provider) FormatForExport(collection *resources.RemoteResources, idName
 		allEntities = append(allEntities, entities...)
 		entries = append(entries, handlerEntries...)
 	}
+
+	entities, baseEntries, err := p.base.FormatForExport(collection, idNamer, inputResolver)
+	if err != nil {
+		return nil, nil, err
+	}
+	allEntities = append(allEntities, entities...)
+	entries = append(entries, baseEntries...)
 	return allEntities, entries, nil
 }
+
+// isBaseKind reports whether kind is served by the BaseHandler-backed
+// handlers rather than the legacy handler map.
+func (p *Provider) isBaseKind(kind string) bool {
+	return slices.Contains(p.base.SupportedKinds(), kind)
+}
@@ -14,12 +14,25 @@ import (
 	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
 	"github.com/rudderlabs/rudder-iac/cli/internal/project/writer"
 	prules "github.com/rudderlabs/rudder-iac/cli/internal/provider/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/destination"
 	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/connection"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/s3"
 	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/table"
 	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
 	vrules "github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/varsubst"
 )
 
+// mapResolver resolves variables from a map.
+type mapResolver map[string]string
+
+func (m mapResolver) Resolve(name string) (string, bool) {
+	v, ok := m[name]
+	return v, ok
+}
+
 // mockRETLStore mocks the RETL client for testing
 type mockRETLStore struct {
 	retlClient.RETLStore
@@ -33,6 +46,9 @@ type mockRETLStore struct {
 	// Preview functions
 	submitPreviewFunc    func(ctx context.Context, request *retlClient.PreviewSubmitRequest) (*retlClient.PreviewSubmitResponse, error)
 	getPreviewResultFunc func(ctx context.Context, resultID string) (*retlClient.PreviewResultResponse, error)
+	// Connection functions
+	listConnectionsFunc  func(ctx context.Context, req *retlClient.ListRETLConnectionsRequest) (*retlClient.RETLConnectionsPage, error)
+	createConnectionFunc func(ctx context.Context, req *retlClient.CreateRETLConnectionRequest) (*retlClient.RETLConnection, error)
 }
 
 // Mock RETL source operations
@@ -87,6 +103,22 @@ func (m *mockRETLStore) GetSourcePreviewResult(ctx context.Context, resultID str
 	return &retlClient.PreviewResultResponse{Status: retlClient.Completed}, nil
 }
 
+// Connection methods default to an account without connections
+
+func (m *mockRETLStore) ListConnections(ctx context.Context, req *retlClient.ListRETLConnectionsRequest) (*retlClient.RETLConnectionsPage, error) {
+	if m.listConnectionsFunc != nil {
+		return m.listConnectionsFunc(ctx, req)
+	}
+	return &retlClient.RETLConnectionsPage{}, nil
+}
+
+func (m *mockRETLStore) CreateConnection(ctx context.Context, req *retlClient.CreateRETLConnectionRequest) (*retlClient.RETLConnection, error) {
+	if m.createConnectionFunc != nil {
+		return m.createConnectionFunc(ctx, req)
+	}
+	return &retlClient.RETLConnection{ID: "test-connection-id"}, nil
+}
+
 // newDefaultMockClient creates a new mock client with default behavior
 func newDefaultMockClient() *mockRETLStore {
 	return &mockRETLStore{
@@ -149,6 +181,8 @@ func TestProvider(t *testing.T) {
 		provider := retl.New(newDefaultMockClient())
 		kinds := provider.SupportedKinds()
 		assert.Contains(t, kinds, "retl-source-sql-model")
+		assert.Contains(t, kinds, table.ResourceKind)
+		assert.Contains(t, kinds, s3.ResourceKind)
 	})
 
 	t.Run("SupportedTypes", func(t *testing.T) {
@@ -156,6 +190,8 @@ func TestProvider(t *testing.T) {
 		provider := retl.New(newDefaultMockClient())
 		types := provider.SupportedTypes()
 		assert.Contains(t, types, sqlmodel.ResourceType)
+		assert.Contains(t, types, table.ResourceType)
+		assert.Contains(t, types, s3.ResourceType)
 	})
 
 	t.Run("SupportedMatchPatterns", func(t *testing.T) {
@@ -166,6 +202,9 @@ func TestProvider(t *testing.T) {
 		var want []vrules.MatchPattern
 		want = append(want, prules.LegacyVersionPatterns(kind)...)
 		want = append(want, prules.V1VersionPatterns(kind)...)
+		want = append(want, prules.V1VersionPatterns(table.ResourceKind)...)
+		want = append(want, prules.V1VersionPatterns(s3.ResourceKind)...)
+		want = append(want, prules.V1VersionPatterns(connection.ResourceKind)...)
 		assert.ElementsMatch(t, want, p.SupportedMatchPatterns())
 	})
 
@@ -197,6 +236,34 @@ func TestProvider(t *testing.T) {
 		})
 	})
 
+	t.Run("SetVariableResolver renders SQL model parameters", func(t *testing.T) {
+		t.Parallel()
+		provider := retl.New(newDefaultMockClient())
+		provider.SetVariableResolver(varsubst.NewSubstitutor(mapResolver{"schema": "analytics_prod"}))
+
+		err := provider.LoadSpec("orders.yaml", &specs.Spec{
+			Kind: "retl-source-sql-model",
+			Spec: map[string]interface{}{
+				"id":                "orders",
+				"display_name":      "Orders",
+				"account_id":        "test-account",
+				"primary_key":       "id",
+				"sql":               "SELECT id FROM {{ params.schema }}.orders",
+				"source_definition": "postgres",
+				"parameters": []interface{}{
+					map[string]interface{}{"name": "schema", "type": "identifier", "default": "analytics"},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		graph, err := provider.ResourceGraph()
+		require.NoError(t, err)
+		res, ok := graph.GetResource(resources.URN("orders", sqlmodel.ResourceType))
+		require.True(t, ok)
+		assert.Equal(t, "SELECT id FROM analytics_prod.orders", res.Data()[sqlmodel.SQLKey])
+	})
+
 	t.Run("GetResourceGraph", func(t *testing.T) {
 		t.Run("Multiple resources", func(t *testing.T) {
 			t.Parallel()
@@ -550,6 +617,66 @@ func TestProviderParseSpec(t *testing.T) {
 	})
 }
 
+func TestProviderConnections(t *testing.T) {
+	connectionSpec := &specs.Spec{
+		Version: specs.SpecVersionV1,
+		Kind:    connection.ResourceKind,
+		Spec: map[string]any{
+			"id":          "orders-sync",
+			"source":      "#retl-source-sql-model:orders-model",
+			"destination": "#destination:webhook",
+			"schedule": map[string]any{
+				"type":          "basic",
+				"every_minutes": 30,
+			},
+			"sync_behaviour": "upsert",
+			"identifiers": []any{
+				map[string]any{"from": "email", "to": "email"},
+			},
+			"event": map[string]any{"type": "identify"},
+		},
+	}
+
+	t.Run("ParseSpec routes to the connection handler", func(t *testing.T) {
+		t.Parallel()
+		provider := retl.New(newDefaultMockClient())
+
+		parsed, err := provider.ParseSpec("orders-sync.yaml", connectionSpec)
+		require.NoError(t, err)
+		require.Len(t, parsed.URNs, 1)
+		assert.Equal(t, resources.URN("orders-sync", connection.ResourceType), parsed.URNs[0].URN)
+	})
+
+	t.Run("ResourceGraph orders connections after their SQL model", func(t *testing.T) {
+		t.Parallel()
+		provider := retl.New(newDefaultMockClient())
+
+		require.NoError(t, provider.LoadSpec("orders-model.yaml", &specs.Spec{
+			Kind: sqlmodel.ResourceKind,
+			Spec: map[string]any{
+				"id":                "orders-model",
+				"display_name":      "Orders",
+				"sql":               "SELECT 1",
+				"account_id":        "acc-1",
+				"primary_key":       "id",
+				"source_definition": "postgres",
+			},
+		}))
+		require.NoError(t, provider.LoadSpec("orders-sync.yaml", connectionSpec))
+
+		graph, err := provider.ResourceGraph()
+		require.NoError(t, err)
+
+		connectionURN := resources.URN("orders-sync", connection.ResourceType)
+		_, ok := graph.GetResource(connectionURN)
+		require.True(t, ok)
+		assert.ElementsMatch(t, []string{
+			resources.URN("orders-model", sqlmodel.ResourceType),
+			resources.URN("webhook", destination.DestinationResourceType),
+		}, graph.GetDependencies(connectionURN))
+	})
+}
+
 func TestProviderPreview(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		t.Parallel()
@@ -580,6 +707,45 @@ func TestProviderPreview(t *testing.T) {
 		assert.Contains(t, err.Error(), "no handler for resource type")
 	})
 
+	t.Run("Table", func(t *testing.T) {
+		t.Parallel()
+		var submitted *retlClient.PreviewSubmitRequest
+		mockClient := newDefaultMockClient()
+		mockClient.submitPreviewFunc = func(ctx context.Context, request *retlClient.PreviewSubmitRequest) (*retlClient.PreviewSubmitResponse, error) {
+			submitted = request
+			return &retlClient.PreviewSubmitResponse{ID: "req-123"}, nil
+		}
+		provider := retl.New(mockClient)
+		require.NoError(t, provider.LoadSpec("users.yaml", &specs.Spec{
+			Version: specs.SpecVersionV1,
+			Kind:    table.ResourceKind,
+			Spec: map[string]any{
+				"id":                "users",
+				"display_name":      "Users",
+				"account_id":        "acc123",
+				"source_definition": "snowflake",
+				"schema":            "analytics",
+				"table":             "users",
+				"primary_key":       "id",
+			},
+		}))
+
+		_, err := provider.Preview(context.Background(), "users", table.ResourceType, resources.ResourceData{}, 10)
+		require.NoError(t, err)
+		require.NotNil(t, submitted)
+		assert.Equal(t, "SELECT * FROM analytics.users", submitted.SQL)
+		assert.Equal(t, "acc123", submitted.AccountID)
+	})
+
+	t.Run("S3Unsupported", func(t *testing.T) {
+		t.Parallel()
+		provider := retl.New(newDefaultMockClient())
+
+		_, err := provider.Preview(context.Background(), "exports", s3.ResourceType, resources.ResourceData{}, 10)
+		require.ErrorIs(t, err, retl.ErrPreviewUnsupported)
+		assert.Contains(t, err.Error(), "S3 source exports")
+	})
+
 	t.Run("MissingSQL", func(t *testing.T) {
 		t.Parallel()
 		mockClient := newDefaultMockClient()
@@ -831,7 +997,11 @@ func TestProviderLoadImportable(t *testing.T) {
 			}
 			hasExternalID := resolved.HasExternalId
 			require.NotNil(t, hasExternalID)
-			require.False(t, *hasExternalID)
+			if *hasExternalID {
+				// The connection handler also looks up managed SQL models to
+				// find importable connections; there are none here.
+				return &retlClient.RETLSources{}, nil
+			}
 			return &retlClient.RETLSources{
 				Data: []retlClient.RETLSource{
 					{
@@ -0,0 +1,96 @@
+package connection
+
+import (
+	"fmt"
+	"strings"
+
+	prules "github.com/rudderlabs/rudder-iac/cli/internal/provider/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/destination"
+	esConnection "github.com/rudderlabs/rudder-iac/cli/internal/providers/event-stream/connection"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/connection"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+)
+
+var validateConnectionSemantic = func(
+	_ string,
+	_ string,
+	_ map[string]any,
+	spec connection.ConnectionSpec,
+	graph *resources.Graph,
+) []rules.ValidationResult {
+	var results []rules.ValidationResult
+
+	if id, ok := endpointID(spec.Source, sqlmodel.ResourceKind); ok {
+		if _, found := graph.GetResource(resources.URN(id, sqlmodel.ResourceType)); !found {
+			results = append(results, rules.ValidationResult{
+				Reference: "/source",
+				Message:   fmt.Sprintf("SQL model '%s' not found in the project", id),
+			})
+		}
+	}
+
+	if id, ok := endpointID(spec.Destination, destination.DestinationSpecKind); ok {
+		destinationURN := resources.URN(id, destination.DestinationResourceType)
+		if _, found := graph.GetResource(destinationURN); !found {
+			results = append(results, rules.ValidationResult{
+				Reference: "/destination",
+				Message:   fmt.Sprintf("destination '%s' not found in the project", id),
+			})
+		} else {
+			results = append(results, validateDestinationHasOnlyRETLSources(graph, id, destinationURN)...)
+		}
+	}
+
+	return results
+}
+
+// validateDestinationHasOnlyRETLSources is the rETL side of V-E1: a
+// destination cannot receive from both event stream and rETL sources. The
+// event stream connection rule reports the same conflict against its own
+// spec, so the author sees it on both declarations.
+func validateDestinationHasOnlyRETLSources(graph *resources.Graph, destinationID, destinationURN string) []rules.ValidationResult {
+	var results []rules.ValidationResult
+	for _, res := range graph.ResourcesByType(esConnection.EventStreamConnectionResourceType) {
+		data := res.Data()
+		src, srcOK := data[esConnection.SourceKey].(*resources.PropertyRef)
+		dst, dstOK := data[esConnection.DestinationKey].(*resources.PropertyRef)
+		if !srcOK || !dstOK || dst.URN != destinationURN {
+			continue
+		}
+		_, sourceID, _ := strings.Cut(src.URN, ":")
+		results = append(results, rules.ValidationResult{
+			Reference: "/destination",
+			Message: fmt.Sprintf(
+				"destination '%s' is also connected to event stream source '%s' in this project; a destination cannot receive from both event stream and rETL sources",
+				destinationID, sourceID,
+			),
+		})
+	}
+	return results
+}
+
+// endpointID extracts the local id from a "#<kind>:<id>" endpoint reference.
+// Malformed or wrong-kind refs return ok=false — the spec-syntax rule already
+// reports those, so semantic checks skip them quietly.
+func endpointID(ref, wantKind string) (string, bool) {
+	matches := connection.RefRegex.FindStringSubmatch(strings.TrimSpace(ref))
+	if matches == nil || matches[1] != wantKind {
+		return "", false
+	}
+	return matches[2], true
+}
+
+func NewConnectionSemanticValidRule() rules.Rule {
+	return prules.NewTypedRule(
+		"retl/connection/semantic-valid",
+		rules.Error,
+		"retl connection endpoints must exist in the project and not share a destination with event stream sources",
+		rules.Examples{},
+		prules.NewSemanticPatternValidator(
+			prules.V1VersionPatterns(connection.ResourceKind),
+			validateConnectionSemantic,
+		),
+	)
+}
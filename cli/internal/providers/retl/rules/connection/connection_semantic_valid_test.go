@@ -0,0 +1,80 @@
+package connection
+
+import (
+	"testing"
+
+	prules "github.com/rudderlabs/rudder-iac/cli/internal/provider/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/destination"
+	esConnection "github.com/rudderlabs/rudder-iac/cli/internal/providers/event-stream/connection"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/connection"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// endpointsGraph builds a graph holding the SQL model "users" and the
+// destination "webhook" the valid connection spec links.
+func endpointsGraph() *resources.Graph {
+	graph := resources.NewGraph()
+	graph.AddResource(resources.NewResource("users", sqlmodel.ResourceType, resources.ResourceData{}, nil))
+	graph.AddResource(resources.NewResource("webhook", destination.DestinationResourceType, resources.ResourceData{}, nil))
+	return graph
+}
+
+func TestConnectionSemanticValidRule_Metadata(t *testing.T) {
+	rule := NewConnectionSemanticValidRule()
+
+	assert.Equal(t, "retl/connection/semantic-valid", rule.ID())
+	assert.Equal(t, rules.Error, rule.Severity())
+	assert.Equal(t, prules.V1VersionPatterns(connection.ResourceKind), rule.AppliesTo())
+}
+
+func TestConnectionSemanticValid(t *testing.T) {
+	t.Parallel()
+
+	t.Run("endpoints exist", func(t *testing.T) {
+		t.Parallel()
+
+		results := validateConnectionSemantic("", "", nil, validConnectionSpec(), endpointsGraph())
+		assert.Empty(t, results)
+	})
+
+	t.Run("missing endpoints", func(t *testing.T) {
+		t.Parallel()
+
+		results := validateConnectionSemantic("", "", nil, validConnectionSpec(), resources.NewGraph())
+		require.Len(t, results, 2)
+		assert.Equal(t, "/source", results[0].Reference)
+		assert.Equal(t, "SQL model 'users' not found in the project", results[0].Message)
+		assert.Equal(t, "/destination", results[1].Reference)
+		assert.Equal(t, "destination 'webhook' not found in the project", results[1].Message)
+	})
+
+	t.Run("malformed refs are left to the syntax rule", func(t *testing.T) {
+		t.Parallel()
+
+		spec := validConnectionSpec()
+		spec.Source = "users"
+		spec.Destination = "#retl-source-sql-model:users"
+
+		results := validateConnectionSemantic("", "", nil, spec, resources.NewGraph())
+		assert.Empty(t, results)
+	})
+
+	t.Run("destination shared with an event stream source", func(t *testing.T) {
+		t.Parallel()
+
+		graph := endpointsGraph()
+		graph.AddResource(resources.NewResource("conn-1", esConnection.EventStreamConnectionResourceType, resources.ResourceData{
+			esConnection.SourceKey:      &resources.PropertyRef{URN: resources.URN("web", "event-stream-source"), Property: "id"},
+			esConnection.DestinationKey: &resources.PropertyRef{URN: resources.URN("webhook", destination.DestinationResourceType), Property: "id"},
+		}, nil))
+
+		results := validateConnectionSemantic("", "", nil, validConnectionSpec(), graph)
+		require.Len(t, results, 1)
+		assert.Equal(t, "/destination", results[0].Reference)
+		assert.Contains(t, results[0].Message, "destination 'webhook' is also connected to event stream source 'web'")
+	})
+}
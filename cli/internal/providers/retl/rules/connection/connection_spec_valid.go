@@ -0,0 +1,226 @@
+package connection
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+
+	prules "github.com/rudderlabs/rudder-iac/cli/internal/provider/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/provider/rules/funcs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/destination"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/connection"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+)
+
+var validateConnectionSpec = func(
+	_ string,
+	_ string,
+	_ map[string]any,
+	spec connection.ConnectionSpec,
+) []rules.ValidationResult {
+	validationErrors, err := rules.ValidateStruct(spec, "")
+	if err != nil {
+		return []rules.ValidationResult{{
+			Message: err.Error(),
+		}}
+	}
+
+	results := funcs.ParseValidationErrors(validationErrors, reflect.TypeOf(spec))
+
+	results = append(results, validateEndpointRef("source", spec.Source, sqlmodel.ResourceKind, "a SQL model")...)
+	results = append(results, validateEndpointRef("destination", spec.Destination, destination.DestinationSpecKind, "a destination")...)
+	results = append(results, validateSchedule(spec.Schedule)...)
+	results = append(results, validateEvent(spec)...)
+	results = append(results, validateMappingTargets(spec)...)
+
+	return results
+}
+
+// validateEndpointRef checks that an endpoint reference points at the wanted
+// kind. An empty ref is skipped — the struct validator's required tag already
+// reports it.
+func validateEndpointRef(field, ref, wantKind, wantLabel string) []rules.ValidationResult {
+	if ref == "" {
+		return nil
+	}
+
+	reference := "/" + field
+
+	// connection.RefRegex is the same pattern the handler parses with, so a ref
+	// pointing at the wrong kind can be told apart from a malformed one without
+	// the two definitions drifting apart.
+	matches := connection.RefRegex.FindStringSubmatch(strings.TrimSpace(ref))
+	if matches == nil {
+		return []rules.ValidationResult{{
+			Reference: reference,
+			Message:   fmt.Sprintf("'%s' is invalid: must be of pattern #%s:<id>", field, wantKind),
+		}}
+	}
+
+	if matches[1] != wantKind {
+		return []rules.ValidationResult{{
+			Reference: reference,
+			Message: fmt.Sprintf(
+				"'%s' must reference %s (#%s:<id>), got a '%s' reference",
+				field, wantLabel, wantKind, matches[1],
+			),
+		}}
+	}
+
+	return nil
+}
+
+// cronParser accepts the standard five-field cron expressions (minute, hour,
+// day of month, month, day of week) that cron schedules are given in.
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// validateSchedule checks the fields each schedule type needs: a basic schedule
+// syncs every_minutes, a cron schedule follows a well-formed cron_expression
+// and a manual one takes neither. An unknown type is left to the struct
+// validator.
+func validateSchedule(schedule *connection.ScheduleSpec) []rules.ValidationResult {
+	if schedule == nil {
+		return nil
+	}
+
+	var (
+		results   []rules.ValidationResult
+		hasEvery  = schedule.EveryMinutes != nil
+		hasCron   = schedule.CronExpression != nil
+		cronEmpty = hasCron && strings.TrimSpace(*schedule.CronExpression) == ""
+	)
+
+	require := func(field string) {
+		results = append(results, rules.ValidationResult{
+			Reference: "/schedule/" + field,
+			Message:   fmt.Sprintf("'schedule.%s' is required for a '%s' schedule", field, schedule.Type),
+		})
+	}
+	forbid := func(field string) {
+		results = append(results, rules.ValidationResult{
+			Reference: "/schedule/" + field,
+			Message:   fmt.Sprintf("'schedule.%s' is not allowed for a '%s' schedule", field, schedule.Type),
+		})
+	}
+
+	switch schedule.Type {
+	case "basic":
+		if !hasEvery {
+			require("every_minutes")
+		}
+		if hasCron {
+			forbid("cron_expression")
+		}
+	case "cron":
+		if !hasCron || cronEmpty {
+			require("cron_expression")
+		} else if _, err := cronParser.Parse(*schedule.CronExpression); err != nil {
+			results = append(results, rules.ValidationResult{
+				Reference: "/schedule/cron_expression",
+				Message:   fmt.Sprintf("'schedule.cron_expression' is not a valid cron expression: %s", err),
+			})
+		}
+		if hasEvery {
+			forbid("every_minutes")
+		}
+	case "manual":
+		if hasEvery {
+			forbid("every_minutes")
+		}
+		if hasCron {
+			forbid("cron_expression")
+		}
+	}
+
+	return results
+}
+
+// validateEvent checks the shape of the event sent for every record: a track
+// event is named either statically or from a column, an identify event takes
+// no name, constants only apply to events, and a connection syncs either
+// events or an object, never both.
+func validateEvent(spec connection.ConnectionSpec) []rules.ValidationResult {
+	var results []rules.ValidationResult
+
+	if spec.Event != nil && spec.Object != "" {
+		results = append(results, rules.ValidationResult{
+			Reference: "/object",
+			Message:   "'object' is not allowed together with 'event'",
+		})
+	}
+
+	if spec.Event == nil {
+		if len(spec.Constants) > 0 {
+			results = append(results, rules.ValidationResult{
+				Reference: "/constants",
+				Message:   "'constants' is only allowed together with 'event'",
+			})
+		}
+		return results
+	}
+
+	hasName := spec.Event.Name != ""
+	hasNameColumn := spec.Event.NameColumn != ""
+
+	switch spec.Event.Type {
+	case "track":
+		if hasName == hasNameColumn {
+			results = append(results, rules.ValidationResult{
+				Reference: "/event",
+				Message:   "exactly one of 'event.name' and 'event.name_column' is required for a 'track' event",
+			})
+		}
+	case "identify":
+		if hasName || hasNameColumn {
+			results = append(results, rules.ValidationResult{
+				Reference: "/event",
+				Message:   "'event.name' and 'event.name_column' are not allowed for an 'identify' event",
+			})
+		}
+	}
+
+	return results
+}
+
+// validateMappingTargets reports a destination field written more than once
+// across identifiers and mappings; the sync would keep only one of the values.
+func validateMappingTargets(spec connection.ConnectionSpec) []rules.ValidationResult {
+	var results []rules.ValidationResult
+
+	seen := make(map[string]bool)
+	check := func(field string, mappings []connection.MappingSpec) {
+		for i, m := range mappings {
+			if m.To == "" {
+				continue
+			}
+			if seen[m.To] {
+				results = append(results, rules.ValidationResult{
+					Reference: fmt.Sprintf("/%s/%d/to", field, i),
+					Message:   fmt.Sprintf("destination field '%s' is mapped more than once", m.To),
+				})
+				continue
+			}
+			seen[m.To] = true
+		}
+	}
+	check("identifiers", spec.Identifiers)
+	check("mappings", spec.Mappings)
+
+	return results
+}
+
+func NewConnectionSpecSyntaxValidRule() rules.Rule {
+	return prules.NewTypedRule(
+		"retl/connection/spec-syntax-valid",
+		rules.Error,
+		"retl connection spec syntax must be valid",
+		rules.Examples{},
+		prules.NewPatternValidator(
+			prules.V1VersionPatterns(connection.ResourceKind),
+			validateConnectionSpec,
+		),
+	)
+}
@@ -0,0 +1,221 @@
+package connection
+
+import (
+	"testing"
+
+	prules "github.com/rudderlabs/rudder-iac/cli/internal/provider/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/connection"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(i int) *int { return &i }
+
+func strPtr(s string) *string { return &s }
+
+func validConnectionSpec() connection.ConnectionSpec {
+	return connection.ConnectionSpec{
+		ID:            "users-to-webhook",
+		Source:        "#retl-source-sql-model:users",
+		Destination:   "#destination:webhook",
+		Schedule:      &connection.ScheduleSpec{Type: "basic", EveryMinutes: intPtr(30)},
+		SyncBehaviour: "upsert",
+		Identifiers:   []connection.MappingSpec{{From: "email", To: "email"}},
+		Event:         &connection.EventSpec{Type: "identify"},
+	}
+}
+
+func TestConnectionSpecSyntaxValidRule_Metadata(t *testing.T) {
+	rule := NewConnectionSpecSyntaxValidRule()
+
+	assert.Equal(t, "retl/connection/spec-syntax-valid", rule.ID())
+	assert.Equal(t, rules.Error, rule.Severity())
+	assert.Equal(t, "retl connection spec syntax must be valid", rule.Description())
+	assert.Equal(t, prules.V1VersionPatterns(connection.ResourceKind), rule.AppliesTo())
+}
+
+func TestConnectionSpecSyntaxValidRule_ValidSpecs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		modify func(*connection.ConnectionSpec)
+	}{
+		{
+			name:   "basic schedule with identify event",
+			modify: func(*connection.ConnectionSpec) {},
+		},
+		{
+			name: "cron schedule with track event named from a column",
+			modify: func(s *connection.ConnectionSpec) {
+				s.Schedule = &connection.ScheduleSpec{Type: "cron", CronExpression: strPtr("0 * * * *")}
+				s.Event = &connection.EventSpec{Type: "track", NameColumn: "event_name"}
+				s.Constants = []connection.ConstantSpec{{Key: "channel", Value: "retl"}}
+			},
+		},
+		{
+			name: "manual schedule syncing an object",
+			modify: func(s *connection.ConnectionSpec) {
+				s.Schedule = &connection.ScheduleSpec{Type: "manual"}
+				s.Event = nil
+				s.Object = "contacts"
+				s.SyncBehaviour = "mirror"
+				s.Mappings = []connection.MappingSpec{{From: "first_name", To: "firstName"}}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := validConnectionSpec()
+			tt.modify(&spec)
+
+			results := validateConnectionSpec("", "", nil, spec)
+			assert.Empty(t, results)
+		})
+	}
+}
+
+func TestConnectionSpecSyntaxValidRule_InvalidSpecs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		modify       func(*connection.ConnectionSpec)
+		expectedRefs []string
+		expectedMsgs []string
+	}{
+		{
+			name: "missing required fields",
+			modify: func(s *connection.ConnectionSpec) {
+				s.Schedule = nil
+				s.Identifiers = nil
+			},
+			expectedRefs: []string{"/schedule", "/identifiers"},
+			expectedMsgs: []string{"'schedule' is required", "'identifiers' is required"},
+		},
+		{
+			name: "invalid sync behaviour",
+			modify: func(s *connection.ConnectionSpec) {
+				s.SyncBehaviour = "append"
+			},
+			expectedRefs: []string{"/sync_behaviour"},
+			expectedMsgs: []string{"'sync_behaviour' must be one of [upsert mirror full]"},
+		},
+		{
+			name: "source referencing a destination",
+			modify: func(s *connection.ConnectionSpec) {
+				s.Source = "#destination:webhook"
+			},
+			expectedRefs: []string{"/source"},
+			expectedMsgs: []string{"'source' must reference a SQL model (#retl-source-sql-model:<id>), got a 'destination' reference"},
+		},
+		{
+			name: "malformed destination reference",
+			modify: func(s *connection.ConnectionSpec) {
+				s.Destination = "webhook"
+			},
+			expectedRefs: []string{"/destination"},
+			expectedMsgs: []string{"'destination' is invalid: must be of pattern #destination:<id>"},
+		},
+		{
+			name: "basic schedule without every_minutes",
+			modify: func(s *connection.ConnectionSpec) {
+				s.Schedule = &connection.ScheduleSpec{Type: "basic", CronExpression: strPtr("0 * * * *")}
+			},
+			expectedRefs: []string{"/schedule/every_minutes", "/schedule/cron_expression"},
+			expectedMsgs: []string{
+				"'schedule.every_minutes' is required for a 'basic' schedule",
+				"'schedule.cron_expression' is not allowed for a 'basic' schedule",
+			},
+		},
+		{
+			name: "cron schedule with empty expression",
+			modify: func(s *connection.ConnectionSpec) {
+				s.Schedule = &connection.ScheduleSpec{Type: "cron", CronExpression: strPtr(" ")}
+			},
+			expectedRefs: []string{"/schedule/cron_expression"},
+			expectedMsgs: []string{"'schedule.cron_expression' is required for a 'cron' schedule"},
+		},
+		{
+			name: "cron schedule with too few fields",
+			modify: func(s *connection.ConnectionSpec) {
+				s.Schedule = &connection.ScheduleSpec{Type: "cron", CronExpression: strPtr("0 * * *")}
+			},
+			expectedRefs: []string{"/schedule/cron_expression"},
+			expectedMsgs: []string{"'schedule.cron_expression' is not a valid cron expression: expected exactly 5 fields, found 4: [0 * * *]"},
+		},
+		{
+			name: "cron schedule with an out of range field",
+			modify: func(s *connection.ConnectionSpec) {
+				s.Schedule = &connection.ScheduleSpec{Type: "cron", CronExpression: strPtr("0 25 * * *")}
+			},
+			expectedRefs: []string{"/schedule/cron_expression"},
+			expectedMsgs: []string{"'schedule.cron_expression' is not a valid cron expression: end of range (25) above maximum (23): 25"},
+		},
+		{
+			name: "manual schedule with every_minutes",
+			modify: func(s *connection.ConnectionSpec) {
+				s.Schedule = &connection.ScheduleSpec{Type: "manual", EveryMinutes: intPtr(5)}
+			},
+			expectedRefs: []string{"/schedule/every_minutes"},
+			expectedMsgs: []string{"'schedule.every_minutes' is not allowed for a 'manual' schedule"},
+		},
+		{
+			name: "track event with both names",
+			modify: func(s *connection.ConnectionSpec) {
+				s.Event = &connection.EventSpec{Type: "track", Name: "Synced", NameColumn: "event_name"}
+			},
+			expectedRefs: []string{"/event"},
+			expectedMsgs: []string{"exactly one of 'event.name' and 'event.name_column' is required for a 'track' event"},
+		},
+		{
+			name: "constants without event",
+			modify: func(s *connection.ConnectionSpec) {
+				s.Event = nil
+				s.Constants = []connection.ConstantSpec{{Key: "channel", Value: "retl"}}
+			},
+			expectedRefs: []string{"/constants"},
+			expectedMsgs: []string{"'constants' is only allowed together with 'event'"},
+		},
+		{
+			name: "event together with object",
+			modify: func(s *connection.ConnectionSpec) {
+				s.Object = "contacts"
+			},
+			expectedRefs: []string{"/object"},
+			expectedMsgs: []string{"'object' is not allowed together with 'event'"},
+		},
+		{
+			name: "destination field mapped twice",
+			modify: func(s *connection.ConnectionSpec) {
+				s.Mappings = []connection.MappingSpec{{From: "work_email", To: "email"}}
+			},
+			expectedRefs: []string{"/mappings/0/to"},
+			expectedMsgs: []string{"destination field 'email' is mapped more than once"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := validConnectionSpec()
+			tt.modify(&spec)
+
+			results := validateConnectionSpec("", "", nil, spec)
+			require.Len(t, results, len(tt.expectedRefs))
+
+			var refs, msgs []string
+			for _, r := range results {
+				refs = append(refs, r.Reference)
+				msgs = append(msgs, r.Message)
+			}
+			assert.ElementsMatch(t, tt.expectedRefs, refs)
+			assert.ElementsMatch(t, tt.expectedMsgs, msgs)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package s3
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	prules "github.com/rudderlabs/rudder-iac/cli/internal/provider/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/provider/rules/funcs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/s3"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+)
+
+// bucketNamePattern follows the S3 bucket naming rules: 3-63 lowercase
+// letters, digits, dots and hyphens, starting and ending with a letter or digit.
+var bucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+var validateS3Spec = func(
+	_ string,
+	_ string,
+	_ map[string]any,
+	spec s3.S3Spec,
+) []rules.ValidationResult {
+	validationErrors, err := rules.ValidateStruct(spec, "")
+	if err != nil {
+		return []rules.ValidationResult{{
+			Message: err.Error(),
+		}}
+	}
+
+	results := funcs.ParseValidationErrors(validationErrors, reflect.TypeOf(spec))
+
+	if spec.Bucket != "" && !bucketNamePattern.MatchString(spec.Bucket) {
+		results = append(results, rules.ValidationResult{
+			Reference: "/bucket",
+			Message:   "'bucket' must be a bucket name such as 'my-bucket', not a URL or path",
+		})
+	}
+	if strings.HasPrefix(spec.Prefix, "/") {
+		results = append(results, rules.ValidationResult{
+			Reference: "/prefix",
+			Message:   "'prefix' must not start with '/'",
+		})
+	}
+
+	return results
+}
+
+func NewS3SpecSyntaxValidRule() rules.Rule {
+	return prules.NewTypedRule(
+		"retl/s3/spec-syntax-valid",
+		rules.Error,
+		"retl s3 source spec syntax must be valid",
+		rules.Examples{},
+		prules.NewPatternValidator(
+			prules.V1VersionPatterns(s3.ResourceKind),
+			validateS3Spec,
+		),
+	)
+}
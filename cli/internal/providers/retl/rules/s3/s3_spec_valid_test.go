@@ -0,0 +1,97 @@
+package s3
+
+import (
+	"testing"
+
+	prules "github.com/rudderlabs/rudder-iac/cli/internal/provider/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/s3"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validS3Spec() s3.S3Spec {
+	return s3.S3Spec{
+		ID:          "events",
+		DisplayName: "Events",
+		AccountID:   "acc-1",
+		Bucket:      "acme-events",
+		Prefix:      "exports/daily/",
+	}
+}
+
+func TestS3SpecSyntaxValidRule_Metadata(t *testing.T) {
+	rule := NewS3SpecSyntaxValidRule()
+
+	assert.Equal(t, "retl/s3/spec-syntax-valid", rule.ID())
+	assert.Equal(t, rules.Error, rule.Severity())
+	assert.Equal(t, "retl s3 source spec syntax must be valid", rule.Description())
+	assert.Equal(t, prules.V1VersionPatterns(s3.ResourceKind), rule.AppliesTo())
+}
+
+func TestS3SpecSyntaxValidRule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		modify       func(*s3.S3Spec)
+		expectedRefs []string
+		expectedMsgs []string
+	}{
+		{
+			name:   "valid spec",
+			modify: func(*s3.S3Spec) {},
+		},
+		{
+			name: "valid spec without prefix",
+			modify: func(s *s3.S3Spec) {
+				s.Prefix = ""
+			},
+		},
+		{
+			name: "missing bucket and account",
+			modify: func(s *s3.S3Spec) {
+				s.Bucket = ""
+				s.AccountID = ""
+			},
+			expectedRefs: []string{"/bucket", "/account_id"},
+			expectedMsgs: []string{"'bucket' is required", "'account_id' is required"},
+		},
+		{
+			name: "bucket given as a URL",
+			modify: func(s *s3.S3Spec) {
+				s.Bucket = "s3://acme-events"
+			},
+			expectedRefs: []string{"/bucket"},
+			expectedMsgs: []string{"'bucket' must be a bucket name such as 'my-bucket', not a URL or path"},
+		},
+		{
+			name: "prefix with leading slash",
+			modify: func(s *s3.S3Spec) {
+				s.Prefix = "/exports"
+			},
+			expectedRefs: []string{"/prefix"},
+			expectedMsgs: []string{"'prefix' must not start with '/'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := validS3Spec()
+			tt.modify(&spec)
+
+			results := validateS3Spec("", "", nil, spec)
+			require.Len(t, results, len(tt.expectedRefs))
+
+			var refs, msgs []string
+			for _, r := range results {
+				refs = append(refs, r.Reference)
+				msgs = append(msgs, r.Message)
+			}
+			assert.ElementsMatch(t, tt.expectedRefs, refs)
+			assert.ElementsMatch(t, tt.expectedMsgs, msgs)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package source
+
+import (
+	"fmt"
+
+	prules "github.com/rudderlabs/rudder-iac/cli/internal/provider/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+)
+
+// sourceSpec holds the fields of a source spec the semantic checks read; it is
+// shared by every source kind.
+type sourceSpec struct {
+	DisplayName string `json:"display_name"`
+}
+
+// namedSource is implemented by the resources of every source kind.
+type namedSource interface {
+	SourceName() string
+}
+
+func validateSourceSemantic(kind, resourceType string) func(string, string, map[string]any, sourceSpec, *resources.Graph) []rules.ValidationResult {
+	return func(
+		_ string,
+		_ string,
+		_ map[string]any,
+		spec sourceSpec,
+		graph *resources.Graph,
+	) []rules.ValidationResult {
+		return validateDisplayNameUniqueness(kind, resourceType, spec, graph)
+	}
+}
+
+func validateDisplayNameUniqueness(kind, resourceType string, spec sourceSpec, graph *resources.Graph) []rules.ValidationResult {
+	count := 0
+	for _, resource := range graph.ResourcesByType(resourceType) {
+		if source, ok := resource.RawData().(namedSource); ok && source.SourceName() == spec.DisplayName {
+			count++
+		}
+	}
+
+	if count > 1 {
+		return []rules.ValidationResult{{
+			Reference: "/display_name",
+			Message: fmt.Sprintf(
+				"duplicate display_name '%s' within kind '%s'",
+				spec.DisplayName,
+				kind,
+			),
+		}}
+	}
+
+	return nil
+}
+
+// NewSourceSemanticValidRule returns the semantic rule of a source kind,
+// identified as "retl/<name>/semantic-valid". label names the source in the
+// rule description.
+func NewSourceSemanticValidRule(name, label, kind, resourceType string) rules.Rule {
+	return prules.NewTypedRule(
+		fmt.Sprintf("retl/%s/semantic-valid", name),
+		rules.Error,
+		fmt.Sprintf("retl %s semantic constraints must be satisfied", label),
+		rules.Examples{},
+		prules.NewSemanticPatternValidator(
+			prules.V1VersionPatterns(kind),
+			validateSourceSemantic(kind, resourceType),
+		),
+	)
+}
@@ -0,0 +1,91 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	prules "github.com/rudderlabs/rudder-iac/cli/internal/provider/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/s3"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/table"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+)
+
+func TestSourceSemanticValidRule_Metadata(t *testing.T) {
+	rule := NewSourceSemanticValidRule("table", "warehouse table", table.ResourceKind, table.ResourceType)
+
+	assert.Equal(t, "retl/table/semantic-valid", rule.ID())
+	assert.Equal(t, rules.Error, rule.Severity())
+	assert.Equal(t, "retl warehouse table semantic constraints must be satisfied", rule.Description())
+	assert.Equal(t, prules.V1VersionPatterns(table.ResourceKind), rule.AppliesTo())
+}
+
+func TestSourceSemanticValid_DisplayNameUniqueness(t *testing.T) {
+	t.Parallel()
+
+	tableSource := func(id, displayName string) *resources.Resource {
+		return resources.NewResource(id, table.ResourceType, resources.ResourceData{}, nil,
+			resources.WithRawData(&table.TableResource{ID: id, DisplayName: displayName}))
+	}
+	s3Source := func(id, displayName string) *resources.Resource {
+		return resources.NewResource(id, s3.ResourceType, resources.ResourceData{}, nil,
+			resources.WithRawData(&s3.S3Resource{ID: id, DisplayName: displayName}))
+	}
+
+	tests := []struct {
+		name         string
+		kind         string
+		resourceType string
+		graph        []*resources.Resource
+		wantMessage  string
+	}{
+		{
+			name:         "unique table display names",
+			kind:         table.ResourceKind,
+			resourceType: table.ResourceType,
+			graph:        []*resources.Resource{tableSource("users", "Users"), tableSource("orders", "Orders")},
+		},
+		{
+			name:         "duplicate table display name",
+			kind:         table.ResourceKind,
+			resourceType: table.ResourceType,
+			graph:        []*resources.Resource{tableSource("users", "Users"), tableSource("users-copy", "Users")},
+			wantMessage:  "duplicate display_name 'Users' within kind 'retl-source-table'",
+		},
+		{
+			name:         "duplicate s3 display name",
+			kind:         s3.ResourceKind,
+			resourceType: s3.ResourceType,
+			graph:        []*resources.Resource{s3Source("users", "Users"), s3Source("users-copy", "Users")},
+			wantMessage:  "duplicate display_name 'Users' within kind 'retl-source-s3'",
+		},
+		{
+			name:         "same display name across kinds",
+			kind:         s3.ResourceKind,
+			resourceType: s3.ResourceType,
+			graph:        []*resources.Resource{tableSource("users", "Users"), s3Source("users", "Users")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			graph := resources.NewGraph()
+			for _, r := range tt.graph {
+				graph.AddResource(r)
+			}
+
+			results := validateSourceSemantic(tt.kind, tt.resourceType)("", "", nil, sourceSpec{DisplayName: "Users"}, graph)
+			if tt.wantMessage == "" {
+				assert.Empty(t, results)
+				return
+			}
+			require.Len(t, results, 1)
+			assert.Equal(t, "/display_name", results[0].Reference)
+			assert.Contains(t, results[0].Message, tt.wantMessage)
+		})
+	}
+}
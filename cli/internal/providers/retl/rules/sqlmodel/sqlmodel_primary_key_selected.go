@@ -0,0 +1,85 @@
+package sqlmodel
+
+import (
+	"fmt"
+	"strings"
+
+	prules "github.com/rudderlabs/rudder-iac/cli/internal/provider/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel/sqllint"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+)
+
+// validatePrimaryKeySelected checks the primary key against the output
+// columns inferred from the model's query. The query is read from the graph,
+// where file-backed models already hold the file contents. Queries that do not
+// parse, or whose columns are not all known because of a * item, are skipped.
+var validatePrimaryKeySelected = func(
+	_ string,
+	_ string,
+	_ map[string]any,
+	spec sqlmodel.SQLModelSpec,
+	graph *resources.Graph,
+) []rules.ValidationResult {
+	if spec.PrimaryKey == "" {
+		return nil
+	}
+
+	dialect, ok := sqllint.DialectFor(string(spec.SourceDefinition))
+	if !ok {
+		return nil
+	}
+
+	resource, ok := graph.GetResource(resources.URN(spec.ID, sqlmodel.ResourceType))
+	if !ok {
+		return nil
+	}
+	sql, _ := resource.Data()[sqlmodel.SQLKey].(string)
+
+	query, err := sqllint.Parse(sql, dialect)
+	if err != nil || !query.Complete() {
+		return nil
+	}
+
+	switch matches := query.Lookup(spec.PrimaryKey); len(matches) {
+	case 0:
+		return []rules.ValidationResult{{
+			Reference: "/primary_key",
+			Message: fmt.Sprintf(
+				"primary_key '%s' is not in the SELECT list (columns: %s)",
+				spec.PrimaryKey,
+				strings.Join(query.ColumnNames(), ", "),
+			),
+		}}
+	case 1:
+		return nil
+	default:
+		return []rules.ValidationResult{{
+			Reference: "/primary_key",
+			Message: fmt.Sprintf(
+				"primary_key '%s' is ambiguous: the SELECT list has %d columns named '%s'",
+				spec.PrimaryKey,
+				len(matches),
+				spec.PrimaryKey,
+			),
+		}}
+	}
+}
+
+func NewSQLModelPrimaryKeySelectedRule() rules.Rule {
+	return prules.NewTypedRule(
+		"retl/sqlmodel/primary-key-selected",
+		rules.Error,
+		"retl sql model primary key must be exactly one column of the SELECT list",
+		rules.Examples{},
+		prules.NewSemanticPatternValidator(
+			prules.LegacyVersionPatterns(sqlmodel.ResourceKind),
+			validatePrimaryKeySelected,
+		),
+		prules.NewSemanticPatternValidator(
+			prules.V1VersionPatterns(sqlmodel.ResourceKind),
+			validatePrimaryKeySelected,
+		),
+	)
+}
@@ -0,0 +1,127 @@
+package sqlmodel
+
+import (
+	"testing"
+
+	prules "github.com/rudderlabs/rudder-iac/cli/internal/provider/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLModelPrimaryKeySelectedRule_Metadata(t *testing.T) {
+	rule := NewSQLModelPrimaryKeySelectedRule()
+
+	expectedPatterns := append(
+		prules.LegacyVersionPatterns(sqlmodel.ResourceKind),
+		prules.V1VersionPatterns(sqlmodel.ResourceKind)...,
+	)
+
+	assert.Equal(t, "retl/sqlmodel/primary-key-selected", rule.ID())
+	assert.Equal(t, rules.Error, rule.Severity())
+	assert.Equal(t, expectedPatterns, rule.AppliesTo())
+}
+
+func TestSQLModelPrimaryKeySelected(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		sourceDefinition sqlmodel.SourceDefinition
+		primaryKey       string
+		sql              string
+		expectedMsg      string
+	}{
+		{
+			name:       "primary key selected",
+			primaryKey: "id",
+			sql:        "SELECT id, email FROM users",
+		},
+		{
+			name:       "primary key selected through an alias, in another case",
+			primaryKey: "USER_ID",
+			sql:        "SELECT u.id AS user_id, email FROM users u",
+		},
+		{
+			name:        "primary key missing",
+			primaryKey:  "user_id",
+			sql:         "SELECT id, lower(email) FROM users",
+			expectedMsg: "primary_key 'user_id' is not in the SELECT list (columns: id, ?)",
+		},
+		{
+			name:        "primary key ambiguous",
+			primaryKey:  "id",
+			sql:         "SELECT u.id, o.id FROM users u JOIN orders o ON o.user_id = u.id",
+			expectedMsg: "primary_key 'id' is ambiguous: the SELECT list has 2 columns named 'id'",
+		},
+		{
+			name:             "primary key selected through a postgres CAST",
+			sourceDefinition: sqlmodel.SourceDefinitionPostgres,
+			primaryKey:       "id",
+			sql:              "SELECT CAST(id AS text), email FROM users",
+		},
+		{
+			name:             "postgres quoted and unquoted names are different columns",
+			sourceDefinition: sqlmodel.SourceDefinitionPostgres,
+			primaryKey:       "id",
+			sql:              `SELECT "ID", id FROM users`,
+		},
+		{
+			name:        "strings with backslash-escaped quotes are parsed",
+			primaryKey:  "id",
+			sql:         `SELECT 'It\'s' AS note FROM users`,
+			expectedMsg: "primary_key 'id' is not in the SELECT list (columns: note)",
+		},
+		{
+			name:       "star selects are skipped",
+			primaryKey: "user_id",
+			sql:        "SELECT * FROM users",
+		},
+		{
+			name:       "unparsable queries are left to the sql rule",
+			primaryKey: "user_id",
+			sql:        "SELECT id FROM users WHERE name = 'bob",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			graph := resources.NewGraph()
+			graph.AddResource(resources.NewResource("users", sqlmodel.ResourceType, resources.ResourceData{
+				sqlmodel.SQLKey: tt.sql,
+			}, nil))
+
+			sourceDefinition := tt.sourceDefinition
+			if sourceDefinition == "" {
+				sourceDefinition = sqlmodel.SourceDefinitionSnowflake
+			}
+
+			spec := sqlmodel.SQLModelSpec{
+				ID:               "users",
+				PrimaryKey:       tt.primaryKey,
+				SourceDefinition: sourceDefinition,
+				File:             ptr("users.sql"),
+			}
+
+			results := validatePrimaryKeySelected("", "", nil, spec, graph)
+			if tt.expectedMsg == "" {
+				assert.Empty(t, results)
+				return
+			}
+			require.Len(t, results, 1)
+			assert.Equal(t, "/primary_key", results[0].Reference)
+			assert.Equal(t, tt.expectedMsg, results[0].Message)
+		})
+	}
+
+	t.Run("model missing from the graph", func(t *testing.T) {
+		t.Parallel()
+
+		spec := sqlmodel.SQLModelSpec{ID: "users", PrimaryKey: "id", SourceDefinition: "postgres"}
+		assert.Empty(t, validatePrimaryKeySelected("", "", nil, spec, resources.NewGraph()))
+	})
+}
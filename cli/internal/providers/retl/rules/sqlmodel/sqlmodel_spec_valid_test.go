@@ -216,6 +216,25 @@ func TestSQLModelSpecSyntaxValidRule_InvalidSpecs(t *testing.T) {
 			},
 			wantMessages: []string{"'sql' and 'file' cannot be specified together"},
 		},
+		{
+			name: "invalid parameters",
+			spec: sqlmodel.SQLModelSpec{
+				ID:               "model-1",
+				DisplayName:      "My Model",
+				AccountID:        "acc-1",
+				PrimaryKey:       "id",
+				SourceDefinition: "postgres",
+				SQL:              ptr("SELECT 1"),
+				Parameters: []sqlmodel.SQLParameter{
+					{Type: "string"},
+					{Name: "since", Type: "date"},
+				},
+			},
+			wantMessages: []string{
+				"'name' is required",
+				"'type' must be one of [string identifier number boolean]",
+			},
+		},
 		{
 			name: "all required fields missing",
 			spec: sqlmodel.SQLModelSpec{},
@@ -0,0 +1,147 @@
+package sqlmodel
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	prules "github.com/rudderlabs/rudder-iac/cli/internal/provider/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel/sqllint"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+)
+
+// validateSQLModelSQL renders the model's parameters into its query and parses
+// the result in its source definition's dialect. Syntax errors in file-backed
+// models are reported against the SQL file as file:line:column; inline queries
+// report the line within sql.
+var validateSQLModelSQL = func(
+	_ string,
+	_ string,
+	filePath string,
+	_ map[string]any,
+	spec sqlmodel.SQLModelSpec,
+) []rules.ValidationResult {
+	// An unknown source definition is reported by the spec syntax rule
+	dialect, ok := sqllint.DialectFor(string(spec.SourceDefinition))
+	if !ok {
+		return nil
+	}
+
+	var (
+		sql       string
+		reference string
+		location  func(sqllint.Position) string
+	)
+	switch {
+	case spec.SQL != nil && spec.File == nil:
+		sql, reference = *spec.SQL, "/sql"
+		location = func(pos sqllint.Position) string {
+			return fmt.Sprintf("sql line %d, column %d", pos.Line, pos.Column)
+		}
+	case spec.File != nil && spec.SQL == nil:
+		sqlFile := *spec.File
+		if !filepath.IsAbs(sqlFile) {
+			sqlFile = filepath.Join(filepath.Dir(specs.DocumentFile(filePath)), sqlFile)
+		}
+		content, err := os.ReadFile(sqlFile)
+		if err != nil {
+			return []rules.ValidationResult{{
+				Reference: "/file",
+				Message:   fmt.Sprintf("SQL file '%s' cannot be read", *spec.File),
+			}}
+		}
+		sql, reference = string(content), "/file"
+		location = func(pos sqllint.Position) string {
+			return fmt.Sprintf("%s:%d:%d", *spec.File, pos.Line, pos.Column)
+		}
+	default:
+		// Missing or conflicting sql and file are reported by the spec syntax rule
+		return nil
+	}
+
+	// Malformed parameter declarations are reported by the spec syntax rule
+	for _, p := range spec.Parameters {
+		if p.Name == "" || !knownParameterTypes[p.Type] {
+			return nil
+		}
+	}
+
+	sql, err := spec.RenderSQL(sql, placeholderValues(spec.Parameters))
+	var paramErr *sqlmodel.ParameterError
+	if errors.As(err, &paramErr) {
+		if paramErr.Index >= 0 {
+			reference = fmt.Sprintf("/parameters/%d", paramErr.Index)
+		}
+		return []rules.ValidationResult{{
+			Reference: reference,
+			Message:   paramErr.Message,
+		}}
+	}
+
+	_, err = sqllint.Parse(sql, dialect)
+	var syntaxErr *sqllint.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return nil
+	}
+
+	return []rules.ValidationResult{{
+		Reference: reference,
+		Message: fmt.Sprintf(
+			"%s: %s (%s dialect)",
+			location(syntaxErr.Pos),
+			syntaxErr.Message,
+			dialect.Name(),
+		),
+	}}
+}
+
+var knownParameterTypes = map[sqlmodel.ParameterType]bool{
+	sqlmodel.ParameterTypeString:     true,
+	sqlmodel.ParameterTypeIdentifier: true,
+	sqlmodel.ParameterTypeNumber:     true,
+	sqlmodel.ParameterTypeBoolean:    true,
+}
+
+// placeholderValues stands in for the variables that will give parameters
+// without a default their value at load time, so the query can still be
+// parsed. Each placeholder is a valid value of the parameter type.
+type placeholderValues []sqlmodel.SQLParameter
+
+func (p placeholderValues) Resolve(name string) (string, bool) {
+	for _, param := range p {
+		if param.Name != name || param.Default != nil {
+			continue
+		}
+		switch param.Type {
+		case sqlmodel.ParameterTypeIdentifier:
+			return param.Name, true
+		case sqlmodel.ParameterTypeNumber:
+			return "0", true
+		case sqlmodel.ParameterTypeBoolean:
+			return "true", true
+		default:
+			return "", true
+		}
+	}
+	return "", false
+}
+
+func NewSQLModelSQLValidRule() rules.Rule {
+	return prules.NewTypedRule(
+		"retl/sqlmodel/sql-valid",
+		rules.Error,
+		"retl sql model query must parse as a single SELECT in its source dialect",
+		rules.Examples{},
+		prules.NewPathAwarePatternValidator(
+			prules.LegacyVersionPatterns(sqlmodel.ResourceKind),
+			validateSQLModelSQL,
+		),
+		prules.NewPathAwarePatternValidator(
+			prules.V1VersionPatterns(sqlmodel.ResourceKind),
+			validateSQLModelSQL,
+		),
+	)
+}
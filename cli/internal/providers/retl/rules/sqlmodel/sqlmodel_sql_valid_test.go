@@ -0,0 +1,145 @@
+package sqlmodel
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	prules "github.com/rudderlabs/rudder-iac/cli/internal/provider/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLModelSQLValidRule_Metadata(t *testing.T) {
+	rule := NewSQLModelSQLValidRule()
+
+	expectedPatterns := append(
+		prules.LegacyVersionPatterns(sqlmodel.ResourceKind),
+		prules.V1VersionPatterns(sqlmodel.ResourceKind)...,
+	)
+
+	assert.Equal(t, "retl/sqlmodel/sql-valid", rule.ID())
+	assert.Equal(t, rules.Error, rule.Severity())
+	assert.Equal(t, expectedPatterns, rule.AppliesTo())
+}
+
+func TestSQLModelSQLValid(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "model.yaml")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "valid.sql"), []byte("SELECT id, email\nFROM users\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.sql"), []byte("SELECT id,\n  email\nFROM users\nWHERE name = 'bob\n"), 0o644))
+
+	spec := func(modify func(*sqlmodel.SQLModelSpec)) sqlmodel.SQLModelSpec {
+		s := sqlmodel.SQLModelSpec{
+			ID:               "users",
+			DisplayName:      "Users",
+			AccountID:        "acc-1",
+			PrimaryKey:       "id",
+			SourceDefinition: "postgres",
+		}
+		modify(&s)
+		return s
+	}
+
+	tests := []struct {
+		name         string
+		spec         sqlmodel.SQLModelSpec
+		expectedRef  string
+		expectedMsgs string
+	}{
+		{
+			name: "valid inline sql",
+			spec: spec(func(s *sqlmodel.SQLModelSpec) { s.SQL = ptr("SELECT id FROM users") }),
+		},
+		{
+			name: "valid sql file",
+			spec: spec(func(s *sqlmodel.SQLModelSpec) { s.File = ptr("valid.sql") }),
+		},
+		{
+			name:         "inline sql with a syntax error",
+			spec:         spec(func(s *sqlmodel.SQLModelSpec) { s.SQL = ptr("SELECT id\nFROM users;\nDROP TABLE users") }),
+			expectedRef:  "/sql",
+			expectedMsgs: "sql line 2, column 11: a SQL model must be a single query, found ';' followed by more SQL (postgres dialect)",
+		},
+		{
+			name:         "sql file with a syntax error",
+			spec:         spec(func(s *sqlmodel.SQLModelSpec) { s.File = ptr("broken.sql") }),
+			expectedRef:  "/file",
+			expectedMsgs: "broken.sql:4:14: unterminated string literal (postgres dialect)",
+		},
+		{
+			name:         "dialect specific quoting",
+			spec:         spec(func(s *sqlmodel.SQLModelSpec) { s.SQL = ptr("SELECT `id` FROM users") }),
+			expectedRef:  "/sql",
+			expectedMsgs: "sql line 1, column 8: unexpected character '`' (postgres dialect)",
+		},
+		{
+			name: "parameters are rendered before parsing",
+			spec: spec(func(s *sqlmodel.SQLModelSpec) {
+				s.SQL = ptr("SELECT id FROM {{ params.schema }}.users WHERE age > {{ params.min_age }}")
+				s.Parameters = []sqlmodel.SQLParameter{
+					{Name: "schema", Type: sqlmodel.ParameterTypeIdentifier},
+					{Name: "min_age", Type: sqlmodel.ParameterTypeNumber, Default: 18},
+				}
+			}),
+		},
+		{
+			name: "undeclared parameter",
+			spec: spec(func(s *sqlmodel.SQLModelSpec) {
+				s.SQL = ptr("SELECT id FROM {{ params.schema }}.users")
+			}),
+			expectedRef:  "/sql",
+			expectedMsgs: "'{{ params.schema }}' references parameter 'schema', which is not declared in parameters",
+		},
+		{
+			name: "parameter default not matching its type",
+			spec: spec(func(s *sqlmodel.SQLModelSpec) {
+				s.SQL = ptr("SELECT id FROM users WHERE active = {{ params.active }}")
+				s.Parameters = []sqlmodel.SQLParameter{
+					{Name: "active", Type: sqlmodel.ParameterTypeBoolean, Default: "yes"},
+				}
+			}),
+			expectedRef:  "/parameters/0",
+			expectedMsgs: "default of parameter 'active' is not a valid boolean",
+		},
+		{
+			name: "parameter with an unknown type is left to the spec rule",
+			spec: spec(func(s *sqlmodel.SQLModelSpec) {
+				s.SQL = ptr("SELECT {{ params.x }}")
+				s.Parameters = []sqlmodel.SQLParameter{{Name: "x", Type: "date"}}
+			}),
+		},
+		{
+			name:         "unreadable sql file",
+			spec:         spec(func(s *sqlmodel.SQLModelSpec) { s.File = ptr("missing.sql") }),
+			expectedRef:  "/file",
+			expectedMsgs: "SQL file 'missing.sql' cannot be read",
+		},
+		{
+			name: "unknown source definition is left to the spec rule",
+			spec: spec(func(s *sqlmodel.SQLModelSpec) {
+				s.SourceDefinition = "oracle"
+				s.SQL = ptr("DELETE FROM users")
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			results := validateSQLModelSQL("", "", specPath, nil, tt.spec)
+			if tt.expectedRef == "" {
+				assert.Empty(t, results)
+				return
+			}
+			require.Len(t, results, 1)
+			assert.Equal(t, tt.expectedRef, results[0].Reference)
+			assert.Equal(t, tt.expectedMsgs, results[0].Message)
+		})
+	}
+}
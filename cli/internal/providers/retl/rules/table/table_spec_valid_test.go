@@ -0,0 +1,93 @@
+package table
+
+import (
+	"testing"
+
+	prules "github.com/rudderlabs/rudder-iac/cli/internal/provider/rules"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/table"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validTableSpec() table.TableSpec {
+	return table.TableSpec{
+		ID:               "users",
+		DisplayName:      "Users",
+		AccountID:        "acc-1",
+		SourceDefinition: "snowflake",
+		Schema:           "analytics",
+		Table:            "users",
+		PrimaryKey:       "id",
+	}
+}
+
+func TestTableSpecSyntaxValidRule_Metadata(t *testing.T) {
+	rule := NewTableSpecSyntaxValidRule()
+
+	assert.Equal(t, "retl/table/spec-syntax-valid", rule.ID())
+	assert.Equal(t, rules.Error, rule.Severity())
+	assert.Equal(t, "retl warehouse table spec syntax must be valid", rule.Description())
+	assert.Equal(t, prules.V1VersionPatterns(table.ResourceKind), rule.AppliesTo())
+}
+
+func TestTableSpecSyntaxValidRule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		modify       func(*table.TableSpec)
+		expectedRefs []string
+		expectedMsgs []string
+	}{
+		{
+			name:   "valid spec",
+			modify: func(*table.TableSpec) {},
+		},
+		{
+			name: "missing schema and table",
+			modify: func(s *table.TableSpec) {
+				s.Schema = ""
+				s.Table = ""
+			},
+			expectedRefs: []string{"/schema", "/table"},
+			expectedMsgs: []string{"'schema' is required", "'table' is required"},
+		},
+		{
+			name: "missing primary key",
+			modify: func(s *table.TableSpec) {
+				s.PrimaryKey = ""
+			},
+			expectedRefs: []string{"/primary_key"},
+			expectedMsgs: []string{"'primary_key' is required"},
+		},
+		{
+			name: "unsupported source definition",
+			modify: func(s *table.TableSpec) {
+				s.SourceDefinition = "s3"
+			},
+			expectedRefs: []string{"/source_definition"},
+			expectedMsgs: []string{"'source_definition' must be one of [postgres redshift snowflake bigquery mysql databricks trino]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := validTableSpec()
+			tt.modify(&spec)
+
+			results := validateTableSpec("", "", nil, spec)
+			require.Len(t, results, len(tt.expectedRefs))
+
+			var refs, msgs []string
+			for _, r := range results {
+				refs = append(refs, r.Reference)
+				msgs = append(msgs, r.Message)
+			}
+			assert.ElementsMatch(t, tt.expectedRefs, refs)
+			assert.ElementsMatch(t, tt.expectedMsgs, msgs)
+		})
+	}
+}
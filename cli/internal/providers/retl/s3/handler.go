@@ -0,0 +1,269 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	retlClient "github.com/rudderlabs/rudder-iac/api/client/retl"
+	"github.com/rudderlabs/rudder-iac/cli/internal/namer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/importmanifest"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/writer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/provider/handler"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resolver"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+// S3Handler is the BaseHandler instantiation for S3 sources.
+type S3Handler = handler.BaseHandler[
+	S3Spec,
+	S3Resource,
+	S3State,
+	RemoteS3Source,
+]
+
+// HandlerMetadata is the static metadata describing the S3 source handler for
+// the BaseHandler framework.
+var HandlerMetadata = handler.HandlerMetadata{
+	ResourceType:     ResourceType,
+	SpecKind:         ResourceKind,
+	SpecMetadataName: MetadataName,
+}
+
+// tableSourceTypeFilter is the sourceType query value passed to
+// ListRetlSources. S3 sources are listed as tables and told apart from
+// warehouse tables by their config type.
+const tableSourceTypeFilter = string(retlClient.TableSourceType)
+
+// HandlerImpl owns S3 source CRUD against the RETL API client.
+type HandlerImpl struct {
+	client    retlClient.RETLStore
+	importDir string
+}
+
+// NewHandler builds a *S3Handler wired to the given client. Exported specs are
+// written under importDir.
+func NewHandler(client retlClient.RETLStore, importDir string) *S3Handler {
+	return handler.NewHandler(&HandlerImpl{
+		client:    client,
+		importDir: filepath.Join(importDir, ImportPath),
+	})
+}
+
+func (h *HandlerImpl) Metadata() handler.HandlerMetadata {
+	return HandlerMetadata
+}
+
+func (h *HandlerImpl) NewSpec() *S3Spec {
+	return &S3Spec{}
+}
+
+// ExtractResourcesFromSpec turns a decoded spec into its S3Resource. enabled
+// defaults to true when omitted.
+func (h *HandlerImpl) ExtractResourcesFromSpec(_ string, spec *S3Spec) (map[string]*S3Resource, error) {
+	enabled := true
+	if spec.Enabled != nil {
+		enabled = *spec.Enabled
+	}
+
+	return map[string]*S3Resource{
+		spec.ID: {
+			ID:          spec.ID,
+			DisplayName: spec.DisplayName,
+			AccountID:   spec.AccountID,
+			Bucket:      spec.Bucket,
+			Prefix:      spec.Prefix,
+			Enabled:     enabled,
+		},
+	}, nil
+}
+
+// Create provisions the S3 source remotely, claiming the spec id as its
+// external id in the same call.
+func (h *HandlerImpl) Create(ctx context.Context, data *S3Resource) (*S3State, error) {
+	created, err := h.client.CreateRetlSource(ctx, &retlClient.RETLSourceCreateRequest{
+		Name:                 data.DisplayName,
+		Config:               toRETLS3TableConfig(data),
+		SourceType:           retlClient.TableSourceType,
+		SourceDefinitionName: SourceDefinitionName,
+		AccountID:            data.AccountID,
+		Enabled:              data.Enabled,
+		ExternalID:           data.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating RETL source: %w", err)
+	}
+	return &S3State{ID: created.ID}, nil
+}
+
+// Update pushes the spec to the remote source.
+func (h *HandlerImpl) Update(ctx context.Context, newData *S3Resource, _ *S3Resource, oldState *S3State) (*S3State, error) {
+	return h.update(ctx, oldState.ID, newData)
+}
+
+func (h *HandlerImpl) update(ctx context.Context, sourceID string, data *S3Resource) (*S3State, error) {
+	if _, err := h.client.UpdateRetlSource(ctx, sourceID, &retlClient.RETLSourceUpdateRequest{
+		Name:      data.DisplayName,
+		Config:    toRETLS3TableConfig(data),
+		IsEnabled: data.Enabled,
+		AccountID: data.AccountID,
+	}); err != nil {
+		return nil, fmt.Errorf("updating RETL source: %w", err)
+	}
+	return &S3State{ID: sourceID}, nil
+}
+
+// Delete removes the remote source.
+func (h *HandlerImpl) Delete(ctx context.Context, _ string, _ *S3Resource, oldState *S3State) error {
+	if err := h.client.DeleteRetlSource(ctx, oldState.ID); err != nil {
+		return fmt.Errorf("deleting RETL source: %w", err)
+	}
+	return nil
+}
+
+// Import adopts an existing remote S3 source, updating it only when it differs
+// from the spec.
+func (h *HandlerImpl) Import(ctx context.Context, data *S3Resource, remoteId string) (*S3State, error) {
+	source, err := h.client.GetRetlSource(ctx, remoteId)
+	if err != nil {
+		return nil, fmt.Errorf("getting RETL source: %w", err)
+	}
+
+	existing, err := toResource(data.ID, *source)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.client.SetExternalId(ctx, remoteId, data.ID); err != nil {
+		return nil, fmt.Errorf("setting external ID for RETL source: %w", err)
+	}
+
+	if *existing == *data {
+		return &S3State{ID: remoteId}, nil
+	}
+
+	state, err := h.update(ctx, remoteId, data)
+	if err != nil {
+		return nil, fmt.Errorf("importing RETL source: %w", err)
+	}
+	return state, nil
+}
+
+// MapRemoteToState converts a managed remote S3 source into the spec-side
+// resource and the persisted state.
+func (h *HandlerImpl) MapRemoteToState(remote *RemoteS3Source, _ handler.URNResolver) (*S3Resource, *S3State, error) {
+	if remote.ExternalID == "" {
+		return nil, nil, nil
+	}
+
+	resource, err := toResource(remote.ExternalID, remote.RETLSource)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resource, &S3State{ID: remote.ID}, nil
+}
+
+// LoadRemoteResources returns the managed S3 sources (ExternalID set).
+func (h *HandlerImpl) LoadRemoteResources(ctx context.Context) ([]*RemoteS3Source, error) {
+	return h.listS3Sources(ctx, true)
+}
+
+// LoadImportableResources returns the unmanaged S3 sources (no ExternalID).
+func (h *HandlerImpl) LoadImportableResources(ctx context.Context) ([]*RemoteS3Source, error) {
+	return h.listS3Sources(ctx, false)
+}
+
+// FormatForExport converts unmanaged remote S3 sources into importable YAML
+// specs, one per source.
+func (h *HandlerImpl) FormatForExport(
+	collection map[string]*RemoteS3Source,
+	_ namer.Namer,
+	_ resolver.ReferenceResolver,
+) ([]writer.FormattableEntity, []importmanifest.ImportEntry, error) {
+	var (
+		entities []writer.FormattableEntity
+		entries  []importmanifest.ImportEntry
+	)
+
+	for externalID, remote := range collection {
+		resource, err := toResource(externalID, remote.RETLSource)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		urn := resources.URN(externalID, ResourceType)
+		entries = append(entries, importmanifest.ImportEntry{
+			WorkspaceID: remote.WorkspaceID,
+			URN:         urn,
+			RemoteID:    remote.ID,
+		})
+
+		spec, err := specs.ToImportSpec(ResourceKind, externalID, specs.WorkspaceImportMetadata{
+			WorkspaceID: remote.WorkspaceID,
+			Resources:   []specs.ImportIds{{URN: urn, RemoteID: remote.ID}},
+		}, toSpecMap(resource))
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating spec for RETL source %s: %w", remote.ID, err)
+		}
+
+		entities = append(entities, writer.FormattableEntity{
+			Content:      spec,
+			RelativePath: filepath.Join(h.importDir, fmt.Sprintf("%s.yaml", externalID)),
+		})
+	}
+
+	return entities, entries, nil
+}
+
+// listS3Sources lists the S3 sources matching hasExternalID, leaving out the
+// warehouse table sources the same listing returns.
+func (h *HandlerImpl) listS3Sources(ctx context.Context, hasExternalID bool) ([]*RemoteS3Source, error) {
+	sources, err := h.client.ListRetlSources(ctx, retlClient.WithSourceType(tableSourceTypeFilter), retlClient.WithHasExternalId(&hasExternalID))
+	if err != nil {
+		return nil, fmt.Errorf("listing RETL sources: %w", err)
+	}
+
+	var s3Sources []*RemoteS3Source
+	for _, source := range sources.Data {
+		if _, ok := source.Config.(retlClient.RETLS3TableConfig); ok {
+			s3Sources = append(s3Sources, &RemoteS3Source{RETLSource: source})
+		}
+	}
+	return s3Sources, nil
+}
+
+// toResource builds the comparable resource of a remote S3 source.
+func toResource(id string, source retlClient.RETLSource) (*S3Resource, error) {
+	cfg, err := retlClient.DecodeConfig[retlClient.RETLS3TableConfig](source.Config)
+	if err != nil {
+		return nil, fmt.Errorf("decoding s3 config for source %s: %w", source.ID, err)
+	}
+	return &S3Resource{
+		ID:          id,
+		DisplayName: source.Name,
+		AccountID:   source.AccountID,
+		Bucket:      cfg.BucketName,
+		Prefix:      cfg.ObjectPrefix,
+		Enabled:     source.IsEnabled,
+	}, nil
+}
+
+// toSpecMap builds the "spec" section of an importable S3 source's YAML.
+func toSpecMap(resource *S3Resource) map[string]any {
+	return map[string]any{
+		IDKey:          resource.ID,
+		DisplayNameKey: resource.DisplayName,
+		AccountIDKey:   resource.AccountID,
+		BucketKey:      resource.Bucket,
+		PrefixKey:      resource.Prefix,
+		EnabledKey:     resource.Enabled,
+	}
+}
+
+func toRETLS3TableConfig(data *S3Resource) retlClient.RETLS3TableConfig {
+	return retlClient.RETLS3TableConfig{
+		BucketName:   data.Bucket,
+		ObjectPrefix: data.Prefix,
+	}
+}
@@ -0,0 +1,269 @@
+package s3_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	retlClient "github.com/rudderlabs/rudder-iac/api/client/retl"
+	"github.com/rudderlabs/rudder-iac/cli/internal/namer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/s3"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+// mockStore implements the source calls the s3 handler makes; any other
+// RETLStore call panics on the nil embedded interface.
+type mockStore struct {
+	retlClient.RETLStore
+
+	sources     []retlClient.RETLSource
+	listOpts    []retlClient.ListRetlSourcesOptions
+	createReqs  []*retlClient.RETLSourceCreateRequest
+	updateReqs  map[string]*retlClient.RETLSourceUpdateRequest
+	externalIDs map[string]string
+}
+
+func newMockStore(sources ...retlClient.RETLSource) *mockStore {
+	return &mockStore{
+		sources:     sources,
+		updateReqs:  map[string]*retlClient.RETLSourceUpdateRequest{},
+		externalIDs: map[string]string{},
+	}
+}
+
+func (m *mockStore) ListRetlSources(_ context.Context, opts ...retlClient.ListRetlSourcesOption) (*retlClient.RETLSources, error) {
+	resolved := retlClient.ListRetlSourcesOptions{}
+	for _, opt := range opts {
+		opt(&resolved)
+	}
+	m.listOpts = append(m.listOpts, resolved)
+	return &retlClient.RETLSources{Data: m.sources}, nil
+}
+
+func (m *mockStore) GetRetlSource(_ context.Context, id string) (*retlClient.RETLSource, error) {
+	for _, source := range m.sources {
+		if source.ID == id {
+			return &source, nil
+		}
+	}
+	return nil, assert.AnError
+}
+
+func (m *mockStore) CreateRetlSource(_ context.Context, req *retlClient.RETLSourceCreateRequest) (*retlClient.RETLSource, error) {
+	m.createReqs = append(m.createReqs, req)
+	return &retlClient.RETLSource{
+		ID:                   "remote-1",
+		Name:                 req.Name,
+		Config:               req.Config,
+		SourceType:           req.SourceType,
+		SourceDefinitionName: req.SourceDefinitionName,
+		AccountID:            req.AccountID,
+		IsEnabled:            req.Enabled,
+		ExternalID:           req.ExternalID,
+	}, nil
+}
+
+func (m *mockStore) UpdateRetlSource(_ context.Context, id string, req *retlClient.RETLSourceUpdateRequest) (*retlClient.RETLSource, error) {
+	m.updateReqs[id] = req
+	return &retlClient.RETLSource{
+		ID:                   id,
+		Name:                 req.Name,
+		Config:               req.Config,
+		SourceType:           retlClient.TableSourceType,
+		SourceDefinitionName: "s3",
+		AccountID:            req.AccountID,
+		IsEnabled:            req.IsEnabled,
+	}, nil
+}
+
+func (m *mockStore) SetExternalId(_ context.Context, id, externalID string) error {
+	m.externalIDs[id] = externalID
+	return nil
+}
+
+func warehouseTable(id, externalID string) retlClient.RETLSource {
+	return retlClient.RETLSource{
+		ID:                   id,
+		Name:                 "Users",
+		SourceType:           retlClient.TableSourceType,
+		SourceDefinitionName: "snowflake",
+		AccountID:            "acc-1",
+		WorkspaceID:          "ws-1",
+		IsEnabled:            true,
+		ExternalID:           externalID,
+		Config: retlClient.RETLTableConfig{
+			Schema:     "analytics",
+			Table:      "users",
+			PrimaryKey: "id",
+		},
+	}
+}
+
+func s3Source(id, externalID string) retlClient.RETLSource {
+	return retlClient.RETLSource{
+		ID:                   id,
+		Name:                 "Exports",
+		SourceType:           retlClient.TableSourceType,
+		SourceDefinitionName: "s3",
+		AccountID:            "acc-2",
+		WorkspaceID:          "ws-1",
+		IsEnabled:            true,
+		ExternalID:           externalID,
+		Config: retlClient.RETLS3TableConfig{
+			BucketName:   "acme-exports",
+			ObjectPrefix: "daily/",
+		},
+	}
+}
+
+func s3Spec(fields map[string]any) *specs.Spec {
+	spec := map[string]any{
+		"id":           "exports",
+		"display_name": "Exports",
+		"account_id":   "acc-2",
+		"bucket":       "acme-exports",
+		"prefix":       "daily/",
+	}
+	for k, v := range fields {
+		spec[k] = v
+	}
+	return &specs.Spec{Version: specs.SpecVersionV1, Kind: s3.ResourceKind, Spec: spec}
+}
+
+func s3Resource() *s3.S3Resource {
+	return &s3.S3Resource{
+		ID:          "exports",
+		DisplayName: "Exports",
+		AccountID:   "acc-2",
+		Bucket:      "acme-exports",
+		Prefix:      "daily/",
+		Enabled:     true,
+	}
+}
+
+func TestS3Handler(t *testing.T) {
+	t.Run("LoadSpec", func(t *testing.T) {
+		t.Parallel()
+
+		h := s3.NewHandler(newMockStore(), "retl")
+		require.NoError(t, h.LoadSpec("exports.yaml", s3Spec(map[string]any{"enabled": false})))
+
+		res, err := h.Resources()
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+
+		want := s3Resource()
+		want.Enabled = false
+		assert.Equal(t, want, res[0].RawData())
+
+		err = h.LoadSpec("copy.yaml", s3Spec(nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "a resource of type 'retl-source-s3' with id 'exports' already exists")
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore()
+		h := s3.NewHandler(store, "retl")
+
+		out, err := h.Create(context.Background(), s3Resource())
+		require.NoError(t, err)
+
+		require.Len(t, store.createReqs, 1)
+		req := store.createReqs[0]
+		assert.Equal(t, retlClient.TableSourceType, req.SourceType)
+		assert.Equal(t, s3.SourceDefinitionName, req.SourceDefinitionName)
+		assert.Equal(t, "exports", req.ExternalID)
+		assert.Equal(t, retlClient.RETLS3TableConfig{BucketName: "acme-exports", ObjectPrefix: "daily/"}, req.Config)
+
+		assert.Equal(t, &s3.S3State{ID: "remote-1"}, out)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore()
+		h := s3.NewHandler(store, "retl")
+
+		data := s3Resource()
+		data.Prefix = "hourly/"
+
+		_, err := h.Update(context.Background(), data, s3Resource(), &s3.S3State{ID: "remote-1"})
+		require.NoError(t, err)
+		require.Contains(t, store.updateReqs, "remote-1")
+		assert.Equal(t, "hourly/", store.updateReqs["remote-1"].Config.(retlClient.RETLS3TableConfig).ObjectPrefix)
+	})
+
+	t.Run("LoadResourcesFromRemote and MapRemoteToState skip warehouse tables", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore(s3Source("remote-1", "exports"), warehouseTable("remote-2", "users"))
+		h := s3.NewHandler(store, "retl")
+
+		collection, err := h.LoadResourcesFromRemote(context.Background())
+		require.NoError(t, err)
+		require.Len(t, collection.GetAll(s3.ResourceType), 1)
+
+		st, err := h.MapRemoteToState(collection)
+		require.NoError(t, err)
+
+		rs := st.GetResource(resources.URN("exports", s3.ResourceType))
+		require.NotNil(t, rs)
+		assert.Equal(t, s3Resource(), rs.InputRaw)
+		assert.Equal(t, &s3.S3State{ID: "remote-1"}, rs.OutputRaw)
+	})
+
+	t.Run("LoadImportable and FormatForExport", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore(s3Source("remote-1", ""), warehouseTable("remote-2", ""))
+		h := s3.NewHandler(store, "retl")
+
+		collection, err := h.LoadImportable(context.Background(), namer.NewExternalIdNamer(namer.StrategyKebabCase))
+		require.NoError(t, err)
+		require.Len(t, collection.GetAll(s3.ResourceType), 1)
+
+		importable, ok := collection.GetByID(s3.ResourceType, "remote-1")
+		require.True(t, ok)
+		assert.Equal(t, "#retl-source-s3:exports", importable.Reference)
+
+		entities, entries, err := h.FormatForExport(collection, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, entities, 1)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "retl/s3/exports.yaml", entities[0].RelativePath)
+
+		spec := entities[0].Content.(*specs.Spec)
+		assert.Equal(t, map[string]any{
+			"id":           "exports",
+			"display_name": "Exports",
+			"account_id":   "acc-2",
+			"bucket":       "acme-exports",
+			"prefix":       "daily/",
+			"enabled":      true,
+		}, spec.Spec)
+	})
+
+	t.Run("Import updates diverging sources", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore(s3Source("remote-1", ""))
+		h := s3.NewHandler(store, "retl")
+
+		data := s3Resource()
+		_, err := h.Import(context.Background(), data, "remote-1")
+		require.NoError(t, err)
+		assert.Equal(t, "exports", store.externalIDs["remote-1"])
+		assert.Empty(t, store.updateReqs)
+
+		data.Bucket = "acme-archive"
+		_, err = h.Import(context.Background(), data, "remote-1")
+		require.NoError(t, err)
+		require.Contains(t, store.updateReqs, "remote-1")
+		assert.Equal(t, "acme-archive", store.updateReqs["remote-1"].Config.(retlClient.RETLS3TableConfig).BucketName)
+	})
+}
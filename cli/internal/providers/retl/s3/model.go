@@ -0,0 +1,75 @@
+package s3
+
+import (
+	retlClient "github.com/rudderlabs/rudder-iac/api/client/retl"
+	"github.com/rudderlabs/rudder-iac/cli/internal/provider/handler"
+)
+
+// ResourceType is the type identifier for S3 source resources
+const (
+	ResourceType = "retl-source-s3"
+	ResourceKind = "retl-source-s3"
+	MetadataName = "retl-source-s3"
+	ImportPath   = "s3"
+
+	// SourceDefinitionName is the source definition every S3 source is
+	// created with.
+	SourceDefinitionName = "s3"
+
+	IDKey          = "id"
+	DisplayNameKey = "display_name"
+	AccountIDKey   = "account_id"
+	BucketKey      = "bucket"
+	PrefixKey      = "prefix"
+	EnabledKey     = "enabled"
+)
+
+// S3Spec represents the YAML specification for a RETL source reading objects
+// from an S3 bucket. JSON tags enable the typed rule engine's
+// json.Marshal/Unmarshal round-trip; validate tags drive go-playground/validator
+// checks.
+type S3Spec struct {
+	ID          string `json:"id"           mapstructure:"id"           validate:"required"`
+	DisplayName string `json:"display_name" mapstructure:"display_name" validate:"required"`
+	AccountID   string `json:"account_id"   mapstructure:"account_id"   validate:"required"`
+	Bucket      string `json:"bucket"       mapstructure:"bucket"       validate:"required"`
+	Prefix      string `json:"prefix"       mapstructure:"prefix"`
+	Enabled     *bool  `json:"enabled"      mapstructure:"enabled"`
+}
+
+// S3Resource is the resolved in-memory representation of an S3 source
+// compared by the differ.
+type S3Resource struct {
+	ID          string
+	DisplayName string
+	AccountID   string
+	Bucket      string
+	Prefix      string
+	Enabled     bool
+}
+
+// SourceName returns the display name of the source.
+func (r *S3Resource) SourceName() string {
+	return r.DisplayName
+}
+
+// S3State is the persisted apply-cycle state: the remote source ID.
+type S3State struct {
+	ID string
+}
+
+// RemoteS3Source wraps a RETL source read from an S3 bucket.
+type RemoteS3Source struct {
+	retlClient.RETLSource
+}
+
+// Metadata exposes the identifying fields BaseHandler uses to key the remote
+// collection and to name importable resources.
+func (r RemoteS3Source) Metadata() handler.RemoteResourceMetadata {
+	return handler.RemoteResourceMetadata{
+		ID:          r.ID,
+		ExternalID:  r.ExternalID,
+		WorkspaceID: r.WorkspaceID,
+		Name:        r.Name,
+	}
+}
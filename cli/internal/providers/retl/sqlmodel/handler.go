@@ -16,6 +16,7 @@ import (
 	"github.com/rudderlabs/rudder-iac/cli/internal/resolver"
 	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
 	"github.com/rudderlabs/rudder-iac/cli/internal/resources/state"
+	"github.com/rudderlabs/rudder-iac/cli/internal/varsubst"
 )
 
 // modelSourceTypeFilter is the sourceType query value passed to
@@ -27,6 +28,10 @@ type Handler struct {
 	client    retlClient.RETLStore
 	resources map[string]*SQLModelResource
 	importDir string
+
+	// vars overrides parameter defaults when rendering SQL. It is nil unless
+	// variable substitution is enabled.
+	vars varsubst.Resolver
 }
 
 // NewHandler creates a new SQL Model resource handler
@@ -38,6 +43,12 @@ func NewHandler(client retlClient.RETLStore, importDir string) *Handler {
 	}
 }
 
+// SetVariableResolver sets the resolver whose variables override the defaults
+// of SQL model parameters.
+func (h *Handler) SetVariableResolver(r varsubst.Resolver) {
+	h.vars = r
+}
+
 func (h *Handler) ParseSpec(_ string, s *specs.Spec) (*specs.ParsedSpec, error) {
 	id, ok := s.Spec["id"].(string)
 	if !ok {
@@ -99,6 +110,11 @@ func (h *Handler) LoadSpec(path string, s *specs.Spec) error {
 		sqlStr = string(sqlContent)
 	}
 
+	sqlStr, err = spec.RenderSQL(sqlStr, h.vars)
+	if err != nil {
+		return fmt.Errorf("rendering SQL of sql model %s: %w", spec.ID, err)
+	}
+
 	// Default Enabled to true if not specified
 	enabled := true
 	if spec.Enabled != nil {
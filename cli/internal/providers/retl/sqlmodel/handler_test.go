@@ -75,6 +75,14 @@ func createTestSpecMap(fields map[string]interface{}) *specs.Spec {
 	}
 }
 
+// mapResolver resolves variables from a map.
+type mapResolver map[string]string
+
+func (m mapResolver) Resolve(name string) (string, bool) {
+	v, ok := m[name]
+	return v, ok
+}
+
 // mockListRetlSources creates a mock list function that returns the given sources
 func mockListRetlSources(sources ...retlClient.RETLSource) func(ctx context.Context, opts ...retlClient.ListRetlSourcesOption) (*retlClient.RETLSources, error) {
 	return func(ctx context.Context, opts ...retlClient.ListRetlSourcesOption) (*retlClient.RETLSources, error) {
@@ -787,6 +795,72 @@ func TestSQLModelHandler(t *testing.T) {
 		assert.Contains(t, err.Error(), "decoding SQL model spec")
 	})
 
+	t.Run("LoadSpec renders parameters", func(t *testing.T) {
+		t.Parallel()
+
+		tmpDir := t.TempDir()
+		require.NoError(t, os.WriteFile(
+			filepath.Join(tmpDir, "orders.sql"),
+			[]byte("SELECT id FROM {{ params.schema }}.orders\nWHERE created_at > now() - interval '1 day' * {{ params.days }}"),
+			0644,
+		))
+
+		newSpec := func() *specs.Spec {
+			return createTestSpecMap(map[string]interface{}{
+				"id":                "orders",
+				"display_name":      "Orders",
+				"file":              "orders.sql",
+				"account_id":        "acc123",
+				"primary_key":       "id",
+				"source_definition": "postgres",
+				"parameters": []interface{}{
+					map[string]interface{}{"name": "schema", "type": "identifier", "default": "analytics"},
+					map[string]interface{}{"name": "days", "type": "number", "default": 30},
+				},
+			})
+		}
+		renderedSQL := func(t *testing.T, handler *sqlmodel.Handler) string {
+			t.Helper()
+			res, err := handler.GetResources()
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			return res[0].Data()[sqlmodel.SQLKey].(string)
+		}
+
+		t.Run("from defaults", func(t *testing.T) {
+			t.Parallel()
+
+			handler := sqlmodel.NewHandler(&mockRETLClient{}, "retl")
+			require.NoError(t, handler.LoadSpec(filepath.Join(tmpDir, "orders.yaml"), newSpec()))
+			assert.Equal(t,
+				"SELECT id FROM analytics.orders\nWHERE created_at > now() - interval '1 day' * 30",
+				renderedSQL(t, handler),
+			)
+		})
+
+		t.Run("overridden by variables", func(t *testing.T) {
+			t.Parallel()
+
+			handler := sqlmodel.NewHandler(&mockRETLClient{}, "retl")
+			handler.SetVariableResolver(mapResolver{"schema": "analytics_staging"})
+			require.NoError(t, handler.LoadSpec(filepath.Join(tmpDir, "orders.yaml"), newSpec()))
+			assert.Equal(t,
+				"SELECT id FROM analytics_staging.orders\nWHERE created_at > now() - interval '1 day' * 30",
+				renderedSQL(t, handler),
+			)
+		})
+
+		t.Run("invalid variable value", func(t *testing.T) {
+			t.Parallel()
+
+			handler := sqlmodel.NewHandler(&mockRETLClient{}, "retl")
+			handler.SetVariableResolver(mapResolver{"days": "thirty"})
+			err := handler.LoadSpec(filepath.Join(tmpDir, "orders.yaml"), newSpec())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "rendering SQL of sql model orders: value of variable 'days' is not a valid number")
+		})
+	})
+
 	t.Run("GetResources", func(t *testing.T) {
 		t.Parallel()
 
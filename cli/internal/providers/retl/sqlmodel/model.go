@@ -73,6 +73,7 @@ type SQLModelSpec struct {
 	PrimaryKey       string           `json:"primary_key"       mapstructure:"primary_key"       validate:"required"`
 	SourceDefinition SourceDefinition `json:"source_definition" mapstructure:"source_definition" validate:"required,oneof=postgres redshift snowflake bigquery mysql databricks trino"`
 	Enabled          *bool            `json:"enabled"           mapstructure:"enabled"`
+	Parameters       []SQLParameter   `json:"parameters"        mapstructure:"parameters"        validate:"omitempty,dive"`
 }
 
 // SQLModelResource represents a processed SQL Model resource ready for API operations
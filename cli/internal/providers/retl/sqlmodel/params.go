@@ -0,0 +1,166 @@
+package sqlmodel
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel/sqllint"
+	"github.com/rudderlabs/rudder-iac/cli/internal/varsubst"
+)
+
+// ParameterType is the type of a SQL model parameter. It decides which values
+// the parameter accepts and how they are written into the SQL.
+type ParameterType string
+
+const (
+	// ParameterTypeString values are written as a string literal of the
+	// model's dialect.
+	ParameterTypeString ParameterType = "string"
+	// ParameterTypeIdentifier values are written as is and must be a plain,
+	// optionally dotted, identifier such as a schema or table name.
+	ParameterTypeIdentifier ParameterType = "identifier"
+	// ParameterTypeNumber values are written as is and must parse as a number.
+	ParameterTypeNumber ParameterType = "number"
+	// ParameterTypeBoolean values are written as TRUE or FALSE.
+	ParameterTypeBoolean ParameterType = "boolean"
+)
+
+// SQLParameter declares a value that is rendered into the model's SQL wherever
+// it is referenced as {{ params.NAME }}.
+type SQLParameter struct {
+	Name    string        `json:"name"    mapstructure:"name"    validate:"required"`
+	Type    ParameterType `json:"type"    mapstructure:"type"    validate:"required,oneof=string identifier number boolean"`
+	Default any           `json:"default" mapstructure:"default"`
+}
+
+// paramRegex matches a {{ params.NAME }} reference. Group 1 captures the name,
+// which is validated in code so that a malformed reference is reported rather
+// than sent to the warehouse. Without a leading dot the token is not claimed
+// by variable substitution, so SQL written inline in a spec reaches rendering
+// untouched.
+var paramRegex = regexp.MustCompile(`\{\{\s*params\.([^}\s]*)\s*\}\}`)
+
+// identifierRegex and numberRegex bound what identifier and number values may
+// contain, since both are written into the SQL unquoted.
+var (
+	identifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*(\.[A-Za-z_][A-Za-z0-9_$]*)*$`)
+	numberRegex     = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+)
+
+// ParameterError reports a problem with a declared parameter or with a
+// {{ params.NAME }} reference in the SQL.
+type ParameterError struct {
+	// Index is the position of the offending parameter in parameters, or -1
+	// when the problem is a reference in the SQL.
+	Index   int
+	Message string
+}
+
+func (e *ParameterError) Error() string {
+	return e.Message
+}
+
+// RenderSQL replaces the {{ params.NAME }} references in sql with the values
+// of the spec's parameters. A parameter takes its value from vars when vars
+// knows its name, so env vars and --var-file can override it per environment,
+// and from its default otherwise. vars may be nil.
+//
+// Resolved values are never included in errors, as they may come from secrets.
+func (s SQLModelSpec) RenderSQL(sql string, vars varsubst.Resolver) (string, error) {
+	if len(s.Parameters) == 0 && !paramRegex.MatchString(sql) {
+		return sql, nil
+	}
+
+	dialect, _ := sqllint.DialectFor(string(s.SourceDefinition))
+
+	indexes := make(map[string]int, len(s.Parameters))
+	for i, p := range s.Parameters {
+		if !varsubst.IsValidVariableName(p.Name) {
+			return "", &ParameterError{Index: i, Message: fmt.Sprintf(
+				"parameter name '%s' must start with a letter or underscore and contain only letters, digits and underscores", p.Name)}
+		}
+		if _, ok := indexes[p.Name]; ok {
+			return "", &ParameterError{Index: i, Message: fmt.Sprintf("parameter '%s' is declared more than once", p.Name)}
+		}
+		indexes[p.Name] = i
+
+		if p.Default != nil {
+			if _, err := p.render(fmt.Sprint(p.Default), dialect); err != nil {
+				return "", &ParameterError{Index: i, Message: fmt.Sprintf("default of parameter '%s' %s", p.Name, err)}
+			}
+		}
+	}
+
+	var renderErr error
+	rendered := paramRegex.ReplaceAllStringFunc(sql, func(ref string) string {
+		if renderErr != nil {
+			return ref
+		}
+
+		name := paramRegex.FindStringSubmatch(ref)[1]
+		i, ok := indexes[name]
+		if !ok {
+			renderErr = &ParameterError{Index: -1, Message: fmt.Sprintf("'%s' references parameter '%s', which is not declared in parameters", ref, name)}
+			return ref
+		}
+		p := s.Parameters[i]
+
+		var value string
+		var found bool
+		if vars != nil {
+			value, found = vars.Resolve(p.Name)
+		}
+		if !found {
+			if p.Default == nil {
+				renderErr = &ParameterError{Index: i, Message: fmt.Sprintf(
+					"parameter '%s' has no default and no variable named '%s' is set", p.Name, p.Name)}
+				return ref
+			}
+			value = fmt.Sprint(p.Default)
+		}
+
+		out, err := p.render(value, dialect)
+		if err != nil {
+			renderErr = &ParameterError{Index: i, Message: fmt.Sprintf("value of variable '%s' %s", p.Name, err)}
+			return ref
+		}
+		return out
+	})
+	if renderErr != nil {
+		return "", renderErr
+	}
+
+	return rendered, nil
+}
+
+// render writes value as SQL according to the parameter type.
+func (p SQLParameter) render(value string, d sqllint.Dialect) (string, error) {
+	switch p.Type {
+	case ParameterTypeString:
+		return d.QuoteString(value), nil
+	case ParameterTypeIdentifier:
+		if !identifierRegex.MatchString(value) {
+			return "", fmt.Errorf("is not a valid identifier")
+		}
+		return value, nil
+	case ParameterTypeNumber:
+		value = strings.TrimSpace(value)
+		if !numberRegex.MatchString(value) {
+			return "", fmt.Errorf("is not a valid number")
+		}
+		return value, nil
+	case ParameterTypeBoolean:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("is not a valid boolean")
+		}
+		if b {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	default:
+		return "", fmt.Errorf("has unsupported type '%s'", p.Type)
+	}
+}
@@ -0,0 +1,158 @@
+package sqlmodel_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+)
+
+func TestSQLModelSpec_RenderSQL(t *testing.T) {
+	t.Parallel()
+
+	params := func(p ...sqlmodel.SQLParameter) []sqlmodel.SQLParameter { return p }
+
+	tests := []struct {
+		name          string
+		dialect       sqlmodel.SourceDefinition
+		params        []sqlmodel.SQLParameter
+		vars          mapResolver
+		sql           string
+		expected      string
+		expectedIndex int
+		expectedErr   string
+	}{
+		{
+			name:     "no parameters leaves sql untouched",
+			dialect:  sqlmodel.SourceDefinitionPostgres,
+			sql:      "SELECT '{{ not a param }}' AS v",
+			expected: "SELECT '{{ not a param }}' AS v",
+		},
+		{
+			name:    "every type from its default",
+			dialect: sqlmodel.SourceDefinitionSnowflake,
+			params: params(
+				sqlmodel.SQLParameter{Name: "schema", Type: sqlmodel.ParameterTypeIdentifier, Default: "db.analytics"},
+				sqlmodel.SQLParameter{Name: "region", Type: sqlmodel.ParameterTypeString, Default: "o'hare"},
+				sqlmodel.SQLParameter{Name: "days", Type: sqlmodel.ParameterTypeNumber, Default: 30},
+				sqlmodel.SQLParameter{Name: "active", Type: sqlmodel.ParameterTypeBoolean, Default: true},
+			),
+			sql:      "SELECT id FROM {{params.schema}}.users WHERE region = {{ params.region }} AND age < {{ params.days }} AND active = {{ params.active }}",
+			expected: `SELECT id FROM db.analytics.users WHERE region = 'o\'hare' AND age < 30 AND active = TRUE`,
+		},
+		{
+			name:    "variables override defaults",
+			dialect: sqlmodel.SourceDefinitionBigQuery,
+			params: params(
+				sqlmodel.SQLParameter{Name: "region", Type: sqlmodel.ParameterTypeString, Default: "us"},
+				sqlmodel.SQLParameter{Name: "active", Type: sqlmodel.ParameterTypeBoolean},
+			),
+			vars:     mapResolver{"region": "o'hare", "active": "false"},
+			sql:      "SELECT {{ params.region }} AS region, {{ params.active }} AS active",
+			expected: `SELECT 'o\'hare' AS region, FALSE AS active`,
+		},
+		{
+			name:     "backslashes are escaped where the warehouse reads them",
+			dialect:  sqlmodel.SourceDefinitionRedshift,
+			params:   params(sqlmodel.SQLParameter{Name: "path", Type: sqlmodel.ParameterTypeString}),
+			vars:     mapResolver{"path": `c:\o'hare\`},
+			sql:      "SELECT {{ params.path }} AS path",
+			expected: `SELECT 'c:\\o\'hare\\' AS path`,
+		},
+		{
+			name:     "backslashes are kept where the warehouse takes them literally",
+			dialect:  sqlmodel.SourceDefinitionPostgres,
+			params:   params(sqlmodel.SQLParameter{Name: "path", Type: sqlmodel.ParameterTypeString}),
+			vars:     mapResolver{"path": `c:\o'hare\`},
+			sql:      "SELECT {{ params.path }} AS path",
+			expected: `SELECT 'c:\o''hare\' AS path`,
+		},
+		{
+			name:          "invalid parameter name",
+			dialect:       sqlmodel.SourceDefinitionPostgres,
+			params:        params(sqlmodel.SQLParameter{Name: "my-schema", Type: sqlmodel.ParameterTypeIdentifier, Default: "x"}),
+			sql:           "SELECT 1",
+			expectedIndex: 0,
+			expectedErr:   "parameter name 'my-schema' must start with a letter or underscore and contain only letters, digits and underscores",
+		},
+		{
+			name:    "duplicate parameter",
+			dialect: sqlmodel.SourceDefinitionPostgres,
+			params: params(
+				sqlmodel.SQLParameter{Name: "days", Type: sqlmodel.ParameterTypeNumber, Default: 1},
+				sqlmodel.SQLParameter{Name: "days", Type: sqlmodel.ParameterTypeNumber, Default: 2},
+			),
+			sql:           "SELECT {{ params.days }}",
+			expectedIndex: 1,
+			expectedErr:   "parameter 'days' is declared more than once",
+		},
+		{
+			name:          "default not matching its type",
+			dialect:       sqlmodel.SourceDefinitionPostgres,
+			params:        params(sqlmodel.SQLParameter{Name: "schema", Type: sqlmodel.ParameterTypeIdentifier, Default: "analytics; DROP TABLE users"}),
+			sql:           "SELECT 1",
+			expectedIndex: 0,
+			expectedErr:   "default of parameter 'schema' is not a valid identifier",
+		},
+		{
+			name:          "undeclared parameter",
+			dialect:       sqlmodel.SourceDefinitionPostgres,
+			params:        params(sqlmodel.SQLParameter{Name: "days", Type: sqlmodel.ParameterTypeNumber, Default: 1}),
+			sql:           "SELECT {{ params.day }}",
+			expectedIndex: -1,
+			expectedErr:   "'{{ params.day }}' references parameter 'day', which is not declared in parameters",
+		},
+		{
+			name:          "no default and no variable",
+			dialect:       sqlmodel.SourceDefinitionPostgres,
+			params:        params(sqlmodel.SQLParameter{Name: "days", Type: sqlmodel.ParameterTypeNumber}),
+			vars:          mapResolver{},
+			sql:           "SELECT {{ params.days }}",
+			expectedIndex: 0,
+			expectedErr:   "parameter 'days' has no default and no variable named 'days' is set",
+		},
+		{
+			name:          "variable value not matching its type",
+			dialect:       sqlmodel.SourceDefinitionPostgres,
+			params:        params(sqlmodel.SQLParameter{Name: "days", Type: sqlmodel.ParameterTypeNumber, Default: 1}),
+			vars:          mapResolver{"days": "NaN"},
+			sql:           "SELECT {{ params.days }}",
+			expectedIndex: 0,
+			expectedErr:   "value of variable 'days' is not a valid number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := sqlmodel.SQLModelSpec{SourceDefinition: tt.dialect, Parameters: tt.params}
+			rendered, err := spec.RenderSQL(tt.sql, tt.vars)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, rendered)
+				return
+			}
+
+			var paramErr *sqlmodel.ParameterError
+			require.True(t, errors.As(err, &paramErr), "expected a ParameterError, got %v", err)
+			assert.Equal(t, tt.expectedIndex, paramErr.Index)
+			assert.Equal(t, tt.expectedErr, paramErr.Message)
+		})
+	}
+
+	t.Run("nil resolver uses defaults", func(t *testing.T) {
+		t.Parallel()
+
+		spec := sqlmodel.SQLModelSpec{
+			SourceDefinition: sqlmodel.SourceDefinitionPostgres,
+			Parameters:       []sqlmodel.SQLParameter{{Name: "days", Type: sqlmodel.ParameterTypeNumber, Default: 7}},
+		}
+		rendered, err := spec.RenderSQL("SELECT {{ params.days }}", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT 7", rendered)
+	})
+}
@@ -34,6 +34,13 @@ func (h *Handler) Preview(ctx context.Context, ID string, data resources.Resourc
 		return nil, fmt.Errorf("account ID not found in resource data")
 	}
 
+	return RunPreview(ctx, h.client, sql, accountID, limit)
+}
+
+// RunPreview submits sql for a preview against the account and polls until the
+// result is ready. Other RETL source kinds preview by building a query for
+// their source and handing it to RunPreview.
+func RunPreview(ctx context.Context, client retlClient.PreviewStore, sql, accountID string, limit int) ([]map[string]any, error) {
 	// Create preview request
 	previewReq := &retlClient.PreviewSubmitRequest{
 		SQL:       sql,
@@ -41,7 +48,7 @@ func (h *Handler) Preview(ctx context.Context, ID string, data resources.Resourc
 		Limit:     limit,
 	}
 
-	submitResp, err := h.client.SubmitSourcePreview(ctx, previewReq)
+	submitResp, err := client.SubmitSourcePreview(ctx, previewReq)
 	if err != nil {
 		return nil, fmt.Errorf("submitting preview request: %w", err)
 	}
@@ -60,7 +67,7 @@ func (h *Handler) Preview(ctx context.Context, ID string, data resources.Resourc
 		case <-timeoutCtx.Done():
 			return nil, fmt.Errorf("preview timed out after %s", DefaultTimeout)
 		case <-ticker.C:
-			resultResp, err := h.client.GetSourcePreviewResult(ctx, requestID)
+			resultResp, err := client.GetSourcePreviewResult(ctx, requestID)
 			if err != nil {
 				return nil, fmt.Errorf("getting preview results: %w", err)
 			}
@@ -0,0 +1,134 @@
+// Package sqllint parses RETL SQL model queries offline. It knows enough of
+// each warehouse dialect to report lexical and structural errors with their
+// position, and to infer the output columns of the query's SELECT list. It is
+// not a full SQL parser: anything it cannot judge is accepted, leaving the
+// final word to the warehouse at preview or sync time.
+package sqllint
+
+import "strings"
+
+// Dialect holds the lexical differences between the warehouses SQL models
+// run against.
+type Dialect struct {
+	name string
+
+	// identQuote is the character delimiting quoted identifiers.
+	identQuote rune
+	// doubleQuotedStrings makes "..." a string literal instead of an identifier.
+	doubleQuotedStrings bool
+	// backslashEscapes makes \ escape the next character in string literals.
+	backslashEscapes bool
+	// escapeStrings enables E'...' string literals, which read backslash
+	// escapes even when plain string literals do not.
+	escapeStrings bool
+	// hashComments makes # start a line comment.
+	hashComments bool
+	// hashOperators makes # an operator, alone (bitwise XOR) and in the JSON
+	// operators #>, #>> and #-.
+	hashOperators bool
+	// dollarQuotes enables $tag$...$tag$ string literals.
+	dollarQuotes bool
+	// tripleQuotes enables '''...''' and """...""" string literals.
+	tripleQuotes bool
+	// trailingComma allows a comma after the last item of the SELECT list.
+	trailingComma bool
+	// upperCaseIdents folds unquoted identifiers to upper case instead of
+	// lower case.
+	upperCaseIdents bool
+	// quotedCaseSensitive keeps the case of quoted identifiers. Otherwise
+	// they are folded like unquoted ones.
+	quotedCaseSensitive bool
+	// castKeepsName names a column cast with :: or CAST(... AS ...) after
+	// the column itself.
+	castKeepsName bool
+}
+
+// Name returns the source definition the dialect belongs to.
+func (d Dialect) Name() string {
+	return d.name
+}
+
+var dialects = map[string]Dialect{
+	"postgres": {
+		name:                "postgres",
+		identQuote:          '"',
+		escapeStrings:       true,
+		hashOperators:       true,
+		dollarQuotes:        true,
+		quotedCaseSensitive: true,
+		castKeepsName:       true,
+	},
+	"redshift": {
+		name:             "redshift",
+		identQuote:       '"',
+		backslashEscapes: true,
+		hashOperators:    true,
+		castKeepsName:    true,
+	},
+	"snowflake": {
+		name:                "snowflake",
+		identQuote:          '"',
+		backslashEscapes:    true,
+		dollarQuotes:        true,
+		upperCaseIdents:     true,
+		quotedCaseSensitive: true,
+	},
+	"trino": {
+		name:       "trino",
+		identQuote: '"',
+	},
+	"bigquery": {
+		name:                "bigquery",
+		identQuote:          '`',
+		doubleQuotedStrings: true,
+		backslashEscapes:    true,
+		hashComments:        true,
+		tripleQuotes:        true,
+		trailingComma:       true,
+	},
+	"mysql": {
+		name:                "mysql",
+		identQuote:          '`',
+		doubleQuotedStrings: true,
+		backslashEscapes:    true,
+		hashComments:        true,
+	},
+	"databricks": {
+		name:                "databricks",
+		identQuote:          '`',
+		doubleQuotedStrings: true,
+		backslashEscapes:    true,
+	},
+}
+
+// DialectFor returns the dialect of a SQL model source definition, and false
+// when the source definition is unknown.
+func DialectFor(sourceDefinition string) (Dialect, bool) {
+	d, ok := dialects[sourceDefinition]
+	return d, ok
+}
+
+// columnName returns the name the warehouse gives a column written as name,
+// folding its case unless it is quoted and quoted identifiers keep their case.
+func (d Dialect) columnName(name string, quoted bool) string {
+	switch {
+	case quoted && d.quotedCaseSensitive:
+		return name
+	case d.upperCaseIdents:
+		return strings.ToUpper(name)
+	default:
+		return strings.ToLower(name)
+	}
+}
+
+// QuoteString renders s as a string literal of the dialect. Dialects that
+// read backslash escapes get s escaped with backslashes, the others double
+// embedded single quotes as standard SQL does.
+func (d Dialect) QuoteString(s string) string {
+	if d.backslashEscapes {
+		s = strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+	} else {
+		s = strings.ReplaceAll(s, "'", "''")
+	}
+	return "'" + s + "'"
+}
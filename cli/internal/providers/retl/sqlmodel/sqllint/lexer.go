@@ -0,0 +1,373 @@
+package sqllint
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Position is a 1-based line and column in the SQL text. Columns count
+// characters, not bytes.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// SyntaxError reports a problem found while parsing a query.
+type SyntaxError struct {
+	Pos     Position
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Pos.Line, e.Pos.Column, e.Message)
+}
+
+type tokenKind int
+
+const (
+	// tokenWord is a bare identifier or keyword
+	tokenWord tokenKind = iota
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenParam
+	// tokenPunct is one of ( ) [ ] { } , ; . *
+	tokenPunct
+	tokenOperator
+	tokenEOF
+)
+
+type token struct {
+	kind tokenKind
+	// text is the token as written, except for quoted identifiers where it
+	// is the unquoted name.
+	text string
+	pos  Position
+}
+
+func (t token) isPunct(p string) bool {
+	return t.kind == tokenPunct && t.text == p
+}
+
+func (t token) isKeyword(kw string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, kw)
+}
+
+// isName reports whether the token can name a column: a quoted identifier
+// or a bare word that is not a reserved keyword.
+func (t token) isName() bool {
+	return t.kind == tokenQuotedIdent || (t.kind == tokenWord && !isReserved(t.text))
+}
+
+// describe renders the token for error messages.
+func (t token) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenQuotedIdent:
+		return fmt.Sprintf("identifier %q", t.text)
+	default:
+		return fmt.Sprintf("'%s'", t.text)
+	}
+}
+
+const operatorChars = "+-/%<>=!|&^~:?"
+
+// stringPrefixes are the letters that may directly precede a string literal
+// to change how it is read (escape, national, hex, bit, raw and byte strings).
+var stringPrefixes = map[string]bool{
+	"e": true, "n": true, "x": true, "b": true, "r": true, "rb": true, "br": true,
+}
+
+type lexer struct {
+	dialect Dialect
+	src     []rune
+	i       int
+	pos     Position
+}
+
+// tokenize splits sql into tokens, dropping whitespace and comments. The
+// returned slice always ends with a tokenEOF token.
+func tokenize(sql string, d Dialect) ([]token, error) {
+	l := &lexer{dialect: d, src: []rune(sql), pos: Position{Line: 1, Column: 1}}
+
+	var tokens []token
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+		if tok.kind == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) peek(offset int) rune {
+	if l.i+offset >= len(l.src) {
+		return 0
+	}
+	return l.src[l.i+offset]
+}
+
+func (l *lexer) advance() rune {
+	r := l.src[l.i]
+	l.i++
+	if r == '\n' {
+		l.pos.Line++
+		l.pos.Column = 1
+	} else {
+		l.pos.Column++
+	}
+	return r
+}
+
+func (l *lexer) next() (token, error) {
+	if err := l.skipSpaceAndComments(); err != nil {
+		return token{}, err
+	}
+
+	start := l.pos
+	if l.i >= len(l.src) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+
+	r := l.peek(0)
+	switch {
+	case r == '\'':
+		return l.lexString(start, '\'', l.dialect.backslashEscapes)
+	case r == '"' && l.dialect.doubleQuotedStrings:
+		return l.lexString(start, '"', l.dialect.backslashEscapes)
+	case r == l.dialect.identQuote:
+		return l.lexQuotedIdent(start)
+	case r == '$' && l.dialect.dollarQuotes && l.dollarTag() != "":
+		return l.lexDollarString(start)
+	case r == '$' || r == '@' || (r == ':' && isIdentStart(l.peek(1))):
+		return l.lexParam(start), nil
+	case unicode.IsDigit(r) || (r == '.' && unicode.IsDigit(l.peek(1))):
+		return l.lexNumber(start), nil
+	case isIdentStart(r):
+		return l.lexWord(start)
+	case strings.ContainsRune("()[]{},;.*", r):
+		l.advance()
+		return token{kind: tokenPunct, text: string(r), pos: start}, nil
+	case r == '#' && l.dialect.hashOperators:
+		return l.lexHashOperator(start), nil
+	case strings.ContainsRune(operatorChars, r):
+		var b strings.Builder
+		for b.Len() < 3 && strings.ContainsRune(operatorChars, l.peek(0)) && l.i < len(l.src) {
+			b.WriteRune(l.advance())
+		}
+		return token{kind: tokenOperator, text: b.String(), pos: start}, nil
+	}
+
+	return token{}, &SyntaxError{Pos: start, Message: fmt.Sprintf("unexpected character %q", r)}
+}
+
+func (l *lexer) skipSpaceAndComments() error {
+	for l.i < len(l.src) {
+		r := l.peek(0)
+		switch {
+		case unicode.IsSpace(r):
+			l.advance()
+		case r == '-' && l.peek(1) == '-', r == '#' && l.dialect.hashComments:
+			for l.i < len(l.src) && l.peek(0) != '\n' {
+				l.advance()
+			}
+		case r == '/' && l.peek(1) == '*':
+			start := l.pos
+			l.advance()
+			l.advance()
+			for !(l.peek(0) == '*' && l.peek(1) == '/') {
+				if l.i >= len(l.src) {
+					return &SyntaxError{Pos: start, Message: "unterminated block comment"}
+				}
+				l.advance()
+			}
+			l.advance()
+			l.advance()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// lexString reads a string literal delimited by quote. escapes makes \
+// escape the next character.
+func (l *lexer) lexString(start Position, quote rune, escapes bool) (token, error) {
+	from := l.i
+	if l.dialect.tripleQuotes && l.peek(1) == quote && l.peek(2) == quote {
+		l.advance()
+		l.advance()
+		l.advance()
+		for !(l.peek(0) == quote && l.peek(1) == quote && l.peek(2) == quote) {
+			if l.i >= len(l.src) {
+				return token{}, &SyntaxError{Pos: start, Message: "unterminated string literal"}
+			}
+			if l.peek(0) == '\\' && escapes && l.i+1 < len(l.src) {
+				l.advance()
+			}
+			l.advance()
+		}
+		l.advance()
+		l.advance()
+		l.advance()
+		return token{kind: tokenString, text: string(l.src[from:l.i]), pos: start}, nil
+	}
+
+	l.advance()
+	for {
+		if l.i >= len(l.src) {
+			return token{}, &SyntaxError{Pos: start, Message: "unterminated string literal"}
+		}
+		r := l.advance()
+		switch {
+		case r == '\\' && escapes && l.i < len(l.src):
+			l.advance()
+		case r == quote && l.peek(0) == quote:
+			l.advance()
+		case r == quote:
+			return token{kind: tokenString, text: string(l.src[from:l.i]), pos: start}, nil
+		}
+	}
+}
+
+func (l *lexer) lexQuotedIdent(start Position) (token, error) {
+	quote := l.advance()
+	var name strings.Builder
+	for {
+		if l.i >= len(l.src) {
+			return token{}, &SyntaxError{Pos: start, Message: "unterminated quoted identifier"}
+		}
+		r := l.advance()
+		if r == quote {
+			if l.peek(0) != quote {
+				break
+			}
+			l.advance()
+		}
+		name.WriteRune(r)
+	}
+	if name.Len() == 0 {
+		return token{}, &SyntaxError{Pos: start, Message: "empty quoted identifier"}
+	}
+	return token{kind: tokenQuotedIdent, text: name.String(), pos: start}, nil
+}
+
+// dollarTag returns the $tag$ opening a dollar-quoted string at the current
+// position, or "" when there is none.
+func (l *lexer) dollarTag() string {
+	for j := l.i + 1; j < len(l.src); j++ {
+		r := l.src[j]
+		if r == '$' {
+			return string(l.src[l.i : j+1])
+		}
+		if !(r == '_' || unicode.IsLetter(r) || (j > l.i+1 && unicode.IsDigit(r))) {
+			return ""
+		}
+	}
+	return ""
+}
+
+func (l *lexer) lexDollarString(start Position) (token, error) {
+	tag := []rune(l.dollarTag())
+	from := l.i
+	for range tag {
+		l.advance()
+	}
+	for {
+		if l.i >= len(l.src) {
+			return token{}, &SyntaxError{Pos: start, Message: "unterminated dollar-quoted string"}
+		}
+		if l.i+len(tag) <= len(l.src) && string(l.src[l.i:l.i+len(tag)]) == string(tag) {
+			for range tag {
+				l.advance()
+			}
+			return token{kind: tokenString, text: string(l.src[from:l.i]), pos: start}, nil
+		}
+		l.advance()
+	}
+}
+
+// lexHashOperator reads #, #>, #>> or #-.
+func (l *lexer) lexHashOperator(start Position) token {
+	from := l.i
+	l.advance()
+	switch l.peek(0) {
+	case '>':
+		l.advance()
+		if l.peek(0) == '>' {
+			l.advance()
+		}
+	case '-':
+		l.advance()
+	}
+	return token{kind: tokenOperator, text: string(l.src[from:l.i]), pos: start}
+}
+
+func (l *lexer) lexParam(start Position) token {
+	from := l.i
+	l.advance()
+	for l.i < len(l.src) && (isIdentPart(l.peek(0)) || l.peek(0) == '@') {
+		l.advance()
+	}
+	return token{kind: tokenParam, text: string(l.src[from:l.i]), pos: start}
+}
+
+func (l *lexer) lexNumber(start Position) token {
+	from := l.i
+	for l.i < len(l.src) && unicode.IsDigit(l.peek(0)) {
+		l.advance()
+	}
+	if l.peek(0) == '.' && l.peek(1) != '.' {
+		l.advance()
+		for l.i < len(l.src) && unicode.IsDigit(l.peek(0)) {
+			l.advance()
+		}
+	}
+	if (l.peek(0) == 'e' || l.peek(0) == 'E') &&
+		(unicode.IsDigit(l.peek(1)) || ((l.peek(1) == '+' || l.peek(1) == '-') && unicode.IsDigit(l.peek(2)))) {
+		l.advance()
+		l.advance()
+		for l.i < len(l.src) && unicode.IsDigit(l.peek(0)) {
+			l.advance()
+		}
+	}
+	// Suffixes and hex digits, e.g. 10L or 0x1F.
+	for l.i < len(l.src) && isIdentPart(l.peek(0)) {
+		l.advance()
+	}
+	return token{kind: tokenNumber, text: string(l.src[from:l.i]), pos: start}
+}
+
+func (l *lexer) lexWord(start Position) (token, error) {
+	from := l.i
+	for l.i < len(l.src) && isIdentPart(l.peek(0)) {
+		l.advance()
+	}
+	word := string(l.src[from:l.i])
+
+	next := l.peek(0)
+	if stringPrefixes[strings.ToLower(word)] && (next == '\'' || (next == '"' && l.dialect.doubleQuotedStrings)) {
+		escapes := l.dialect.backslashEscapes || (l.dialect.escapeStrings && strings.EqualFold(word, "e"))
+		tok, err := l.lexString(start, next, escapes)
+		if err != nil {
+			return token{}, err
+		}
+		tok.text = word + tok.text
+		return tok, nil
+	}
+
+	return token{kind: tokenWord, text: word, pos: start}, nil
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isIdentPart(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,50 @@
+package sqllint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize_HashOperators(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		sql      string
+		operator string
+	}{
+		{sql: "j #> '{a,b}'", operator: "#>"},
+		{sql: "j #>> '{a,b}'", operator: "#>>"},
+		{sql: "j #- '{a}'", operator: "#-"},
+		{sql: "j#>'{a}'", operator: "#>"},
+		{sql: "a # b", operator: "#"},
+	}
+
+	for _, name := range []string{"postgres", "redshift"} {
+		for _, tt := range tests {
+			t.Run(name+" "+tt.sql, func(t *testing.T) {
+				t.Parallel()
+
+				tokens, err := tokenize(tt.sql, dialects[name])
+				require.NoError(t, err)
+				require.Len(t, tokens, 4)
+				assert.Equal(t, tokenWord, tokens[0].kind)
+				assert.Equal(t, token{kind: tokenOperator, text: tt.operator, pos: tokens[1].pos}, tokens[1])
+				assert.NotEqual(t, tokenOperator, tokens[2].kind)
+				assert.Equal(t, tokenEOF, tokens[3].kind)
+			})
+		}
+	}
+
+	t.Run("other dialects", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := tokenize("j #> 'a'", dialects["trino"])
+		assert.EqualError(t, err, "line 1, column 3: unexpected character '#'")
+
+		tokens, err := tokenize("j #> 'a'", dialects["mysql"])
+		require.NoError(t, err)
+		require.Len(t, tokens, 2, "# starts a comment in mysql")
+	})
+}
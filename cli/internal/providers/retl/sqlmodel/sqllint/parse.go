@@ -0,0 +1,457 @@
+package sqllint
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Column is an output column of a query, inferred from its SELECT list.
+type Column struct {
+	// Name is the column name, or empty for an expression without an alias
+	// whose name the warehouse makes up.
+	Name string
+	// Quoted is set when Name was written as a quoted identifier.
+	Quoted bool
+	// Star is set for * and table.* items, whose columns cannot be known
+	// without the warehouse schema.
+	Star bool
+	Pos  Position
+}
+
+// Query is the result of parsing a SQL model query.
+type Query struct {
+	Columns []Column
+
+	dialect Dialect
+}
+
+// Complete reports whether every output column of the query is known, that
+// is whether its SELECT list has no * items.
+func (q *Query) Complete() bool {
+	for _, c := range q.Columns {
+		if c.Star {
+			return false
+		}
+	}
+	return true
+}
+
+// Lookup returns the output columns called name. Columns are named the way
+// the warehouse names them: unquoted names are folded to the dialect's case,
+// and quoted names keep theirs where the dialect is case-sensitive for them.
+// When no column has exactly that name, name is compared case-insensitively.
+func (q *Query) Lookup(name string) []Column {
+	var exact, folded []Column
+	for _, c := range q.Columns {
+		if c.Name == "" {
+			continue
+		}
+		if q.dialect.columnName(c.Name, c.Quoted) == name {
+			exact = append(exact, c)
+		}
+		if strings.EqualFold(c.Name, name) {
+			folded = append(folded, c)
+		}
+	}
+	if len(exact) > 0 {
+		return exact
+	}
+	return folded
+}
+
+// ColumnNames returns the names of the output columns, rendering * items as
+// written and unnamed expressions as "?".
+func (q *Query) ColumnNames() []string {
+	names := make([]string, 0, len(q.Columns))
+	for _, c := range q.Columns {
+		switch {
+		case c.Star:
+			names = append(names, "*")
+		case c.Name == "":
+			names = append(names, "?")
+		default:
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+// reserved holds the keywords that never name a column on their own, so a
+// word from this set ending a SELECT item is not read as an alias.
+var reserved = map[string]bool{
+	"ALL": true, "AND": true, "AS": true, "ASC": true, "BETWEEN": true, "BY": true,
+	"CASE": true, "CROSS": true, "DESC": true, "DISTINCT": true, "ELSE": true,
+	"END": true, "EXCEPT": true, "EXCLUDE": true, "EXISTS": true, "FALSE": true,
+	"FETCH": true, "FOR": true, "FROM": true, "FULL": true, "GROUP": true,
+	"HAVING": true, "ILIKE": true, "IN": true, "INNER": true, "INTERSECT": true,
+	"INTERVAL": true, "INTO": true, "IS": true, "JOIN": true, "LEFT": true,
+	"LIKE": true, "LIMIT": true, "MINUS": true, "NOT": true, "NULL": true,
+	"OFFSET": true, "ON": true, "OR": true, "ORDER": true, "OVER": true,
+	"QUALIFY": true, "RENAME": true, "REPLACE": true, "RIGHT": true,
+	"SELECT": true, "THEN": true, "TRUE": true, "UNION": true, "USING": true,
+	"WHEN": true, "WHERE": true, "WINDOW": true, "WITH": true,
+}
+
+func isReserved(word string) bool {
+	return reserved[strings.ToUpper(word)]
+}
+
+// clauseKeywords end the SELECT list.
+var clauseKeywords = map[string]bool{
+	"FROM": true, "WHERE": true, "GROUP": true, "HAVING": true, "ORDER": true,
+	"LIMIT": true, "QUALIFY": true, "WINDOW": true, "UNION": true,
+	"INTERSECT": true, "EXCEPT": true, "MINUS": true, "INTO": true,
+	"FETCH": true, "OFFSET": true, "FOR": true,
+}
+
+// starModifiers may follow * in a SELECT item, e.g. SELECT * EXCEPT (a).
+var starModifiers = map[string]bool{
+	"EXCEPT": true, "EXCLUDE": true, "REPLACE": true, "RENAME": true, "ILIKE": true,
+}
+
+// aliasAfterReserved holds the reserved words an implicit alias may follow,
+// as in CASE ... END AS x written without AS.
+var aliasAfterReserved = map[string]bool{
+	"END": true, "NULL": true, "TRUE": true, "FALSE": true,
+}
+
+var closing = map[string]string{"(": ")", "[": "]", "{": "}"}
+
+type parser struct {
+	dialect Dialect
+	tokens  []token
+	// match maps the index of every opening bracket to its closing one.
+	match map[int]int
+}
+
+// Parse checks that sql is a single SELECT query, optionally with a WITH
+// clause, and infers its output columns. Problems are returned as a
+// *SyntaxError.
+func Parse(sql string, d Dialect) (*Query, error) {
+	tokens, err := tokenize(sql, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop trailing semicolons; the EOF token stays last.
+	eof := tokens[len(tokens)-1]
+	tokens = tokens[:len(tokens)-1]
+	for len(tokens) > 0 && tokens[len(tokens)-1].isPunct(";") {
+		tokens = tokens[:len(tokens)-1]
+	}
+	if len(tokens) == 0 {
+		return nil, &SyntaxError{Pos: Position{Line: 1, Column: 1}, Message: "query is empty"}
+	}
+	tokens = append(tokens, eof)
+
+	p := &parser{dialect: d, tokens: tokens, match: map[int]int{}}
+	if err := p.checkStructure(); err != nil {
+		return nil, err
+	}
+	return p.parseQuery()
+}
+
+func (p *parser) at(i int) token {
+	if i >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[i]
+}
+
+// checkStructure matches brackets and rejects more than one statement.
+func (p *parser) checkStructure() error {
+	var open []int
+	for i, t := range p.tokens {
+		if t.kind != tokenPunct {
+			continue
+		}
+		switch t.text {
+		case "(", "[", "{":
+			open = append(open, i)
+		case ")", "]", "}":
+			if len(open) == 0 {
+				return &SyntaxError{Pos: t.pos, Message: fmt.Sprintf("unexpected '%s' without a matching opening bracket", t.text)}
+			}
+			last := open[len(open)-1]
+			if want := closing[p.tokens[last].text]; want != t.text {
+				return &SyntaxError{Pos: t.pos, Message: fmt.Sprintf("expected '%s' to close '%s' at line %d, column %d, found '%s'",
+					want, p.tokens[last].text, p.tokens[last].pos.Line, p.tokens[last].pos.Column, t.text)}
+			}
+			p.match[last] = i
+			open = open[:len(open)-1]
+		case ";":
+			return &SyntaxError{Pos: t.pos, Message: "a SQL model must be a single query, found ';' followed by more SQL"}
+		}
+	}
+	if len(open) > 0 {
+		t := p.tokens[open[len(open)-1]]
+		return &SyntaxError{Pos: t.pos, Message: fmt.Sprintf("'%s' is never closed", t.text)}
+	}
+	return nil
+}
+
+func (p *parser) parseQuery() (*Query, error) {
+	i := 0
+	if p.at(0).isKeyword("WITH") {
+		var err error
+		if i, err = p.skipWith(1); err != nil {
+			return nil, err
+		}
+	}
+
+	j := i
+	for p.at(j).isPunct("(") {
+		j++
+	}
+	if t := p.at(j); !t.isKeyword("SELECT") {
+		if i == 0 && j == 0 {
+			return nil, &SyntaxError{Pos: t.pos, Message: fmt.Sprintf("a SQL model must be a SELECT query, found %s", t.describe())}
+		}
+		return nil, &SyntaxError{Pos: t.pos, Message: fmt.Sprintf("expected SELECT, found %s", t.describe())}
+	}
+
+	columns, err := p.selectList(j)
+	if err != nil {
+		return nil, err
+	}
+	return &Query{Columns: columns, dialect: p.dialect}, nil
+}
+
+// skipWith skips the common table expressions of a WITH clause starting at
+// i and returns the index of the main query.
+func (p *parser) skipWith(i int) (int, error) {
+	if p.at(i).isKeyword("RECURSIVE") {
+		i++
+	}
+	for {
+		name := p.at(i)
+		if !name.isName() {
+			return 0, &SyntaxError{Pos: name.pos, Message: fmt.Sprintf("expected a common table expression name, found %s", name.describe())}
+		}
+		i++
+		if p.at(i).isPunct("(") {
+			i = p.match[i] + 1
+		}
+		if t := p.at(i); !t.isKeyword("AS") {
+			return 0, &SyntaxError{Pos: t.pos, Message: fmt.Sprintf("expected AS after common table expression '%s', found %s", name.text, t.describe())}
+		}
+		i++
+		if p.at(i).isKeyword("NOT") {
+			i++
+		}
+		if p.at(i).isKeyword("MATERIALIZED") {
+			i++
+		}
+		if t := p.at(i); !t.isPunct("(") {
+			return 0, &SyntaxError{Pos: t.pos, Message: fmt.Sprintf("expected '(' after AS in common table expression '%s', found %s", name.text, t.describe())}
+		}
+		i = p.match[i] + 1
+		if !p.at(i).isPunct(",") {
+			return i, nil
+		}
+		i++
+	}
+}
+
+// selectList splits the SELECT list of the SELECT keyword at i into items
+// and infers a column from each.
+func (p *parser) selectList(i int) ([]Column, error) {
+	selectTok := p.at(i)
+	i++
+
+	if p.at(i).isKeyword("DISTINCT") {
+		i++
+		if p.at(i).isKeyword("ON") && p.at(i+1).isPunct("(") {
+			i = p.match[i+1] + 1
+		}
+	} else if p.at(i).isKeyword("ALL") {
+		i++
+	}
+	if p.at(i).isKeyword("TOP") && (p.at(i+1).kind == tokenNumber || p.at(i+1).isPunct("(")) {
+		if p.at(i + 1).isPunct("(") {
+			i = p.match[i+1] + 1
+		} else {
+			i += 2
+		}
+	}
+	if p.at(i).isKeyword("AS") && (p.at(i+1).isKeyword("STRUCT") || p.at(i+1).isKeyword("VALUE")) {
+		i += 2
+	}
+
+	var (
+		items  [][]token
+		commas []token
+		cur    []token
+	)
+	for ; ; i++ {
+		t := p.at(i)
+		if t.kind == tokenEOF || t.isPunct(")") {
+			break
+		}
+		if t.kind == tokenWord && clauseKeywords[strings.ToUpper(t.text)] {
+			isStarModifier := len(cur) > 0 && cur[len(cur)-1].isPunct("*") && starModifiers[strings.ToUpper(t.text)]
+			if !isStarModifier {
+				break
+			}
+		}
+		if t.isPunct(",") {
+			items = append(items, cur)
+			commas = append(commas, t)
+			cur = nil
+			continue
+		}
+		if end, ok := p.match[i]; ok {
+			cur = append(cur, p.tokens[i:end+1]...)
+			i = end
+			continue
+		}
+		cur = append(cur, t)
+	}
+	items = append(items, cur)
+
+	if len(items) == 1 && len(items[0]) == 0 {
+		return nil, &SyntaxError{Pos: selectTok.pos, Message: fmt.Sprintf("expected a column list after SELECT, found %s", p.at(i).describe())}
+	}
+	if last := len(items) - 1; len(items[last]) == 0 && p.dialect.trailingComma {
+		items = items[:last]
+	}
+
+	columns := make([]Column, 0, len(items))
+	for n, item := range items {
+		if len(item) == 0 {
+			if n < len(commas) {
+				return nil, &SyntaxError{Pos: commas[n].pos, Message: "expected a column expression before ','"}
+			}
+			return nil, &SyntaxError{Pos: commas[n-1].pos, Message: fmt.Sprintf("expected a column expression after ',', found %s", p.at(i).describe())}
+		}
+		columns = append(columns, p.columnOf(item))
+	}
+	return columns, nil
+}
+
+// columnOf infers the output column of a single SELECT item.
+func (p *parser) columnOf(item []token) Column {
+	if isStar(item) {
+		return Column{Star: true, Pos: item[0].pos}
+	}
+
+	if n := len(item); n >= 2 && item[n-1].isName() {
+		alias, prev := item[n-1], item[n-2]
+		if prev.isKeyword("AS") || canPrecedeAlias(prev) {
+			return Column{Name: alias.text, Quoted: alias.kind == tokenQuotedIdent, Pos: alias.pos}
+		}
+	}
+
+	expr := item
+	if p.dialect.castKeepsName {
+		expr = stripCasts(expr)
+	}
+	if name, ok := columnRef(expr); ok {
+		return Column{Name: name.text, Quoted: name.kind == tokenQuotedIdent, Pos: item[0].pos}
+	}
+	return Column{Pos: item[0].pos}
+}
+
+// isStar reports whether item is *, or table.* and the like, optionally
+// followed by modifiers such as EXCEPT (...).
+func isStar(item []token) bool {
+	for k, t := range item {
+		if !t.isPunct("*") {
+			continue
+		}
+		if k == 0 {
+			return true
+		}
+		if item[k-1].isPunct(".") {
+			_, ok := columnRef(item[:k-1])
+			return ok
+		}
+		return false
+	}
+	return false
+}
+
+// columnRef returns the last part of item when it is a possibly qualified
+// column reference, such as a or t.a.
+func columnRef(item []token) (token, bool) {
+	if len(item)%2 == 0 {
+		return token{}, false
+	}
+	for k, t := range item {
+		if k%2 == 0 && !t.isName() {
+			return token{}, false
+		}
+		if k%2 == 1 && !t.isPunct(".") {
+			return token{}, false
+		}
+	}
+	return item[len(item)-1], true
+}
+
+// stripCasts drops trailing ::type casts, including parameterised types
+// such as ::numeric(10, 2), and unwraps CAST(x AS type) to x.
+func stripCasts(item []token) []token {
+	for {
+		n := len(item)
+		if operand, ok := castOperand(item); ok {
+			item = operand
+			continue
+		}
+		switch {
+		case n >= 3 && item[n-2].kind == tokenOperator && item[n-2].text == "::" && item[n-1].kind == tokenWord:
+			item = item[:n-2]
+		case n >= 4 && item[n-1].isPunct(")"):
+			k := n - 2
+			for k >= 0 && !item[k].isPunct("(") {
+				k--
+			}
+			if k < 2 || item[k-1].kind != tokenWord || item[k-2].kind != tokenOperator || item[k-2].text != "::" {
+				return item
+			}
+			item = item[:k-2]
+		default:
+			return item
+		}
+	}
+}
+
+// castOperand returns x when item is exactly CAST(x AS type).
+func castOperand(item []token) ([]token, bool) {
+	n := len(item)
+	if n < 6 || !item[0].isKeyword("CAST") || !item[1].isPunct("(") || !item[n-1].isPunct(")") {
+		return nil, false
+	}
+
+	as, depth := -1, 0
+	for k := 2; k < n-1; k++ {
+		switch t := item[k]; {
+		case t.isPunct("("), t.isPunct("["), t.isPunct("{"):
+			depth++
+		case t.isPunct(")"), t.isPunct("]"), t.isPunct("}"):
+			depth--
+			if depth < 0 {
+				// The opening parenthesis closes before the end of item,
+				// as in CAST(a AS int) + 1.
+				return nil, false
+			}
+		case depth == 0 && t.isKeyword("AS"):
+			as = k
+		}
+	}
+	if as <= 2 {
+		return nil, false
+	}
+	return item[2:as], true
+}
+
+func canPrecedeAlias(t token) bool {
+	switch t.kind {
+	case tokenQuotedIdent, tokenNumber, tokenString:
+		return true
+	case tokenWord:
+		return !isReserved(t.text) || aliasAfterReserved[strings.ToUpper(t.text)]
+	case tokenPunct:
+		return t.text == ")" || t.text == "]"
+	}
+	return false
+}
@@ -0,0 +1,468 @@
+package sqllint_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel/sqllint"
+)
+
+func dialect(t *testing.T, name string) sqllint.Dialect {
+	t.Helper()
+	d, ok := sqllint.DialectFor(name)
+	require.True(t, ok, "unknown dialect %s", name)
+	return d
+}
+
+func TestDialectFor(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"postgres", "redshift", "snowflake", "bigquery", "mysql", "databricks", "trino"} {
+		d, ok := sqllint.DialectFor(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, name, d.Name())
+	}
+
+	_, ok := sqllint.DialectFor("oracle")
+	assert.False(t, ok)
+}
+
+func TestParse_Columns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dialect  string
+		sql      string
+		expected []string
+		complete bool
+	}{
+		{
+			name:     "plain and qualified columns",
+			dialect:  "postgres",
+			sql:      "SELECT id, u.email FROM users u",
+			expected: []string{"id", "email"},
+			complete: true,
+		},
+		{
+			name:     "explicit and implicit aliases",
+			dialect:  "snowflake",
+			sql:      "SELECT user_id AS id, count(*) orders, CASE WHEN x THEN 1 ELSE 0 END flag, 1 one FROM t",
+			expected: []string{"id", "orders", "flag", "one"},
+			complete: true,
+		},
+		{
+			name:     "expressions without alias are unnamed",
+			dialect:  "trino",
+			sql:      "SELECT lower(email), a + b, x IS NULL, CASE WHEN x THEN 1 END FROM t",
+			expected: []string{"?", "?", "?", "?"},
+			complete: true,
+		},
+		{
+			name:     "stars make the list incomplete",
+			dialect:  "bigquery",
+			sql:      "SELECT t.*, * EXCEPT (secret), id FROM t",
+			expected: []string{"*", "*", "id"},
+			complete: false,
+		},
+		{
+			name:     "snowflake exclude modifier",
+			dialect:  "snowflake",
+			sql:      "SELECT * EXCLUDE secret FROM t",
+			expected: []string{"*"},
+			complete: false,
+		},
+		{
+			name:     "quoted identifiers",
+			dialect:  "postgres",
+			sql:      `SELECT "User Id", t."select" AS "Order" FROM t`,
+			expected: []string{"User Id", "Order"},
+			complete: true,
+		},
+		{
+			name:     "backtick identifiers and double-quoted strings",
+			dialect:  "databricks",
+			sql:      "SELECT `user id`, \"literal\" AS kind FROM t",
+			expected: []string{"user id", "kind"},
+			complete: true,
+		},
+		{
+			name:     "postgres casts keep the column name",
+			dialect:  "postgres",
+			sql:      "SELECT id::text, amount::numeric(10, 2) FROM t",
+			expected: []string{"id", "amount"},
+			complete: true,
+		},
+		{
+			name:     "postgres CAST keeps the column name",
+			dialect:  "postgres",
+			sql:      "SELECT CAST(id AS text), CAST(u.amount AS numeric(10, 2))::text, CAST(a + b AS int), CAST(a AS int) + 1 FROM t",
+			expected: []string{"id", "amount", "?", "?"},
+			complete: true,
+		},
+		{
+			name:     "snowflake casts are unnamed",
+			dialect:  "snowflake",
+			sql:      "SELECT id::varchar, CAST(id AS varchar) FROM t",
+			expected: []string{"?", "?"},
+			complete: true,
+		},
+		{
+			name:     "snowflake backslash escapes",
+			dialect:  "snowflake",
+			sql:      `SELECT 'It\'s' AS note, 'a\\' AS path FROM t`,
+			expected: []string{"note", "path"},
+			complete: true,
+		},
+		{
+			name:     "redshift backslash escapes",
+			dialect:  "redshift",
+			sql:      `SELECT 'It\'s' AS note FROM t`,
+			expected: []string{"note"},
+			complete: true,
+		},
+		{
+			name:     "postgres escape strings",
+			dialect:  "postgres",
+			sql:      `SELECT E'a\'b' AS note, e'\\' AS slash, 'c:\' AS path FROM t`,
+			expected: []string{"note", "slash", "path"},
+			complete: true,
+		},
+		{
+			name:     "commas and keywords inside parentheses",
+			dialect:  "mysql",
+			sql:      "SELECT concat(a, b) AS ab, extract(year FROM created_at) AS yr, (SELECT max(x) FROM y) AS m FROM t",
+			expected: []string{"ab", "yr", "m"},
+			complete: true,
+		},
+		{
+			name:     "main query after a WITH clause",
+			dialect:  "postgres",
+			sql:      "WITH recent (id) AS (SELECT id FROM orders), other AS MATERIALIZED (SELECT 1) SELECT DISTINCT r.id AS order_id FROM recent r",
+			expected: []string{"order_id"},
+			complete: true,
+		},
+		{
+			name:     "first branch of a union in parentheses",
+			dialect:  "trino",
+			sql:      "(SELECT a, b FROM x) UNION ALL (SELECT c, d FROM y)",
+			expected: []string{"a", "b"},
+			complete: true,
+		},
+		{
+			name:     "comments, trailing semicolons and dollar quotes",
+			dialect:  "postgres",
+			sql:      "-- users\nSELECT /* the key */ id, $$it's$$ AS note FROM users;;",
+			expected: []string{"id", "note"},
+			complete: true,
+		},
+		{
+			name:     "bigquery trailing comma, hash comments and raw strings",
+			dialect:  "bigquery",
+			sql:      "SELECT id, r'\\d+' AS pattern, # comment\n FROM `project.dataset.users`",
+			expected: []string{"id", "pattern"},
+			complete: true,
+		},
+		{
+			name:     "postgres json path operators",
+			dialect:  "postgres",
+			sql:      "SELECT j #> '{a,b}' AS ab, j #>> '{a}' a, j #- '{a}' AS rest, flags # 1 AS x FROM t",
+			expected: []string{"ab", "a", "rest", "x"},
+			complete: true,
+		},
+		{
+			name:     "snowflake top",
+			dialect:  "snowflake",
+			sql:      "SELECT TOP 10 id FROM t",
+			expected: []string{"id"},
+			complete: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := sqllint.Parse(tt.sql, dialect(t, tt.dialect))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, q.ColumnNames())
+			assert.Equal(t, tt.complete, q.Complete())
+		})
+	}
+}
+
+func TestParse_SyntaxErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dialect  string
+		sql      string
+		expected sqllint.Position
+		message  string
+	}{
+		{
+			name:     "empty query",
+			dialect:  "postgres",
+			sql:      "  -- nothing\n;",
+			expected: sqllint.Position{Line: 1, Column: 1},
+			message:  "query is empty",
+		},
+		{
+			name:     "not a select",
+			dialect:  "postgres",
+			sql:      "DELETE FROM users",
+			expected: sqllint.Position{Line: 1, Column: 1},
+			message:  "a SQL model must be a SELECT query, found 'DELETE'",
+		},
+		{
+			name:     "several statements",
+			dialect:  "snowflake",
+			sql:      "SELECT 1;\nSELECT 2",
+			expected: sqllint.Position{Line: 1, Column: 9},
+			message:  "a SQL model must be a single query, found ';' followed by more SQL",
+		},
+		{
+			name:     "unterminated string",
+			dialect:  "postgres",
+			sql:      "SELECT id\nFROM users\nWHERE name = 'bob",
+			expected: sqllint.Position{Line: 3, Column: 14},
+			message:  "unterminated string literal",
+		},
+		{
+			name:     "unterminated quoted identifier",
+			dialect:  "bigquery",
+			sql:      "SELECT `id FROM t",
+			expected: sqllint.Position{Line: 1, Column: 8},
+			message:  "unterminated quoted identifier",
+		},
+		{
+			name:     "unterminated block comment",
+			dialect:  "mysql",
+			sql:      "SELECT id /* oops FROM t",
+			expected: sqllint.Position{Line: 1, Column: 11},
+			message:  "unterminated block comment",
+		},
+		{
+			name:     "backticks outside backtick dialects",
+			dialect:  "postgres",
+			sql:      "SELECT `id` FROM t",
+			expected: sqllint.Position{Line: 1, Column: 8},
+			message:  "unexpected character '`'",
+		},
+		{
+			name:     "unclosed parenthesis",
+			dialect:  "trino",
+			sql:      "SELECT count(id FROM t",
+			expected: sqllint.Position{Line: 1, Column: 13},
+			message:  "'(' is never closed",
+		},
+		{
+			name:     "stray closing parenthesis",
+			dialect:  "trino",
+			sql:      "SELECT id) FROM t",
+			expected: sqllint.Position{Line: 1, Column: 10},
+			message:  "unexpected ')' without a matching opening bracket",
+		},
+		{
+			name:     "mismatched brackets",
+			dialect:  "trino",
+			sql:      "SELECT arr[1) FROM t",
+			expected: sqllint.Position{Line: 1, Column: 13},
+			message:  "expected ']' to close '[' at line 1, column 11, found ')'",
+		},
+		{
+			name:     "empty select list",
+			dialect:  "redshift",
+			sql:      "SELECT FROM t",
+			expected: sqllint.Position{Line: 1, Column: 1},
+			message:  "expected a column list after SELECT, found 'FROM'",
+		},
+		{
+			name:     "trailing comma",
+			dialect:  "snowflake",
+			sql:      "SELECT id,\n  email,\nFROM t",
+			expected: sqllint.Position{Line: 2, Column: 8},
+			message:  "expected a column expression after ',', found 'FROM'",
+		},
+		{
+			name:     "double comma",
+			dialect:  "databricks",
+			sql:      "SELECT id,, email FROM t",
+			expected: sqllint.Position{Line: 1, Column: 11},
+			message:  "expected a column expression before ','",
+		},
+		{
+			name:     "CTE without AS",
+			dialect:  "postgres",
+			sql:      "WITH recent (SELECT 1) SELECT * FROM recent",
+			expected: sqllint.Position{Line: 1, Column: 24},
+			message:  "expected AS after common table expression 'recent', found 'SELECT'",
+		},
+		{
+			name:     "WITH not followed by SELECT",
+			dialect:  "postgres",
+			sql:      "WITH recent AS (SELECT 1) DELETE FROM t",
+			expected: sqllint.Position{Line: 1, Column: 27},
+			message:  "expected SELECT, found 'DELETE'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := sqllint.Parse(tt.sql, dialect(t, tt.dialect))
+			require.Error(t, err)
+
+			var syntaxErr *sqllint.SyntaxError
+			require.True(t, errors.As(err, &syntaxErr))
+			assert.Equal(t, tt.expected, syntaxErr.Pos)
+			assert.Equal(t, tt.message, syntaxErr.Message)
+		})
+	}
+}
+
+func TestQuery_Lookup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dialect  string
+		sql      string
+		lookup   string
+		expected []int
+	}{
+		{
+			name:     "postgres unquoted names fold to lower case",
+			dialect:  "postgres",
+			sql:      `SELECT id, "ID", email AS Email FROM t`,
+			lookup:   "id",
+			expected: []int{8},
+		},
+		{
+			name:     "postgres quoted names keep their case",
+			dialect:  "postgres",
+			sql:      `SELECT id, "ID", email AS Email FROM t`,
+			lookup:   "ID",
+			expected: []int{12},
+		},
+		{
+			name:     "names matching only case-insensitively",
+			dialect:  "postgres",
+			sql:      `SELECT id, "ID", email AS Email FROM t`,
+			lookup:   "Id",
+			expected: []int{8, 12},
+		},
+		{
+			name:     "folded alias",
+			dialect:  "postgres",
+			sql:      `SELECT id, "ID", email AS Email FROM t`,
+			lookup:   "EMAIL",
+			expected: []int{27},
+		},
+		{
+			name:     "snowflake unquoted names fold to upper case",
+			dialect:  "snowflake",
+			sql:      `SELECT id, "id" FROM t`,
+			lookup:   "ID",
+			expected: []int{8},
+		},
+		{
+			name:     "snowflake quoted names keep their case",
+			dialect:  "snowflake",
+			sql:      `SELECT id, "id" FROM t`,
+			lookup:   "id",
+			expected: []int{12},
+		},
+		{
+			name:     "redshift folds quoted names too",
+			dialect:  "redshift",
+			sql:      `SELECT id, "ID" FROM t`,
+			lookup:   "id",
+			expected: []int{8, 12},
+		},
+		{
+			name:     "bigquery names are case-insensitive",
+			dialect:  "bigquery",
+			sql:      "SELECT id, `ID` FROM t",
+			lookup:   "Id",
+			expected: []int{8, 12},
+		},
+		{
+			name:    "no such column",
+			dialect: "postgres",
+			sql:     `SELECT id FROM t`,
+			lookup:  "user_id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := sqllint.Parse(tt.sql, dialect(t, tt.dialect))
+			require.NoError(t, err)
+
+			var columns []int
+			for _, c := range q.Lookup(tt.lookup) {
+				columns = append(columns, c.Pos.Column)
+			}
+			assert.Equal(t, tt.expected, columns)
+		})
+	}
+}
+
+func TestDialect_QuoteString(t *testing.T) {
+	t.Parallel()
+
+	// Standard SQL dialects take backslashes literally and double embedded
+	// quotes; the others read backslash escapes, so backslashes are escaped
+	// as well.
+	doubled := map[string]string{
+		"plain":         `'plain'`,
+		`it's`:          `'it''s'`,
+		`a\`:            `'a\'`,
+		`\'`:            `'\'''`,
+		`it's a \ test`: `'it''s a \ test'`,
+	}
+	escaped := map[string]string{
+		"plain":         `'plain'`,
+		`it's`:          `'it\'s'`,
+		`a\`:            `'a\\'`,
+		`\'`:            `'\\\''`,
+		`it's a \ test`: `'it\'s a \\ test'`,
+	}
+
+	tests := []struct {
+		dialect  string
+		expected map[string]string
+	}{
+		{dialect: "postgres", expected: doubled},
+		{dialect: "trino", expected: doubled},
+		{dialect: "redshift", expected: escaped},
+		{dialect: "snowflake", expected: escaped},
+		{dialect: "bigquery", expected: escaped},
+		{dialect: "mysql", expected: escaped},
+		{dialect: "databricks", expected: escaped},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			t.Parallel()
+
+			d := dialect(t, tt.dialect)
+			for value, expected := range tt.expected {
+				quoted := d.QuoteString(value)
+				assert.Equal(t, expected, quoted, value)
+
+				q, err := sqllint.Parse("SELECT "+quoted+" AS v FROM t", d)
+				require.NoError(t, err, value)
+				assert.Equal(t, []string{"v"}, q.ColumnNames(), value)
+			}
+		})
+	}
+}
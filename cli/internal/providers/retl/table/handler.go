@@ -0,0 +1,284 @@
+package table
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	retlClient "github.com/rudderlabs/rudder-iac/api/client/retl"
+	"github.com/rudderlabs/rudder-iac/cli/internal/namer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/importmanifest"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/writer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/provider/handler"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resolver"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+// TableHandler is the BaseHandler instantiation for warehouse table sources.
+type TableHandler = handler.BaseHandler[
+	TableSpec,
+	TableResource,
+	TableState,
+	RemoteTableSource,
+]
+
+// HandlerMetadata is the static metadata describing the warehouse table
+// handler for the BaseHandler framework.
+var HandlerMetadata = handler.HandlerMetadata{
+	ResourceType:     ResourceType,
+	SpecKind:         ResourceKind,
+	SpecMetadataName: MetadataName,
+}
+
+// tableSourceTypeFilter is the sourceType query value passed to
+// ListRetlSources. It returns S3 sources as well, which are told apart by
+// their config type.
+const tableSourceTypeFilter = string(retlClient.TableSourceType)
+
+// HandlerImpl owns warehouse table source CRUD against the RETL API client.
+type HandlerImpl struct {
+	client    retlClient.RETLStore
+	importDir string
+}
+
+// NewHandler builds a *TableHandler wired to the given client. Exported specs
+// are written under importDir.
+func NewHandler(client retlClient.RETLStore, importDir string) *TableHandler {
+	return handler.NewHandler(&HandlerImpl{
+		client:    client,
+		importDir: filepath.Join(importDir, ImportPath),
+	})
+}
+
+func (h *HandlerImpl) Metadata() handler.HandlerMetadata {
+	return HandlerMetadata
+}
+
+func (h *HandlerImpl) NewSpec() *TableSpec {
+	return &TableSpec{}
+}
+
+// ExtractResourcesFromSpec turns a decoded spec into its TableResource.
+// enabled defaults to true when omitted.
+func (h *HandlerImpl) ExtractResourcesFromSpec(_ string, spec *TableSpec) (map[string]*TableResource, error) {
+	enabled := true
+	if spec.Enabled != nil {
+		enabled = *spec.Enabled
+	}
+
+	return map[string]*TableResource{
+		spec.ID: {
+			ID:               spec.ID,
+			DisplayName:      spec.DisplayName,
+			AccountID:        spec.AccountID,
+			SourceDefinition: spec.SourceDefinition,
+			Schema:           spec.Schema,
+			Table:            spec.Table,
+			PrimaryKey:       spec.PrimaryKey,
+			Enabled:          enabled,
+		},
+	}, nil
+}
+
+// Create provisions the table source remotely, claiming the spec id as its
+// external id in the same call.
+func (h *HandlerImpl) Create(ctx context.Context, data *TableResource) (*TableState, error) {
+	created, err := h.client.CreateRetlSource(ctx, &retlClient.RETLSourceCreateRequest{
+		Name:                 data.DisplayName,
+		Config:               toRETLTableConfig(data),
+		SourceType:           retlClient.TableSourceType,
+		SourceDefinitionName: data.SourceDefinition,
+		AccountID:            data.AccountID,
+		Enabled:              data.Enabled,
+		ExternalID:           data.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating RETL source: %w", err)
+	}
+	return &TableState{ID: created.ID}, nil
+}
+
+// Update pushes the spec to the remote source. The warehouse a source reads
+// from is fixed when it is created.
+func (h *HandlerImpl) Update(ctx context.Context, newData *TableResource, oldData *TableResource, oldState *TableState) (*TableState, error) {
+	if newData.SourceDefinition != oldData.SourceDefinition {
+		return nil, fmt.Errorf("source definition name cannot be changed")
+	}
+	return h.update(ctx, oldState.ID, newData)
+}
+
+func (h *HandlerImpl) update(ctx context.Context, sourceID string, data *TableResource) (*TableState, error) {
+	if _, err := h.client.UpdateRetlSource(ctx, sourceID, &retlClient.RETLSourceUpdateRequest{
+		Name:      data.DisplayName,
+		Config:    toRETLTableConfig(data),
+		IsEnabled: data.Enabled,
+		AccountID: data.AccountID,
+	}); err != nil {
+		return nil, fmt.Errorf("updating RETL source: %w", err)
+	}
+	return &TableState{ID: sourceID}, nil
+}
+
+// Delete removes the remote source.
+func (h *HandlerImpl) Delete(ctx context.Context, _ string, _ *TableResource, oldState *TableState) error {
+	if err := h.client.DeleteRetlSource(ctx, oldState.ID); err != nil {
+		return fmt.Errorf("deleting RETL source: %w", err)
+	}
+	return nil
+}
+
+// Import adopts an existing remote table source, updating it only when it
+// differs from the spec. The spec must read from the same warehouse as the
+// remote source.
+func (h *HandlerImpl) Import(ctx context.Context, data *TableResource, remoteId string) (*TableState, error) {
+	source, err := h.client.GetRetlSource(ctx, remoteId)
+	if err != nil {
+		return nil, fmt.Errorf("getting RETL source: %w", err)
+	}
+
+	existing, err := toResource(data.ID, *source)
+	if err != nil {
+		return nil, err
+	}
+	if existing.SourceDefinition != data.SourceDefinition {
+		return nil, fmt.Errorf("RETL source %s reads from %s, but the spec reads from %s", remoteId, existing.SourceDefinition, data.SourceDefinition)
+	}
+
+	if err := h.client.SetExternalId(ctx, remoteId, data.ID); err != nil {
+		return nil, fmt.Errorf("setting external ID for RETL source: %w", err)
+	}
+
+	if *existing == *data {
+		return &TableState{ID: remoteId}, nil
+	}
+
+	state, err := h.update(ctx, remoteId, data)
+	if err != nil {
+		return nil, fmt.Errorf("importing RETL source: %w", err)
+	}
+	return state, nil
+}
+
+// MapRemoteToState converts a managed remote table source into the spec-side
+// resource and the persisted state.
+func (h *HandlerImpl) MapRemoteToState(remote *RemoteTableSource, _ handler.URNResolver) (*TableResource, *TableState, error) {
+	if remote.ExternalID == "" {
+		return nil, nil, nil
+	}
+
+	resource, err := toResource(remote.ExternalID, remote.RETLSource)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resource, &TableState{ID: remote.ID}, nil
+}
+
+// LoadRemoteResources returns the managed table sources (ExternalID set).
+func (h *HandlerImpl) LoadRemoteResources(ctx context.Context) ([]*RemoteTableSource, error) {
+	return h.listTables(ctx, true)
+}
+
+// LoadImportableResources returns the unmanaged table sources (no ExternalID).
+func (h *HandlerImpl) LoadImportableResources(ctx context.Context) ([]*RemoteTableSource, error) {
+	return h.listTables(ctx, false)
+}
+
+// FormatForExport converts unmanaged remote table sources into importable
+// YAML specs, one per source.
+func (h *HandlerImpl) FormatForExport(
+	collection map[string]*RemoteTableSource,
+	_ namer.Namer,
+	_ resolver.ReferenceResolver,
+) ([]writer.FormattableEntity, []importmanifest.ImportEntry, error) {
+	var (
+		entities []writer.FormattableEntity
+		entries  []importmanifest.ImportEntry
+	)
+
+	for externalID, remote := range collection {
+		resource, err := toResource(externalID, remote.RETLSource)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		urn := resources.URN(externalID, ResourceType)
+		entries = append(entries, importmanifest.ImportEntry{
+			WorkspaceID: remote.WorkspaceID,
+			URN:         urn,
+			RemoteID:    remote.ID,
+		})
+
+		spec, err := specs.ToImportSpec(ResourceKind, externalID, specs.WorkspaceImportMetadata{
+			WorkspaceID: remote.WorkspaceID,
+			Resources:   []specs.ImportIds{{URN: urn, RemoteID: remote.ID}},
+		}, toSpecMap(resource))
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating spec for RETL source %s: %w", remote.ID, err)
+		}
+
+		entities = append(entities, writer.FormattableEntity{
+			Content:      spec,
+			RelativePath: filepath.Join(h.importDir, fmt.Sprintf("%s.yaml", externalID)),
+		})
+	}
+
+	return entities, entries, nil
+}
+
+// listTables lists the table sources matching hasExternalID, leaving out the
+// S3 sources the same listing returns.
+func (h *HandlerImpl) listTables(ctx context.Context, hasExternalID bool) ([]*RemoteTableSource, error) {
+	sources, err := h.client.ListRetlSources(ctx, retlClient.WithSourceType(tableSourceTypeFilter), retlClient.WithHasExternalId(&hasExternalID))
+	if err != nil {
+		return nil, fmt.Errorf("listing RETL sources: %w", err)
+	}
+
+	var tables []*RemoteTableSource
+	for _, source := range sources.Data {
+		if _, ok := source.Config.(retlClient.RETLTableConfig); ok {
+			tables = append(tables, &RemoteTableSource{RETLSource: source})
+		}
+	}
+	return tables, nil
+}
+
+// toResource builds the comparable resource of a remote table source.
+func toResource(id string, source retlClient.RETLSource) (*TableResource, error) {
+	cfg, err := retlClient.DecodeConfig[retlClient.RETLTableConfig](source.Config)
+	if err != nil {
+		return nil, fmt.Errorf("decoding table config for source %s: %w", source.ID, err)
+	}
+	return &TableResource{
+		ID:               id,
+		DisplayName:      source.Name,
+		AccountID:        source.AccountID,
+		SourceDefinition: source.SourceDefinitionName,
+		Schema:           cfg.Schema,
+		Table:            cfg.Table,
+		PrimaryKey:       cfg.PrimaryKey,
+		Enabled:          source.IsEnabled,
+	}, nil
+}
+
+// toSpecMap builds the "spec" section of an importable table source's YAML.
+func toSpecMap(resource *TableResource) map[string]any {
+	return map[string]any{
+		IDKey:               resource.ID,
+		DisplayNameKey:      resource.DisplayName,
+		AccountIDKey:        resource.AccountID,
+		SourceDefinitionKey: resource.SourceDefinition,
+		SchemaKey:           resource.Schema,
+		TableKey:            resource.Table,
+		PrimaryKeyKey:       resource.PrimaryKey,
+		EnabledKey:          resource.Enabled,
+	}
+}
+
+func toRETLTableConfig(data *TableResource) retlClient.RETLTableConfig {
+	return retlClient.RETLTableConfig{
+		Schema:     data.Schema,
+		Table:      data.Table,
+		PrimaryKey: data.PrimaryKey,
+	}
+}
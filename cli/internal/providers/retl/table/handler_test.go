@@ -0,0 +1,402 @@
+package table_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	retlClient "github.com/rudderlabs/rudder-iac/api/client/retl"
+	"github.com/rudderlabs/rudder-iac/cli/internal/namer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/table"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+// mockStore implements the source and preview calls the table handler makes;
+// any other RETLStore call panics on the nil embedded interface.
+type mockStore struct {
+	retlClient.RETLStore
+
+	sources     []retlClient.RETLSource
+	listOpts    []retlClient.ListRetlSourcesOptions
+	createReqs  []*retlClient.RETLSourceCreateRequest
+	updateReqs  map[string]*retlClient.RETLSourceUpdateRequest
+	externalIDs map[string]string
+	previewReqs []*retlClient.PreviewSubmitRequest
+}
+
+func newMockStore(sources ...retlClient.RETLSource) *mockStore {
+	return &mockStore{
+		sources:     sources,
+		updateReqs:  map[string]*retlClient.RETLSourceUpdateRequest{},
+		externalIDs: map[string]string{},
+	}
+}
+
+func (m *mockStore) ListRetlSources(_ context.Context, opts ...retlClient.ListRetlSourcesOption) (*retlClient.RETLSources, error) {
+	resolved := retlClient.ListRetlSourcesOptions{}
+	for _, opt := range opts {
+		opt(&resolved)
+	}
+	m.listOpts = append(m.listOpts, resolved)
+	return &retlClient.RETLSources{Data: m.sources}, nil
+}
+
+func (m *mockStore) GetRetlSource(_ context.Context, id string) (*retlClient.RETLSource, error) {
+	for _, source := range m.sources {
+		if source.ID == id {
+			return &source, nil
+		}
+	}
+	return nil, assert.AnError
+}
+
+func (m *mockStore) CreateRetlSource(_ context.Context, req *retlClient.RETLSourceCreateRequest) (*retlClient.RETLSource, error) {
+	m.createReqs = append(m.createReqs, req)
+	return &retlClient.RETLSource{
+		ID:                   "remote-1",
+		Name:                 req.Name,
+		Config:               req.Config,
+		SourceType:           req.SourceType,
+		SourceDefinitionName: req.SourceDefinitionName,
+		AccountID:            req.AccountID,
+		IsEnabled:            req.Enabled,
+		ExternalID:           req.ExternalID,
+	}, nil
+}
+
+func (m *mockStore) UpdateRetlSource(_ context.Context, id string, req *retlClient.RETLSourceUpdateRequest) (*retlClient.RETLSource, error) {
+	m.updateReqs[id] = req
+	return &retlClient.RETLSource{
+		ID:                   id,
+		Name:                 req.Name,
+		Config:               req.Config,
+		SourceType:           retlClient.TableSourceType,
+		SourceDefinitionName: "snowflake",
+		AccountID:            req.AccountID,
+		IsEnabled:            req.IsEnabled,
+	}, nil
+}
+
+func (m *mockStore) SetExternalId(_ context.Context, id, externalID string) error {
+	m.externalIDs[id] = externalID
+	return nil
+}
+
+func (m *mockStore) SubmitSourcePreview(_ context.Context, req *retlClient.PreviewSubmitRequest) (*retlClient.PreviewSubmitResponse, error) {
+	m.previewReqs = append(m.previewReqs, req)
+	return &retlClient.PreviewSubmitResponse{ID: "req-1"}, nil
+}
+
+func (m *mockStore) GetSourcePreviewResult(_ context.Context, _ string) (*retlClient.PreviewResultResponse, error) {
+	return &retlClient.PreviewResultResponse{
+		Status: retlClient.Completed,
+		Rows:   []map[string]any{{"id": 1}},
+	}, nil
+}
+
+func warehouseTable(id, externalID string) retlClient.RETLSource {
+	return retlClient.RETLSource{
+		ID:                   id,
+		Name:                 "Users",
+		SourceType:           retlClient.TableSourceType,
+		SourceDefinitionName: "snowflake",
+		AccountID:            "acc-1",
+		WorkspaceID:          "ws-1",
+		IsEnabled:            true,
+		ExternalID:           externalID,
+		Config: retlClient.RETLTableConfig{
+			Schema:     "analytics",
+			Table:      "users",
+			PrimaryKey: "id",
+		},
+	}
+}
+
+func s3Source(id string) retlClient.RETLSource {
+	return retlClient.RETLSource{
+		ID:                   id,
+		Name:                 "Exports",
+		SourceType:           retlClient.TableSourceType,
+		SourceDefinitionName: "s3",
+		AccountID:            "acc-2",
+		Config:               retlClient.RETLS3TableConfig{BucketName: "acme-exports"},
+	}
+}
+
+func tableSpec(fields map[string]any) *specs.Spec {
+	spec := map[string]any{
+		"id":                "users",
+		"display_name":      "Users",
+		"account_id":        "acc-1",
+		"source_definition": "snowflake",
+		"schema":            "analytics",
+		"table":             "users",
+		"primary_key":       "id",
+	}
+	for k, v := range fields {
+		spec[k] = v
+	}
+	return &specs.Spec{Version: specs.SpecVersionV1, Kind: table.ResourceKind, Spec: spec}
+}
+
+func tableResource() *table.TableResource {
+	return &table.TableResource{
+		ID:               "users",
+		DisplayName:      "Users",
+		AccountID:        "acc-1",
+		SourceDefinition: "snowflake",
+		Schema:           "analytics",
+		Table:            "users",
+		PrimaryKey:       "id",
+		Enabled:          true,
+	}
+}
+
+func TestTableHandler(t *testing.T) {
+	t.Run("LoadSpec", func(t *testing.T) {
+		t.Parallel()
+
+		h := table.NewHandler(newMockStore(), "retl")
+		require.NoError(t, h.LoadSpec("users.yaml", tableSpec(nil)))
+
+		res, err := h.Resources()
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, table.ResourceType, res[0].Type())
+		assert.Equal(t, tableResource(), res[0].RawData())
+
+		err = h.LoadSpec("copy.yaml", tableSpec(nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "a resource of type 'retl-source-table' with id 'users' already exists")
+	})
+
+	t.Run("LoadSpec defaults enabled to true", func(t *testing.T) {
+		t.Parallel()
+
+		h := table.NewHandler(newMockStore(), "retl")
+		require.NoError(t, h.LoadSpec("users.yaml", tableSpec(map[string]any{"enabled": false})))
+
+		res, err := h.Resources()
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.False(t, res[0].RawData().(*table.TableResource).Enabled)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore()
+		h := table.NewHandler(store, "retl")
+
+		out, err := h.Create(context.Background(), tableResource())
+		require.NoError(t, err)
+
+		require.Len(t, store.createReqs, 1)
+		req := store.createReqs[0]
+		assert.Equal(t, retlClient.TableSourceType, req.SourceType)
+		assert.Equal(t, "snowflake", req.SourceDefinitionName)
+		assert.Equal(t, "users", req.ExternalID)
+		assert.Equal(t, retlClient.RETLTableConfig{Schema: "analytics", Table: "users", PrimaryKey: "id"}, req.Config)
+
+		assert.Equal(t, &table.TableState{ID: "remote-1"}, out)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore()
+		h := table.NewHandler(store, "retl")
+
+		data := tableResource()
+		data.Table = "customers"
+		state := &table.TableState{ID: "remote-1"}
+
+		_, err := h.Update(context.Background(), data, tableResource(), state)
+		require.NoError(t, err)
+		require.Contains(t, store.updateReqs, "remote-1")
+		assert.Equal(t, "customers", store.updateReqs["remote-1"].Config.(retlClient.RETLTableConfig).Table)
+
+		data.SourceDefinition = "bigquery"
+		_, err = h.Update(context.Background(), data, tableResource(), state)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "source definition name cannot be changed")
+	})
+
+	t.Run("LoadResourcesFromRemote skips S3 sources", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore(warehouseTable("remote-1", "users"), s3Source("remote-2"))
+		h := table.NewHandler(store, "retl")
+
+		collection, err := h.LoadResourcesFromRemote(context.Background())
+		require.NoError(t, err)
+
+		require.Len(t, store.listOpts, 1)
+		assert.Equal(t, "table", store.listOpts[0].SourceType)
+		require.NotNil(t, store.listOpts[0].HasExternalId)
+		assert.True(t, *store.listOpts[0].HasExternalId)
+
+		all := collection.GetAll(table.ResourceType)
+		require.Len(t, all, 1)
+		assert.Contains(t, all, "remote-1")
+	})
+
+	t.Run("MapRemoteToState", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore(warehouseTable("remote-1", "users"))
+		h := table.NewHandler(store, "retl")
+
+		collection, err := h.LoadResourcesFromRemote(context.Background())
+		require.NoError(t, err)
+
+		st, err := h.MapRemoteToState(collection)
+		require.NoError(t, err)
+
+		rs := st.GetResource(resources.URN("users", table.ResourceType))
+		require.NotNil(t, rs)
+		assert.Equal(t, tableResource(), rs.InputRaw)
+		assert.Equal(t, &table.TableState{ID: "remote-1"}, rs.OutputRaw)
+	})
+
+	t.Run("LoadImportable and FormatForExport", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore(warehouseTable("remote-1", ""), s3Source("remote-2"))
+		h := table.NewHandler(store, "retl")
+
+		collection, err := h.LoadImportable(context.Background(), namer.NewExternalIdNamer(namer.StrategyKebabCase))
+		require.NoError(t, err)
+
+		importable, ok := collection.GetByID(table.ResourceType, "remote-1")
+		require.True(t, ok)
+		assert.Equal(t, "users", importable.ExternalID)
+		assert.Equal(t, "#retl-source-table:users", importable.Reference)
+		_, ok = collection.GetByID(table.ResourceType, "remote-2")
+		assert.False(t, ok)
+
+		entities, entries, err := h.FormatForExport(collection, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, entities, 1)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "retl/tables/users.yaml", entities[0].RelativePath)
+		assert.Equal(t, "ws-1", entries[0].WorkspaceID)
+		assert.Equal(t, resources.URN("users", table.ResourceType), entries[0].URN)
+
+		spec := entities[0].Content.(*specs.Spec)
+		assert.Equal(t, table.ResourceKind, spec.Kind)
+		assert.Equal(t, map[string]any{
+			"id":                "users",
+			"display_name":      "Users",
+			"account_id":        "acc-1",
+			"source_definition": "snowflake",
+			"schema":            "analytics",
+			"table":             "users",
+			"primary_key":       "id",
+			"enabled":           true,
+		}, spec.Spec)
+	})
+
+	t.Run("Import updates diverging sources", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore(warehouseTable("remote-1", ""))
+		h := table.NewHandler(store, "retl")
+
+		data := tableResource()
+		_, err := h.Import(context.Background(), data, "remote-1")
+		require.NoError(t, err)
+		assert.Equal(t, "users", store.externalIDs["remote-1"])
+		assert.Empty(t, store.updateReqs)
+
+		data.PrimaryKey = "user_id"
+		_, err = h.Import(context.Background(), data, "remote-1")
+		require.NoError(t, err)
+		require.Contains(t, store.updateReqs, "remote-1")
+		assert.Equal(t, "user_id", store.updateReqs["remote-1"].Config.(retlClient.RETLTableConfig).PrimaryKey)
+	})
+
+	t.Run("Import rejects a different warehouse", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore(warehouseTable("remote-1", ""))
+		h := table.NewHandler(store, "retl")
+
+		data := tableResource()
+		data.SourceDefinition = "bigquery"
+		_, err := h.Import(context.Background(), data, "remote-1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reads from snowflake, but the spec reads from bigquery")
+		assert.Empty(t, store.externalIDs)
+	})
+}
+
+func TestPreview(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		sourceDefinition string
+		schema           string
+		table            string
+		expectedSQL      string
+	}{
+		{
+			name:             "plain identifiers are left unquoted",
+			sourceDefinition: "snowflake",
+			schema:           "analytics",
+			table:            "users",
+			expectedSQL:      "SELECT * FROM analytics.users",
+		},
+		{
+			name:             "double quotes for postgres",
+			sourceDefinition: "postgres",
+			schema:           "public",
+			table:            `user "events"`,
+			expectedSQL:      `SELECT * FROM public."user ""events"""`,
+		},
+		{
+			name:             "backticks for bigquery",
+			sourceDefinition: "bigquery",
+			schema:           "my-project.analytics",
+			table:            "users",
+			expectedSQL:      "SELECT * FROM `my-project.analytics`.users",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := newMockStore()
+
+			data := tableResource()
+			data.SourceDefinition = tt.sourceDefinition
+			data.Schema = tt.schema
+			data.Table = tt.table
+
+			rows, err := table.Preview(context.Background(), store, data, 5)
+			require.NoError(t, err)
+			assert.Equal(t, []map[string]any{{"id": 1}}, rows)
+
+			require.Len(t, store.previewReqs, 1)
+			assert.Equal(t, tt.expectedSQL, store.previewReqs[0].SQL)
+			assert.Equal(t, "acc-1", store.previewReqs[0].AccountID)
+			assert.Equal(t, 5, store.previewReqs[0].Limit)
+		})
+	}
+
+	t.Run("missing account", func(t *testing.T) {
+		t.Parallel()
+
+		data := tableResource()
+		data.AccountID = ""
+
+		_, err := table.Preview(context.Background(), newMockStore(), data, 5)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "account ID is required")
+	})
+}
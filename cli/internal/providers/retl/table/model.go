@@ -0,0 +1,77 @@
+package table
+
+import (
+	retlClient "github.com/rudderlabs/rudder-iac/api/client/retl"
+	"github.com/rudderlabs/rudder-iac/cli/internal/provider/handler"
+)
+
+// ResourceType is the type identifier for warehouse table resources
+const (
+	ResourceType = "retl-source-table"
+	ResourceKind = "retl-source-table"
+	MetadataName = "retl-source-table"
+	ImportPath   = "tables"
+
+	IDKey               = "id"
+	DisplayNameKey      = "display_name"
+	AccountIDKey        = "account_id"
+	SourceDefinitionKey = "source_definition"
+	SchemaKey           = "schema"
+	TableKey            = "table"
+	PrimaryKeyKey       = "primary_key"
+	EnabledKey          = "enabled"
+)
+
+// TableSpec represents the YAML specification for a RETL source reading a
+// warehouse table as-is. JSON tags enable the typed rule engine's
+// json.Marshal/Unmarshal round-trip; validate tags drive go-playground/validator
+// checks.
+type TableSpec struct {
+	ID               string `json:"id"                mapstructure:"id"                validate:"required"`
+	DisplayName      string `json:"display_name"      mapstructure:"display_name"      validate:"required"`
+	AccountID        string `json:"account_id"        mapstructure:"account_id"        validate:"required"`
+	SourceDefinition string `json:"source_definition" mapstructure:"source_definition" validate:"required,oneof=postgres redshift snowflake bigquery mysql databricks trino"`
+	Schema           string `json:"schema"            mapstructure:"schema"            validate:"required"`
+	Table            string `json:"table"             mapstructure:"table"             validate:"required"`
+	PrimaryKey       string `json:"primary_key"       mapstructure:"primary_key"       validate:"required"`
+	Enabled          *bool  `json:"enabled"           mapstructure:"enabled"`
+}
+
+// TableResource is the resolved in-memory representation of a table source
+// compared by the differ.
+type TableResource struct {
+	ID               string
+	DisplayName      string
+	AccountID        string
+	SourceDefinition string
+	Schema           string
+	Table            string
+	PrimaryKey       string
+	Enabled          bool
+}
+
+// SourceName returns the display name of the source.
+func (r *TableResource) SourceName() string {
+	return r.DisplayName
+}
+
+// TableState is the persisted apply-cycle state: the remote source ID.
+type TableState struct {
+	ID string
+}
+
+// RemoteTableSource wraps a RETL source read from a warehouse table.
+type RemoteTableSource struct {
+	retlClient.RETLSource
+}
+
+// Metadata exposes the identifying fields BaseHandler uses to key the remote
+// collection and to name importable resources.
+func (r RemoteTableSource) Metadata() handler.RemoteResourceMetadata {
+	return handler.RemoteResourceMetadata{
+		ID:          r.ID,
+		ExternalID:  r.ExternalID,
+		WorkspaceID: r.WorkspaceID,
+		Name:        r.Name,
+	}
+}
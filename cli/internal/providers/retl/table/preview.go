@@ -0,0 +1,49 @@
+package table
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	retlClient "github.com/rudderlabs/rudder-iac/api/client/retl"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+)
+
+// plainIdentifier matches identifiers every supported warehouse accepts
+// unquoted. Anything else is quoted, which some warehouses treat as
+// case-sensitive, so plain identifiers are left alone.
+var plainIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Preview reads rows of the table through the same preview API SQL models use,
+// by selecting everything from it. If limit is 0, the request will be
+// validated without returning data.
+func Preview(ctx context.Context, client retlClient.RETLStore, resource *TableResource, limit int) ([]map[string]any, error) {
+	if resource.AccountID == "" {
+		return nil, fmt.Errorf("account ID is required to preview table %s", resource.ID)
+	}
+	if resource.Schema == "" || resource.Table == "" {
+		return nil, fmt.Errorf("schema and table are required to preview table %s", resource.ID)
+	}
+
+	return sqlmodel.RunPreview(ctx, client, previewSQL(resource.SourceDefinition, resource.Schema, resource.Table), resource.AccountID, limit)
+}
+
+// previewSQL selects every column of schema.table, quoting each identifier in
+// the warehouse's own style when it needs quoting.
+func previewSQL(sourceDefinition, schema, table string) string {
+	quote := `"`
+	switch sqlmodel.SourceDefinition(sourceDefinition) {
+	case sqlmodel.SourceDefinitionBigQuery, sqlmodel.SourceDefinitionMySQL, sqlmodel.SourceDefinitionDatabricks:
+		quote = "`"
+	}
+
+	identifier := func(name string) string {
+		if plainIdentifier.MatchString(name) {
+			return name
+		}
+		return quote + strings.ReplaceAll(name, quote, quote+quote) + quote
+	}
+
+	return fmt.Sprintf("SELECT * FROM %s.%s", identifier(schema), identifier(table))
+}
@@ -0,0 +1,281 @@
+package display
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/samber/lo"
+
+	transformations "github.com/rudderlabs/rudder-iac/api/client/transformations"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/testorchestrator"
+)
+
+// ReportFormat is a test report format understood by CI tooling.
+type ReportFormat string
+
+const (
+	ReportFormatJUnit ReportFormat = "junit"
+	ReportFormatTAP   ReportFormat = "tap"
+
+	// librariesSuiteName names the suite libraries are reported in.
+	librariesSuiteName = "libraries"
+
+	// reportDiffContextLines is the context shown around changes in output
+	// diffs, matching the verbose terminal output.
+	reportDiffContextLines = 3
+)
+
+// ReportFormats lists the supported report formats.
+var ReportFormats = []ReportFormat{ReportFormatJUnit, ReportFormatTAP}
+
+// ParseReportFormat returns the report format named s.
+func ParseReportFormat(s string) (ReportFormat, error) {
+	format := ReportFormat(strings.ToLower(s))
+	if !lo.Contains(ReportFormats, format) {
+		return "", fmt.Errorf("unsupported report format %q, expected one of: %s", s, strings.Join(lo.Map(ReportFormats, func(f ReportFormat, _ int) string {
+			return string(f)
+		}), ", "))
+	}
+	return format, nil
+}
+
+// WriteReport writes results to w in the given format.
+//
+// Every test definition, that is every input file of every suite, becomes a
+// test case of the suite of its transformation. Libraries are reported as test
+// cases of a suite of their own.
+func WriteReport(w io.Writer, format ReportFormat, results *testorchestrator.TestResults) error {
+	cases := reportCases(results)
+
+	switch format {
+	case ReportFormatJUnit:
+		return writeJUnit(w, cases)
+	case ReportFormatTAP:
+		return writeTAP(w, cases)
+	default:
+		return fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+// reportCase is the outcome of a single test case, independent of the format
+// it is reported in.
+type reportCase struct {
+	suite  string
+	name   string
+	status transformations.TestRunStatus
+	// message summarises a failure or error on a single line.
+	message string
+	// detail holds the output diff of failures and the errors of errored
+	// cases.
+	detail string
+}
+
+func reportCases(results *testorchestrator.TestResults) []reportCase {
+	var cases []reportCase
+
+	libraries := lo.UniqBy(results.Libraries, func(lib transformations.LibraryTestResult) string {
+		return lib.HandleName
+	})
+	for _, lib := range libraries {
+		c := reportCase{
+			suite:  librariesSuiteName,
+			name:   lib.HandleName,
+			status: transformations.TestRunStatusPass,
+		}
+		if !lib.Pass {
+			c.status = transformations.TestRunStatusError
+			c.message = firstLine(lib.Message)
+			c.detail = lib.Message
+		}
+		cases = append(cases, c)
+	}
+
+	for _, tr := range results.Transformations {
+		defsByID := lo.SliceToMap(tr.Definitions, func(def *transformations.TestDefinition) (string, *transformations.TestDefinition) {
+			return def.ID, def
+		})
+
+		for _, res := range tr.Result.TestSuiteResult.Results {
+			c := reportCase{
+				suite:  tr.Result.Name,
+				name:   res.Name,
+				status: res.Status,
+			}
+
+			switch res.Status {
+			case transformations.TestRunStatusFail:
+				var expected []any
+				if def, ok := defsByID[res.ID]; ok {
+					expected = def.ExpectedOutput
+				}
+				c.message = testStatusMismatch
+				c.detail = outputMismatch(expected, res.ActualOutput, reportDiffContextLines)
+
+			case transformations.TestRunStatusError:
+				c.message = testStatusError
+				if len(res.Errors) > 0 {
+					c.message = firstLine(res.Errors[0].Message)
+				}
+				c.detail = describeErrors(res.Errors)
+			}
+
+			cases = append(cases, c)
+		}
+	}
+
+	return cases
+}
+
+// describeErrors lists errors once per distinct message, with the indices of
+// the input events they occurred for.
+func describeErrors(errs []transformations.TestError) string {
+	groups, order := groupErrorsByMessage(errs)
+
+	var sb strings.Builder
+	for i, msg := range order {
+		group := groups[msg]
+		if i > 0 {
+			sb.WriteString("\n\n")
+		}
+		sb.WriteString(group.message)
+		sb.WriteString("\n")
+		if len(group.indices) == 1 {
+			fmt.Fprintf(&sb, labelErrorOccurred1, group.indices[0])
+		} else {
+			indices := lo.Map(group.indices, func(idx int, _ int) string {
+				return fmt.Sprint(idx)
+			})
+			fmt.Fprintf(&sb, labelErrorOccurredN, len(group.indices), strings.Join(indices, ", "))
+		}
+	}
+	return sb.String()
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",cdata"`
+}
+
+func writeJUnit(w io.Writer, cases []reportCase) error {
+	report := junitTestSuites{Name: "transformations"}
+	suiteIdx := make(map[string]int)
+
+	for _, c := range cases {
+		idx, ok := suiteIdx[c.suite]
+		if !ok {
+			idx = len(report.Suites)
+			suiteIdx[c.suite] = idx
+			report.Suites = append(report.Suites, junitTestSuite{Name: c.suite})
+		}
+		suite := &report.Suites[idx]
+
+		tc := junitTestCase{Name: c.name, ClassName: c.suite}
+		switch c.status {
+		case transformations.TestRunStatusFail:
+			tc.Failure = &junitProblem{Message: c.message, Type: failureTypeOutputMismatch, Body: c.detail}
+			suite.Failures++
+			report.Failures++
+		case transformations.TestRunStatusError:
+			tc.Error = &junitProblem{Message: c.message, Type: failureTypeExecutionError, Body: c.detail}
+			suite.Errors++
+			report.Errors++
+		}
+
+		suite.TestCases = append(suite.TestCases, tc)
+		suite.Tests++
+		report.Tests++
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("writing junit report: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("encoding junit report: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("writing junit report: %w", err)
+	}
+	return nil
+}
+
+// writeTAP writes cases as TAP version 13, describing failures and errors in
+// YAML diagnostic blocks.
+func writeTAP(w io.Writer, cases []reportCase) error {
+	var sb strings.Builder
+
+	sb.WriteString("TAP version 13\n")
+	fmt.Fprintf(&sb, "1..%d\n", len(cases))
+
+	for i, c := range cases {
+		result := "ok"
+		if c.status != transformations.TestRunStatusPass {
+			result = "not ok"
+		}
+		fmt.Fprintf(&sb, "%s %d - %s: %s\n", result, i+1, tapEscape(c.suite), tapEscape(c.name))
+
+		if c.status == transformations.TestRunStatusPass {
+			continue
+		}
+
+		severity := "fail"
+		if c.status == transformations.TestRunStatusError {
+			severity = "error"
+		}
+
+		sb.WriteString("  ---\n")
+		fmt.Fprintf(&sb, "  message: %q\n", c.message)
+		fmt.Fprintf(&sb, "  severity: %s\n", severity)
+		if c.detail != "" {
+			sb.WriteString("  detail: |\n")
+			for _, line := range strings.Split(strings.TrimRight(c.detail, "\n"), "\n") {
+				sb.WriteString("    " + line + "\n")
+			}
+		}
+		sb.WriteString("  ...\n")
+	}
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("writing tap report: %w", err)
+	}
+	return nil
+}
+
+// tapEscape escapes the characters that would otherwise end a TAP test
+// description or start a directive.
+func tapEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "#", `\#`, "\n", " ").Replace(s)
+}
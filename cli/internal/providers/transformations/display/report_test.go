@@ -0,0 +1,165 @@
+package display
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	transformations "github.com/rudderlabs/rudder-iac/api/client/transformations"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/testorchestrator"
+)
+
+func reportTestResults() *testorchestrator.TestResults {
+	return &testorchestrator.TestResults{
+		Status: testorchestrator.RunStatusExecuted,
+		Libraries: []transformations.LibraryTestResult{
+			{ID: "lib-1", HandleName: "utils", Pass: true},
+			{ID: "lib-2", HandleName: "broken", Pass: false, Message: "javascript syntax error\n\tat line 3"},
+			{ID: "lib-1", HandleName: "utils", Pass: true},
+		},
+		Transformations: []*testorchestrator.TransformationTestWithDefinitions{
+			{
+				Definitions: []*transformations.TestDefinition{
+					{ID: "smoke/input/a.json", Name: "smoke (a.json)", ExpectedOutput: []any{map[string]any{"event": "a"}}},
+					{ID: "smoke/input/b.json", Name: "smoke (b.json)", ExpectedOutput: []any{map[string]any{"event": "b"}}},
+					{ID: "smoke/input/c.json", Name: "smoke (c.json)"},
+				},
+				Result: &transformations.TransformationTestResult{
+					ID:   "tr-1",
+					Name: "Enrich #1",
+					TestSuiteResult: transformations.TestSuiteRunResult{
+						Status: transformations.TestRunStatusError,
+						Results: []transformations.TestResult{
+							{ID: "smoke/input/a.json", Name: "smoke (a.json)", Status: transformations.TestRunStatusPass},
+							{
+								ID:           "smoke/input/b.json",
+								Name:         "smoke (b.json)",
+								Status:       transformations.TestRunStatusFail,
+								ActualOutput: []any{map[string]any{"event": "changed"}},
+							},
+							{
+								ID:     "smoke/input/c.json",
+								Name:   "smoke (c.json)",
+								Status: transformations.TestRunStatusError,
+								Errors: []transformations.TestError{
+									{Message: "TypeError: boom\n    at transformEvent", EventIndex: 0},
+									{Message: "TypeError: boom\n    at transformEvent", EventIndex: 2},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestParseReportFormat(t *testing.T) {
+	format, err := ParseReportFormat("JUnit")
+	require.NoError(t, err)
+	assert.Equal(t, ReportFormatJUnit, format)
+
+	format, err = ParseReportFormat("tap")
+	require.NoError(t, err)
+	assert.Equal(t, ReportFormatTAP, format)
+
+	_, err = ParseReportFormat("html")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected one of: junit, tap")
+}
+
+func TestWriteReport_JUnit(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteReport(&buf, ReportFormatJUnit, reportTestResults()))
+
+	assert.True(t, strings.HasPrefix(buf.String(), xml.Header))
+
+	var report junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+
+	assert.Equal(t, 5, report.Tests)
+	assert.Equal(t, 1, report.Failures)
+	assert.Equal(t, 2, report.Errors)
+	require.Len(t, report.Suites, 2)
+
+	libs := report.Suites[0]
+	assert.Equal(t, "libraries", libs.Name)
+	assert.Equal(t, 2, libs.Tests)
+	assert.Equal(t, 1, libs.Errors)
+	assert.Nil(t, libs.TestCases[0].Error)
+	require.NotNil(t, libs.TestCases[1].Error)
+	assert.Equal(t, "javascript syntax error", libs.TestCases[1].Error.Message)
+
+	suite := report.Suites[1]
+	assert.Equal(t, "Enrich #1", suite.Name)
+	require.Len(t, suite.TestCases, 3)
+
+	pass, fail, errored := suite.TestCases[0], suite.TestCases[1], suite.TestCases[2]
+	assert.Equal(t, "smoke (a.json)", pass.Name)
+	assert.Equal(t, "Enrich #1", pass.ClassName)
+	assert.Nil(t, pass.Failure)
+	assert.Nil(t, pass.Error)
+
+	require.NotNil(t, fail.Failure)
+	assert.Equal(t, "output mismatch", fail.Failure.Message)
+	assert.Contains(t, fail.Failure.Body, "--- Expected")
+	assert.Contains(t, fail.Failure.Body, `-    "event": "b"`)
+	assert.Contains(t, fail.Failure.Body, `+    "event": "changed"`)
+
+	require.NotNil(t, errored.Error)
+	assert.Equal(t, "TypeError: boom", errored.Error.Message)
+	assert.Contains(t, errored.Error.Body, "This error occurred 2 times (input indices: 0, 2)")
+}
+
+func TestWriteReport_TAP(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteReport(&buf, ReportFormatTAP, reportTestResults()))
+
+	expected := `TAP version 13
+1..5
+ok 1 - libraries: utils
+not ok 2 - libraries: broken
+  ---
+  message: "javascript syntax error"
+  severity: error
+  detail: |
+    javascript syntax error
+    	at line 3
+  ...
+ok 3 - Enrich \#1: smoke (a.json)
+not ok 4 - Enrich \#1: smoke (b.json)
+  ---
+  message: "output mismatch"
+  severity: fail
+  detail: |
+    --- Expected
+    +++ Actual
+    @@ -1,5 +1,5 @@
+     [
+       {
+    -    "event": "b"
+    +    "event": "changed"
+       }
+     ]
+  ...
+not ok 5 - Enrich \#1: smoke (c.json)
+  ---
+  message: "TypeError: boom"
+  severity: error
+  detail: |
+    TypeError: boom
+        at transformEvent
+    This error occurred 2 times (input indices: 0, 2)
+  ...
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteReport_UnsupportedFormat(t *testing.T) {
+	err := WriteReport(&bytes.Buffer{}, ReportFormat("html"), reportTestResults())
+	require.Error(t, err)
+}
@@ -38,22 +38,23 @@ const (
 	testStatusError    = "execution error"
 
 	// Message labels
-	labelFullStackTrace  = "Full stack trace:"
-	labelImportedLibs    = "imported libraries: %s"
-	labelInputEvent      = "Input event at index %d:"
-	labelErrorOccurred1  = "This error occurred 1 time (input index: %d)"
-	labelErrorOccurredN  = "This error occurred %d times (input indices: %s)"
-	labelResultFailed    = "Result: FAILED (exit code 1)"
-	labelResultPassed    = "Result: PASSED"
-	labelLegend          = "Legend: %s passed  %s execution error  %s output mismatch"
-	labelVerboseTip      = "Tip: Run with --verbose for full diffs and input payloads"
-	labelSuiteField      = "Suite:       %s"
-	labelTestField       = "Test:        %s"
-	labelInputFileField  = "Input:       %s"
-	labelOutputFileField = "Output:      %s"
-	labelErrorField      = "Error: %s"
-	labelLibrarySummary  = "Libraries: %d passed, %d errored"
-	labelTestCaseSummary = "Test cases: %d passed, %d errored, %d mismatched"
+	labelFullStackTrace   = "Full stack trace:"
+	labelImportedLibs     = "imported libraries: %s"
+	labelInputEvent       = "Input event at index %d:"
+	labelErrorOccurred1   = "This error occurred 1 time (input index: %d)"
+	labelErrorOccurredN   = "This error occurred %d times (input indices: %s)"
+	labelResultFailed     = "Result: FAILED (exit code 1)"
+	labelResultPassed     = "Result: PASSED"
+	labelLegend           = "Legend: %s passed  %s execution error  %s output mismatch"
+	labelVerboseTip       = "Tip: Run with --verbose for full diffs and input payloads"
+	labelSuiteField       = "Suite:       %s"
+	labelTestField        = "Test:        %s"
+	labelInputFileField   = "Input:       %s"
+	labelOutputFileField  = "Output:      %s"
+	labelErrorField       = "Error: %s"
+	labelLibrarySummary   = "Libraries: %d passed, %d errored"
+	labelTestCaseSummary  = "Test cases: %d passed, %d errored, %d mismatched"
+	labelOutputMismatched = "Actual output mismatched from expected output"
 
 	// Symbols
 	symbolPass     = "✓"
@@ -513,25 +514,27 @@ func groupErrorsByMessage(errors []transformations.TestError) (map[string]*error
 }
 
 func (rd *ResultDisplayer) printMismatchedOutput(detail failureDetail) {
-	if len(detail.expectedOutput) == 0 {
-		newLine()
-		ui.Println("Actual output mismatched from expected output")
-		return
-	}
-
 	contextLines := 0
 	if rd.verbose {
 		contextLines = 3
 	}
 
-	diff := generateDiff(
-		formatJSON(detail.expectedOutput),
-		formatJSON(detail.actualOutput),
+	newLine()
+	ui.Println(outputMismatch(detail.expectedOutput, detail.actualOutput, contextLines))
+}
+
+// outputMismatch describes how the actual output differs from the expected
+// one, as a unified diff when there is an expected output to compare against.
+func outputMismatch(expected, actual []any, contextLines int) string {
+	if len(expected) == 0 {
+		return labelOutputMismatched
+	}
+
+	return generateDiff(
+		formatJSON(expected),
+		formatJSON(actual),
 		contextLines,
 	)
-
-	newLine()
-	ui.Println(diff)
 }
 
 func formatJSON(v any) string {
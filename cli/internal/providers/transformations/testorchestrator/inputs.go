@@ -45,28 +45,19 @@ func ResolveTestDefinitions(transformation *model.TransformationResource) ([]*tr
 // buildTestDefinitionsForSuite builds test cases for a single suite by reading
 // input and output files directly from the configured directories.
 func buildTestDefinitionsForSuite(suite specs.TransformationTest) ([]*transformations.TestDefinition, error) {
-	// Resolve relative paths against SpecDir (set during spec loading)
-	inputDir := resolveDir(suite.SpecDir, suite.Input)
-	outputDir := resolveDir(suite.SpecDir, suite.Output)
-
-	inputFiles, err := listJSONFiles(inputDir)
+	files, err := listSuiteFiles(suite)
 	if err != nil {
-		return nil, fmt.Errorf("listing input files: %w", err)
+		return nil, err
 	}
 
-	if len(inputFiles) == 0 {
+	if len(files.inputs) == 0 {
 		testLogger.Debug("No input files found for suite", "suite", suite.Name)
 		return nil, nil
 	}
 
-	outputFiles, err := listJSONFiles(outputDir)
-	if err != nil {
-		return nil, fmt.Errorf("listing output files: %w", err)
-	}
-
 	var testDefs []*transformations.TestDefinition
 
-	for filename, fullPath := range inputFiles {
+	for filename, fullPath := range files.inputs {
 		inputEvents, err := parseJSONFile(fullPath)
 		if err != nil {
 			return nil, fmt.Errorf("parsing input file %s: %w", filename, err)
@@ -76,7 +67,7 @@ func buildTestDefinitionsForSuite(suite specs.TransformationTest) ([]*transforma
 			expectedOutput []any
 			outputFile     string
 		)
-		if outputPath, exists := outputFiles[filename]; exists {
+		if outputPath, exists := files.outputs[filename]; exists {
 			expectedOutput, err = parseJSONFile(outputPath)
 			if err != nil {
 				return nil, fmt.Errorf("parsing output file %s: %w", filename, err)
@@ -85,7 +76,7 @@ func buildTestDefinitionsForSuite(suite specs.TransformationTest) ([]*transforma
 		}
 
 		testDef := &transformations.TestDefinition{
-			ID:             fmt.Sprintf("%s/%s/%s", suite.Name, suite.Input, filename),
+			ID:             testDefinitionID(suite, filename),
 			Name:           fmt.Sprintf("%s (%s)", suite.Name, filename),
 			InputFile:      filepath.Join(suite.Input, filename),
 			OutputFile:     outputFile,
@@ -98,6 +89,40 @@ func buildTestDefinitionsForSuite(suite specs.TransformationTest) ([]*transforma
 	return testDefs, nil
 }
 
+// suiteFiles holds the JSON files of a test suite keyed by file name. An input
+// file is paired with the output file of the same name.
+type suiteFiles struct {
+	inputDir  string
+	outputDir string
+	inputs    map[string]string
+	outputs   map[string]string
+}
+
+// listSuiteFiles lists the input and output files of suite, resolving relative
+// directories against SpecDir (set during spec loading).
+func listSuiteFiles(suite specs.TransformationTest) (*suiteFiles, error) {
+	files := &suiteFiles{
+		inputDir:  resolveDir(suite.SpecDir, suite.Input),
+		outputDir: resolveDir(suite.SpecDir, suite.Output),
+	}
+
+	var err error
+	if files.inputs, err = listJSONFiles(files.inputDir); err != nil {
+		return nil, fmt.Errorf("listing input files: %w", err)
+	}
+	if files.outputs, err = listJSONFiles(files.outputDir); err != nil {
+		return nil, fmt.Errorf("listing output files: %w", err)
+	}
+
+	return files, nil
+}
+
+// testDefinitionID identifies the test definition built from an input file of
+// suite.
+func testDefinitionID(suite specs.TransformationTest, filename string) string {
+	return fmt.Sprintf("%s/%s/%s", suite.Name, suite.Input, filename)
+}
+
 func defaultTestDefinitions() ([]*transformations.TestDefinition, error) {
 	defaultEvents := GetDefaultEvents()
 
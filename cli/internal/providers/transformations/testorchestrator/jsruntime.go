@@ -0,0 +1,236 @@
+package testorchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dop251/goja"
+	"github.com/evanw/esbuild/pkg/api"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/model"
+)
+
+// rudderStackLibraryPrefix prefixes the built-in libraries the RudderStack
+// transformer provides, which are not available to the embedded engine.
+const rudderStackLibraryPrefix = "@rs/"
+
+// jsRuntime is an embedded JavaScript engine able to load transformation and
+// library code. Code is converted to CommonJS so that library imports resolve
+// through require, against the libraries of the project keyed by import name.
+type jsRuntime struct {
+	vm        *goja.Runtime
+	libraries map[string]*model.LibraryResource
+	modules   map[string]*goja.Object
+	loading   map[string]bool
+}
+
+func newJSRuntime(libraries map[string]*model.LibraryResource) *jsRuntime {
+	rt := &jsRuntime{
+		vm:        goja.New(),
+		libraries: libraries,
+		modules:   make(map[string]*goja.Object),
+		loading:   make(map[string]bool),
+	}
+
+	// Transformations may log through the transformer's log helper; the
+	// output only matters for debugging the CLI itself.
+	logFn := func(call goja.FunctionCall) goja.Value {
+		args := make([]string, 0, len(call.Arguments))
+		for _, arg := range call.Arguments {
+			args = append(args, arg.String())
+		}
+		testLogger.Debug("transformation log", "message", strings.Join(args, " "))
+		return goja.Undefined()
+	}
+	console := rt.vm.NewObject()
+	_ = console.Set("log", logFn)
+	_ = rt.vm.Set("console", console)
+	_ = rt.vm.Set("log", logFn)
+
+	return rt
+}
+
+// load compiles and evaluates code as a module and returns its exports. The
+// evaluation, top-level statements included, is interrupted when ctx is done.
+func (rt *jsRuntime) load(ctx context.Context, name, code string) (*goja.Object, error) {
+	stop := rt.interruptOnDone(ctx)
+	defer stop()
+
+	return rt.evaluate(name, code)
+}
+
+// evaluate compiles and evaluates code as a module and returns its exports.
+// It relies on the caller to guard the engine against code that never
+// returns.
+func (rt *jsRuntime) evaluate(name, code string) (*goja.Object, error) {
+	result := api.Transform(code, api.TransformOptions{
+		Loader:     api.LoaderJS,
+		Format:     api.FormatCommonJS,
+		Target:     api.ES2017,
+		Sourcefile: name,
+	})
+	if len(result.Errors) > 0 {
+		msgs := make([]string, 0, len(result.Errors))
+		for _, e := range result.Errors {
+			msgs = append(msgs, e.Text)
+		}
+		return nil, fmt.Errorf("javascript syntax error: \n\t%s", strings.Join(msgs, "\n\t"))
+	}
+
+	wrapped := "(function (exports, require, module) {\n" + string(result.Code) + "\n})"
+	program, err := goja.Compile(name, wrapped, true)
+	if err != nil {
+		return nil, fmt.Errorf("compiling %s: %w", name, err)
+	}
+
+	fn, err := rt.vm.RunProgram(program)
+	if err != nil {
+		return nil, rt.wrapError(err)
+	}
+	wrapper, ok := goja.AssertFunction(fn)
+	if !ok {
+		return nil, fmt.Errorf("compiling %s: module wrapper is not a function", name)
+	}
+
+	module := rt.vm.NewObject()
+	exports := rt.vm.NewObject()
+	_ = module.Set("exports", exports)
+
+	if _, err := wrapper(goja.Undefined(), exports, rt.vm.ToValue(rt.require), module); err != nil {
+		return nil, rt.wrapError(err)
+	}
+	return module.Get("exports").ToObject(rt.vm), nil
+}
+
+// require resolves an import to the exports of the project library with that
+// import name, loading it on first use. It only runs from within code that
+// load or call already guard.
+func (rt *jsRuntime) require(call goja.FunctionCall) goja.Value {
+	name := call.Argument(0).String()
+
+	if exports, ok := rt.modules[name]; ok {
+		return exports
+	}
+
+	if strings.HasPrefix(name, rudderStackLibraryPrefix) {
+		panic(rt.vm.NewGoError(fmt.Errorf("built-in library %s is not available when testing locally", name)))
+	}
+
+	lib, ok := rt.libraries[name]
+	if !ok {
+		panic(rt.vm.NewGoError(fmt.Errorf("library %s not found in project", name)))
+	}
+
+	if rt.loading[name] {
+		panic(rt.vm.NewGoError(fmt.Errorf("circular import of library %s", name)))
+	}
+	rt.loading[name] = true
+	defer delete(rt.loading, name)
+
+	exports, err := rt.evaluate(name, lib.Code)
+	if err != nil {
+		panic(rt.vm.NewGoError(fmt.Errorf("loading library %s: %w", name, err)))
+	}
+	rt.modules[name] = exports
+	return exports
+}
+
+// call invokes fn with JSON-compatible Go values as arguments and returns its
+// result converted back into Go values. Returned promises are awaited. The
+// call is interrupted when ctx is done.
+func (rt *jsRuntime) call(ctx context.Context, fn goja.Callable, args ...goja.Value) (any, error) {
+	stop := rt.interruptOnDone(ctx)
+	defer stop()
+
+	value, err := fn(goja.Undefined(), args...)
+	if err != nil {
+		return nil, rt.wrapError(err)
+	}
+
+	if promise, ok := value.Export().(*goja.Promise); ok {
+		switch promise.State() {
+		case goja.PromiseStateFulfilled:
+			value = promise.Result()
+		case goja.PromiseStateRejected:
+			return nil, errors.New(rt.describe(promise.Result()))
+		default:
+			return nil, errors.New("returned promise never settled")
+		}
+	}
+
+	return rt.toGo(value)
+}
+
+// interruptOnDone interrupts the engine once ctx is done, until the returned
+// function is called.
+func (rt *jsRuntime) interruptOnDone(ctx context.Context) (stop func() bool) {
+	return context.AfterFunc(ctx, func() {
+		rt.vm.Interrupt(ctx.Err())
+	})
+}
+
+// toJS converts a JSON-compatible Go value into a plain JavaScript value, so
+// code sees ordinary objects and arrays rather than wrapped Go types.
+func (rt *jsRuntime) toJS(v any) (goja.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encoding value: %w", err)
+	}
+	parse, _ := goja.AssertFunction(rt.vm.Get("JSON").ToObject(rt.vm).Get("parse"))
+	return parse(goja.Undefined(), rt.vm.ToValue(string(data)))
+}
+
+// toGo converts a JavaScript value into Go values the way JSON.stringify
+// sees it. undefined and null are both returned as nil.
+func (rt *jsRuntime) toGo(value goja.Value) (any, error) {
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		return nil, nil
+	}
+
+	stringify, _ := goja.AssertFunction(rt.vm.Get("JSON").ToObject(rt.vm).Get("stringify"))
+	encoded, err := stringify(goja.Undefined(), value)
+	if err != nil {
+		return nil, rt.wrapError(err)
+	}
+	if goja.IsUndefined(encoded) {
+		return nil, nil
+	}
+
+	var result any
+	if err := json.Unmarshal([]byte(encoded.String()), &result); err != nil {
+		return nil, fmt.Errorf("decoding value: %w", err)
+	}
+	return result, nil
+}
+
+// wrapError converts engine errors into errors whose message carries the
+// JavaScript error on the first line and its stack trace on the following
+// ones, the shape the result displayer expects.
+func (rt *jsRuntime) wrapError(err error) error {
+	var interrupted *goja.InterruptedError
+	if errors.As(err, &interrupted) {
+		if cause, ok := interrupted.Value().(error); ok && errors.Is(cause, context.DeadlineExceeded) {
+			return errors.New("execution timed out")
+		}
+		return fmt.Errorf("execution interrupted: %v", interrupted.Value())
+	}
+
+	var exception *goja.Exception
+	if errors.As(err, &exception) {
+		return errors.New(strings.TrimSpace(exception.String()))
+	}
+	return err
+}
+
+// describe renders a thrown or rejected value.
+func (rt *jsRuntime) describe(value goja.Value) string {
+	if obj, ok := value.(*goja.Object); ok {
+		if stack := obj.Get("stack"); stack != nil && !goja.IsUndefined(stack) {
+			return stack.String()
+		}
+	}
+	return value.String()
+}
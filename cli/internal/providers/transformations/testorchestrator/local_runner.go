@@ -0,0 +1,339 @@
+package testorchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+
+	transformations "github.com/rudderlabs/rudder-iac/api/client/transformations"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/model"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/parser"
+	ttypes "github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/types"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+const (
+	languageJavaScript = "javascript"
+
+	// DefaultLocalTimeout bounds a single test definition run locally, so a
+	// transformation that never returns cannot hang the test run.
+	DefaultLocalTimeout = 10 * time.Second
+)
+
+// ErrModifiedNotSupportedLocally is returned when running modified tests
+// locally: finding what changed requires comparing against the workspace.
+var ErrModifiedNotSupportedLocally = errors.New("testing modified transformations requires the workspace and is not supported locally")
+
+// LocalRunner executes JavaScript transformation tests in an embedded engine
+// instead of the transformer API, so no workspace, credentials or network are
+// needed. Library imports resolve to the libraries defined in the project.
+type LocalRunner struct {
+	graph   *resources.Graph
+	planner *Planner
+	parser  parser.JavaScriptParser
+	timeout time.Duration
+}
+
+// NewLocalRunner creates a runner testing the transformations of graph locally.
+func NewLocalRunner(graph *resources.Graph) *LocalRunner {
+	return &LocalRunner{
+		graph:   graph,
+		planner: NewPlanner(graph),
+		timeout: DefaultLocalTimeout,
+	}
+}
+
+// Run executes tests based on the specified mode and returns results in the
+// same shape as Runner.Run.
+func (r *LocalRunner) Run(ctx context.Context, mode Mode, targetID string) (*TestResults, error) {
+	testLogger.Info("Starting local test run", "mode", mode, "targetID", targetID)
+
+	if mode == ModeModified {
+		return nil, ErrModifiedNotSupportedLocally
+	}
+
+	// Without a remote graph every resource counts as new, which does not
+	// matter for the single and all modes.
+	testPlan, err := r.planner.BuildPlan(resources.NewGraph(), mode, targetID, "")
+	if err != nil {
+		return nil, fmt.Errorf("building test plan: %w", err)
+	}
+
+	if len(testPlan.TestUnits) == 0 && len(testPlan.StandaloneLibraries) == 0 {
+		testLogger.Info("No resources to test")
+		return &TestResults{Status: RunStatusNoResources}, nil
+	}
+
+	libraries, err := r.librariesByImportName()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		results    = &TestResults{Status: RunStatusExecuted}
+		testedLibs = make(map[string]struct{})
+	)
+
+	testLibrary := func(lib *model.LibraryResource) {
+		if _, ok := testedLibs[lib.ID]; ok {
+			return
+		}
+		testedLibs[lib.ID] = struct{}{}
+		results.Libraries = append(results.Libraries, r.testLibrary(ctx, lib, libraries))
+	}
+
+	for _, unit := range testPlan.TestUnits {
+		testDefs, err := ResolveTestDefinitions(unit.Transformation)
+		if err != nil {
+			return nil, fmt.Errorf("resolving test definitions for %s: %w", unit.Transformation.ID, err)
+		}
+
+		for _, lib := range unit.Libraries {
+			testLibrary(lib)
+		}
+
+		results.Transformations = append(results.Transformations, &TransformationTestWithDefinitions{
+			Result:      r.testTransformation(ctx, unit.Transformation, testDefs, libraries),
+			Definitions: testDefs,
+		})
+	}
+
+	for _, lib := range testPlan.StandaloneLibraries {
+		testLibrary(lib)
+	}
+
+	return results, nil
+}
+
+func (r *LocalRunner) librariesByImportName() (map[string]*model.LibraryResource, error) {
+	libraries := make(map[string]*model.LibraryResource)
+	for _, resource := range r.graph.ResourcesByType(ttypes.LibraryResourceType) {
+		lib, ok := resource.RawData().(*model.LibraryResource)
+		if !ok {
+			return nil, fmt.Errorf("extracting library data for %s", resource.ID())
+		}
+		libraries[lib.ImportName] = lib
+	}
+	return libraries, nil
+}
+
+// testLibrary checks that a library parses and evaluates, the local
+// equivalent of the transformer's library validation.
+func (r *LocalRunner) testLibrary(ctx context.Context, lib *model.LibraryResource, libraries map[string]*model.LibraryResource) transformations.LibraryTestResult {
+	result := transformations.LibraryTestResult{
+		ID:         lib.ID,
+		Name:       lib.Name,
+		HandleName: lib.ImportName,
+		Pass:       true,
+	}
+
+	if err := r.checkLanguage(lib.Language); err != nil {
+		result.Pass, result.Message = false, err.Error()
+		return result
+	}
+
+	if _, err := r.parser.ExtractImports(lib.Code); err != nil {
+		result.Pass, result.Message = false, err.Error()
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if _, err := newJSRuntime(libraries).load(ctx, lib.ImportName, lib.Code); err != nil {
+		result.Pass, result.Message = false, err.Error()
+	}
+	return result
+}
+
+func (r *LocalRunner) testTransformation(
+	ctx context.Context,
+	tr *model.TransformationResource,
+	testDefs []*transformations.TestDefinition,
+	libraries map[string]*model.LibraryResource,
+) *transformations.TransformationTestResult {
+	result := &transformations.TransformationTestResult{
+		ID:   tr.ID,
+		Name: tr.Name,
+	}
+
+	// Problems with the code as a whole fail every test definition.
+	setupErr := r.checkLanguage(tr.Language)
+	if setupErr == nil {
+		result.Imports, setupErr = r.parser.ExtractImports(tr.Code)
+	}
+	if setupErr == nil {
+		for _, name := range result.Imports {
+			if _, ok := libraries[name]; !ok {
+				setupErr = fmt.Errorf("library %s not found in project", name)
+				break
+			}
+		}
+	}
+
+	for _, def := range testDefs {
+		var testResult transformations.TestResult
+		if setupErr != nil {
+			testResult = erroredTestResult(def, setupErr)
+		} else {
+			testResult = r.runTestDefinition(ctx, tr, def, libraries)
+		}
+		result.TestSuiteResult.Results = append(result.TestSuiteResult.Results, testResult)
+	}
+
+	result.TestSuiteResult.Status = suiteStatus(result.TestSuiteResult.Results)
+	result.Pass = result.TestSuiteResult.Status == transformations.TestRunStatusPass
+	return result
+}
+
+func (r *LocalRunner) checkLanguage(language string) error {
+	if !strings.EqualFold(language, languageJavaScript) {
+		return fmt.Errorf("%s code cannot be tested locally, run the tests without --local", language)
+	}
+	return nil
+}
+
+// runTestDefinition runs one test definition in a fresh engine, so state
+// kept in module scope does not leak between test definitions.
+func (r *LocalRunner) runTestDefinition(
+	ctx context.Context,
+	tr *model.TransformationResource,
+	def *transformations.TestDefinition,
+	libraries map[string]*model.LibraryResource,
+) transformations.TestResult {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	rt := newJSRuntime(libraries)
+	exports, err := rt.load(ctx, tr.ID, tr.Code)
+	if err != nil {
+		return erroredTestResult(def, err)
+	}
+
+	output, testErrors, err := r.transform(ctx, rt, exports, def.Input)
+	if err != nil {
+		return erroredTestResult(def, err)
+	}
+
+	testResult := transformations.TestResult{
+		ID:           def.ID,
+		Name:         def.Name,
+		Description:  def.Description,
+		ActualOutput: output,
+		Errors:       testErrors,
+	}
+
+	switch {
+	case len(testErrors) > 0:
+		testResult.Status = transformations.TestRunStatusError
+	case def.ExpectedOutput != nil && !outputsEqual(def.ExpectedOutput, output):
+		testResult.Status = transformations.TestRunStatusFail
+	default:
+		testResult.Status = transformations.TestRunStatusPass
+	}
+	return testResult
+}
+
+// transform feeds the input events through transformBatch when the code
+// exports it, and otherwise through transformEvent one event at a time.
+// Events transformEvent returns null or undefined for are dropped, and
+// arrays it returns are flattened into the output.
+func (r *LocalRunner) transform(ctx context.Context, rt *jsRuntime, exports *goja.Object, input []any) ([]any, []transformations.TestError, error) {
+	metadata := rt.vm.ToValue(func(goja.FunctionCall) goja.Value {
+		return rt.vm.NewObject()
+	})
+
+	if fn, ok := goja.AssertFunction(exports.Get("transformBatch")); ok {
+		events, err := rt.toJS(input)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		out, err := rt.call(ctx, fn, events, metadata)
+		if err != nil {
+			return nil, []transformations.TestError{{Message: err.Error()}}, nil
+		}
+		if out == nil {
+			return nil, nil, nil
+		}
+		batch, ok := out.([]any)
+		if !ok {
+			return nil, []transformations.TestError{{Message: "transformBatch must return an array of events"}}, nil
+		}
+		return batch, nil, nil
+	}
+
+	fn, ok := goja.AssertFunction(exports.Get("transformEvent"))
+	if !ok {
+		return nil, nil, errors.New("transformation must export a transformEvent or transformBatch function")
+	}
+
+	var (
+		output     []any
+		testErrors []transformations.TestError
+	)
+	for i, event := range input {
+		jsEvent, err := rt.toJS(event)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		out, err := rt.call(ctx, fn, jsEvent, metadata)
+		if err != nil {
+			testErrors = append(testErrors, transformations.TestError{
+				Message:    err.Error(),
+				Event:      event,
+				EventIndex: i,
+			})
+			continue
+		}
+
+		switch out := out.(type) {
+		case nil:
+		case []any:
+			output = append(output, out...)
+		default:
+			output = append(output, out)
+		}
+	}
+
+	return output, testErrors, nil
+}
+
+func erroredTestResult(def *transformations.TestDefinition, err error) transformations.TestResult {
+	return transformations.TestResult{
+		ID:          def.ID,
+		Name:        def.Name,
+		Description: def.Description,
+		Status:      transformations.TestRunStatusError,
+		Errors:      []transformations.TestError{{Message: err.Error()}},
+	}
+}
+
+// suiteStatus reports the worst status among results.
+func suiteStatus(results []transformations.TestResult) transformations.TestRunStatus {
+	status := transformations.TestRunStatusPass
+	for _, res := range results {
+		switch res.Status {
+		case transformations.TestRunStatusError:
+			return transformations.TestRunStatusError
+		case transformations.TestRunStatusFail:
+			status = transformations.TestRunStatusFail
+		}
+	}
+	return status
+}
+
+// outputsEqual compares decoded JSON outputs, treating no output and an
+// empty output as equal.
+func outputsEqual(expected, actual []any) bool {
+	if len(expected) == 0 && len(actual) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(expected, actual)
+}
@@ -0,0 +1,227 @@
+package testorchestrator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tc "github.com/rudderlabs/rudder-iac/api/client/transformations"
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/model"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+func newLocalTransResource(t *testing.T, id, language, code string, files map[string]string) *resources.Resource {
+	t.Helper()
+
+	var tests []specs.TransformationTest
+	if files != nil {
+		dir := t.TempDir()
+		for name, content := range files {
+			path := filepath.Join(dir, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		}
+		tests = []specs.TransformationTest{{Name: "suite", Input: "input", Output: "output", SpecDir: dir}}
+	}
+
+	return resources.NewResource(id, "transformation", resources.ResourceData{}, nil,
+		resources.WithRawData(&model.TransformationResource{ID: id, Name: id, Language: language, Code: code, Tests: tests}),
+	)
+}
+
+func newLocalLibResource(id, importName, code string) *resources.Resource {
+	return resources.NewResource(id, "transformation-library", resources.ResourceData{}, nil,
+		resources.WithRawData(&model.LibraryResource{ID: id, Name: id, Language: "javascript", ImportName: importName, Code: code}),
+	)
+}
+
+func TestLocalRunnerRun(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("runs transformEvent with library imports", func(t *testing.T) {
+		graph := resources.NewGraph()
+		lib := newLocalLibResource("lib", "greeting", `export function greet(name) { return "hello " + name; }`)
+		trans := newLocalTransResource(t, "greet", "javascript", `
+import { greet } from "greeting";
+
+export function transformEvent(event, metadata) {
+  if (event.drop) return null;
+  event.greeting = greet(event.name);
+  event.meta = metadata(event);
+  return event;
+}
+`, map[string]string{
+			"input/events.json":  `[{"name": "ada"}, {"name": "bob", "drop": true}]`,
+			"output/events.json": `[{"name": "ada", "greeting": "hello ada", "meta": {}}]`,
+		})
+		graph.AddResource(lib)
+		graph.AddResource(trans)
+		graph.AddDependency(trans.URN(), lib.URN())
+
+		results, err := NewLocalRunner(graph).Run(ctx, ModeAll, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, RunStatusExecuted, results.Status)
+		assert.False(t, results.HasFailures())
+		require.Len(t, results.Libraries, 1)
+		assert.Equal(t, tc.LibraryTestResult{ID: "lib", Name: "lib", HandleName: "greeting", Pass: true}, results.Libraries[0])
+
+		require.Len(t, results.Transformations, 1)
+		result := results.Transformations[0].Result
+		assert.True(t, result.Pass)
+		assert.Equal(t, []string{"greeting"}, result.Imports)
+		require.Len(t, result.TestSuiteResult.Results, 1)
+		assert.Equal(t, tc.TestRunStatusPass, result.TestSuiteResult.Results[0].Status)
+		assert.Equal(t, []any{map[string]any{"name": "ada", "greeting": "hello ada", "meta": map[string]any{}}}, result.TestSuiteResult.Results[0].ActualOutput)
+	})
+
+	t.Run("reports mismatches and per-event errors", func(t *testing.T) {
+		graph := resources.NewGraph()
+		graph.AddResource(newLocalTransResource(t, "mismatch", "javascript", `
+export function transformEvent(event) {
+  if (event.fail) throw new Error("boom");
+  return { ...event, extra: 1 };
+}
+`, map[string]string{
+			"input/ok.json":     `{"id": 1}`,
+			"output/ok.json":    `{"id": 1}`,
+			"input/error.json":  `[{"id": 1}, {"id": 2, "fail": true}]`,
+			"output/error.json": `[]`,
+		}))
+
+		results, err := NewLocalRunner(graph).Run(ctx, ModeSingle, "mismatch")
+		require.NoError(t, err)
+		assert.True(t, results.HasFailures())
+
+		byName := map[string]tc.TestResult{}
+		for _, res := range results.Transformations[0].Result.TestSuiteResult.Results {
+			byName[res.Name] = res
+		}
+
+		assert.Equal(t, tc.TestRunStatusFail, byName["suite (ok.json)"].Status)
+		assert.Equal(t, []any{map[string]any{"id": float64(1), "extra": float64(1)}}, byName["suite (ok.json)"].ActualOutput)
+
+		errored := byName["suite (error.json)"]
+		assert.Equal(t, tc.TestRunStatusError, errored.Status)
+		require.Len(t, errored.Errors, 1)
+		assert.Contains(t, errored.Errors[0].Message, "Error: boom")
+		assert.Equal(t, 1, errored.Errors[0].EventIndex)
+		assert.Equal(t, map[string]any{"id": float64(2), "fail": true}, errored.Errors[0].Event)
+		assert.Equal(t, tc.TestRunStatusError, results.Transformations[0].Result.TestSuiteResult.Status)
+	})
+
+	t.Run("runs transformBatch and awaits async results", func(t *testing.T) {
+		graph := resources.NewGraph()
+		graph.AddResource(newLocalTransResource(t, "batch", "javascript", `
+export async function transformBatch(events) {
+  const ids = await Promise.resolve(events.map((e) => e.id));
+  return [{ ids }];
+}
+`, map[string]string{
+			"input/batch.json":  `[{"id": "a"}, {"id": "b"}]`,
+			"output/batch.json": `[{"ids": ["a", "b"]}]`,
+		}))
+
+		results, err := NewLocalRunner(graph).Run(ctx, ModeAll, "")
+		require.NoError(t, err)
+		assert.False(t, results.HasFailures())
+	})
+
+	t.Run("fails every test of code that cannot run locally", func(t *testing.T) {
+		graph := resources.NewGraph()
+		graph.AddResource(newLocalTransResource(t, "python", "python", "def transformEvent(event, metadata):\n    return event\n", nil))
+		graph.AddResource(newLocalTransResource(t, "missing-lib", "javascript", `
+import { x } from "not-in-project";
+export function transformEvent(event) { return event; }
+`, nil))
+
+		results, err := NewLocalRunner(graph).Run(ctx, ModeAll, "")
+		require.NoError(t, err)
+		require.Len(t, results.Transformations, 2)
+
+		messages := map[string]string{}
+		for _, tr := range results.Transformations {
+			assert.False(t, tr.Result.Pass)
+			require.NotEmpty(t, tr.Result.TestSuiteResult.Results)
+			messages[tr.Result.ID] = tr.Result.TestSuiteResult.Results[0].Errors[0].Message
+		}
+		assert.Contains(t, messages["python"], "python code cannot be tested locally")
+		assert.Equal(t, "library not-in-project not found in project", messages["missing-lib"])
+	})
+
+	t.Run("interrupts code that does not return", func(t *testing.T) {
+		graph := resources.NewGraph()
+		graph.AddResource(newLocalTransResource(t, "loop", "javascript", `export function transformEvent(event) { for (;;) {} }`, map[string]string{
+			"input/loop.json": `{}`,
+		}))
+
+		runner := NewLocalRunner(graph)
+		runner.timeout = 50 * time.Millisecond
+
+		results, err := runner.Run(ctx, ModeAll, "")
+		require.NoError(t, err)
+		res := results.Transformations[0].Result.TestSuiteResult.Results[0]
+		assert.Equal(t, tc.TestRunStatusError, res.Status)
+		assert.Equal(t, "execution timed out", res.Errors[0].Message)
+	})
+
+	t.Run("interrupts top-level code that does not return", func(t *testing.T) {
+		graph := resources.NewGraph()
+		graph.AddResource(newLocalTransResource(t, "loop", "javascript", `while (true) {}
+export function transformEvent(event) { return event; }`, map[string]string{
+			"input/loop.json": `{}`,
+		}))
+		graph.AddResource(newLocalLibResource("looplib", "looplib", `while (true) {}
+export const x = 1;`))
+
+		runner := NewLocalRunner(graph)
+		runner.timeout = 50 * time.Millisecond
+
+		results, err := runner.Run(ctx, ModeAll, "")
+		require.NoError(t, err)
+
+		res := results.Transformations[0].Result.TestSuiteResult.Results[0]
+		assert.Equal(t, tc.TestRunStatusError, res.Status)
+		assert.Equal(t, "execution timed out", res.Errors[0].Message)
+
+		require.Len(t, results.Libraries, 1)
+		assert.False(t, results.Libraries[0].Pass)
+		assert.Equal(t, "execution timed out", results.Libraries[0].Message)
+	})
+
+	t.Run("reports library errors", func(t *testing.T) {
+		graph := resources.NewGraph()
+		graph.AddResource(newLocalLibResource("broken", "broken", `export function f( {`))
+		graph.AddResource(newLocalLibResource("builtin", "builtin", `import { sha256 } from "@rs/hash/v1"; export const h = sha256("x");`))
+
+		results, err := NewLocalRunner(graph).Run(ctx, ModeAll, "")
+		require.NoError(t, err)
+		require.Len(t, results.Libraries, 2)
+		assert.True(t, results.HasFailures())
+
+		messages := map[string]string{}
+		for _, lib := range results.Libraries {
+			assert.False(t, lib.Pass)
+			messages[lib.ID] = lib.Message
+		}
+		assert.Contains(t, messages["broken"], "extracting imports")
+		assert.Contains(t, messages["builtin"], "built-in library @rs/hash/v1 is not available when testing locally")
+	})
+
+	t.Run("rejects modified mode", func(t *testing.T) {
+		_, err := NewLocalRunner(resources.NewGraph()).Run(ctx, ModeModified, "")
+		assert.ErrorIs(t, err, ErrModifiedNotSupportedLocally)
+	})
+
+	t.Run("returns RunStatusNoResources when no resources to test", func(t *testing.T) {
+		results, err := NewLocalRunner(resources.NewGraph()).Run(ctx, ModeAll, "")
+		require.NoError(t, err)
+		assert.Equal(t, RunStatusNoResources, results.Status)
+	})
+}
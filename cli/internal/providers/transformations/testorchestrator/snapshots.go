@@ -0,0 +1,153 @@
+package testorchestrator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	transformations "github.com/rudderlabs/rudder-iac/api/client/transformations"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/model"
+	ttypes "github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/types"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+// SnapshotChange describes what happened to an expected output file when
+// updating snapshots.
+type SnapshotChange string
+
+const (
+	SnapshotCreated SnapshotChange = "created"
+	SnapshotUpdated SnapshotChange = "updated"
+	SnapshotDeleted SnapshotChange = "deleted"
+	// SnapshotSkipped marks expected outputs left untouched because their
+	// test errored, so there is no actual output to record.
+	SnapshotSkipped SnapshotChange = "skipped"
+)
+
+// SnapshotUpdate is a change made, or skipped, to an expected output file.
+type SnapshotUpdate struct {
+	Transformation string
+	Suite          string
+	Path           string
+	Change         SnapshotChange
+}
+
+// UpdateSnapshots records the actual outputs in results as the expected
+// outputs of the test suites of the tested transformations in graph.
+//
+// Every input file gets an output file of the same name in the suite's output
+// directory holding the actual output, and output files without an input file
+// are deleted. Output files already matching the actual output are left as they
+// are and not returned. Outputs of errored tests are skipped, as there is no
+// actual output to record. The returned updates are sorted by path.
+func UpdateSnapshots(graph *resources.Graph, results *TestResults) ([]SnapshotUpdate, error) {
+	var updates []SnapshotUpdate
+
+	for _, tr := range results.Transformations {
+		resource, ok := graph.GetResource(resources.URN(tr.Result.ID, ttypes.TransformationResourceType))
+		if !ok {
+			return nil, fmt.Errorf("transformation %s not found in project", tr.Result.ID)
+		}
+		transformation, ok := resource.RawData().(*model.TransformationResource)
+		if !ok {
+			return nil, fmt.Errorf("extracting transformation data for %s", tr.Result.ID)
+		}
+
+		resultsByID := make(map[string]transformations.TestResult, len(tr.Result.TestSuiteResult.Results))
+		for _, res := range tr.Result.TestSuiteResult.Results {
+			resultsByID[res.ID] = res
+		}
+
+		for _, suite := range transformation.Tests {
+			files, err := listSuiteFiles(suite)
+			if err != nil {
+				return nil, fmt.Errorf("updating snapshots of suite %s of %s: %w", suite.Name, transformation.ID, err)
+			}
+			if files.outputDir == "" {
+				continue
+			}
+
+			record := func(path string, change SnapshotChange) {
+				updates = append(updates, SnapshotUpdate{
+					Transformation: transformation.Name,
+					Suite:          suite.Name,
+					Path:           path,
+					Change:         change,
+				})
+			}
+
+			for filename := range files.inputs {
+				res, ok := resultsByID[testDefinitionID(suite, filename)]
+				if !ok {
+					continue
+				}
+
+				outputPath, exists := files.outputs[filename]
+				if !exists {
+					outputPath = filepath.Join(files.outputDir, filename)
+				}
+
+				if res.Status == transformations.TestRunStatusError {
+					record(outputPath, SnapshotSkipped)
+					continue
+				}
+
+				changed, err := writeSnapshot(outputPath, res.ActualOutput)
+				if err != nil {
+					return nil, fmt.Errorf("updating snapshot %s: %w", outputPath, err)
+				}
+				switch {
+				case !exists:
+					record(outputPath, SnapshotCreated)
+				case changed:
+					record(outputPath, SnapshotUpdated)
+				}
+			}
+
+			for filename, outputPath := range files.outputs {
+				if _, ok := files.inputs[filename]; ok {
+					continue
+				}
+				if err := os.Remove(outputPath); err != nil {
+					return nil, fmt.Errorf("deleting snapshot %s: %w", outputPath, err)
+				}
+				record(outputPath, SnapshotDeleted)
+			}
+		}
+	}
+
+	sort.Slice(updates, func(i, j int) bool {
+		return updates[i].Path < updates[j].Path
+	})
+	return updates, nil
+}
+
+// writeSnapshot writes output to path unless the file already holds an
+// equivalent output, and reports whether it wrote the file.
+func writeSnapshot(path string, output []any) (bool, error) {
+	if existing, err := parseJSONFile(path); err == nil && outputsEqual(existing, output) {
+		return false, nil
+	}
+
+	if output == nil {
+		output = []any{}
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(output); err != nil {
+		return false, fmt.Errorf("encoding output: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return false, fmt.Errorf("creating output directory: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return false, fmt.Errorf("writing file: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,94 @@
+package testorchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	transformations "github.com/rudderlabs/rudder-iac/api/client/transformations"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/model"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+func TestUpdateSnapshots(t *testing.T) {
+	graph := resources.NewGraph()
+	trans := newLocalTransResource(t, "enrich", "javascript", "", map[string]string{
+		"input/new.json":      `[{"event": "new"}]`,
+		"input/changed.json":  `[{"event": "changed"}]`,
+		"input/same.json":     `[{"event": "same"}]`,
+		"input/errored.json":  `[{"event": "errored"}]`,
+		"input/dropped.json":  `[{"event": "dropped"}]`,
+		"output/changed.json": `[{"event": "old"}]`,
+		"output/same.json":    "[\n{\"event\":\"same\"}]",
+		"output/errored.json": `[{"event": "kept"}]`,
+		"output/removed.json": `[{"event": "removed"}]`,
+		"output/dropped.json": `[{"event": "dropped"}]`,
+	})
+	graph.AddResource(trans)
+
+	suite := "suite/input/"
+	results := &TestResults{
+		Status: RunStatusExecuted,
+		Transformations: []*TransformationTestWithDefinitions{{
+			Result: &transformations.TransformationTestResult{
+				ID:   "enrich",
+				Name: "enrich",
+				TestSuiteResult: transformations.TestSuiteRunResult{
+					Results: []transformations.TestResult{
+						{ID: suite + "new.json", Status: transformations.TestRunStatusFail, ActualOutput: []any{map[string]any{"event": "<new>"}}},
+						{ID: suite + "changed.json", Status: transformations.TestRunStatusFail, ActualOutput: []any{map[string]any{"event": "changed"}}},
+						{ID: suite + "same.json", Status: transformations.TestRunStatusPass, ActualOutput: []any{map[string]any{"event": "same"}}},
+						{ID: suite + "errored.json", Status: transformations.TestRunStatusError},
+						{ID: suite + "dropped.json", Status: transformations.TestRunStatusFail},
+					},
+				},
+			},
+		}},
+	}
+
+	updates, err := UpdateSnapshots(graph, results)
+	require.NoError(t, err)
+
+	outputDir := filepath.Join(trans.RawData().(*model.TransformationResource).Tests[0].SpecDir, "output")
+	path := func(name string) string {
+		return filepath.Join(outputDir, name)
+	}
+
+	assert.Equal(t, []SnapshotUpdate{
+		{Transformation: "enrich", Suite: "suite", Path: path("changed.json"), Change: SnapshotUpdated},
+		{Transformation: "enrich", Suite: "suite", Path: path("dropped.json"), Change: SnapshotUpdated},
+		{Transformation: "enrich", Suite: "suite", Path: path("errored.json"), Change: SnapshotSkipped},
+		{Transformation: "enrich", Suite: "suite", Path: path("new.json"), Change: SnapshotCreated},
+		{Transformation: "enrich", Suite: "suite", Path: path("removed.json"), Change: SnapshotDeleted},
+	}, updates)
+
+	read := func(name string) string {
+		data, err := os.ReadFile(path(name))
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	assert.Equal(t, "[\n  {\n    \"event\": \"<new>\"\n  }\n]\n", read("new.json"))
+	assert.JSONEq(t, `[{"event": "changed"}]`, read("changed.json"))
+	assert.Equal(t, "[\n{\"event\":\"same\"}]", read("same.json"), "equivalent outputs are not rewritten")
+	assert.JSONEq(t, `[{"event": "kept"}]`, read("errored.json"))
+	assert.JSONEq(t, `[]`, read("dropped.json"))
+	assert.NoFileExists(t, path("removed.json"))
+
+	t.Run("updating again changes nothing", func(t *testing.T) {
+		results.Transformations[0].Result.TestSuiteResult.Results = results.Transformations[0].Result.TestSuiteResult.Results[:3]
+
+		updates, err := UpdateSnapshots(graph, results)
+		require.NoError(t, err)
+		assert.Empty(t, updates)
+	})
+
+	t.Run("fails for transformations missing from the graph", func(t *testing.T) {
+		_, err := UpdateSnapshots(resources.NewGraph(), results)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "transformation enrich not found in project")
+	})
+}
@@ -41,6 +41,22 @@ func (r *TestResults) HasFailures() bool {
 	})
 }
 
+// HasErrors computes whether any library or test errored, ignoring tests whose
+// output merely mismatched the expected output
+func (r *TestResults) HasErrors() bool {
+	if lo.ContainsBy(r.Libraries, func(lib transformations.LibraryTestResult) bool {
+		return !lib.Pass
+	}) {
+		return true
+	}
+
+	return lo.ContainsBy(r.Transformations, func(tr *TransformationTestWithDefinitions) bool {
+		return lo.ContainsBy(tr.Result.TestSuiteResult.Results, func(res transformations.TestResult) bool {
+			return res.Status == transformations.TestRunStatusError
+		})
+	})
+}
+
 // DefaultSuiteTransformationNames returns the names of transformations that used the default test suite
 func (r *TestResults) DefaultSuiteTransformationNames() []string {
 	const defaultTestSuiteName = "default-events"
@@ -164,6 +164,29 @@ func TestHasFailures(t *testing.T) {
 	}
 }
 
+func TestHasErrors(t *testing.T) {
+	withResults := func(statuses ...transformations.TestRunStatus) *TestResults {
+		var results []transformations.TestResult
+		for _, status := range statuses {
+			results = append(results, transformations.TestResult{Status: status})
+		}
+		return &TestResults{
+			Transformations: []*TransformationTestWithDefinitions{{
+				Result: &transformations.TransformationTestResult{
+					TestSuiteResult: transformations.TestSuiteRunResult{Results: results},
+				},
+			}},
+		}
+	}
+
+	assert.False(t, (&TestResults{}).HasErrors())
+	assert.False(t, withResults(transformations.TestRunStatusPass, transformations.TestRunStatusFail).HasErrors())
+	assert.True(t, withResults(transformations.TestRunStatusPass, transformations.TestRunStatusError).HasErrors())
+	assert.True(t, (&TestResults{
+		Libraries: []transformations.LibraryTestResult{{HandleName: "lib", Pass: false}},
+	}).HasErrors())
+}
+
 func TestTestResults_DefaultSuiteTransformationNames(t *testing.T) {
 	tests := []struct {
 		name     string
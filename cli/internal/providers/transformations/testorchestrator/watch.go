@@ -0,0 +1,98 @@
+package testorchestrator
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/model"
+	ttypes "github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/types"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+// AffectedIDs returns the IDs of the transformations and standalone libraries
+// to test again after the project changed from previous to current, sorted.
+//
+// Code and name changes are found the way ModeModified finds them against the
+// workspace, with previous standing in for the remote graph, so transformations
+// depending on a changed library are included. Changes to test data are found
+// by matching changedPaths against the input and output directories of each
+// transformation's test suites.
+func AffectedIDs(previous, current *resources.Graph, changedPaths []string) ([]string, error) {
+	plan, err := NewPlanner(current).BuildPlan(previous, ModeModified, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("building test plan: %w", err)
+	}
+
+	ids := make(map[string]struct{})
+	for _, unit := range plan.TestUnits {
+		ids[unit.Transformation.ID] = struct{}{}
+	}
+	for _, lib := range plan.StandaloneLibraries {
+		ids[lib.ID] = struct{}{}
+	}
+
+	for _, resource := range current.ResourcesByType(ttypes.TransformationResourceType) {
+		tr, ok := resource.RawData().(*model.TransformationResource)
+		if !ok {
+			return nil, fmt.Errorf("extracting transformation data for %s", resource.ID())
+		}
+		if suitesContainAny(tr, changedPaths) {
+			ids[tr.ID] = struct{}{}
+		}
+	}
+
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+	return sorted, nil
+}
+
+// TestSuiteDirs returns the input and output directories of the test suites
+// of every transformation in graph.
+func TestSuiteDirs(graph *resources.Graph) []string {
+	var dirs []string
+	for _, resource := range graph.ResourcesByType(ttypes.TransformationResourceType) {
+		tr, ok := resource.RawData().(*model.TransformationResource)
+		if !ok {
+			continue
+		}
+		for _, suite := range tr.Tests {
+			for _, dir := range []string{resolveDir(suite.SpecDir, suite.Input), resolveDir(suite.SpecDir, suite.Output)} {
+				if dir != "" {
+					dirs = append(dirs, dir)
+				}
+			}
+		}
+	}
+	return dirs
+}
+
+func suitesContainAny(tr *model.TransformationResource, paths []string) bool {
+	for _, suite := range tr.Tests {
+		for _, dir := range []string{resolveDir(suite.SpecDir, suite.Input), resolveDir(suite.SpecDir, suite.Output)} {
+			for _, path := range paths {
+				if isWithinDir(dir, path) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func isWithinDir(dir, path string) bool {
+	if dir == "" {
+		return false
+	}
+	dir, errDir := filepath.Abs(dir)
+	path, errPath := filepath.Abs(path)
+	if errDir != nil || errPath != nil {
+		return false
+	}
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,70 @@
+package testorchestrator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/project/specs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/model"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+func TestAffectedIDs(t *testing.T) {
+	newGraph := func(libCode, t2Code string) *resources.Graph {
+		graph := resources.NewGraph()
+		lib := newLibResource("lib-1", "Lib", libCode)
+		t1 := newTransResource("t1", "Trans 1", "code-v1")
+		graph.AddResource(lib)
+		graph.AddResource(t1)
+		graph.AddResource(newTransResource("t2", "Trans 2", t2Code))
+		graph.AddResource(newLibResource("lib-2", "Standalone", "code-v1"))
+		graph.AddDependency(t1.URN(), lib.URN())
+		return graph
+	}
+
+	t.Run("nothing changed", func(t *testing.T) {
+		ids, err := AffectedIDs(newGraph("code-v1", "code-v1"), newGraph("code-v1", "code-v1"), nil)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+
+	t.Run("changed code and transformations depending on changed libraries", func(t *testing.T) {
+		ids, err := AffectedIDs(newGraph("code-v1", "code-v1"), newGraph("code-v2", "code-v2"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"t1", "t2"}, ids)
+	})
+
+	t.Run("new standalone library", func(t *testing.T) {
+		current := newGraph("code-v1", "code-v1")
+		current.AddResource(newLibResource("lib-3", "New", "code-v1"))
+
+		ids, err := AffectedIDs(newGraph("code-v1", "code-v1"), current, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"lib-3"}, ids)
+	})
+
+	t.Run("changed test data", func(t *testing.T) {
+		dir := t.TempDir()
+		graph := resources.NewGraph()
+		graph.AddResource(resources.NewResource("t1", "transformation", resources.ResourceData{}, nil,
+			resources.WithRawData(&model.TransformationResource{
+				ID:    "t1",
+				Name:  "Trans 1",
+				Tests: []specs.TransformationTest{{Name: "suite", Input: "./input", Output: "./output", SpecDir: dir}},
+			}),
+		))
+
+		ids, err := AffectedIDs(graph, graph, []string{filepath.Join(dir, "output", "events.json")})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"t1"}, ids)
+
+		ids, err = AffectedIDs(graph, graph, []string{filepath.Join(dir, "transformation.yaml")})
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+
+		assert.Equal(t, []string{filepath.Join(dir, "input"), filepath.Join(dir, "output")}, TestSuiteDirs(graph))
+	})
+}
@@ -10,7 +10,10 @@ import (
 	dtypes "github.com/rudderlabs/rudder-iac/cli/internal/providers/destination"
 	esconnection "github.com/rudderlabs/rudder-iac/cli/internal/providers/event-stream/connection"
 	essource "github.com/rudderlabs/rudder-iac/cli/internal/providers/event-stream/source"
+	retlconnection "github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/connection"
+	retls3 "github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/s3"
 	"github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/sqlmodel"
+	retltable "github.com/rudderlabs/rudder-iac/cli/internal/providers/retl/table"
 	ttypes "github.com/rudderlabs/rudder-iac/cli/internal/providers/transformations/types"
 	"github.com/rudderlabs/rudder-iac/cli/internal/ruledoc"
 	"github.com/rudderlabs/rudder-iac/cli/internal/testutils"
@@ -48,6 +51,9 @@ func gatekeeperScopedPatterns() []vrules.MatchPattern {
 	p = append(p, providerrules.V1VersionPatterns(ttypes.LibrarySpecKind)...)
 	p = append(p, providerrules.V1VersionPatterns(dtypes.DestinationSpecKind)...)
 	p = append(p, providerrules.V1VersionPatterns(atypes.AccountSpecKind)...)
+	p = append(p, providerrules.V1VersionPatterns(retlconnection.ResourceKind)...)
+	p = append(p, providerrules.V1VersionPatterns(retls3.ResourceKind)...)
+	p = append(p, providerrules.V1VersionPatterns(retltable.ResourceKind)...)
 	return p
 }
 
@@ -16,15 +16,15 @@ var (
 // Diff represents the differences between two resource graphs
 type Diff struct {
 	// NewResources contains URNs of resources that will be created (exist in target but not in source, and have no ImportMetadata)
-	NewResources []string
+	NewResources []string `json:"newResources"`
 	// ImportableResources contains URNs of resources that will be imported (exist in target but not in source, and have ImportMetadata)
-	ImportableResources []string
+	ImportableResources []string `json:"importableResources"`
 	// UpdatedResources contains URNs of resources that exist in both graphs but have different data
-	UpdatedResources map[string]ResourceDiff
+	UpdatedResources map[string]ResourceDiff `json:"updatedResources"`
 	// RemovedResources contains URNs of resources that exist in source but not in target
-	RemovedResources []string
+	RemovedResources []string `json:"removedResources"`
 	// UnmodifiedResources contains URNs of resources that exist in both graphs with identical data
-	UnmodifiedResources []string
+	UnmodifiedResources []string `json:"unmodifiedResources"`
 }
 
 func (d *Diff) HasDiff() bool {
@@ -53,12 +53,12 @@ func (d *Diff) HasNonSecretDiff() bool {
 }
 
 type ResourceDiff struct {
-	URN   string
-	Diffs map[string]PropertyDiff
+	URN   string                  `json:"urn"`
+	Diffs map[string]PropertyDiff `json:"diffs"`
 	// SecretOnly is true when every property diff is secret-driven, so the
 	// resource is "always re-applied" rather than genuine drift. It is computed
 	// once while the diffs are built (see compareData) and cached here.
-	SecretOnly bool
+	SecretOnly bool `json:"secretOnly"`
 }
 
 // IsSecretOnly reports whether this resource updates only because of unknown
@@ -68,14 +68,14 @@ func (rd ResourceDiff) IsSecretOnly() bool {
 }
 
 type PropertyDiff struct {
-	Property    string
-	SourceValue any
-	TargetValue any
+	Property    string `json:"property"`
+	SourceValue any    `json:"sourceValue"`
+	TargetValue any    `json:"targetValue"`
 	// SecretOnly is true when this diff exists only because of an unknown secret, so
 	// the reporter can render it distinctly and classify the resource as always
 	// re-applied. It propagates up: a containing map diff is SecretOnly only when every
 	// child diff is.
-	SecretOnly bool
+	SecretOnly bool `json:"secretOnly"`
 }
 
 type DiffOptions struct {
@@ -252,6 +252,14 @@ func CompareData(r1, r2 resources.ResourceData) (map[string]PropertyDiff, bool)
 				record(key, PropertyDiff{Property: key, SourceValue: v1, TargetValue: v2})
 			}
 		default:
+			// Typed slices and maps (e.g. a RawData field holding []Mapping) are
+			// left as-is by the struct→map decode and would panic under !=.
+			if !reflect.TypeOf(v1).Comparable() {
+				if !reflect.DeepEqual(v1, v2) {
+					record(key, PropertyDiff{Property: key, SourceValue: v1, TargetValue: v2})
+				}
+				return
+			}
 			if v1 != v2 {
 				record(key, PropertyDiff{Property: key, SourceValue: v1, TargetValue: v2})
 			}
@@ -89,6 +89,29 @@ func TestCompareData_ArrayOfObjects(t *testing.T) {
 	})
 }
 
+// TestCompareData_TypedSlices covers RawData fields holding slices of typed
+// structs, which the struct→map decode leaves untouched and which cannot be
+// compared with !=.
+func TestCompareData_TypedSlices(t *testing.T) {
+	type mapping struct{ From, To string }
+
+	t.Run("equal typed slices do not diff", func(t *testing.T) {
+		diffs, _ := differ.CompareData(
+			resources.ResourceData{"mappings": []mapping{{From: "email", To: "traits.email"}}},
+			resources.ResourceData{"mappings": []mapping{{From: "email", To: "traits.email"}}},
+		)
+		assert.Empty(t, diffs)
+	})
+
+	t.Run("different typed slices diff", func(t *testing.T) {
+		diffs, _ := differ.CompareData(
+			resources.ResourceData{"mappings": []mapping{{From: "email", To: "traits.email"}}},
+			resources.ResourceData{"mappings": []mapping{{From: "mail", To: "traits.email"}}},
+		)
+		assert.Contains(t, diffs, "mappings")
+	})
+}
+
 func TestComputeDiff(t *testing.T) {
 	g1 := resources.NewGraph()
 	g2 := resources.NewGraph()
@@ -0,0 +1,48 @@
+// Package drift compares the resources managed in a workspace with the
+// project specs that describe them, without planning or applying anything.
+package drift
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/provider"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/differ"
+)
+
+// Provider is the subset of a provider needed to read the workspace state.
+type Provider interface {
+	provider.RemoteResourceLoader
+	provider.StateLoader
+}
+
+// Detect loads the remote resources, maps them to state and diffs them
+// against the local resource graph. Resources that only differ because of
+// secrets, which can't be read back and so always differ, are dropped from
+// the result so that it holds genuine drift only.
+func Detect(ctx context.Context, p Provider, local *resources.Graph, workspaceID string) (*differ.Diff, error) {
+	remoteCollection, err := p.LoadResourcesFromRemote(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading remote resources: %w", err)
+	}
+
+	remoteState, err := p.MapRemoteToState(remoteCollection)
+	if err != nil {
+		return nil, fmt.Errorf("mapping remote resources to state: %w", err)
+	}
+
+	diff := differ.ComputeDiff(syncer.StateToGraph(remoteState), local, differ.DiffOptions{
+		WorkspaceID: workspaceID,
+	})
+
+	for urn, rd := range diff.UpdatedResources {
+		if rd.IsSecretOnly() {
+			delete(diff.UpdatedResources, urn)
+			diff.UnmodifiedResources = append(diff.UnmodifiedResources, urn)
+		}
+	}
+
+	return diff, nil
+}
@@ -0,0 +1,72 @@
+package drift_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources/state"
+	"github.com/rudderlabs/rudder-iac/cli/internal/secret"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/drift"
+	"github.com/rudderlabs/rudder-iac/cli/internal/testutils"
+)
+
+func TestDetect(t *testing.T) {
+	remoteState := &state.State{Resources: map[string]*state.ResourceState{
+		"event:signup": {ID: "signup", Type: "event", Input: map[string]any{"name": "Signed Up"}},
+		"event:login":  {ID: "login", Type: "event", Input: map[string]any{"name": "Login"}},
+		"destination:webhook": {ID: "webhook", Type: "destination", Input: map[string]any{
+			"token": secret.NewUnknown(),
+		}},
+		"event:legacy": {ID: "legacy", Type: "event", Input: map[string]any{"name": "Legacy"}},
+	}}
+
+	local := resources.NewGraph()
+	local.AddResource(resources.NewResource("signup", "event", resources.ResourceData{"name": "Signed Up"}, nil))
+	local.AddResource(resources.NewResource("login", "event", resources.ResourceData{"name": "Logged In"}, nil))
+	local.AddResource(resources.NewResource("webhook", "destination", resources.ResourceData{"token": secret.New("hunter2")}, nil))
+	local.AddResource(resources.NewResource("checkout", "event", resources.ResourceData{"name": "Checkout"}, nil))
+
+	t.Run("reports divergence and ignores secret-only diffs", func(t *testing.T) {
+		provider := testutils.NewMockProvider(nil, nil)
+		provider.LoadResourcesFromRemoteVal = resources.NewRemoteResources()
+		provider.MapRemoteToStateVal = remoteState
+
+		diff, err := drift.Detect(context.Background(), provider, local, "ws-1")
+		require.NoError(t, err)
+
+		assert.True(t, diff.HasNonSecretDiff())
+		require.Len(t, diff.UpdatedResources, 1)
+		assert.Contains(t, diff.UpdatedResources, "event:login")
+		assert.Equal(t, []string{"event:checkout"}, diff.NewResources)
+		assert.Equal(t, []string{"event:legacy"}, diff.RemovedResources)
+		assert.ElementsMatch(t, []string{"event:signup", "destination:webhook"}, diff.UnmodifiedResources)
+	})
+
+	t.Run("clean when only secrets differ", func(t *testing.T) {
+		provider := testutils.NewMockProvider(nil, nil)
+		provider.LoadResourcesFromRemoteVal = resources.NewRemoteResources()
+		provider.MapRemoteToStateVal = &state.State{Resources: map[string]*state.ResourceState{
+			"destination:webhook": remoteState.Resources["destination:webhook"],
+		}}
+
+		secretsOnly := resources.NewGraph()
+		secretsOnly.AddResource(resources.NewResource("webhook", "destination", resources.ResourceData{"token": secret.New("hunter2")}, nil))
+
+		diff, err := drift.Detect(context.Background(), provider, secretsOnly, "ws-1")
+		require.NoError(t, err)
+		assert.False(t, diff.HasNonSecretDiff())
+	})
+
+	t.Run("returns remote loading errors", func(t *testing.T) {
+		provider := testutils.NewMockProvider(nil, nil)
+		provider.LoadResourcesFromRemoteErr = errors.New("unauthorized")
+
+		_, err := drift.Detect(context.Background(), provider, local, "ws-1")
+		assert.ErrorContains(t, err, "loading remote resources: unauthorized")
+	})
+}
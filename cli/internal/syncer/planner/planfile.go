@@ -0,0 +1,336 @@
+package planner
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/secret"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/differ"
+)
+
+// SavedPlanVersion is the format version written into saved plan files.
+// Files with a different version are rejected on read.
+const SavedPlanVersion = 2
+
+var (
+	ErrRemoteStateChanged = errors.New("remote state has changed since the plan was created")
+	ErrProjectChanged     = errors.New("project no longer produces the saved plan")
+)
+
+// SavedPlan is the reviewable, serialized form of a Plan. Besides the
+// operations and diff it carries the target graph the plan was computed
+// against and fingerprints of both the remote state and the plan itself,
+// so that applying it later can refuse to run when either has moved. The
+// fingerprints are HMACs keyed with a random per-plan key, so that the
+// secrets they cover cannot be matched against hashes from other plans.
+type SavedPlan struct {
+	Version           int              `json:"version"`
+	WorkspaceID       string           `json:"workspaceId"`
+	CreatedAt         time.Time        `json:"createdAt"`
+	FingerprintKey    string           `json:"fingerprintKey"`
+	RemoteFingerprint string           `json:"remoteFingerprint"`
+	PlanFingerprint   string           `json:"planFingerprint"`
+	Operations        []SavedOperation `json:"operations"`
+	Diff              *differ.Diff     `json:"diff"`
+	TargetGraph       []SavedResource  `json:"targetGraph"`
+}
+
+type SavedOperation struct {
+	Type string `json:"type"`
+	URN  string `json:"urn"`
+}
+
+type SavedResource struct {
+	URN          string         `json:"urn"`
+	Type         string         `json:"type"`
+	ID           string         `json:"id"`
+	Data         map[string]any `json:"data,omitempty"`
+	Dependencies []string       `json:"dependencies,omitempty"`
+}
+
+// NewSavedPlan builds the serializable form of plan, fingerprinting the
+// source graph (the remote state the plan was computed from) and the
+// operations together with the target graph under a new fingerprint key.
+func NewSavedPlan(workspaceID string, plan *Plan, source, target *resources.Graph) (*SavedPlan, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generating fingerprint key: %w", err)
+	}
+
+	operations := savedOperations(plan)
+	remoteFingerprint, planFingerprint, err := fingerprints(key, operations, source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SavedPlan{
+		Version:           SavedPlanVersion,
+		WorkspaceID:       workspaceID,
+		CreatedAt:         time.Now().UTC(),
+		FingerprintKey:    hex.EncodeToString(key),
+		RemoteFingerprint: remoteFingerprint,
+		PlanFingerprint:   planFingerprint,
+		Operations:        operations,
+		Diff:              sortedDiff(plan.Diff),
+		TargetGraph:       savedGraph(target),
+	}, nil
+}
+
+// Verify checks that plan, freshly computed from the live remote state
+// (source) and local project (target), still matches the saved plan.
+func (p *SavedPlan) Verify(workspaceID string, plan *Plan, source, target *resources.Graph) error {
+	if p.WorkspaceID != workspaceID {
+		return fmt.Errorf("plan was created for workspace %q, current workspace is %q", p.WorkspaceID, workspaceID)
+	}
+
+	key, err := hex.DecodeString(p.FingerprintKey)
+	if err != nil || len(key) == 0 {
+		return errors.New("plan file has an invalid fingerprint key")
+	}
+
+	remoteFingerprint, planFingerprint, err := fingerprints(key, savedOperations(plan), source, target)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(p.RemoteFingerprint), []byte(remoteFingerprint)) {
+		return ErrRemoteStateChanged
+	}
+	if !hmac.Equal([]byte(p.PlanFingerprint), []byte(planFingerprint)) {
+		return ErrProjectChanged
+	}
+	return nil
+}
+
+func WriteSavedPlan(path string, plan *SavedPlan) error {
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling plan: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing plan file: %w", err)
+	}
+	return nil
+}
+
+func ReadSavedPlan(path string) (*SavedPlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading plan file: %w", err)
+	}
+
+	var plan SavedPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("parsing plan file: %w", err)
+	}
+	if plan.Version != SavedPlanVersion {
+		return nil, fmt.Errorf("unsupported plan file version %d, expected %d", plan.Version, SavedPlanVersion)
+	}
+	return &plan, nil
+}
+
+// savedGraph flattens g into a list of resources sorted by URN. Resources
+// backed by raw data are decoded into maps the same way the differ compares
+// them.
+func savedGraph(g *resources.Graph) []SavedResource {
+	urns := make([]string, 0, len(g.Resources()))
+	for urn := range g.Resources() {
+		urns = append(urns, urn)
+	}
+	sort.Strings(urns)
+
+	result := make([]SavedResource, 0, len(urns))
+	for _, urn := range urns {
+		r, _ := g.GetResource(urn)
+
+		data := map[string]any(r.Data())
+		if r.RawData() != nil {
+			data = nil
+			_ = mapstructure.Decode(r.RawData(), &data)
+		}
+
+		dependencies := append([]string(nil), g.GetDependencies(urn)...)
+		sort.Strings(dependencies)
+
+		result = append(result, SavedResource{
+			URN:          urn,
+			Type:         r.Type(),
+			ID:           r.ID(),
+			Data:         data,
+			Dependencies: dependencies,
+		})
+	}
+	return result
+}
+
+// fingerprintedGraph lists the resources of g sorted by URN for
+// fingerprinting. Unlike savedGraph it keeps raw data as is, since decoding
+// it into a map turns secret.String values into empty maps.
+func fingerprintedGraph(g *resources.Graph) []any {
+	urns := make([]string, 0, len(g.Resources()))
+	for urn := range g.Resources() {
+		urns = append(urns, urn)
+	}
+	sort.Strings(urns)
+
+	result := make([]any, 0, len(urns))
+	for _, urn := range urns {
+		r, _ := g.GetResource(urn)
+
+		var data any = r.Data()
+		if r.RawData() != nil {
+			data = r.RawData()
+		}
+
+		dependencies := append([]string(nil), g.GetDependencies(urn)...)
+		sort.Strings(dependencies)
+
+		result = append(result, map[string]any{
+			"urn":          urn,
+			"type":         r.Type(),
+			"id":           r.ID(),
+			"data":         data,
+			"dependencies": dependencies,
+		})
+	}
+	return result
+}
+
+func savedOperations(plan *Plan) []SavedOperation {
+	operations := make([]SavedOperation, 0, len(plan.Operations))
+	for _, o := range plan.Operations {
+		operations = append(operations, SavedOperation{Type: o.Type.String(), URN: o.Resource.URN()})
+	}
+	return operations
+}
+
+// fingerprints returns the fingerprint of the remote state and that of the
+// plan, both keyed with key.
+func fingerprints(key []byte, operations []SavedOperation, source, target *resources.Graph) (string, string, error) {
+	remoteFingerprint, err := fingerprint(key, fingerprintedGraph(source))
+	if err != nil {
+		return "", "", fmt.Errorf("fingerprinting remote state: %w", err)
+	}
+
+	planFingerprint, err := fingerprint(key, struct {
+		Operations  []SavedOperation `json:"operations"`
+		TargetGraph []any            `json:"targetGraph"`
+	}{operations, fingerprintedGraph(target)})
+	if err != nil {
+		return "", "", fmt.Errorf("fingerprinting plan: %w", err)
+	}
+
+	return remoteFingerprint, planFingerprint, nil
+}
+
+// sortedDiff returns a copy of diff with its URN lists sorted, so saved plans
+// are stable across runs.
+func sortedDiff(diff *differ.Diff) *differ.Diff {
+	if diff == nil {
+		return nil
+	}
+	sorted := func(urns []string) []string {
+		out := append([]string{}, urns...)
+		sort.Strings(out)
+		return out
+	}
+	return &differ.Diff{
+		NewResources:        sorted(diff.NewResources),
+		ImportableResources: sorted(diff.ImportableResources),
+		UpdatedResources:    diff.UpdatedResources,
+		RemovedResources:    sorted(diff.RemovedResources),
+		UnmodifiedResources: sorted(diff.UnmodifiedResources),
+	}
+}
+
+// fingerprint computes the HMAC-SHA256 of the JSON encoding of v under key.
+// Map keys are marshalled in sorted order, so equal values always produce the
+// same fingerprint. Secrets are hashed by their real value: their masked form
+// hides most changes, which would let a stale plan pass Verify.
+func fingerprint(key []byte, v any) (string, error) {
+	data, err := json.Marshal(unmasked(reflect.ValueOf(v)))
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+var (
+	secretType        = reflect.TypeFor[secret.String]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+)
+
+// unmasked converts v into plain maps, slices and values, following json
+// struct tags, with secret.String values replaced by their revealed value.
+// Other values that marshal themselves are kept as they are.
+func unmasked(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Type() == secretType {
+		s := v.Interface().(secret.String)
+		return map[string]any{"secret": s.Reveal(), "unknown": s.IsUnknown()}
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return unmasked(v.Elem())
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = unmasked(iter.Value())
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = unmasked(v.Index(i))
+		}
+		return out
+	case reflect.Struct:
+		if v.Type().Implements(jsonMarshalerType) {
+			return v.Interface()
+		}
+		out := make(map[string]any, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if !f.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			out[name] = unmasked(v.Field(i))
+		}
+		return out
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return nil
+	default:
+		return v.Interface()
+	}
+}
@@ -0,0 +1,178 @@
+package planner_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/secret"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/planner"
+)
+
+func TestSavedPlan_RoundTrip(t *testing.T) {
+	existing := newResource("res1", "resource 1", nil)
+	updated := newResource("res1", "resource 1 updated", nil)
+	created := newResource("res2", "resource 2", &resources.PropertyRef{URN: updated.URN(), Property: "name"})
+
+	source := newGraphWithResources(existing)
+	target := newGraphWithResources(updated, created)
+
+	plan, err := planner.New("workspace-id").Plan(source, target)
+	require.NoError(t, err)
+	saved, err := planner.NewSavedPlan("workspace-id", plan, source, target)
+	require.NoError(t, err)
+
+	assert.Equal(t, planner.SavedPlanVersion, saved.Version)
+	assert.Equal(t, []planner.SavedOperation{
+		{Type: "Create", URN: created.URN()},
+		{Type: "Update", URN: updated.URN()},
+	}, saved.Operations)
+	require.Len(t, saved.TargetGraph, 2)
+	assert.Equal(t, []string{updated.URN()}, saved.TargetGraph[1].Dependencies)
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, planner.WriteSavedPlan(path, saved))
+
+	read, err := planner.ReadSavedPlan(path)
+	require.NoError(t, err)
+	assert.Equal(t, saved.RemoteFingerprint, read.RemoteFingerprint)
+	assert.Equal(t, saved.PlanFingerprint, read.PlanFingerprint)
+	assert.Equal(t, saved.FingerprintKey, read.FingerprintKey)
+	assert.NoError(t, read.Verify("workspace-id", plan, source, target))
+	assert.Equal(t, saved.Operations, read.Operations)
+	assert.Equal(t, saved.Diff.NewResources, read.Diff.NewResources)
+	assert.Contains(t, read.Diff.UpdatedResources, updated.URN())
+}
+
+func TestSavedPlan_Verify(t *testing.T) {
+	existing := newResource("res1", "resource 1", nil)
+	target := newGraphWithResources(newResource("res1", "resource 1 updated", nil))
+
+	verify := func(t *testing.T, saved *planner.SavedPlan, workspaceID string, source, target *resources.Graph) error {
+		t.Helper()
+		plan, err := planner.New(workspaceID).Plan(source, target)
+		require.NoError(t, err)
+		return saved.Verify(workspaceID, plan, source, target)
+	}
+
+	source := newGraphWithResources(existing)
+	plan, err := planner.New("workspace-id").Plan(source, target)
+	require.NoError(t, err)
+	saved, err := planner.NewSavedPlan("workspace-id", plan, source, target)
+	require.NoError(t, err)
+
+	t.Run("unchanged", func(t *testing.T) {
+		assert.NoError(t, verify(t, saved, "workspace-id", newGraphWithResources(existing), target))
+	})
+
+	t.Run("remote state changed", func(t *testing.T) {
+		source := newGraphWithResources(newResource("res1", "changed in the UI", nil))
+		assert.ErrorIs(t, verify(t, saved, "workspace-id", source, target), planner.ErrRemoteStateChanged)
+	})
+
+	t.Run("project changed", func(t *testing.T) {
+		changedTarget := newGraphWithResources(newResource("res1", "another local change", nil))
+		assert.ErrorIs(t, verify(t, saved, "workspace-id", newGraphWithResources(existing), changedTarget), planner.ErrProjectChanged)
+	})
+
+	t.Run("different workspace", func(t *testing.T) {
+		assert.ErrorContains(t, verify(t, saved, "other-workspace-id", newGraphWithResources(existing), target), "other-workspace-id")
+	})
+
+	t.Run("invalid fingerprint key", func(t *testing.T) {
+		tampered := *saved
+		tampered.FingerprintKey = "not-hex"
+		assert.ErrorContains(t, verify(t, &tampered, "workspace-id", newGraphWithResources(existing), target), "invalid fingerprint key")
+	})
+}
+
+func TestSavedPlan_FingerprintKey(t *testing.T) {
+	target := newGraphWithResources(resources.NewResource("res1", "some-type", resources.ResourceData{
+		"token": secret.New("super-secret-token"),
+	}, nil))
+
+	plan, err := planner.New("workspace-id").Plan(newGraph(), target)
+	require.NoError(t, err)
+	first, err := planner.NewSavedPlan("workspace-id", plan, newGraph(), target)
+	require.NoError(t, err)
+	second, err := planner.NewSavedPlan("workspace-id", plan, newGraph(), target)
+	require.NoError(t, err)
+
+	// Each plan is keyed on its own, so equal plans do not share fingerprints
+	// that would let their secrets be compared across plan files.
+	assert.NotEqual(t, first.FingerprintKey, second.FingerprintKey)
+	assert.NotEqual(t, first.PlanFingerprint, second.PlanFingerprint)
+	assert.NoError(t, first.Verify("workspace-id", plan, newGraph(), target))
+	assert.NoError(t, second.Verify("workspace-id", plan, newGraph(), target))
+}
+
+func TestSavedPlan_MasksSecrets(t *testing.T) {
+	r := resources.NewResource("res1", "some-type", resources.ResourceData{
+		"token": secret.New("super-secret-token"),
+	}, nil)
+	target := newGraphWithResources(r)
+
+	plan, err := planner.New("workspace-id").Plan(newGraph(), target)
+	require.NoError(t, err)
+	saved, err := planner.NewSavedPlan("workspace-id", plan, newGraph(), target)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, planner.WriteSavedPlan(path, saved))
+
+	read, err := planner.ReadSavedPlan(path)
+	require.NoError(t, err)
+	assert.Equal(t, "****oken", read.TargetGraph[0].Data["token"])
+}
+
+type rawDataWithSecret struct {
+	Name  string
+	Token secret.String
+}
+
+func TestSavedPlan_Verify_SecretChanged(t *testing.T) {
+	newTarget := func(token, rawToken string) *resources.Graph {
+		return newGraphWithResources(
+			resources.NewResource("res1", "some-type", resources.ResourceData{
+				"token": secret.New(token),
+			}, nil),
+			resources.NewResource("res2", "some-type", resources.ResourceData{}, nil,
+				resources.WithRawData(&rawDataWithSecret{Name: "res2", Token: secret.New(rawToken)})),
+		)
+	}
+	verify := func(t *testing.T, saved *planner.SavedPlan, target *resources.Graph) error {
+		t.Helper()
+		plan, err := planner.New("workspace-id").Plan(newGraph(), target)
+		require.NoError(t, err)
+		return saved.Verify("workspace-id", plan, newGraph(), target)
+	}
+
+	target := newTarget("abc", "first-secret-token")
+	plan, err := planner.New("workspace-id").Plan(newGraph(), target)
+	require.NoError(t, err)
+	saved, err := planner.NewSavedPlan("workspace-id", plan, newGraph(), target)
+	require.NoError(t, err)
+
+	t.Run("unchanged", func(t *testing.T) {
+		assert.NoError(t, verify(t, saved, newTarget("abc", "first-secret-token")))
+	})
+
+	t.Run("short secret changed", func(t *testing.T) {
+		assert.ErrorIs(t, verify(t, saved, newTarget("xyz", "first-secret-token")), planner.ErrProjectChanged)
+	})
+
+	t.Run("raw data secret keeps its last four characters", func(t *testing.T) {
+		assert.ErrorIs(t, verify(t, saved, newTarget("abc", "other-secret-token")), planner.ErrProjectChanged)
+	})
+}
+
+func TestReadSavedPlan_UnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, planner.WriteSavedPlan(path, &planner.SavedPlan{Version: 99}))
+
+	_, err := planner.ReadSavedPlan(path)
+	assert.ErrorContains(t, err, "unsupported plan file version 99")
+}
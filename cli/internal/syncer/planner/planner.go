@@ -11,6 +11,8 @@ import (
 
 type Planner struct {
 	workspaceId string
+	protection  Protection
+	replaces    ReplaceFunc
 }
 
 type OperationType int
@@ -51,13 +53,38 @@ type Plan struct {
 	Operations []*Operation
 }
 
-func New(workspaceId string) *Planner {
-	return &Planner{
+type Option func(*Planner)
+
+// WithProtection makes Plan fail instead of deleting or replacing protected
+// resources.
+func WithProtection(protection Protection) Option {
+	return func(p *Planner) {
+		p.protection = protection
+	}
+}
+
+// WithReplaces tells Plan which updates replace their resource, so that
+// protected resources are not replaced either.
+func WithReplaces(replaces ReplaceFunc) Option {
+	return func(p *Planner) {
+		p.replaces = replaces
+	}
+}
+
+func New(workspaceId string, opts ...Option) *Planner {
+	p := &Planner{
 		workspaceId: workspaceId,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
-func (p *Planner) Plan(source, target *resources.Graph) *Plan {
+// Plan computes the operations that turn source into target. It fails with a
+// *ProtectedResourcesError if any of the deleted or replaced resources is
+// protected.
+func (p *Planner) Plan(source, target *resources.Graph) (*Plan, error) {
 	diff := differ.ComputeDiff(source, target, differ.DiffOptions{WorkspaceID: p.workspaceId})
 	plan := &Plan{
 		Diff: diff,
@@ -82,9 +109,13 @@ func (p *Planner) Plan(source, target *resources.Graph) *Plan {
 	for r := range diff.UpdatedResources {
 		updatedURNs = append(updatedURNs, r)
 	}
+	var protected []string
 	sortedUpdated := sortByDependencies(updatedURNs, target)
 	for _, urn := range sortedUpdated {
 		resource, _ := target.GetResource(urn)
+		if p.protection.protects(urn) && p.isReplacement(resource.Type(), diff.UpdatedResources[urn]) {
+			protected = append(protected, urn)
+		}
 		plan.Operations = append(plan.Operations, &Operation{Type: Update, Resource: resource})
 	}
 
@@ -93,10 +124,32 @@ func (p *Planner) Plan(source, target *resources.Graph) *Plan {
 	slices.Reverse(sortedDeleted)
 	for _, urn := range sortedDeleted {
 		resource, _ := source.GetResource(urn)
+		if p.protection.protects(urn) {
+			protected = append(protected, urn)
+		}
 		plan.Operations = append(plan.Operations, &Operation{Type: Delete, Resource: resource})
 	}
 
-	return plan
+	if len(protected) > 0 {
+		sort.Strings(protected)
+		return nil, &ProtectedResourcesError{URNs: protected}
+	}
+
+	return plan, nil
+}
+
+// isReplacement reports whether an update changes a property that can't
+// change in place.
+func (p *Planner) isReplacement(resourceType string, diff differ.ResourceDiff) bool {
+	if p.replaces == nil {
+		return false
+	}
+	for _, property := range p.replaces(resourceType) {
+		if _, ok := diff.Diffs[property]; ok {
+			return true
+		}
+	}
+	return false
 }
 
 // sortByDependencies returns resources ordered by their dependencies,
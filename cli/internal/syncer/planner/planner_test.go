@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
 	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/planner"
@@ -132,7 +133,8 @@ func TestPlanner_Plan(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := planner.New("workspace-id")
-			plan := p.Plan(tt.source, tt.target)
+			plan, err := p.Plan(tt.source, tt.target)
+			require.NoError(t, err)
 			for i, op := range plan.Operations {
 				fmt.Printf("Operation %d: %d %s\n", i, op.Type, op.Resource.ID())
 			}
@@ -141,6 +143,71 @@ func TestPlanner_Plan(t *testing.T) {
 	}
 }
 
+func TestPlanner_Plan_Protection(t *testing.T) {
+	kept := newResource("res1", "resource 1", nil)
+	protected := newResource("res2", "resource 2", nil)
+	other := resources.NewResource("res3", "other-type", resources.ResourceData{"name": "resource 3"}, []string{})
+
+	source := newGraphWithResources(kept, protected, other)
+
+	t.Run("fails when deleting protected URNs", func(t *testing.T) {
+		p := planner.New("workspace-id", planner.WithProtection(planner.Protection{URNs: []string{protected.URN()}}))
+		_, err := p.Plan(source, newGraphWithResources(kept))
+
+		var protectedErr *planner.ProtectedResourcesError
+		require.ErrorAs(t, err, &protectedErr)
+		assert.Equal(t, []string{protected.URN()}, protectedErr.URNs)
+	})
+
+	t.Run("fails when deleting resources matching protected patterns", func(t *testing.T) {
+		p := planner.New("workspace-id", planner.WithProtection(planner.Protection{Patterns: []string{"other-type:*"}}))
+		_, err := p.Plan(source, newGraphWithResources(kept, protected))
+		assert.ErrorContains(t, err, "plan would delete or replace protected resources: other-type:res3")
+	})
+
+	t.Run("fails when replacing protected resources", func(t *testing.T) {
+		replaces := func(resourceType string) []string {
+			if resourceType == "some-type" {
+				return []string{"name"}
+			}
+			return nil
+		}
+		p := planner.New("workspace-id",
+			planner.WithProtection(planner.Protection{URNs: []string{protected.URN()}}),
+			planner.WithReplaces(replaces),
+		)
+
+		_, err := p.Plan(source, newGraphWithResources(kept, newResource("res2", "renamed", nil), other))
+		var protectedErr *planner.ProtectedResourcesError
+		require.ErrorAs(t, err, &protectedErr)
+		assert.Equal(t, []string{protected.URN()}, protectedErr.URNs)
+
+		plan, err := p.Plan(source, newGraphWithResources(newResource("res1", "renamed", nil), protected, other))
+		require.NoError(t, err, "unprotected resources may be replaced")
+		assert.Len(t, plan.Operations, 1)
+	})
+
+	t.Run("allows in-place updates of protected resources", func(t *testing.T) {
+		p := planner.New("workspace-id",
+			planner.WithProtection(planner.Protection{URNs: []string{protected.URN()}}),
+			planner.WithReplaces(func(string) []string { return []string{"dependency"} }),
+		)
+		plan, err := p.Plan(source, newGraphWithResources(kept, newResource("res2", "renamed", nil), other))
+		require.NoError(t, err)
+		assert.Len(t, plan.Operations, 1)
+	})
+
+	t.Run("allows changes that keep protected resources", func(t *testing.T) {
+		p := planner.New("workspace-id", planner.WithProtection(planner.Protection{
+			URNs:     []string{protected.URN()},
+			Patterns: []string{"other-type:*"},
+		}))
+		plan, err := p.Plan(source, newGraphWithResources(protected, other))
+		require.NoError(t, err)
+		assert.Equal(t, []*planner.Operation{{Type: planner.Delete, Resource: kept}}, plan.Operations)
+	})
+}
+
 func newGraph() *resources.Graph {
 	return resources.NewGraph()
 }
@@ -0,0 +1,49 @@
+package planner
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Protection lists the resources a plan must not delete or replace.
+type Protection struct {
+	// URNs are individual protected resources, e.g. those declared in specs
+	// with metadata.lifecycle.prevent_destroy set.
+	URNs []string
+	// Patterns protect every resource whose URN matches one of them, e.g.
+	// "tracking-plan:*", as listed in the project's lifecycle specs. They are
+	// matched against the resources that exist remotely, so they keep
+	// protecting resources whose specs were removed.
+	Patterns []string
+}
+
+func (p Protection) protects(urn string) bool {
+	if slices.Contains(p.URNs, urn) {
+		return true
+	}
+	for _, pattern := range p.Patterns {
+		if ok, _ := path.Match(pattern, urn); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplaceFunc returns the properties of a resource type that cannot change in
+// place: updating any of them deletes and recreates the resource.
+type ReplaceFunc func(resourceType string) []string
+
+// ProtectedResourcesError is returned by Plan when the plan would delete or
+// replace protected resources.
+type ProtectedResourcesError struct {
+	URNs []string
+}
+
+func (e *ProtectedResourcesError) Error() string {
+	return fmt.Sprintf(
+		"plan would delete or replace protected resources: %s; remove their lifecycle.prevent_destroy setting or lifecycle spec entry to allow it",
+		strings.Join(e.URNs, ", "),
+	)
+}
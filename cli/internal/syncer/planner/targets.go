@@ -0,0 +1,93 @@
+package planner
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+)
+
+// Targets restricts a plan to the resources matching a set of patterns, plus
+// the resources they are related to. A pattern is either a URN or a glob over
+// URNs, e.g. "tracking-plan:*".
+type Targets struct {
+	Patterns []string
+
+	// Dependents pulls in the resources that depend on the matched ones
+	// instead of their dependencies, as needed when deleting: a resource
+	// can't be deleted while others still reference it.
+	Dependents bool
+}
+
+// ValidateTargetPattern checks that pattern is a well-formed URN glob.
+func ValidateTargetPattern(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid target %q: %w", pattern, err)
+	}
+	return nil
+}
+
+// Filter returns the source and target graphs restricted to the targeted
+// resources and their closure. Patterns are matched against both graphs, so
+// a resource that exists only remotely can still be targeted for deletion.
+// Every pattern must match at least one resource.
+func (t Targets) Filter(source, target *resources.Graph) (*resources.Graph, *resources.Graph, error) {
+	matched := make(map[string]bool)
+	for _, pattern := range t.Patterns {
+		found := false
+		for _, g := range []*resources.Graph{source, target} {
+			for urn := range g.Resources() {
+				ok, err := path.Match(pattern, urn)
+				if err != nil {
+					return nil, nil, fmt.Errorf("invalid target %q: %w", pattern, err)
+				}
+				if ok {
+					matched[urn] = true
+					found = true
+				}
+			}
+		}
+		if !found {
+			return nil, nil, fmt.Errorf("target %q matches no resources", pattern)
+		}
+	}
+
+	// Dependencies are what the targeted resources will need once applied,
+	// so they come from the target graph; a dependency that was only dropped
+	// locally must not be pulled in. Dependents are what still references the
+	// targeted resources before they are deleted, so they come from the source.
+	related := target.GetDependencies
+	if t.Dependents {
+		related = source.GetDependents
+	}
+
+	selected := make(map[string]bool)
+	var visit func(urn string)
+	visit = func(urn string) {
+		if selected[urn] {
+			return
+		}
+		selected[urn] = true
+		for _, r := range related(urn) {
+			visit(r)
+		}
+	}
+
+	for urn := range matched {
+		visit(urn)
+	}
+
+	return subgraph(source, selected), subgraph(target, selected), nil
+}
+
+func subgraph(g *resources.Graph, selected map[string]bool) *resources.Graph {
+	sub := resources.NewGraph()
+	for urn, r := range g.Resources() {
+		if !selected[urn] {
+			continue
+		}
+		sub.AddResource(r)
+		sub.AddDependencies(urn, g.GetDependencies(urn))
+	}
+	return sub
+}
@@ -0,0 +1,102 @@
+package planner_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/planner"
+)
+
+func TestTargets_Filter(t *testing.T) {
+	// res1 <- res2 <- res3, with res4 unrelated and res0 only in the source.
+	res0 := newResource("res0", "resource 0", nil)
+	res1 := newResource("res1", "resource 1", nil)
+	res2 := newResource("res2", "resource 2", &resources.PropertyRef{URN: res1.URN(), Property: "name"})
+	res3 := newResource("res3", "resource 3", &resources.PropertyRef{URN: res2.URN(), Property: "name"})
+	res4 := newResource("res4", "resource 4", nil)
+
+	source := newGraphWithResources(res0, res1, res2, res3)
+	target := newGraphWithResources(res1, res2, res3, res4)
+
+	urns := func(g *resources.Graph) []string {
+		var out []string
+		for urn := range g.Resources() {
+			out = append(out, urn)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name           string
+		targets        planner.Targets
+		expectedSource []string
+		expectedTarget []string
+	}{
+		{
+			name:           "includes transitive dependencies",
+			targets:        planner.Targets{Patterns: []string{res3.URN()}},
+			expectedSource: []string{res1.URN(), res2.URN(), res3.URN()},
+			expectedTarget: []string{res1.URN(), res2.URN(), res3.URN()},
+		},
+		{
+			name:           "includes transitive dependents",
+			targets:        planner.Targets{Patterns: []string{res1.URN()}, Dependents: true},
+			expectedSource: []string{res1.URN(), res2.URN(), res3.URN()},
+			expectedTarget: []string{res1.URN(), res2.URN(), res3.URN()},
+		},
+		{
+			name:           "matches resources only present remotely",
+			targets:        planner.Targets{Patterns: []string{res0.URN()}},
+			expectedSource: []string{res0.URN()},
+			expectedTarget: nil,
+		},
+		{
+			name:           "matches globs",
+			targets:        planner.Targets{Patterns: []string{"some-type:res[04]"}},
+			expectedSource: []string{res0.URN()},
+			expectedTarget: []string{res4.URN()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filteredSource, filteredTarget, err := tt.targets.Filter(source, target)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expectedSource, urns(filteredSource))
+			assert.ElementsMatch(t, tt.expectedTarget, urns(filteredTarget))
+		})
+	}
+
+	t.Run("keeps dependencies between selected resources", func(t *testing.T) {
+		_, filteredTarget, err := planner.Targets{Patterns: []string{res3.URN()}}.Filter(source, target)
+		require.NoError(t, err)
+		assert.Equal(t, []string{res2.URN()}, filteredTarget.GetDependencies(res3.URN()))
+	})
+
+	t.Run("takes dependencies from the target graph", func(t *testing.T) {
+		// res5 used to depend on res1 remotely and now depends on res4.
+		remote := newResource("res5", "resource 5", &resources.PropertyRef{URN: res1.URN(), Property: "name"})
+		local := newResource("res5", "resource 5", &resources.PropertyRef{URN: res4.URN(), Property: "name"})
+
+		filteredSource, filteredTarget, err := planner.Targets{Patterns: []string{local.URN()}}.Filter(
+			newGraphWithResources(res1, remote),
+			newGraphWithResources(res1, res4, local),
+		)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{remote.URN()}, urns(filteredSource))
+		assert.ElementsMatch(t, []string{res4.URN(), local.URN()}, urns(filteredTarget))
+	})
+
+	t.Run("fails when a target matches nothing", func(t *testing.T) {
+		_, _, err := planner.Targets{Patterns: []string{res1.URN(), "other:*"}}.Filter(source, target)
+		assert.EqualError(t, err, `target "other:*" matches no resources`)
+	})
+}
+
+func TestValidateTargetPattern(t *testing.T) {
+	assert.NoError(t, planner.ValidateTargetPattern("tracking-plan:*"))
+	assert.Error(t, planner.ValidateTargetPattern("tracking-plan:["))
+}
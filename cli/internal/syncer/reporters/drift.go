@@ -0,0 +1,133 @@
+package reporters
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/differ"
+)
+
+// Drift statuses, as reported in the JSON drift record.
+const (
+	DriftModified      = "modified"
+	DriftMissingRemote = "missing_remote"
+	DriftPendingImport = "pending_import"
+	DriftNotInProject  = "not_in_project"
+)
+
+type jsonDriftRecord struct {
+	Type      string              `json:"type"`
+	Drifted   bool                `json:"drifted"`
+	Summary   jsonDriftSummary    `json:"summary"`
+	Resources []jsonDriftResource `json:"resources"`
+}
+
+type jsonDriftSummary struct {
+	Modified      int `json:"modified"`
+	MissingRemote int `json:"missingRemote"`
+	PendingImport int `json:"pendingImport"`
+	NotInProject  int `json:"notInProject"`
+}
+
+type jsonDriftResource struct {
+	Status string             `json:"status"`
+	URN    string             `json:"urn"`
+	Diffs  []jsonPropertyDiff `json:"diffs,omitempty"`
+}
+
+// WriteDrift renders the drift between the workspace and the project as text.
+// The diff does not tell which side changed, so labels only state how the two
+// differ.
+// Property diffs read remote value => project value, matching the plan output
+// of apply, where the remote side is the source.
+func WriteDrift(w io.Writer, diff *differ.Diff) {
+	b := &strings.Builder{}
+
+	if len(diff.UpdatedResources) > 0 {
+		listResources(b, "Differs between project and workspace", sortedUpdated(diff), func(urn string) string {
+			r := diff.UpdatedResources[urn]
+			details := ""
+			for _, k := range sortedPropertyKeys(r) {
+				for _, line := range renderPropertyDiff(r.Diffs[k]) {
+					details += line
+				}
+			}
+			return details
+		})
+	}
+
+	if len(diff.NewResources) > 0 {
+		listResources(b, "Not present in workspace", sorted(diff.NewResources), nil)
+	}
+
+	if len(diff.ImportableResources) > 0 {
+		listResources(b, "Pending import", sorted(diff.ImportableResources), nil)
+	}
+
+	if len(diff.RemovedResources) > 0 {
+		listResources(b, "Managed in workspace but not in project", sorted(diff.RemovedResources), nil)
+	}
+
+	fmt.Fprint(w, b.String())
+}
+
+// WriteJSONDrift writes the drift between the workspace and the project as a
+// single JSON record, in the same line-delimited format JSONSyncReporter uses.
+func WriteJSONDrift(w io.Writer, diff *differ.Diff) {
+	record := jsonDriftRecord{
+		Type:      RecordDrift,
+		Drifted:   diff.HasNonSecretDiff(),
+		Resources: []jsonDriftResource{},
+		Summary: jsonDriftSummary{
+			Modified:      len(diff.UpdatedResources),
+			MissingRemote: len(diff.NewResources),
+			PendingImport: len(diff.ImportableResources),
+			NotInProject:  len(diff.RemovedResources),
+		},
+	}
+
+	for _, urn := range sortedUpdated(diff) {
+		record.Resources = append(record.Resources, jsonDriftResource{
+			Status: DriftModified,
+			URN:    urn,
+			Diffs:  jsonPropertyDiffs(diff.UpdatedResources[urn]),
+		})
+	}
+	for _, urn := range sorted(diff.NewResources) {
+		record.Resources = append(record.Resources, jsonDriftResource{Status: DriftMissingRemote, URN: urn})
+	}
+	for _, urn := range sorted(diff.ImportableResources) {
+		record.Resources = append(record.Resources, jsonDriftResource{Status: DriftPendingImport, URN: urn})
+	}
+	for _, urn := range sorted(diff.RemovedResources) {
+		record.Resources = append(record.Resources, jsonDriftResource{Status: DriftNotInProject, URN: urn})
+	}
+
+	(&JSONSyncReporter{Writer: w}).emit(record)
+}
+
+func sortedUpdated(diff *differ.Diff) []string {
+	urns := make([]string, 0, len(diff.UpdatedResources))
+	for urn := range diff.UpdatedResources {
+		urns = append(urns, urn)
+	}
+	sort.Strings(urns)
+	return urns
+}
+
+func sortedPropertyKeys(rd differ.ResourceDiff) []string {
+	keys := make([]string, 0, len(rd.Diffs))
+	for k := range rd.Diffs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sorted(urns []string) []string {
+	out := append([]string(nil), urns...)
+	sort.Strings(out)
+	return out
+}
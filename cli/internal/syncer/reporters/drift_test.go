@@ -0,0 +1,92 @@
+package reporters_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/differ"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/reporters"
+)
+
+func TestWriteJSONDrift(t *testing.T) {
+	var buf bytes.Buffer
+
+	reporters.WriteJSONDrift(&buf, &differ.Diff{
+		NewResources:     []string{"event:checkout"},
+		RemovedResources: []string{"event:legacy"},
+		UpdatedResources: map[string]differ.ResourceDiff{
+			"event:login": {
+				URN: "event:login",
+				Diffs: map[string]differ.PropertyDiff{
+					"name": {Property: "name", SourceValue: "Login", TargetValue: "Logged In"},
+				},
+			},
+		},
+	})
+
+	assert.JSONEq(t, `{
+		"type": "drift",
+		"drifted": true,
+		"summary": {"modified": 1, "missingRemote": 1, "pendingImport": 0, "notInProject": 1},
+		"resources": [
+			{"status": "modified", "urn": "event:login", "diffs": [
+				{"property": "name", "sourceValue": "Login", "targetValue": "Logged In"}
+			]},
+			{"status": "missing_remote", "urn": "event:checkout"},
+			{"status": "not_in_project", "urn": "event:legacy"}
+		]
+	}`, buf.String())
+}
+
+func TestWriteJSONDrift_Clean(t *testing.T) {
+	var buf bytes.Buffer
+
+	reporters.WriteJSONDrift(&buf, &differ.Diff{UnmodifiedResources: []string{"event:login"}})
+
+	assert.JSONEq(t, `{
+		"type": "drift",
+		"drifted": false,
+		"summary": {"modified": 0, "missingRemote": 0, "pendingImport": 0, "notInProject": 0},
+		"resources": []
+	}`, buf.String())
+}
+
+func TestWriteDrift(t *testing.T) {
+	var buf bytes.Buffer
+
+	reporters.WriteDrift(&buf, &differ.Diff{
+		ImportableResources: []string{"event:imported"},
+		RemovedResources:    []string{"event:legacy"},
+	})
+
+	out := buf.String()
+	assert.Contains(t, out, "Pending import")
+	assert.Contains(t, out, "event:imported")
+	assert.Contains(t, out, "Managed in workspace but not in project")
+	assert.Contains(t, out, "event:legacy")
+	assert.NotContains(t, out, "Not present in workspace")
+}
+
+func TestWriteDrift_UpdatedAndNew(t *testing.T) {
+	var buf bytes.Buffer
+
+	reporters.WriteDrift(&buf, &differ.Diff{
+		NewResources: []string{"event:checkout"},
+		UpdatedResources: map[string]differ.ResourceDiff{
+			"event:login": {
+				URN: "event:login",
+				Diffs: map[string]differ.PropertyDiff{
+					"name": {Property: "name", SourceValue: "Login", TargetValue: "Logged In"},
+				},
+			},
+		},
+	})
+
+	out := buf.String()
+	assert.Contains(t, out, "Differs between project and workspace")
+	assert.Contains(t, out, "event:login")
+	assert.Contains(t, out, "Not present in workspace")
+	assert.Contains(t, out, "event:checkout")
+}
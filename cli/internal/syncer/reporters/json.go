@@ -0,0 +1,257 @@
+package reporters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/secret"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/differ"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/planner"
+)
+
+// Record types emitted by JSONSyncReporter, one JSON object per line.
+const (
+	RecordPlan          = "plan"
+	RecordMessage       = "message"
+	RecordSyncStarted   = "sync_started"
+	RecordSyncCompleted = "sync_completed"
+	RecordTaskStarted   = "task_started"
+	RecordTaskCompleted = "task_completed"
+	RecordError         = "error"
+	RecordDrift         = "drift"
+	RecordNotApplied    = "not_applied"
+)
+
+// JSONSyncReporter implements SyncReporter by writing newline-delimited JSON
+// records, so that pipelines can consume plans and apply results without
+// scraping terminal output. Secret values are masked through secret.String.
+type JSONSyncReporter struct {
+	Writer io.Writer
+	mu     sync.Mutex
+}
+
+type jsonPlanRecord struct {
+	Type       string          `json:"type"`
+	Summary    jsonPlanSummary `json:"summary"`
+	Operations []jsonOperation `json:"operations"`
+}
+
+type jsonPlanSummary struct {
+	Import int `json:"import"`
+	Create int `json:"create"`
+	Update int `json:"update"`
+	Delete int `json:"delete"`
+}
+
+type jsonOperation struct {
+	Type         string             `json:"type"`
+	URN          string             `json:"urn"`
+	ResourceType string             `json:"resourceType"`
+	ID           string             `json:"id"`
+	SecretOnly   bool               `json:"secretOnly,omitempty"`
+	Diffs        []jsonPropertyDiff `json:"diffs,omitempty"`
+}
+
+type jsonPropertyDiff struct {
+	Property    string `json:"property"`
+	SourceValue any    `json:"sourceValue"`
+	TargetValue any    `json:"targetValue"`
+	SecretOnly  bool   `json:"secretOnly,omitempty"`
+}
+
+type jsonMessageRecord struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+type jsonSyncStartedRecord struct {
+	Type       string `json:"type"`
+	TotalTasks int    `json:"totalTasks"`
+}
+
+type jsonSyncCompletedRecord struct {
+	Type string `json:"type"`
+}
+
+type jsonTaskRecord struct {
+	Type        string `json:"type"`
+	TaskID      string `json:"taskId"`
+	Description string `json:"description"`
+	Success     *bool  `json:"success,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+type jsonErrorRecord struct {
+	Type  string `json:"type"`
+	Error string `json:"error"`
+}
+
+func (r *JSONSyncReporter) getWriter() io.Writer {
+	if r.Writer == nil {
+		return os.Stdout
+	}
+	return r.Writer
+}
+
+func (r *JSONSyncReporter) ReportPlan(plan *planner.Plan) {
+	record := jsonPlanRecord{
+		Type:       RecordPlan,
+		Operations: make([]jsonOperation, 0, len(plan.Operations)),
+	}
+
+	for _, o := range plan.Operations {
+		op := jsonOperation{
+			Type:         o.Type.String(),
+			URN:          o.Resource.URN(),
+			ResourceType: o.Resource.Type(),
+			ID:           o.Resource.ID(),
+		}
+
+		switch o.Type {
+		case planner.Import:
+			record.Summary.Import++
+		case planner.Create:
+			record.Summary.Create++
+		case planner.Update:
+			record.Summary.Update++
+			if plan.Diff != nil {
+				rd := plan.Diff.UpdatedResources[o.Resource.URN()]
+				op.SecretOnly = rd.IsSecretOnly()
+				op.Diffs = jsonPropertyDiffs(rd)
+			}
+		case planner.Delete:
+			record.Summary.Delete++
+		}
+
+		record.Operations = append(record.Operations, op)
+	}
+
+	r.emit(record)
+}
+
+// ErrConfirmationRequired is returned by JSONSyncReporter.AskConfirmation, as
+// machine-readable output cannot prompt for confirmation.
+var ErrConfirmationRequired = errors.New("confirmation required: changes were not applied, run with --confirm=false to apply them with JSON output")
+
+// AskConfirmation in JSONSyncReporter cannot prompt, so it emits a
+// "not_applied" record and fails with ErrConfirmationRequired. Pipelines
+// are expected to run with --confirm=false.
+func (r *JSONSyncReporter) AskConfirmation() (bool, error) {
+	r.emit(jsonMessageRecord{Type: RecordNotApplied, Message: "confirmation required"})
+	return false, ErrConfirmationRequired
+}
+
+func (r *JSONSyncReporter) SyncStarted(totalTasks int) {
+	r.emit(jsonSyncStartedRecord{Type: RecordSyncStarted, TotalTasks: totalTasks})
+}
+
+func (r *JSONSyncReporter) SyncCompleted() {
+	r.emit(jsonSyncCompletedRecord{Type: RecordSyncCompleted})
+}
+
+func (r *JSONSyncReporter) TaskStarted(taskId string, description string) {
+	r.emit(jsonTaskRecord{Type: RecordTaskStarted, TaskID: taskId, Description: description})
+}
+
+func (r *JSONSyncReporter) TaskCompleted(taskId string, description string, err error) {
+	success := err == nil
+	record := jsonTaskRecord{Type: RecordTaskCompleted, TaskID: taskId, Description: description, Success: &success}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	r.emit(record)
+}
+
+// Message emits an informational message, such as "No changes to apply",
+// as a record instead of plain text.
+func (r *JSONSyncReporter) Message(message string) {
+	r.emit(jsonMessageRecord{Type: RecordMessage, Message: message})
+}
+
+// emit writes record as a single line. Tasks complete concurrently, so
+// writes are serialized to keep records from interleaving.
+func (r *JSONSyncReporter) emit(record any) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		data, _ = json.Marshal(jsonErrorRecord{Type: RecordError, Error: fmt.Sprintf("encoding %T record: %s", record, err)})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fmt.Fprintln(r.getWriter(), string(data))
+}
+
+// WriteJSONError writes err to w as an error record, in the same format
+// JSONSyncReporter uses for its other records.
+func WriteJSONError(w io.Writer, err error) {
+	data, _ := json.Marshal(jsonErrorRecord{Type: RecordError, Error: err.Error()})
+	fmt.Fprintln(w, string(data))
+}
+
+func jsonPropertyDiffs(rd differ.ResourceDiff) []jsonPropertyDiff {
+	keys := make([]string, 0, len(rd.Diffs))
+	for k := range rd.Diffs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	diffs := make([]jsonPropertyDiff, 0, len(keys))
+	for _, k := range keys {
+		d := rd.Diffs[k]
+		diffs = append(diffs, jsonPropertyDiff{
+			Property:    d.Property,
+			SourceValue: jsonValue(d.SourceValue),
+			TargetValue: jsonValue(d.TargetValue),
+			SecretOnly:  d.SecretOnly,
+		})
+	}
+	return diffs
+}
+
+// jsonValue is the JSON analogue of printable: secrets are reduced to their
+// masked form and references to the URN they point at, while everything else
+// is left to encoding/json.
+func jsonValue(val any) any {
+	switch v := val.(type) {
+	case secret.String:
+		return v.String()
+	case *secret.String:
+		if v == nil {
+			return nil
+		}
+		return v.String()
+	case resources.PropertyRef:
+		return map[string]any{"urn": v.URN, "property": v.Property}
+	case *resources.PropertyRef:
+		if v == nil {
+			return nil
+		}
+		return map[string]any{"urn": v.URN, "property": v.Property}
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, vv := range v {
+			out[k] = jsonValue(vv)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, vv := range v {
+			out[i] = jsonValue(vv)
+		}
+		return out
+	case []map[string]any:
+		out := make([]any, len(v))
+		for i, vv := range v {
+			out[i] = jsonValue(vv)
+		}
+		return out
+	default:
+		return val
+	}
+}
@@ -0,0 +1,109 @@
+package reporters_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
+	"github.com/rudderlabs/rudder-iac/cli/internal/secret"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/differ"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/planner"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/reporters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSyncReporter_ReportPlan(t *testing.T) {
+	var buf bytes.Buffer
+	r := &reporters.JSONSyncReporter{Writer: &buf}
+
+	created := resources.NewResource("ev1", "event", resources.ResourceData{"name": "Event"}, nil)
+	updated := resources.NewResource("dest1", "destination", resources.ResourceData{"name": "Dest"}, nil)
+	deleted := resources.NewResource("prop1", "property", resources.ResourceData{"name": "Prop"}, nil)
+
+	r.ReportPlan(&planner.Plan{
+		Diff: &differ.Diff{
+			UpdatedResources: map[string]differ.ResourceDiff{
+				updated.URN(): {
+					URN: updated.URN(),
+					Diffs: map[string]differ.PropertyDiff{
+						"name": {Property: "name", SourceValue: "Old", TargetValue: "Dest"},
+						"api_key": {
+							Property:    "api_key",
+							SourceValue: secret.NewUnknown(),
+							TargetValue: secret.New("very-secret-key"),
+							SecretOnly:  true,
+						},
+						"account": {
+							Property:    "account",
+							SourceValue: nil,
+							TargetValue: &resources.PropertyRef{URN: "account:acc1", Property: "id"},
+						},
+					},
+				},
+			},
+		},
+		Operations: []*planner.Operation{
+			{Type: planner.Create, Resource: created},
+			{Type: planner.Update, Resource: updated},
+			{Type: planner.Delete, Resource: deleted},
+		},
+	})
+
+	assert.JSONEq(t, `{
+		"type": "plan",
+		"summary": {"import": 0, "create": 1, "update": 1, "delete": 1},
+		"operations": [
+			{"type": "Create", "urn": "event:ev1", "resourceType": "event", "id": "ev1"},
+			{"type": "Update", "urn": "destination:dest1", "resourceType": "destination", "id": "dest1", "diffs": [
+				{"property": "account", "sourceValue": null, "targetValue": {"urn": "account:acc1", "property": "id"}},
+				{"property": "api_key", "sourceValue": "(unknown)", "targetValue": "****-key", "secretOnly": true},
+				{"property": "name", "sourceValue": "Old", "targetValue": "Dest"}
+			]},
+			{"type": "Delete", "urn": "property:prop1", "resourceType": "property", "id": "prop1"}
+		]
+	}`, buf.String())
+	assert.NotContains(t, buf.String(), "very-secret-key")
+}
+
+func TestJSONSyncReporter_AskConfirmation(t *testing.T) {
+	var buf bytes.Buffer
+	r := &reporters.JSONSyncReporter{Writer: &buf}
+
+	confirmed, err := r.AskConfirmation()
+	require.ErrorIs(t, err, reporters.ErrConfirmationRequired)
+	assert.False(t, confirmed)
+	assert.JSONEq(t, `{"type":"not_applied","message":"confirmation required"}`, buf.String())
+}
+
+func TestJSONSyncReporter_Events(t *testing.T) {
+	var buf bytes.Buffer
+	r := &reporters.JSONSyncReporter{Writer: &buf}
+
+	r.SyncStarted(2)
+	r.TaskStarted("event:ev1", "Create event:ev1")
+	r.TaskCompleted("event:ev1", "Create event:ev1", nil)
+	r.TaskStarted("event:ev2", "Create event:ev2")
+	r.TaskCompleted("event:ev2", "Create event:ev2", fmt.Errorf("boom"))
+	r.SyncCompleted()
+	r.Message("No changes to apply")
+	reporters.WriteJSONError(&buf, fmt.Errorf("syncing resources: boom"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expected := []string{
+		`{"type":"sync_started","totalTasks":2}`,
+		`{"type":"task_started","taskId":"event:ev1","description":"Create event:ev1"}`,
+		`{"type":"task_completed","taskId":"event:ev1","description":"Create event:ev1","success":true}`,
+		`{"type":"task_started","taskId":"event:ev2","description":"Create event:ev2"}`,
+		`{"type":"task_completed","taskId":"event:ev2","description":"Create event:ev2","success":false,"error":"boom"}`,
+		`{"type":"sync_completed"}`,
+		`{"type":"message","message":"No changes to apply"}`,
+		`{"type":"error","error":"syncing resources: boom"}`,
+	}
+	require.Len(t, lines, len(expected))
+	for i := range expected {
+		assert.JSONEq(t, expected[i], lines[i])
+	}
+}
@@ -3,6 +3,7 @@ package syncer
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/rudderlabs/rudder-iac/api/client"
@@ -23,6 +24,10 @@ type ProjectSyncer struct {
 	concurrency     int
 	dryRun          bool
 	askConfirmation bool
+	planOutput      string
+	savedPlan       *planner.SavedPlan
+	targets         *planner.Targets
+	protection      planner.Protection
 }
 
 type SyncProvider interface {
@@ -97,6 +102,54 @@ func WithAskConfirmation(askConfirmation bool) Option {
 	}
 }
 
+// WithPlanOutput writes the computed plan, in its saved form, to path
+// before any changes are executed.
+func WithPlanOutput(path string) Option {
+	return func(s *ProjectSyncer) error {
+		s.planOutput = path
+		return nil
+	}
+}
+
+// WithSavedPlan makes the syncer verify that the plan it computes matches
+// savedPlan, refusing to execute when the remote state or the project has
+// changed since the saved plan was created.
+func WithSavedPlan(savedPlan *planner.SavedPlan) Option {
+	return func(s *ProjectSyncer) error {
+		if savedPlan == nil {
+			return fmt.Errorf("saved plan cannot be nil")
+		}
+		s.savedPlan = savedPlan
+		return nil
+	}
+}
+
+// WithTargets restricts the plan to the targeted resources and their closure,
+// leaving the rest of the project and workspace untouched.
+func WithTargets(targets planner.Targets) Option {
+	return func(s *ProjectSyncer) error {
+		if len(targets.Patterns) == 0 {
+			return fmt.Errorf("at least one target is required")
+		}
+		for _, pattern := range targets.Patterns {
+			if err := planner.ValidateTargetPattern(pattern); err != nil {
+				return err
+			}
+		}
+		s.targets = &targets
+		return nil
+	}
+}
+
+// WithProtection makes the sync fail before executing anything when the plan
+// would delete or replace protected resources.
+func WithProtection(protection planner.Protection) Option {
+	return func(s *ProjectSyncer) error {
+		s.protection = protection
+		return nil
+	}
+}
+
 type SyncReporter interface {
 	ReportPlan(plan *planner.Plan)
 	AskConfirmation() (bool, error)
@@ -106,6 +159,13 @@ type SyncReporter interface {
 	TaskCompleted(taskId string, description string, err error)
 }
 
+// MessageReporter is optionally implemented by a SyncReporter which renders
+// informational messages itself, e.g. as structured records, rather than
+// having them printed as plain text.
+type MessageReporter interface {
+	Message(message string)
+}
+
 func (s *ProjectSyncer) Sync(ctx context.Context, target *resources.Graph) error {
 	errs := s.apply(ctx, target, false)
 	if len(errs) > 0 {
@@ -133,22 +193,50 @@ func (s *ProjectSyncer) apply(ctx context.Context, target *resources.Graph, cont
 	}
 	source := StateToGraph(state)
 
-	p := planner.New(s.workspace.ID)
-	plan := p.Plan(source, target)
+	if s.targets != nil {
+		source, target, err = s.targets.Filter(source, target)
+		if err != nil {
+			spinner.Stop()
+			return []error{err}
+		}
+	}
+
+	plannerOpts := []planner.Option{planner.WithProtection(s.protection)}
+	if r, ok := s.provider.(provider.Replacer); ok {
+		plannerOpts = append(plannerOpts, planner.WithReplaces(r.ReplaceProperties))
+	}
+
+	p := planner.New(s.workspace.ID, plannerOpts...)
+	plan, err := p.Plan(source, target)
 
 	spinner.Stop()
 
+	if err != nil {
+		return []error{err}
+	}
+
+	if s.targets != nil {
+		s.warning(fmt.Sprintf(
+			"Targeting %d resource(s) from %s; the rest of the project is ignored and may still have pending changes",
+			countResources(source, target), strings.Join(s.targets.Patterns, ", "),
+		))
+	}
+
+	if err := s.handleSavedPlan(plan, source, target); err != nil {
+		return []error{err}
+	}
+
 	s.reporter.ReportPlan(plan)
 
 	if s.dryRun {
 		if len(plan.Operations) == 0 {
-			fmt.Println("No changes to apply")
+			s.message("No changes to apply")
 		}
 		return nil
 	}
 
 	if len(plan.Operations) == 0 {
-		fmt.Println("No changes to apply")
+		s.message("No changes to apply")
 		return nil
 	}
 
@@ -177,6 +265,61 @@ func (s *ProjectSyncer) apply(ctx context.Context, target *resources.Graph, cont
 	return nil
 }
 
+// handleSavedPlan verifies the computed plan against the saved plan and
+// writes it to the plan output, when either is configured.
+func (s *ProjectSyncer) handleSavedPlan(plan *planner.Plan, source, target *resources.Graph) error {
+	if s.savedPlan == nil && s.planOutput == "" {
+		return nil
+	}
+
+	if s.savedPlan != nil {
+		if err := s.savedPlan.Verify(s.workspace.ID, plan, source, target); err != nil {
+			return fmt.Errorf("refusing to apply saved plan: %w", err)
+		}
+	}
+
+	if s.planOutput != "" {
+		current, err := planner.NewSavedPlan(s.workspace.ID, plan, source, target)
+		if err != nil {
+			return fmt.Errorf("building saved plan: %w", err)
+		}
+		if err := planner.WriteSavedPlan(s.planOutput, current); err != nil {
+			return err
+		}
+		s.message(fmt.Sprintf("Plan saved to %s", s.planOutput))
+	}
+
+	return nil
+}
+
+func (s *ProjectSyncer) message(message string) {
+	if r, ok := s.reporter.(MessageReporter); ok {
+		r.Message(message)
+		return
+	}
+	fmt.Println(message)
+}
+
+// warning is like message, but highlighted when printed as plain text.
+func (s *ProjectSyncer) warning(message string) {
+	if r, ok := s.reporter.(MessageReporter); ok {
+		r.Message(message)
+		return
+	}
+	ui.PrintWarning(message)
+}
+
+// countResources returns the number of distinct resources across graphs.
+func countResources(graphs ...*resources.Graph) int {
+	urns := make(map[string]struct{})
+	for _, g := range graphs {
+		for urn := range g.Resources() {
+			urns[urn] = struct{}{}
+		}
+	}
+	return len(urns)
+}
+
 func StateToGraph(state *state.State) *resources.Graph {
 	graph := resources.NewGraph()
 
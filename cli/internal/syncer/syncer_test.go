@@ -2,12 +2,14 @@ package syncer_test
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/rudderlabs/rudder-iac/api/client"
 	"github.com/rudderlabs/rudder-iac/cli/internal/resources"
 	"github.com/rudderlabs/rudder-iac/cli/internal/resources/state"
 	"github.com/rudderlabs/rudder-iac/cli/internal/syncer"
+	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/planner"
 	"github.com/rudderlabs/rudder-iac/cli/internal/syncer/testutils"
 	internalTestutils "github.com/rudderlabs/rudder-iac/cli/internal/testutils"
 	"github.com/stretchr/testify/assert"
@@ -232,3 +234,161 @@ func TestSyncerDelete(t *testing.T) {
 		}, "TaskCompleted should contain deletion task for "+urn)
 	}
 }
+
+func TestSyncerSavedPlan(t *testing.T) {
+	event := internalTestutils.NewMockEvent("event1", resources.ResourceData{
+		"name": "Test Event",
+	})
+
+	targetGraph := resources.NewGraph()
+	targetGraph.AddResource(event)
+
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+
+	provider := &internalTestutils.DataCatalogProvider{
+		InitialState:       state.EmptyState(),
+		ReconstructedState: state.EmptyState(),
+	}
+
+	s, err := syncer.New(provider, mockWorkspace(),
+		syncer.WithReporter(testutils.NewMockReporter()),
+		syncer.WithDryRun(true),
+		syncer.WithPlanOutput(planPath),
+	)
+	require.NoError(t, err)
+	require.NoError(t, s.Sync(context.Background(), targetGraph))
+	assert.Empty(t, provider.OperationLog)
+
+	savedPlan, err := planner.ReadSavedPlan(planPath)
+	require.NoError(t, err)
+	assert.Equal(t, []planner.SavedOperation{{Type: "Create", URN: event.URN()}}, savedPlan.Operations)
+
+	t.Run("applies matching plan", func(t *testing.T) {
+		provider := &internalTestutils.DataCatalogProvider{
+			InitialState:       state.EmptyState(),
+			ReconstructedState: state.EmptyState(),
+		}
+
+		s, err := syncer.New(provider, mockWorkspace(),
+			syncer.WithReporter(testutils.NewMockReporter()),
+			syncer.WithSavedPlan(savedPlan),
+		)
+		require.NoError(t, err)
+		require.NoError(t, s.Sync(context.Background(), targetGraph))
+		assert.Len(t, provider.OperationLog, 1)
+	})
+
+	t.Run("refuses plan when remote state changed", func(t *testing.T) {
+		remoteState := state.EmptyState()
+		remoteState.AddResource(&state.ResourceState{
+			ID:     event.ID(),
+			Type:   event.Type(),
+			Input:  resources.ResourceData{"name": "Created from the UI"},
+			Output: resources.ResourceData{"id": "remote-event1"},
+		})
+		provider := &internalTestutils.DataCatalogProvider{
+			InitialState:       remoteState,
+			ReconstructedState: remoteState,
+		}
+
+		s, err := syncer.New(provider, mockWorkspace(),
+			syncer.WithReporter(testutils.NewMockReporter()),
+			syncer.WithSavedPlan(savedPlan),
+		)
+		require.NoError(t, err)
+		err = s.Sync(context.Background(), targetGraph)
+		assert.ErrorIs(t, err, planner.ErrRemoteStateChanged)
+		assert.Empty(t, provider.OperationLog)
+	})
+}
+
+func TestSyncerDestroyTargets(t *testing.T) {
+	event := internalTestutils.NewMockEvent("event1", resources.ResourceData{
+		"name": "Test Event",
+	})
+
+	property := internalTestutils.NewMockProperty("property1", resources.ResourceData{
+		"name": "Test Property",
+	})
+
+	trackingPlan := internalTestutils.NewMockTrackingPlan("trackingPlan1", resources.ResourceData{
+		"name":     "Test Tracking Plan",
+		"event_id": resources.PropertyRef{URN: event.URN(), Property: "id"},
+	})
+
+	initialState := state.EmptyState()
+	for _, r := range []*resources.Resource{event, property, trackingPlan} {
+		initialState.AddResource(&state.ResourceState{
+			ID:     r.ID(),
+			Type:   r.Type(),
+			Input:  r.Data(),
+			Output: resources.ResourceData{"id": "generated-" + r.ID()},
+		})
+	}
+
+	provider := &internalTestutils.DataCatalogProvider{
+		InitialState:       initialState,
+		ReconstructedState: initialState,
+	}
+
+	mockReporter := testutils.NewMockReporter()
+	s, err := syncer.New(provider, mockWorkspace(),
+		syncer.WithReporter(mockReporter),
+		syncer.WithTargets(planner.Targets{Patterns: []string{event.URN()}, Dependents: true}),
+	)
+	require.NoError(t, err)
+
+	errs := s.Destroy(context.Background())
+	require.Empty(t, errs)
+
+	// The tracking plan references the event, so it goes too; the property is left alone.
+	operations := make([]string, 0, len(provider.OperationLog))
+	for _, entry := range provider.OperationLog {
+		operations = append(operations, entry.Operation+" "+entry.Args[1].(string)+":"+entry.Args[0].(string))
+	}
+	assert.Equal(t, []string{
+		"Delete " + trackingPlan.URN(),
+		"Delete " + event.URN(),
+	}, operations)
+}
+
+func TestWithTargets_RejectsInvalidPatterns(t *testing.T) {
+	_, err := syncer.New(&internalTestutils.DataCatalogProvider{}, mockWorkspace(),
+		syncer.WithTargets(planner.Targets{Patterns: []string{"event:["}}),
+	)
+	assert.ErrorContains(t, err, `invalid target "event:["`)
+}
+
+func TestSyncerProtectsResourcesWithRemovedSpecs(t *testing.T) {
+	event := internalTestutils.NewMockEvent("event1", resources.ResourceData{
+		"name": "Test Event",
+	})
+
+	initialState := state.EmptyState()
+	initialState.AddResource(&state.ResourceState{
+		ID:     event.ID(),
+		Type:   event.Type(),
+		Input:  event.Data(),
+		Output: resources.ResourceData{"id": "generated-event-event1"},
+	})
+
+	provider := &internalTestutils.DataCatalogProvider{
+		InitialState:       initialState,
+		ReconstructedState: initialState,
+	}
+
+	// The event's spec was removed from the project, so the target graph no
+	// longer declares it; only the lifecycle pattern still protects it.
+	s, err := syncer.New(provider, mockWorkspace(),
+		syncer.WithReporter(testutils.NewMockReporter()),
+		syncer.WithProtection(planner.Protection{Patterns: []string{"event:*"}}),
+	)
+	require.NoError(t, err)
+
+	err = s.Sync(context.Background(), resources.NewGraph())
+
+	var protectedErr *planner.ProtectedResourcesError
+	require.ErrorAs(t, err, &protectedErr)
+	assert.Equal(t, []string{event.URN()}, protectedErr.URNs)
+	assert.Empty(t, provider.OperationLog, "no operation should run when the plan is rejected")
+}
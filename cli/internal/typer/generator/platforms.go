@@ -4,13 +4,21 @@ import (
 	"fmt"
 
 	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/dart"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/golang"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/jsonschema"
 	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/kotlin"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/python"
 	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/swift"
 	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/typescript"
 )
 
 var platforms = map[string]core.Generator{
+	"dart":       &dart.Generator{},
+	"go":         &golang.Generator{},
+	"jsonschema": &jsonschema.Generator{},
 	"kotlin":     &kotlin.Generator{},
+	"python":     &python.Generator{},
 	"swift":      &swift.Generator{},
 	"typescript": &typescript.Generator{},
 }
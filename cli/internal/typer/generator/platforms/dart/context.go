@@ -0,0 +1,116 @@
+package dart
+
+// DartTypeAlias → typedef X = Y;
+type DartTypeAlias struct {
+	Name    string
+	Type    string
+	Comment string
+}
+
+// DartEnumValue is one value of an enum.
+type DartEnumValue struct {
+	Name  string // lowerCamelCase Dart identifier, e.g. "smartTv"
+	Value any    // Original raw value, e.g. "smartTV" or 200
+}
+
+// DartEnum → enum X implements _Serializable { a('a'), ... }
+type DartEnum struct {
+	Name    string
+	Comment string
+	// ValueType is the type of the values sent in events: "String", "int",
+	// "double", "bool" or "Object?" when they don't share one.
+	ValueType string
+	Values    []DartEnumValue
+}
+
+// DartField is one final field of a class.
+type DartField struct {
+	Name     string // lowerCamelCase Dart identifier
+	JSONName string // original key in the tracking plan
+	Type     string
+	Comment  string
+	// Required fields are required constructor parameters and always sent,
+	// even when nullable. Optional fields are left out when null.
+	Required bool
+	// Serialize is true when the value must be converted with _serialize
+	// before being sent, as for classes, enums and collections.
+	Serialize bool
+}
+
+// DartClass → final class X { ... Map<String, Object?> toJson() }
+type DartClass struct {
+	Name    string
+	Comment string
+	// Superclass is the sealed class the class extends, if any.
+	Superclass string
+	Fields     []DartField
+	// Discriminator and DiscriminatorValue are set for variant cases, which
+	// always send the discriminator key with their match value.
+	Discriminator      string
+	DiscriminatorValue any
+	// Wraps is true for the cases of multi-type sealed classes, which hold a
+	// single value passed positionally and sent as is.
+	Wraps bool
+}
+
+// DartSealedClass → sealed class X implemented by one class per case, for
+// variants and properties of multiple types
+type DartSealedClass struct {
+	Name    string
+	Comment string
+	Cases   []DartClass
+}
+
+// DartMethod is one method of the generated RudderTyper class.
+type DartMethod struct {
+	Name      string
+	Comment   string
+	EventName string
+	// Call is the RudderController method called: "track", "identify",
+	// "screen" or "group".
+	Call string
+	// ArgumentName and ArgumentType describe the typed properties or traits
+	// argument. Both are empty for events without any.
+	ArgumentName string
+	ArgumentType string
+	// ContextTraits is true when the traits are sent in the context of the
+	// message rather than as its traits.
+	ContextTraits bool
+}
+
+// DartContext is the root data object passed to ruddertyper.dart.tmpl.
+type DartContext struct {
+	TypeAliases         []DartTypeAlias
+	Enums               []DartEnum
+	Classes             []DartClass
+	SealedClasses       []DartSealedClass
+	Methods             []DartMethod
+	EventContext        map[string]string // injected into every event, values are Dart literals
+	RudderCLIVersion    string
+	TrackingPlanName    string
+	TrackingPlanID      string
+	TrackingPlanVersion int
+	TrackingPlanURL     string
+
+	// declared holds the names of the sealed classes of multi-type
+	// properties already added, as properties are shared across schemas.
+	declared map[string]bool
+}
+
+// Parameters returns the positional parameters of the method: the identity
+// the call needs, if any, then the typed properties or traits.
+func (m DartMethod) Parameters() []string {
+	var params []string
+	switch m.Call {
+	case "identify":
+		params = append(params, "String userId")
+	case "screen":
+		params = append(params, "String screenName")
+	case "group":
+		params = append(params, "String groupId")
+	}
+	if m.ArgumentType != "" {
+		params = append(params, m.ArgumentType+" "+m.ArgumentName)
+	}
+	return params
+}
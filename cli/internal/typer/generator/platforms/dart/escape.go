@@ -0,0 +1,102 @@
+package dart
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FormatDartDocComment turns s into a doc comment, one line per line of s.
+//
+// Examples:
+//   - `User's email` → `/// User's email`
+//   - "Line 1\n\nLine 2" → "/// Line 1\n///\n/// Line 2"
+func FormatDartDocComment(s string) string {
+	s = strings.TrimSpace(strings.ReplaceAll(s, "\r\n", "\n"))
+	if s == "" {
+		return ""
+	}
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			lines[i] = "///"
+			continue
+		}
+		lines[i] = "/// " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FormatDartLiteral formats a value from the tracking plan as a Dart literal.
+// Strings are single-quoted, as Effective Dart recommends.
+func FormatDartLiteral(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return formatString(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case float32:
+		return formatFloat(float64(v))
+	case float64:
+		return formatFloat(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// formatString returns s as a single-quoted Dart string literal, escaping
+// "$" so that it is not taken for interpolation.
+func formatString(s string) string {
+	var b strings.Builder
+	b.WriteByte('\'')
+	for _, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '\'':
+			b.WriteString(`\'`)
+		case '$':
+			b.WriteString(`\$`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if unicode.IsPrint(r) {
+				b.WriteRune(r)
+			} else {
+				fmt.Fprintf(&b, `\u{%x}`, r)
+			}
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// formatFloat keeps a decimal point on whole floats so that they stay
+// doubles.
+func formatFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eEIN") {
+		s += ".0"
+	}
+	return s
+}
+
+// indent prefixes every non-empty line of s with prefix.
+func indent(prefix, s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,39 @@
+package dart_test
+
+import (
+	"testing"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/dart"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDartDocComment(t *testing.T) {
+	assert.Equal(t, "/// User's email", dart.FormatDartDocComment("User's email"))
+	assert.Equal(t, "/// Line 1\n///\n/// Line 2", dart.FormatDartDocComment("Line 1\n\nLine 2\n"))
+	assert.Equal(t, "", dart.FormatDartDocComment("  "))
+}
+
+func TestFormatDartLiteral(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected string
+	}{
+		{"string", `it's "here"`, `'it\'s "here"'`},
+		{"interpolation", "$100 and ${total}", `'\$100 and \${total}'`},
+		{"backslash", `C:\path`, `'C:\\path'`},
+		{"newline", "a\nb", `'a\nb'`},
+		{"unicode string", "已完成", `'已完成'`},
+		{"integer", 200, "200"},
+		{"float", 2.5, "2.5"},
+		{"whole float", 2.0, "2.0"},
+		{"true", true, "true"},
+		{"nil", nil, "null"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, dart.FormatDartLiteral(tt.input))
+		})
+	}
+}
@@ -0,0 +1,704 @@
+package dart
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+	"github.com/rudderlabs/rudder-iac/cli/internal/ui"
+)
+
+const Platform = "dart"
+
+// Generator implements core.Generator for the Dart platform.
+type Generator struct{}
+
+const (
+	objectType = "Map<String, Object?>"
+	anyType    = "Object?"
+	nullType   = "Null"
+)
+
+// dartType is a resolved Dart type.
+type dartType struct {
+	expr     string
+	nullable bool
+	// serialize is true when values of the type must be converted with
+	// _serialize before being sent.
+	serialize bool
+}
+
+// orNull returns the type of optional or nullable values of t.
+func (t dartType) orNull() dartType {
+	if t.nullable {
+		return t
+	}
+	return dartType{expr: t.expr + "?", nullable: true, serialize: t.serialize}
+}
+
+// ========== Main Entry Point ==========
+
+// Generate produces a Dart library from a tracking plan
+func (g *Generator) Generate(p *plan.TrackingPlan, options core.GenerateOptions, platformOptions any) ([]*core.File, error) {
+	defaults := g.DefaultOptions().(DartOptions)
+	dartOptions := defaults
+	if platformOptions != nil {
+		dartOptions = platformOptions.(DartOptions)
+	}
+
+	if err := dartOptions.Validate(); err != nil {
+		return nil, err
+	}
+
+	outputFileName := dartOptions.OutputFileName
+	if outputFileName == "" {
+		outputFileName = defaults.OutputFileName
+	}
+
+	ctx := &DartContext{
+		RudderCLIVersion:    options.RudderCLIVersion,
+		TrackingPlanName:    p.Name,
+		TrackingPlanID:      p.Metadata.TrackingPlanID,
+		TrackingPlanVersion: p.Metadata.TrackingPlanVersion,
+		TrackingPlanURL:     p.Metadata.URL,
+		EventContext:        formatEventContext(p.Metadata, options.RudderCLIVersion),
+		declared:            make(map[string]bool),
+	}
+
+	nameRegistry := core.NewNameRegistry(DartCollisionHandler)
+	for _, name := range reservedTypeNames {
+		if _, err := nameRegistry.RegisterName("reserved:"+name, globalTypeScope, name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Custom types and property enums are processed first so that event rules
+	// referring to them see their final names.
+	if err := processCustomTypes(p, ctx, nameRegistry); err != nil {
+		return nil, err
+	}
+	if err := processPropertyEnums(p, ctx, nameRegistry); err != nil {
+		return nil, err
+	}
+	if err := processEventRules(p, ctx, nameRegistry); err != nil {
+		return nil, err
+	}
+
+	file, err := GenerateFile(outputFileName, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*core.File{file}, nil
+}
+
+func formatEventContext(meta plan.PlanMetadata, rudderCLIVersion string) map[string]string {
+	return map[string]string{
+		"platform":            FormatDartLiteral(Platform),
+		"rudderCLIVersion":    FormatDartLiteral(rudderCLIVersion),
+		"trackingPlanId":      FormatDartLiteral(meta.TrackingPlanID),
+		"trackingPlanVersion": fmt.Sprintf("%d", meta.TrackingPlanVersion),
+	}
+}
+
+// ========== Type Mapping ==========
+
+func mapPrimitiveType(t plan.PrimitiveType) (dartType, error) {
+	switch t {
+	case plan.PrimitiveTypeString:
+		return dartType{expr: "String"}, nil
+	case plan.PrimitiveTypeInteger:
+		return dartType{expr: "int"}, nil
+	case plan.PrimitiveTypeNumber:
+		return dartType{expr: "double"}, nil
+	case plan.PrimitiveTypeBoolean:
+		return dartType{expr: "bool"}, nil
+	case plan.PrimitiveTypeNull:
+		return dartType{expr: nullType, nullable: true}, nil
+	case plan.PrimitiveTypeArray:
+		return dartType{expr: "List<" + anyType + ">", serialize: true}, nil
+	case plan.PrimitiveTypeObject:
+		return dartType{expr: objectType, serialize: true}, nil
+	default:
+		return dartType{}, fmt.Errorf("unsupported primitive type: %s", t)
+	}
+}
+
+func hasEnumConfig(config *plan.PropertyConfig) bool {
+	return config != nil && len(config.Enum) > 0
+}
+
+func isEmptySchema(schema *plan.ObjectSchema) bool {
+	return schema == nil || len(schema.Properties) == 0
+}
+
+// enumValueType returns the type shared by all enum values: "String", "int",
+// "double" or "bool". Integers and floats mixed together are double; any
+// other mix is "Object?".
+func enumValueType(values []any) string {
+	kinds := make(map[string]bool)
+	for _, value := range values {
+		switch value.(type) {
+		case string:
+			kinds["String"] = true
+		case bool:
+			kinds["bool"] = true
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			kinds["int"] = true
+		case float32, float64:
+			kinds["double"] = true
+		default:
+			return "Object?"
+		}
+	}
+
+	if kinds["int"] && kinds["double"] {
+		delete(kinds, "int")
+	}
+	if len(kinds) != 1 {
+		return "Object?"
+	}
+	for kind := range kinds {
+		return kind
+	}
+	return "Object?"
+}
+
+// splitNull separates the null type from the other types.
+func splitNull(types []plan.PropertyType) ([]plan.PropertyType, bool) {
+	var nonNull []plan.PropertyType
+	hasNull := false
+	for _, t := range types {
+		if pt := plan.AsPrimitiveType(t); pt != nil && *pt == plan.PrimitiveTypeNull {
+			hasNull = true
+			continue
+		}
+		nonNull = append(nonNull, t)
+	}
+	return nonNull, hasNull
+}
+
+// resolveSingleType returns the Dart type of a value having types, when
+// there is at most one besides null. ok is false for several types, which
+// need a sealed class.
+func resolveSingleType(types []plan.PropertyType, nameRegistry *core.NameRegistry) (dartType, bool, error) {
+	nonNull, hasNull := splitNull(types)
+	switch len(nonNull) {
+	case 0:
+		if hasNull {
+			return dartType{expr: nullType, nullable: true}, true, nil
+		}
+		return dartType{expr: anyType, nullable: true, serialize: true}, true, nil
+	case 1:
+		t, err := resolveType(nonNull[0], nameRegistry)
+		if err != nil {
+			return dartType{}, false, err
+		}
+		if hasNull {
+			t = t.orNull()
+		}
+		return t, true, nil
+	default:
+		return dartType{}, false, nil
+	}
+}
+
+// resolveType returns the Dart type of a single primitive or custom type.
+func resolveType(t plan.PropertyType, nameRegistry *core.NameRegistry) (dartType, error) {
+	if plan.IsCustomType(t) {
+		return resolveCustomTypeReference(plan.AsCustomType(t), nameRegistry)
+	}
+
+	primitive := plan.AsPrimitiveType(t)
+	if primitive == nil {
+		return dartType{expr: anyType, nullable: true, serialize: true}, nil
+	}
+	return mapPrimitiveType(*primitive)
+}
+
+// resolveCustomTypeReference returns the registered name of a custom type,
+// along with whether its declaration is nullable or needs serializing.
+func resolveCustomTypeReference(ct *plan.CustomType, nameRegistry *core.NameRegistry) (dartType, error) {
+	name, err := getOrRegisterCustomTypeName(ct, nameRegistry)
+	if err != nil {
+		return dartType{}, err
+	}
+
+	t := dartType{expr: name, serialize: true}
+	if len(ct.Variants) == 0 && !hasEnumConfig(ct.Config) {
+		switch ct.Type {
+		case plan.PrimitiveTypeNull:
+			t.nullable, t.serialize = true, false
+		case plan.PrimitiveTypeString, plan.PrimitiveTypeInteger, plan.PrimitiveTypeNumber, plan.PrimitiveTypeBoolean:
+			t.serialize = false
+		}
+	}
+	return t, nil
+}
+
+// resolvePropertyType returns the Dart type of a property held by a field of
+// className. Properties with their own enum resolve to the enum, properties
+// of several types to a sealed class, and inline object schemas to a class
+// named after the field.
+func resolvePropertyType(className string, propSchema *plan.PropertySchema, ctx *DartContext, nameRegistry *core.NameRegistry) (dartType, error) {
+	prop := &propSchema.Property
+	_, hasNull := splitNull(prop.Types)
+
+	if hasEnumConfig(prop.Config) {
+		name, err := getOrRegisterPropertyTypeName(prop, nameRegistry)
+		if err != nil {
+			return dartType{}, err
+		}
+		t := dartType{expr: name, serialize: true}
+		if hasNull {
+			return t.orNull(), nil
+		}
+		return t, nil
+	}
+
+	if propSchema.Schema != nil {
+		if isEmptySchema(propSchema.Schema) {
+			return dartType{expr: objectType, serialize: true}, nil
+		}
+
+		name, err := getOrRegisterNestedClassName(className, prop.Name, nameRegistry)
+		if err != nil {
+			return dartType{}, err
+		}
+		nested, err := buildClass(name, prop.Description, propSchema.Schema, ctx, nameRegistry)
+		if err != nil {
+			return dartType{}, err
+		}
+		ctx.Classes = append(ctx.Classes, *nested)
+		return dartType{expr: name, serialize: true}, nil
+	}
+
+	t, ok, err := resolveSingleType(prop.Types, nameRegistry)
+	if err != nil {
+		return dartType{}, err
+	}
+	if !ok {
+		name, err := getOrRegisterPropertyTypeName(prop, nameRegistry)
+		if err != nil {
+			return dartType{}, err
+		}
+		if err := declareMultiTypeSealedClass(name, prop.Description, prop.Types, ctx, nameRegistry); err != nil {
+			return dartType{}, err
+		}
+		t = dartType{expr: name, serialize: true}
+		if hasNull {
+			t = t.orNull()
+		}
+	}
+
+	if isArray(prop.Types) && len(prop.ItemTypes) > 0 {
+		return resolveArrayItemType(prop, hasNull, ctx, nameRegistry)
+	}
+	return t, nil
+}
+
+// isArray reports whether types is the array type alone, or with null.
+func isArray(types []plan.PropertyType) bool {
+	nonNull, _ := splitNull(types)
+	if len(nonNull) != 1 {
+		return false
+	}
+	primitive := plan.AsPrimitiveType(nonNull[0])
+	return primitive != nil && *primitive == plan.PrimitiveTypeArray
+}
+
+// resolveArrayItemType returns the list type of an array property with item
+// types. Items of several types are held by a sealed class.
+func resolveArrayItemType(prop *plan.Property, nullable bool, ctx *DartContext, nameRegistry *core.NameRegistry) (dartType, error) {
+	item, ok, err := resolveSingleType(prop.ItemTypes, nameRegistry)
+	if err != nil {
+		return dartType{}, err
+	}
+	if !ok {
+		name, err := getOrRegisterPropertyArrayItemTypeName(prop, nameRegistry)
+		if err != nil {
+			return dartType{}, err
+		}
+		comment := fmt.Sprintf("Item type for %s array", prop.Name)
+		if err := declareMultiTypeSealedClass(name, comment, prop.ItemTypes, ctx, nameRegistry); err != nil {
+			return dartType{}, err
+		}
+		item = dartType{expr: name}
+		if _, hasNull := splitNull(prop.ItemTypes); hasNull {
+			item = item.orNull()
+		}
+	}
+
+	t := dartType{expr: "List<" + item.expr + ">", serialize: true}
+	if nullable {
+		return t.orNull(), nil
+	}
+	return t, nil
+}
+
+// ========== Multi-type Sealed Classes ==========
+
+// declareMultiTypeSealedClass adds a sealed class with a case per primitive
+// type of types, once per name. Null is not a case: values that can be null
+// use a nullable type instead.
+func declareMultiTypeSealedClass(name, comment string, types []plan.PropertyType, ctx *DartContext, nameRegistry *core.NameRegistry) error {
+	if ctx.declared[name] {
+		return nil
+	}
+	ctx.declared[name] = true
+
+	sealed := DartSealedClass{Name: name, Comment: comment}
+	nonNull, _ := splitNull(types)
+	for _, t := range nonNull {
+		primitive := plan.AsPrimitiveType(t)
+		if primitive == nil {
+			return fmt.Errorf("unsupported property type in multi-type union: %T", t)
+		}
+
+		valueType, err := mapPrimitiveType(*primitive)
+		if err != nil {
+			return err
+		}
+		caseName, err := nameRegistry.RegisterName("case:"+name+":"+string(*primitive), globalTypeScope, name+core.ToPascalCase(string(*primitive)))
+		if err != nil {
+			return err
+		}
+
+		sealed.Cases = append(sealed.Cases, DartClass{
+			Name:       caseName,
+			Comment:    fmt.Sprintf("Wraps a value of type '%s'", *primitive),
+			Superclass: name,
+			Wraps:      true,
+			Fields: []DartField{{
+				Name:      "value",
+				Type:      valueType.expr,
+				Required:  true,
+				Serialize: valueType.serialize,
+			}},
+		})
+	}
+
+	ctx.SealedClasses = append(ctx.SealedClasses, sealed)
+	return nil
+}
+
+// ========== Class Builders ==========
+
+// buildClass builds a class with a field per property of schema. Classes of
+// inline object schemas are added to ctx as they are found.
+func buildClass(name, comment string, schema *plan.ObjectSchema, ctx *DartContext, nameRegistry *core.NameRegistry) (*DartClass, error) {
+	fields, err := buildFields(name, schema.Properties, "", ctx, nameRegistry)
+	if err != nil {
+		return nil, err
+	}
+	return &DartClass{
+		Name:    name,
+		Comment: comment,
+		Fields:  fields,
+	}, nil
+}
+
+// buildFields builds the fields of className for properties, sorted by key,
+// leaving out the property named skip. Optional fields are nullable and left
+// out of the payload when null.
+func buildFields(className string, properties map[string]plan.PropertySchema, skip string, ctx *DartContext, nameRegistry *core.NameRegistry) ([]DartField, error) {
+	if err := reserveMemberNames(className, reservedMemberNames, nameRegistry); err != nil {
+		return nil, err
+	}
+
+	keys := slices.Sorted(maps.Keys(properties))
+	fields := make([]DartField, 0, len(keys))
+	for _, key := range keys {
+		if key == skip {
+			continue
+		}
+
+		propSchema := properties[key]
+		fieldName, err := getOrRegisterFieldName(className, key, nameRegistry)
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := resolvePropertyType(className, &propSchema, ctx, nameRegistry)
+		if err != nil {
+			return nil, fmt.Errorf("resolving type of property %q of %s: %w", key, className, err)
+		}
+		if !propSchema.Required {
+			t = t.orNull()
+		}
+
+		fields = append(fields, DartField{
+			Name:      fieldName,
+			JSONName:  key,
+			Type:      t.expr,
+			Comment:   propSchema.Property.Description,
+			Required:  propSchema.Required,
+			Serialize: t.serialize,
+		})
+	}
+
+	return fields, nil
+}
+
+// ========== Custom Type Processing ==========
+
+// processCustomTypes declares every custom type of the plan:
+//   - variants → sealed class implemented by a class per case
+//   - enum → enum
+//   - object → class, or a map typedef without properties
+//   - array → typedef of a list of the item type
+//   - primitive → typedef of the primitive type
+func processCustomTypes(p *plan.TrackingPlan, ctx *DartContext, nameRegistry *core.NameRegistry) error {
+	customTypes := p.ExtractAllCustomTypes()
+	for _, name := range slices.Sorted(maps.Keys(customTypes)) {
+		if err := processCustomType(customTypes[name], ctx, nameRegistry); err != nil {
+			return fmt.Errorf("processing custom type %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func processCustomType(ct *plan.CustomType, ctx *DartContext, nameRegistry *core.NameRegistry) error {
+	typeName, err := getOrRegisterCustomTypeName(ct, nameRegistry)
+	if err != nil {
+		return err
+	}
+
+	if len(ct.Variants) > 0 {
+		baseSchema := ct.Schema
+		if baseSchema == nil {
+			baseSchema = &plan.ObjectSchema{}
+		}
+		variant, err := buildVariant(typeName, ct.Description, baseSchema, ct.Variants, ctx, nameRegistry)
+		if err != nil {
+			return err
+		}
+		ctx.SealedClasses = append(ctx.SealedClasses, *variant)
+		return nil
+	}
+
+	if hasEnumConfig(ct.Config) {
+		enum, err := buildEnum(typeName, ct.Description, ct.Config.Enum, nameRegistry)
+		if err != nil {
+			return err
+		}
+		ctx.Enums = append(ctx.Enums, *enum)
+		return nil
+	}
+
+	var aliased dartType
+	switch ct.Type {
+	case plan.PrimitiveTypeObject:
+		if !isEmptySchema(ct.Schema) {
+			c, err := buildClass(typeName, ct.Description, ct.Schema, ctx, nameRegistry)
+			if err != nil {
+				return err
+			}
+			ctx.Classes = append(ctx.Classes, *c)
+			return nil
+		}
+		aliased = dartType{expr: objectType}
+	case plan.PrimitiveTypeArray:
+		aliased = dartType{expr: "List<" + anyType + ">"}
+		if ct.ItemType != nil {
+			item, err := resolveType(ct.ItemType, nameRegistry)
+			if err != nil {
+				return err
+			}
+			aliased = dartType{expr: "List<" + item.expr + ">"}
+		}
+	default:
+		aliased, err = mapPrimitiveType(ct.Type)
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx.TypeAliases = append(ctx.TypeAliases, DartTypeAlias{
+		Name:    typeName,
+		Type:    aliased.expr,
+		Comment: ct.Description,
+	})
+	return nil
+}
+
+// ========== Enum Processing ==========
+
+// processPropertyEnums declares an enum for every property of the plan with
+// an enum config.
+func processPropertyEnums(p *plan.TrackingPlan, ctx *DartContext, nameRegistry *core.NameRegistry) error {
+	properties := p.ExtractAllProperties()
+	for _, name := range slices.Sorted(maps.Keys(properties)) {
+		prop := properties[name]
+		if !hasEnumConfig(prop.Config) {
+			continue
+		}
+
+		typeName, err := getOrRegisterPropertyTypeName(prop, nameRegistry)
+		if err != nil {
+			return err
+		}
+		enum, err := buildEnum(typeName, prop.Description, prop.Config.Enum, nameRegistry)
+		if err != nil {
+			return fmt.Errorf("processing enum of property %q: %w", name, err)
+		}
+		ctx.Enums = append(ctx.Enums, *enum)
+	}
+	return nil
+}
+
+func buildEnum(typeName, comment string, values []any, nameRegistry *core.NameRegistry) (*DartEnum, error) {
+	if err := reserveMemberNames(typeName, reservedEnumMemberNames, nameRegistry); err != nil {
+		return nil, err
+	}
+
+	enum := &DartEnum{
+		Name:      typeName,
+		Comment:   comment,
+		ValueType: enumValueType(values),
+	}
+
+	for _, value := range values {
+		name, err := getOrRegisterEnumValue(typeName, value, nameRegistry)
+		if err != nil {
+			return nil, err
+		}
+		enum.Values = append(enum.Values, DartEnumValue{Name: name, Value: value})
+	}
+	return enum, nil
+}
+
+// ========== Event Rule Processing ==========
+
+func processEventRules(p *plan.TrackingPlan, ctx *DartContext, nameRegistry *core.NameRegistry) error {
+	// Map rules by a unique composite key for deterministic processing
+	ruleMap := make(map[string]*plan.EventRule)
+	for _, rule := range p.Rules {
+		key := string(rule.Event.EventType) + ":" + rule.Event.Name + ":" + string(rule.Section)
+		ruleMap[key] = &rule
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(ruleMap)) {
+		rule := ruleMap[key]
+
+		if !validateEventRuleSection(rule) {
+			ui.PrintWarning(fmt.Sprintf("invalid section %q for event type %q, skipping", rule.Section, rule.Event.EventType))
+			continue
+		}
+
+		// The Flutter SDK has no page calls
+		if rule.Event.EventType == plan.EventTypePage {
+			ui.PrintWarning(fmt.Sprintf("unsupported event type: %q", rule.Event.EventType))
+			continue
+		}
+
+		if err := processEventRule(rule, ctx, nameRegistry); err != nil {
+			return fmt.Errorf("processing %s event %q: %w", rule.Event.EventType, rule.Event.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func processEventRule(rule *plan.EventRule, ctx *DartContext, nameRegistry *core.NameRegistry) error {
+	typeName, err := getOrRegisterEventTypeName(rule, nameRegistry)
+	if err != nil {
+		return err
+	}
+
+	argumentType := typeName
+	switch {
+	case len(rule.Variants) > 0:
+		variant, err := buildVariant(typeName, rule.Event.Description, &rule.Schema, rule.Variants, ctx, nameRegistry)
+		if err != nil {
+			return err
+		}
+		ctx.SealedClasses = append(ctx.SealedClasses, *variant)
+
+	case isEmptySchema(&rule.Schema):
+		// Events without properties take none, unless any are allowed
+		if !rule.Schema.AdditionalProperties {
+			argumentType = ""
+			break
+		}
+		ctx.TypeAliases = append(ctx.TypeAliases, DartTypeAlias{
+			Name:    typeName,
+			Type:    objectType,
+			Comment: rule.Event.Description,
+		})
+
+	default:
+		c, err := buildClass(typeName, rule.Event.Description, &rule.Schema, ctx, nameRegistry)
+		if err != nil {
+			return err
+		}
+		ctx.Classes = append(ctx.Classes, *c)
+	}
+
+	method, err := buildMethod(rule, argumentType, nameRegistry)
+	if err != nil {
+		return err
+	}
+	ctx.Methods = append(ctx.Methods, *method)
+	return nil
+}
+
+func validateEventRuleSection(rule *plan.EventRule) bool {
+	switch rule.Event.EventType {
+	case plan.EventTypeTrack, plan.EventTypePage, plan.EventTypeScreen:
+		return rule.Section == plan.IdentitySectionProperties
+	case plan.EventTypeIdentify, plan.EventTypeGroup:
+		return rule.Section == plan.IdentitySectionTraits || rule.Section == plan.IdentitySectionContextTraits
+	}
+	return false
+}
+
+// ========== RudderTyper Method Builder ==========
+
+func buildMethod(rule *plan.EventRule, argumentType string, nameRegistry *core.NameRegistry) (*DartMethod, error) {
+	name, err := getOrRegisterEventMethodName(rule, nameRegistry)
+	if err != nil {
+		return nil, err
+	}
+
+	method := &DartMethod{
+		Name:         name,
+		EventName:    rule.Event.Name,
+		Call:         string(rule.Event.EventType),
+		ArgumentType: argumentType,
+	}
+
+	var summary string
+	switch rule.Event.EventType {
+	case plan.EventTypeTrack:
+		summary = fmt.Sprintf(`Tracks the "%s" event.`, rule.Event.Name)
+	case plan.EventTypeIdentify:
+		summary = "Identifies a user."
+	case plan.EventTypeScreen:
+		summary = "Tracks a screen view."
+	case plan.EventTypeGroup:
+		summary = "Associates the user with a group."
+	}
+
+	if argumentType != "" {
+		switch rule.Section {
+		case plan.IdentitySectionProperties:
+			method.ArgumentName = "properties"
+		case plan.IdentitySectionTraits:
+			method.ArgumentName = "traits"
+		case plan.IdentitySectionContextTraits:
+			method.ArgumentName = "traits"
+			method.ContextTraits = true
+			summary += " The traits are sent in the context of the message."
+		}
+	}
+
+	method.Comment = summary
+	if description := strings.TrimSpace(rule.Event.Description); description != "" {
+		method.Comment += "\n\n" + description
+	}
+	return method, nil
+}
@@ -0,0 +1,104 @@
+package dart_test
+
+import (
+	_ "embed"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/dart"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan/testutils"
+)
+
+//go:embed testdata/ruddertyper.dart
+var rudderTyperDart string
+
+func TestGenerate(t *testing.T) {
+	trackingPlan := testutils.GetReferenceTrackingPlan()
+
+	generator := &dart.Generator{}
+	files, err := generator.Generate(trackingPlan, core.GenerateOptions{
+		RudderCLIVersion: "1.0.0",
+	}, nil)
+
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "ruddertyper.dart", files[0].Path)
+
+	if diff := cmp.Diff(rudderTyperDart, files[0].Content); diff != "" {
+		t.Errorf("generated content does not match testdata/ruddertyper.dart (-want +got):\n%s\nRun 'make typer-dart-update-testdata' to update the golden file.", diff)
+	}
+}
+
+func TestGenerateWithOptions(t *testing.T) {
+	trackingPlan := testutils.GetReferenceTrackingPlan()
+
+	generator := &dart.Generator{}
+	files, err := generator.Generate(trackingPlan, core.GenerateOptions{
+		RudderCLIVersion: "1.0.0",
+	}, dart.DartOptions{
+		OutputFileName: "analytics_events.dart",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "analytics_events.dart", files[0].Path)
+}
+
+func TestGenerateInvalidOutputFileName(t *testing.T) {
+	generator := &dart.Generator{}
+
+	for _, name := range []string{"events", "AnalyticsEvents.dart", "my-events.dart", "1events.dart"} {
+		_, err := generator.Generate(testutils.GetReferenceTrackingPlan(), core.GenerateOptions{}, dart.DartOptions{OutputFileName: name})
+		assert.Error(t, err, name)
+	}
+}
+
+func TestGenerateEscapesKeywords(t *testing.T) {
+	trackingPlan := &plan.TrackingPlan{
+		Name: "Test Plan",
+		Rules: []plan.EventRule{{
+			Event:   plan.Event{EventType: plan.EventTypeTrack, Name: "Keywords"},
+			Section: plan.IdentitySectionProperties,
+			Schema: plan.ObjectSchema{
+				Properties: map[string]plan.PropertySchema{
+					"class":   {Property: plan.Property{Name: "class", Types: []plan.PropertyType{plan.PrimitiveTypeString}}, Required: true},
+					"to_json": {Property: plan.Property{Name: "to_json", Types: []plan.PropertyType{plan.PrimitiveTypeString}}, Required: true},
+				},
+			},
+		}},
+	}
+
+	files, err := (&dart.Generator{}).Generate(trackingPlan, core.GenerateOptions{}, nil)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	assert.Contains(t, files[0].Content, "  final String class$;\n")
+	assert.Contains(t, files[0].Content, "    'class': class$,\n")
+	assert.Contains(t, files[0].Content, "  final String toJson1;\n")
+	assert.Contains(t, files[0].Content, "    'to_json': toJson1,\n")
+}
+
+func TestGenerateRejectsCustomTypesInMultiTypeUnions(t *testing.T) {
+	email := &plan.CustomType{Name: "email", Type: plan.PrimitiveTypeString}
+	trackingPlan := &plan.TrackingPlan{
+		Name: "Test Plan",
+		Rules: []plan.EventRule{{
+			Event:   plan.Event{EventType: plan.EventTypeTrack, Name: "Union"},
+			Section: plan.IdentitySectionProperties,
+			Schema: plan.ObjectSchema{
+				Properties: map[string]plan.PropertySchema{
+					"contact": {Property: plan.Property{Name: "contact", Types: []plan.PropertyType{email, plan.PrimitiveTypeInteger}}},
+				},
+			},
+		}},
+	}
+
+	_, err := (&dart.Generator{}).Generate(trackingPlan, core.GenerateOptions{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported property type in multi-type union")
+}
@@ -0,0 +1,274 @@
+package dart
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+)
+
+const (
+	globalTypeScope = "types"
+	methodScope     = "methods"
+)
+
+const (
+	// typeNamePrefix, fieldNamePrefix and enumValuePrefix are prepended to
+	// names starting with a digit, which cannot start a Dart identifier.
+	// enumValuePrefix also names enum values that aren't strings.
+	typeNamePrefix  = "Type"
+	fieldNamePrefix = "field"
+	enumValuePrefix = "value"
+)
+
+// keywordSuffix is appended to names that are Dart keywords.
+const keywordSuffix = "$"
+
+// FormatClassName converts a name to an UpperCamelCase identifier suitable
+// for Dart types. If prefix is provided, it's prepended to the formatted name.
+func FormatClassName(prefix, name string) string {
+	formatted := sanitizeForIdentifier(strings.TrimSpace(name))
+	if prefix != "" {
+		formatted = prefix + " " + formatted
+	}
+	return escapeIdentifier(core.ToPascalCase(formatted), typeNamePrefix)
+}
+
+// FormatFieldName converts a name to a lowerCamelCase identifier suitable for
+// Dart fields. Returns empty string if the name holds no letters or digits.
+func FormatFieldName(name string) string {
+	return escapeIdentifier(core.ToCamelCase(sanitizeForIdentifier(strings.TrimSpace(name))), fieldNamePrefix)
+}
+
+// FormatMethodName converts a name to a lowerCamelCase identifier suitable for
+// Dart methods. If prefix is provided, it's prepended to the formatted name.
+func FormatMethodName(prefix, name string) string {
+	formatted := sanitizeForIdentifier(strings.TrimSpace(name))
+	if prefix != "" {
+		formatted = prefix + " " + formatted
+	}
+	return escapeIdentifier(core.ToCamelCase(formatted), fieldNamePrefix)
+}
+
+// FormatEnumValueName converts an enum value to a lowerCamelCase identifier
+// naming it within its enum. Values other than strings are named after
+// their literal. Returns empty string for values without letters or digits,
+// such as emoji.
+//
+// Examples:
+//   - "smartTV" → "smartTv"
+//   - 2.5 → "value2_5"
+//   - -1 → "valueMinus1"
+//   - true → "valueTrue"
+func FormatEnumValueName(value any) string {
+	switch v := value.(type) {
+	case string:
+		return escapeIdentifier(core.ToCamelCase(sanitizeForIdentifier(strings.TrimSpace(v))), enumValuePrefix)
+	default:
+		return enumValuePrefix + formatValueSuffix(v)
+	}
+}
+
+// formatValueSuffix converts a match value of a variant to the UpperCamelCase
+// suffix naming the class of its case.
+func formatValueSuffix(value any) string {
+	switch v := value.(type) {
+	case string:
+		return core.ToPascalCase(sanitizeForIdentifier(strings.TrimSpace(v)))
+	case bool:
+		return core.ToPascalCase(strconv.FormatBool(v))
+	default:
+		formatted := fmt.Sprintf("%v", v)
+		formatted = strings.ReplaceAll(formatted, "-", "Minus")
+		formatted = strings.ReplaceAll(formatted, "+", "")
+		return strings.ReplaceAll(formatted, ".", "_")
+	}
+}
+
+// sanitizeForIdentifier replaces characters that are invalid in Dart
+// identifiers with spaces, so they become word boundaries in case conversion.
+// Dart identifiers are ASCII, so letters and digits of other scripts are
+// replaced as well.
+func sanitizeForIdentifier(s string) string {
+	var result strings.Builder
+	result.Grow(len(s))
+
+	for _, ch := range s {
+		if ch < unicode.MaxASCII && (unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '_' || ch == '-' || ch == ' ' || ch == '.') {
+			result.WriteRune(ch)
+		} else {
+			result.WriteRune(' ')
+		}
+	}
+
+	return result.String()
+}
+
+// escapeIdentifier prefixes names starting with a digit and suffixes
+// keywords.
+func escapeIdentifier(name, digitPrefix string) string {
+	if name == "" {
+		return ""
+	}
+	if unicode.IsDigit(rune(name[0])) {
+		if unicode.IsUpper(rune(digitPrefix[0])) {
+			name = digitPrefix + name
+		} else {
+			name = digitPrefix + strings.ToUpper(name[:1]) + name[1:]
+		}
+	}
+	if DartKeywords[name] {
+		name += keywordSuffix
+	}
+	return name
+}
+
+// enumValueKey identifies an enum value, keeping values of different types
+// such as 1 and "1" apart.
+func enumValueKey(value any) string {
+	return fmt.Sprintf("%T:%v", value, value)
+}
+
+// getOrRegisterCustomTypeName returns the registered type name for a custom type.
+func getOrRegisterCustomTypeName(customType *plan.CustomType, nameRegistry *core.NameRegistry) (string, error) {
+	typeName := FormatClassName("CustomType", customType.Name)
+	return nameRegistry.RegisterName("customtype:"+customType.Name, globalTypeScope, typeName)
+}
+
+// getOrRegisterPropertyTypeName returns the registered type name for the enum
+// or multi-type sealed class of a property.
+func getOrRegisterPropertyTypeName(property *plan.Property, nameRegistry *core.NameRegistry) (string, error) {
+	typeName := FormatClassName("Property", property.Name)
+	return nameRegistry.RegisterName("property:"+property.Name, globalTypeScope, typeName)
+}
+
+// getOrRegisterPropertyArrayItemTypeName returns the registered type name for
+// the multi-type sealed class of the items of an array property.
+func getOrRegisterPropertyArrayItemTypeName(property *plan.Property, nameRegistry *core.NameRegistry) (string, error) {
+	typeName := FormatClassName("ArrayItem", property.Name)
+	return nameRegistry.RegisterName("arrayitem:"+property.Name, globalTypeScope, typeName)
+}
+
+// getOrRegisterNestedClassName returns the registered class name for an
+// inline object schema of a property, named after the class holding it.
+func getOrRegisterNestedClassName(parentName, propName string, nameRegistry *core.NameRegistry) (string, error) {
+	typeName := parentName + core.ToPascalCase(sanitizeForIdentifier(propName))
+	if typeName == parentName {
+		typeName = parentName + "Object"
+	}
+	return nameRegistry.RegisterName("nested:"+parentName+":"+propName, globalTypeScope, typeName)
+}
+
+// getOrRegisterFieldName registers a field name within a class scope and
+// returns a collision-free identifier, so that keys which sanitize to the same
+// identifier get unique names.
+func getOrRegisterFieldName(className, propName string, nameRegistry *core.NameRegistry) (string, error) {
+	scope := fmt.Sprintf("class:%s:members", className)
+	formatted := FormatFieldName(propName)
+	if formatted == "" {
+		formatted = "field"
+	}
+	return nameRegistry.RegisterName(propName, scope, formatted)
+}
+
+// reserveMemberNames keeps names from being used as members of a class or
+// enum.
+func reserveMemberNames(className string, names []string, nameRegistry *core.NameRegistry) error {
+	scope := fmt.Sprintf("class:%s:members", className)
+	for _, name := range names {
+		if _, err := nameRegistry.RegisterName("reserved:"+name, scope, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getOrRegisterEnumValue returns the registered name of an enum value within
+// its enum. Values made only of symbols or emoji are numbered, as in value1.
+func getOrRegisterEnumValue(enumName string, value any, nameRegistry *core.NameRegistry) (string, error) {
+	name := FormatEnumValueName(value)
+	if name == "" {
+		name = enumValuePrefix
+	}
+
+	scope := fmt.Sprintf("class:%s:members", enumName)
+	registered, err := nameRegistry.RegisterName("enum:"+enumValueKey(value), scope, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to register name for enum %q: %w", enumName, err)
+	}
+	return registered, nil
+}
+
+// getOrRegisterCaseName returns the registered class name for one case of a
+// sealed class: a match value of a variant, or a nil value for its default
+// case.
+func getOrRegisterCaseName(sealedName string, matchValue any, nameRegistry *core.NameRegistry) (string, error) {
+	if matchValue == nil {
+		return nameRegistry.RegisterName("case:"+sealedName+":default", globalTypeScope, sealedName+"Default")
+	}
+	typeName := sealedName + "Case" + formatValueSuffix(matchValue)
+	return nameRegistry.RegisterName("case:"+sealedName+":"+enumValueKey(matchValue), globalTypeScope, typeName)
+}
+
+// getOrRegisterEventTypeName returns the registered type name of the
+// properties or traits of an event rule.
+func getOrRegisterEventTypeName(rule *plan.EventRule, nameRegistry *core.NameRegistry) (string, error) {
+	prefix, baseName, err := eventNameParts(rule)
+	if err != nil {
+		return "", err
+	}
+
+	var suffix string
+	switch rule.Section {
+	case plan.IdentitySectionProperties:
+		suffix = "Properties"
+	case plan.IdentitySectionTraits, plan.IdentitySectionContextTraits:
+		suffix = "Traits"
+	default:
+		return "", fmt.Errorf("unsupported event rule section: %s", rule.Section)
+	}
+
+	typeName := FormatClassName(prefix, baseName+" "+suffix)
+	key := "event:" + string(rule.Event.EventType) + ":" + rule.Event.Name + ":" + string(rule.Section)
+	return nameRegistry.RegisterName(key, globalTypeScope, typeName)
+}
+
+// getOrRegisterEventMethodName returns the registered method name for an
+// event. Events with names that sanitize to the same method name are assigned
+// unique names (e.g., trackEventName, trackEventName1).
+func getOrRegisterEventMethodName(rule *plan.EventRule, nameRegistry *core.NameRegistry) (string, error) {
+	prefix, name, err := eventNameParts(rule)
+	if err != nil {
+		return "", err
+	}
+
+	// Identify and group rules for traits and context traits are sent by
+	// separate methods, as Dart has no overloading.
+	key := "method:" + string(rule.Event.EventType) + ":" + rule.Event.Name + ":" + string(rule.Section)
+	return nameRegistry.RegisterName(key, methodScope, FormatMethodName(prefix, name))
+}
+
+// eventNameParts returns the prefix naming the event type of a rule and, for
+// track events, the event name. Other event types are named by type alone.
+func eventNameParts(rule *plan.EventRule) (string, string, error) {
+	switch rule.Event.EventType {
+	case plan.EventTypeTrack:
+		return "Track", rule.Event.Name, nil
+	case plan.EventTypeIdentify:
+		return "Identify", "", nil
+	case plan.EventTypeScreen:
+		return "Screen", "", nil
+	case plan.EventTypeGroup:
+		return "Group", "", nil
+	default:
+		return "", "", fmt.Errorf("unsupported event type: %s", rule.Event.EventType)
+	}
+}
+
+// DartCollisionHandler provides a Dart-specific collision handler for the NameRegistry
+func DartCollisionHandler(name string, existingNames []string) string {
+	return core.DefaultCollisionHandler(name, existingNames)
+}
@@ -0,0 +1,104 @@
+package dart_test
+
+import (
+	"testing"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/dart"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatClassName(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		input    string
+		expected string
+	}{
+		{"snake_case", "", "user_id", "UserId"},
+		{"kebab-case", "", "email-address", "EmailAddress"},
+		{"camelCase", "", "firstName", "FirstName"},
+		{"special characters", "", `Product "Premium" Clicked`, "ProductPremiumClicked"},
+		{"leading number", "", "123user", "Type123user"},
+		{"empty string", "", "", ""},
+		{"with prefix", "Track", "User Signed Up", "TrackUserSignedUp"},
+		{"cyrillic", "CustomType", "типы_данных", "CustomType"},
+		{"emoji", "", "🎯", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, dart.FormatClassName(tt.prefix, tt.input))
+		})
+	}
+}
+
+func TestFormatFieldName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"snake_case", "first_name", "firstName"},
+		{"camelCase", "ipAddress", "ipAddress"},
+		{"acronym", "smartTV", "smartTv"},
+		{"space separated", "First Name", "firstName"},
+		{"leading number", "1st_place", "field1stPlace"},
+		{"keyword", "class", "class$"},
+		{"contextual keyword", "required", "required$"},
+		{"chinese", "用户名", ""},
+		{"symbols only", "!!!", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, dart.FormatFieldName(tt.input))
+		})
+	}
+}
+
+func TestFormatMethodName(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		input    string
+		expected string
+	}{
+		{"track", "Track", "User Signed Up", "trackUserSignedUp"},
+		{"special characters", "Track", "$eventWithNameCamelCase$!", "trackEventWithNameCamelCase"},
+		{"prefix only", "Identify", "", "identify"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, dart.FormatMethodName(tt.prefix, tt.input))
+		})
+	}
+}
+
+func TestFormatEnumValueName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected string
+	}{
+		{"string", "smartTV", "smartTv"},
+		{"leading number", "200: OK", "value200Ok"},
+		{"keyword", "default", "default$"},
+		{"integer", 1, "value1"},
+		{"negative", -1, "valueMinus1"},
+		{"float", 2.5, "value2_5"},
+		{"boolean", true, "valueTrue"},
+		{"emoji", "🎯", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, dart.FormatEnumValueName(tt.input))
+		})
+	}
+}
+
+func TestDartCollisionHandler(t *testing.T) {
+	assert.Equal(t, "trackEvent1", dart.DartCollisionHandler("trackEvent", []string{"trackEvent"}))
+	assert.Equal(t, "TrackEventProperties1", dart.DartCollisionHandler("TrackEventProperties", []string{"TrackEventProperties"}))
+}
@@ -0,0 +1,39 @@
+package dart
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DartOptions holds platform-specific options for Dart code generation.
+// These can be passed via --option flags in the CLI, e.g.:
+//
+//	--option outputFileName=analytics_events.dart
+type DartOptions struct {
+	OutputFileName string `mapstructure:"outputFileName" description:"Name of the generated Dart library file. Defaults to ruddertyper.dart"`
+}
+
+// DefaultOptions returns the default Dart generation options.
+func (g *Generator) DefaultOptions() any {
+	return DartOptions{
+		OutputFileName: "ruddertyper.dart",
+	}
+}
+
+// fileNameRegex validates library file names, which Dart names in
+// lowercase_with_underscores.
+var fileNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*\.dart$`)
+
+// Validate validates Dart-specific options
+func (o *DartOptions) Validate() error {
+	if o.OutputFileName == "" {
+		return nil
+	}
+	if !fileNameRegex.MatchString(o.OutputFileName) {
+		return fmt.Errorf(
+			"invalid output file name %q: must be lowercase_with_underscores with the .dart extension (e.g., analytics_events.dart)",
+			o.OutputFileName,
+		)
+	}
+	return nil
+}
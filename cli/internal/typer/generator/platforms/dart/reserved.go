@@ -0,0 +1,112 @@
+package dart
+
+// Source: https://dart.dev/language/keywords
+//
+// DartKeywords holds the reserved words, built-in identifiers and contextual
+// keywords of Dart. Generated names matching one get a "$" suffix, the
+// convention of Dart code generators.
+var DartKeywords = map[string]bool{
+	// Reserved words
+	"assert":   true,
+	"break":    true,
+	"case":     true,
+	"catch":    true,
+	"class":    true,
+	"const":    true,
+	"continue": true,
+	"default":  true,
+	"do":       true,
+	"else":     true,
+	"enum":     true,
+	"extends":  true,
+	"false":    true,
+	"final":    true,
+	"finally":  true,
+	"for":      true,
+	"if":       true,
+	"in":       true,
+	"is":       true,
+	"new":      true,
+	"null":     true,
+	"rethrow":  true,
+	"return":   true,
+	"super":    true,
+	"switch":   true,
+	"this":     true,
+	"throw":    true,
+	"true":     true,
+	"try":      true,
+	"var":      true,
+	"void":     true,
+	"while":    true,
+	"with":     true,
+	// Built-in identifiers, which cannot name types
+	"abstract":   true,
+	"as":         true,
+	"covariant":  true,
+	"deferred":   true,
+	"dynamic":    true,
+	"export":     true,
+	"extension":  true,
+	"external":   true,
+	"factory":    true,
+	"Function":   true,
+	"get":        true,
+	"implements": true,
+	"import":     true,
+	"interface":  true,
+	"late":       true,
+	"library":    true,
+	"mixin":      true,
+	"operator":   true,
+	"part":       true,
+	"required":   true,
+	"set":        true,
+	"static":     true,
+	"typedef":    true,
+	// Contextual keywords
+	"async":  true,
+	"await":  true,
+	"base":   true,
+	"hide":   true,
+	"on":     true,
+	"sealed": true,
+	"show":   true,
+	"sync":   true,
+	"when":   true,
+	"yield":  true,
+}
+
+// reservedTypeNames are declared by the generated library or the Flutter SDK
+// it imports, so types generated from the tracking plan must not use them.
+var reservedTypeNames = []string{
+	"RudderTyper",
+	"RudderController",
+	"RudderOption",
+	"RudderProperty",
+	"RudderTraits",
+	"Object",
+	"String",
+	"List",
+	"Map",
+	"Null",
+}
+
+// reservedMemberNames are members of every generated class, so none of its
+// fields may use them.
+var reservedMemberNames = []string{
+	"toJson",
+	"hashCode",
+	"runtimeType",
+	"toString",
+	"noSuchMethod",
+}
+
+// reservedEnumMemberNames are members of every generated enum, so none of
+// its values may use them.
+var reservedEnumMemberNames = append([]string{
+	"value",
+	"values",
+	"index",
+	"name",
+}, reservedMemberNames...)
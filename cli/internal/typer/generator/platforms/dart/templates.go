@@ -0,0 +1,121 @@
+package dart
+
+import (
+	"bytes"
+	_ "embed"
+	"strings"
+	"text/template"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+)
+
+//go:embed templates/disclaimer.tmpl
+var disclaimerTemplate string
+
+//go:embed templates/header.tmpl
+var headerTemplate string
+
+//go:embed templates/helpers.tmpl
+var helpersTemplate string
+
+//go:embed templates/typealias.tmpl
+var typealiasTemplate string
+
+//go:embed templates/enum.tmpl
+var enumTemplate string
+
+//go:embed templates/class.tmpl
+var classTemplate string
+
+//go:embed templates/sealed.tmpl
+var sealedTemplate string
+
+//go:embed templates/ruddertyper.tmpl
+var ruddertyperTemplate string
+
+// topLevelSeparator is the blank line dart format keeps between top-level
+// declarations.
+const topLevelSeparator = "\n\n"
+
+// GenerateFile renders ctx. The Dart formatter isn't available to the CLI,
+// so each top-level declaration is rendered on its own and joined with a
+// blank line, as dart format would lay them out.
+func GenerateFile(path string, ctx *DartContext) (*core.File, error) {
+	funcMap := template.FuncMap{
+		"doc":     FormatDartDocComment,
+		"literal": FormatDartLiteral,
+		"indent":  indent,
+		"dec": func(i int) int {
+			return i - 1
+		},
+		"oneLine": func(s string) string {
+			return strings.Join(strings.Fields(s), " ")
+		},
+	}
+
+	tmpl := template.New("dart").Funcs(funcMap)
+	for name, src := range map[string]string{
+		"disclaimer.tmpl":  disclaimerTemplate,
+		"header.tmpl":      headerTemplate,
+		"helpers.tmpl":     helpersTemplate,
+		"typealias.tmpl":   typealiasTemplate,
+		"enum.tmpl":        enumTemplate,
+		"class.tmpl":       classTemplate,
+		"sealed.tmpl":      sealedTemplate,
+		"ruddertyper.tmpl": ruddertyperTemplate,
+	} {
+		if _, err := tmpl.New(name).Parse(src); err != nil {
+			return nil, err
+		}
+	}
+
+	var blocks []string
+	render := func(name string, data any) error {
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+			return err
+		}
+		blocks = append(blocks, strings.TrimSpace(buf.String()))
+		return nil
+	}
+
+	if err := render("header.tmpl", ctx); err != nil {
+		return nil, err
+	}
+	if err := render("helpers.tmpl", ctx); err != nil {
+		return nil, err
+	}
+	for _, alias := range ctx.TypeAliases {
+		if err := render("typealias.tmpl", alias); err != nil {
+			return nil, err
+		}
+	}
+	for _, enum := range ctx.Enums {
+		if err := render("enum.tmpl", enum); err != nil {
+			return nil, err
+		}
+	}
+	for _, class := range ctx.Classes {
+		if err := render("class.tmpl", class); err != nil {
+			return nil, err
+		}
+	}
+	for _, sealed := range ctx.SealedClasses {
+		if err := render("sealed.tmpl", sealed); err != nil {
+			return nil, err
+		}
+		for _, class := range sealed.Cases {
+			if err := render("class.tmpl", class); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := render("ruddertyper.tmpl", ctx); err != nil {
+		return nil, err
+	}
+
+	return &core.File{
+		Path:    path,
+		Content: strings.Join(blocks, topLevelSeparator) + "\n",
+	}, nil
+}
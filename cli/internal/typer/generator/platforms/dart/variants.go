@@ -0,0 +1,104 @@
+package dart
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+)
+
+// buildVariant builds the sealed class of a variant type and a class per
+// match value of its cases, plus a default case. Case classes leave the
+// discriminator out and send it with their match value, while the default
+// case holds it as a regular field.
+func buildVariant(
+	name string,
+	comment string,
+	baseSchema *plan.ObjectSchema,
+	variants []plan.Variant,
+	ctx *DartContext,
+	nameRegistry *core.NameRegistry,
+) (*DartSealedClass, error) {
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("no variants provided")
+	}
+
+	// We currently support only one variant per type
+	if len(variants) > 1 {
+		return nil, fmt.Errorf("multiple variants per type are not supported; found %d variants", len(variants))
+	}
+
+	variant := variants[0]
+	sealed := &DartSealedClass{
+		Name:    name,
+		Comment: comment,
+	}
+
+	for _, variantCase := range variant.Cases {
+		for _, matchValue := range variantCase.Match {
+			caseName, err := getOrRegisterCaseName(name, matchValue, nameRegistry)
+			if err != nil {
+				return nil, err
+			}
+
+			merged := mergeVariantSchemaProperties(baseSchema, &variantCase.Schema)
+			fields, err := buildFields(caseName, merged, variant.Discriminator, ctx, nameRegistry)
+			if err != nil {
+				return nil, err
+			}
+
+			sealed.Cases = append(sealed.Cases, DartClass{
+				Name:               caseName,
+				Comment:            variantCase.Description,
+				Superclass:         name,
+				Fields:             fields,
+				Discriminator:      variant.Discriminator,
+				DiscriminatorValue: matchValue,
+			})
+		}
+	}
+
+	// Always create a default case. If DefaultSchema is explicitly provided,
+	// it adds to the base properties.
+	defaultName, err := getOrRegisterCaseName(name, nil, nameRegistry)
+	if err != nil {
+		return nil, err
+	}
+	merged := mergeVariantSchemaProperties(baseSchema, variant.DefaultSchema)
+	fields, err := buildFields(defaultName, merged, "", ctx, nameRegistry)
+	if err != nil {
+		return nil, err
+	}
+	sealed.Cases = append(sealed.Cases, DartClass{
+		Name:       defaultName,
+		Comment:    fmt.Sprintf("Default case, used when %s matches none of the other cases", variant.Discriminator),
+		Superclass: name,
+		Fields:     fields,
+	})
+
+	return sealed, nil
+}
+
+// mergeVariantSchemaProperties merges the properties of the base schema of a
+// variant with those of one of its cases. Properties in both are required if
+// either requires them.
+func mergeVariantSchemaProperties(baseSchema, caseSchema *plan.ObjectSchema) map[string]plan.PropertySchema {
+	merged := make(map[string]plan.PropertySchema)
+	if baseSchema != nil {
+		maps.Copy(merged, baseSchema.Properties)
+	}
+	if caseSchema == nil {
+		return merged
+	}
+
+	for name, casePropSchema := range caseSchema.Properties {
+		if existing, exists := merged[name]; exists {
+			existing.Required = existing.Required || casePropSchema.Required
+			merged[name] = existing
+		} else {
+			merged[name] = casePropSchema
+		}
+	}
+	return merged
+}
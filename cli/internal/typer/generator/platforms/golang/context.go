@@ -0,0 +1,98 @@
+package golang
+
+// GoTypeAlias → type X = Y
+type GoTypeAlias struct {
+	Name    string
+	Type    string
+	Comment string
+}
+
+// GoEnumValue is one constant of an enum type.
+type GoEnumValue struct {
+	Name  string // Go identifier, e.g. "PropertyDeviceTypeMobile"
+	Value any    // Original raw value, e.g. "mobile" or 200
+}
+
+// GoEnum → type X string; const ( XA X = "a" )
+//
+// Enums whose values do not share a type have an "any" underlying type, and
+// their values are declared as variables as constants cannot hold them.
+type GoEnum struct {
+	Name       string
+	Comment    string
+	Underlying string // "string", "int64", "float64", "bool" or "any"
+	Values     []GoEnumValue
+}
+
+// IsConst reports whether the values of the enum are declared as constants.
+func (e GoEnum) IsConst() bool {
+	return e.Underlying != "any"
+}
+
+// GoField is one field of a struct.
+type GoField struct {
+	Name      string // exported Go identifier
+	JSONName  string // original key in the tracking plan
+	Type      string
+	Comment   string
+	OmitEmpty bool // true for optional fields
+}
+
+// GoStruct → type X struct { ... }
+type GoStruct struct {
+	Name    string
+	Comment string
+	Fields  []GoField
+}
+
+// GoVariantCase is one struct implementing a variant interface.
+type GoVariantCase struct {
+	Struct GoStruct
+	// Discriminator is the Go expression of the discriminator value the case
+	// always marshals, e.g. `PropertyDeviceTypeMobile` or `true`. It is empty
+	// for the default case, which holds the discriminator as a field instead.
+	Discriminator string
+}
+
+// GoVariant → type X interface { isX() } implemented by one struct per case
+type GoVariant struct {
+	Name          string
+	Comment       string
+	Discriminator string // JSON key of the discriminator property
+	Cases         []GoVariantCase
+}
+
+// GoMethod is one method of the generated RudderTyper client.
+type GoMethod struct {
+	Name      string
+	Comment   string
+	EventName string
+	// MessageType is the analytics-go message the method sends: "Track",
+	// "Identify", "Page", "Screen" or "Group".
+	MessageType string
+	// ArgumentName and ArgumentType describe the typed properties or traits
+	// argument. Both are empty for events without any.
+	ArgumentName string
+	ArgumentType string
+	// Target is the message field the argument is sent in: "Properties",
+	// "Traits" or "Context.Traits".
+	Target string
+	// SetsEvent is true for track methods, which set the event name.
+	SetsEvent bool
+}
+
+// GoContext is the root data object passed to ruddertyper.go.tmpl.
+type GoContext struct {
+	PackageName         string
+	TypeAliases         []GoTypeAlias
+	Enums               []GoEnum
+	Structs             []GoStruct
+	Variants            []GoVariant
+	Methods             []GoMethod
+	EventContext        map[string]string // injected into every event, values are Go literals
+	RudderCLIVersion    string
+	TrackingPlanName    string
+	TrackingPlanID      string
+	TrackingPlanVersion int
+	TrackingPlanURL     string
+}
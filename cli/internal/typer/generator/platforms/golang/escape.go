@@ -0,0 +1,83 @@
+package golang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FormatGoComment turns s into line comments, one per line of s.
+//
+// Examples:
+//   - `User's email` → `// User's email`
+//   - "Line 1\n\nLine 2" → "// Line 1\n//\n// Line 2"
+func FormatGoComment(s string) string {
+	s = strings.TrimSpace(strings.ReplaceAll(s, "\r\n", "\n"))
+	if s == "" {
+		return ""
+	}
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			lines[i] = "//"
+			continue
+		}
+		lines[i] = "// " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FormatGoLiteral formats a value from the tracking plan as a Go literal.
+// Floats always keep a decimal point so that they stay floats when assigned
+// to an interface.
+func FormatGoLiteral(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case float32:
+		return formatFloat(float64(v))
+	case float64:
+		return formatFloat(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func formatFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eEIN") {
+		s += ".0"
+	}
+	return s
+}
+
+// FormatJSONTag returns the struct tag encoding a field as the JSON key name.
+func FormatJSONTag(name string, omitEmpty bool) string {
+	if omitEmpty {
+		name += ",omitempty"
+	}
+	return fmt.Sprintf("`json:%q`", name)
+}
+
+// isValidJSONKey reports whether encoding/json accepts name as the key of a
+// struct tag. Quotes, backslashes, backticks and commas cannot be expressed.
+func isValidJSONKey(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/golang"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatGoComment(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"single line", "User's email", "// User's email"},
+		{"block comment markers", "a /* b */ c", "// a /* b */ c"},
+		{"multiple lines", "Line 1\n\nLine 2\r\n", "// Line 1\n//\n// Line 2"},
+		{"surrounding whitespace", "  padded  ", "// padded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, golang.FormatGoComment(tt.input))
+		})
+	}
+}
+
+func TestFormatGoLiteral(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected string
+	}{
+		{"string", "mobile", `"mobile"`},
+		{"string with quotes", `Internal "Server" Error`, `"Internal \"Server\" Error"`},
+		{"string with newline", "a\nb", `"a\nb"`},
+		{"unicode string", "已完成", `"已完成"`},
+		{"integer", 42, "42"},
+		{"float", 2.5, "2.5"},
+		{"integral float", 5.0, "5.0"},
+		{"boolean", false, "false"},
+		{"nil", nil, "nil"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, golang.FormatGoLiteral(tt.input))
+		})
+	}
+}
+
+func TestFormatJSONTag(t *testing.T) {
+	assert.Equal(t, "`json:\"first_name\"`", golang.FormatJSONTag("first_name", false))
+	assert.Equal(t, "`json:\"用户名,omitempty\"`", golang.FormatJSONTag("用户名", true))
+}
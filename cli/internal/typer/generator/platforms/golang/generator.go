@@ -0,0 +1,631 @@
+package golang
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+	"github.com/rudderlabs/rudder-iac/cli/internal/ui"
+)
+
+const Platform = "go"
+
+// Generator implements core.Generator for the Go platform.
+type Generator struct{}
+
+const (
+	// openObjectType holds objects accepting any keys; closedObjectType is an
+	// object schema without properties nor additional properties.
+	openObjectType   = "map[string]any"
+	closedObjectType = "struct{}"
+	anyType          = "any"
+)
+
+// goType is a resolved Go type expression.
+type goType struct {
+	expr string
+	// nilable is true when nil is a valid value of the type, so that optional
+	// and nullable values of it need no pointer.
+	nilable bool
+}
+
+// pointer returns the type of optional or nullable values of t.
+func (t goType) pointer() goType {
+	if t.nilable {
+		return t
+	}
+	return goType{expr: "*" + t.expr, nilable: true}
+}
+
+// ========== Main Entry Point ==========
+
+// Generate produces a Go source file from a tracking plan
+func (g *Generator) Generate(p *plan.TrackingPlan, options core.GenerateOptions, platformOptions any) ([]*core.File, error) {
+	defaults := g.DefaultOptions().(GoOptions)
+	goOptions := defaults
+	if platformOptions != nil {
+		goOptions = platformOptions.(GoOptions)
+	}
+
+	if err := goOptions.Validate(); err != nil {
+		return nil, err
+	}
+
+	packageName := goOptions.PackageName
+	if packageName == "" {
+		packageName = defaults.PackageName
+	}
+
+	outputFileName := goOptions.OutputFileName
+	if outputFileName == "" {
+		outputFileName = defaults.OutputFileName
+	}
+
+	ctx := &GoContext{
+		PackageName:         packageName,
+		RudderCLIVersion:    options.RudderCLIVersion,
+		TrackingPlanName:    p.Name,
+		TrackingPlanID:      p.Metadata.TrackingPlanID,
+		TrackingPlanVersion: p.Metadata.TrackingPlanVersion,
+		TrackingPlanURL:     p.Metadata.URL,
+		EventContext:        formatEventContext(p.Metadata, options.RudderCLIVersion),
+	}
+
+	nameRegistry := core.NewNameRegistry(GoCollisionHandler)
+	for _, name := range reservedTypeNames {
+		if _, err := nameRegistry.RegisterName("reserved:"+name, globalTypeScope, name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Custom types and property enums are processed first so that event rules
+	// referring to them see their final names.
+	if err := processCustomTypes(p, ctx, nameRegistry); err != nil {
+		return nil, err
+	}
+	if err := processPropertyEnums(p, ctx, nameRegistry); err != nil {
+		return nil, err
+	}
+	if err := processEventRules(p, ctx, nameRegistry); err != nil {
+		return nil, err
+	}
+
+	file, err := GenerateFile(outputFileName, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*core.File{file}, nil
+}
+
+func formatEventContext(meta plan.PlanMetadata, rudderCLIVersion string) map[string]string {
+	return map[string]string{
+		"platform":            FormatGoLiteral(Platform),
+		"rudderCLIVersion":    FormatGoLiteral(rudderCLIVersion),
+		"trackingPlanId":      FormatGoLiteral(meta.TrackingPlanID),
+		"trackingPlanVersion": fmt.Sprintf("%d", meta.TrackingPlanVersion),
+	}
+}
+
+// ========== Type Mapping ==========
+
+func mapPrimitiveType(t plan.PrimitiveType) (goType, error) {
+	switch t {
+	case plan.PrimitiveTypeString:
+		return goType{expr: "string"}, nil
+	case plan.PrimitiveTypeInteger:
+		return goType{expr: "int64"}, nil
+	case plan.PrimitiveTypeNumber:
+		return goType{expr: "float64"}, nil
+	case plan.PrimitiveTypeBoolean:
+		return goType{expr: "bool"}, nil
+	case plan.PrimitiveTypeNull:
+		return goType{expr: anyType, nilable: true}, nil
+	case plan.PrimitiveTypeArray:
+		return goType{expr: "[]" + anyType, nilable: true}, nil
+	case plan.PrimitiveTypeObject:
+		return goType{expr: openObjectType, nilable: true}, nil
+	default:
+		return goType{}, fmt.Errorf("unsupported primitive type: %s", t)
+	}
+}
+
+func hasEnumConfig(config *plan.PropertyConfig) bool {
+	return config != nil && len(config.Enum) > 0
+}
+
+func isEmptySchema(schema *plan.ObjectSchema) bool {
+	return schema == nil || len(schema.Properties) == 0
+}
+
+// emptyObjectType returns the type of an object schema without properties,
+// honouring additionalProperties. A missing schema accepts anything.
+func emptyObjectType(schema *plan.ObjectSchema) goType {
+	if schema == nil || schema.AdditionalProperties {
+		return goType{expr: openObjectType, nilable: true}
+	}
+	return goType{expr: closedObjectType}
+}
+
+// enumUnderlyingType returns the underlying type shared by all enum values:
+// "string", "int64", "float64" or "bool". Integers and floats mixed together
+// are float64; any other mix is "any".
+func enumUnderlyingType(values []any) string {
+	kinds := make(map[string]bool)
+	for _, value := range values {
+		switch value.(type) {
+		case string:
+			kinds["string"] = true
+		case bool:
+			kinds["bool"] = true
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			kinds["int64"] = true
+		case float32, float64:
+			kinds["float64"] = true
+		default:
+			return anyType
+		}
+	}
+
+	if kinds["int64"] && kinds["float64"] {
+		delete(kinds, "int64")
+	}
+	if len(kinds) != 1 {
+		return anyType
+	}
+	for kind := range kinds {
+		return kind
+	}
+	return anyType
+}
+
+// splitNull separates the null type from the other types.
+func splitNull(types []plan.PropertyType) ([]plan.PropertyType, bool) {
+	var nonNull []plan.PropertyType
+	hasNull := false
+	for _, t := range types {
+		if pt := plan.AsPrimitiveType(t); pt != nil && *pt == plan.PrimitiveTypeNull {
+			hasNull = true
+			continue
+		}
+		nonNull = append(nonNull, t)
+	}
+	return nonNull, hasNull
+}
+
+// resolveTypes returns the Go type of a value having one of types. Go has no
+// union types, so values of several types are "any", except for a single type
+// and null, which is a pointer to the type.
+func resolveTypes(types []plan.PropertyType, itemTypes []plan.PropertyType, nameRegistry *core.NameRegistry) (goType, error) {
+	nonNull, hasNull := splitNull(types)
+	if len(nonNull) != 1 {
+		return goType{expr: anyType, nilable: true}, nil
+	}
+
+	t, err := resolveType(nonNull[0], itemTypes, nameRegistry)
+	if err != nil {
+		return goType{}, err
+	}
+	if hasNull {
+		return t.pointer(), nil
+	}
+	return t, nil
+}
+
+// resolveType returns the Go type of a single primitive or custom type.
+// itemTypes narrow arrays.
+func resolveType(t plan.PropertyType, itemTypes []plan.PropertyType, nameRegistry *core.NameRegistry) (goType, error) {
+	if plan.IsCustomType(t) {
+		return resolveCustomTypeReference(plan.AsCustomType(t), nameRegistry)
+	}
+
+	primitive := plan.AsPrimitiveType(t)
+	if primitive == nil {
+		return goType{expr: anyType, nilable: true}, nil
+	}
+	if *primitive == plan.PrimitiveTypeArray && len(itemTypes) > 0 {
+		item, err := resolveTypes(itemTypes, nil, nameRegistry)
+		if err != nil {
+			return goType{}, err
+		}
+		return goType{expr: "[]" + item.expr, nilable: true}, nil
+	}
+	return mapPrimitiveType(*primitive)
+}
+
+// resolveCustomTypeReference returns the registered name of a custom type,
+// along with whether its declaration is nilable.
+func resolveCustomTypeReference(ct *plan.CustomType, nameRegistry *core.NameRegistry) (goType, error) {
+	name, err := getOrRegisterCustomTypeName(ct, nameRegistry)
+	if err != nil {
+		return goType{}, err
+	}
+	return goType{expr: name, nilable: isNilableCustomType(ct)}, nil
+}
+
+func isNilableCustomType(ct *plan.CustomType) bool {
+	switch {
+	case len(ct.Variants) > 0:
+		return true
+	case hasEnumConfig(ct.Config):
+		return enumUnderlyingType(ct.Config.Enum) == anyType
+	}
+
+	switch ct.Type {
+	case plan.PrimitiveTypeObject:
+		if !isEmptySchema(ct.Schema) {
+			return false
+		}
+		return emptyObjectType(ct.Schema).nilable
+	case plan.PrimitiveTypeArray, plan.PrimitiveTypeNull:
+		return true
+	default:
+		return false
+	}
+}
+
+// resolvePropertyType returns the Go type of a property held by a field of
+// structName. Properties with their own enum resolve to the enum, and inline
+// object schemas to a struct named after the field.
+func resolvePropertyType(structName string, propSchema *plan.PropertySchema, ctx *GoContext, nameRegistry *core.NameRegistry) (goType, error) {
+	prop := &propSchema.Property
+
+	if hasEnumConfig(prop.Config) {
+		name, err := getOrRegisterPropertyEnumName(prop, nameRegistry)
+		if err != nil {
+			return goType{}, err
+		}
+		t := goType{expr: name, nilable: enumUnderlyingType(prop.Config.Enum) == anyType}
+		if _, hasNull := splitNull(prop.Types); hasNull {
+			return t.pointer(), nil
+		}
+		return t, nil
+	}
+
+	if propSchema.Schema != nil {
+		if isEmptySchema(propSchema.Schema) {
+			return emptyObjectType(propSchema.Schema), nil
+		}
+
+		name, err := getOrRegisterNestedStructName(structName, prop.Name, nameRegistry)
+		if err != nil {
+			return goType{}, err
+		}
+		nested, err := buildStruct(name, prop.Description, propSchema.Schema, ctx, nameRegistry)
+		if err != nil {
+			return goType{}, err
+		}
+		ctx.Structs = append(ctx.Structs, *nested)
+		return goType{expr: name}, nil
+	}
+
+	return resolveTypes(prop.Types, prop.ItemTypes, nameRegistry)
+}
+
+// ========== Struct Builders ==========
+
+// buildStruct builds a struct with a field per property of schema. Structs
+// of inline object schemas are added to ctx as they are found.
+func buildStruct(name, comment string, schema *plan.ObjectSchema, ctx *GoContext, nameRegistry *core.NameRegistry) (*GoStruct, error) {
+	fields, err := buildFields(name, schema.Properties, "", ctx, nameRegistry)
+	if err != nil {
+		return nil, err
+	}
+	return &GoStruct{
+		Name:    name,
+		Comment: comment,
+		Fields:  fields,
+	}, nil
+}
+
+// buildFields builds the fields of structName for properties, sorted by key,
+// leaving out the property named skip. Optional fields are omitted from the
+// JSON when empty, which needs a pointer unless nil already means absent.
+func buildFields(structName string, properties map[string]plan.PropertySchema, skip string, ctx *GoContext, nameRegistry *core.NameRegistry) ([]GoField, error) {
+	keys := slices.Sorted(maps.Keys(properties))
+
+	fields := make([]GoField, 0, len(keys))
+	for _, key := range keys {
+		if key == skip {
+			continue
+		}
+		if !isValidJSONKey(key) {
+			return nil, fmt.Errorf("property %q of %s cannot be encoded by a Go struct tag", key, structName)
+		}
+
+		propSchema := properties[key]
+		fieldName, err := getOrRegisterFieldName(structName, key, nameRegistry)
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := resolvePropertyType(structName, &propSchema, ctx, nameRegistry)
+		if err != nil {
+			return nil, fmt.Errorf("resolving type of property %q of %s: %w", key, structName, err)
+		}
+		if !propSchema.Required {
+			t = t.pointer()
+		}
+
+		fields = append(fields, GoField{
+			Name:      fieldName,
+			JSONName:  key,
+			Type:      t.expr,
+			Comment:   propSchema.Property.Description,
+			OmitEmpty: !propSchema.Required,
+		})
+	}
+
+	return fields, nil
+}
+
+// ========== Custom Type Processing ==========
+
+// processCustomTypes declares every custom type of the plan:
+//   - variants → interface implemented by a struct per case
+//   - enum → defined type with a constant per value
+//   - object → struct, or an alias of an empty object type
+//   - array → alias of a slice of the item type
+//   - primitive → alias of the primitive type
+func processCustomTypes(p *plan.TrackingPlan, ctx *GoContext, nameRegistry *core.NameRegistry) error {
+	customTypes := p.ExtractAllCustomTypes()
+	for _, name := range slices.Sorted(maps.Keys(customTypes)) {
+		if err := processCustomType(customTypes[name], ctx, nameRegistry); err != nil {
+			return fmt.Errorf("processing custom type %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func processCustomType(ct *plan.CustomType, ctx *GoContext, nameRegistry *core.NameRegistry) error {
+	typeName, err := getOrRegisterCustomTypeName(ct, nameRegistry)
+	if err != nil {
+		return err
+	}
+
+	if len(ct.Variants) > 0 {
+		baseSchema := ct.Schema
+		if baseSchema == nil {
+			baseSchema = &plan.ObjectSchema{}
+		}
+		variant, err := buildVariant(typeName, ct.Description, baseSchema, ct.Variants, ctx, nameRegistry)
+		if err != nil {
+			return err
+		}
+		ctx.Variants = append(ctx.Variants, *variant)
+		return nil
+	}
+
+	if hasEnumConfig(ct.Config) {
+		enum, err := buildEnum(typeName, ct.Description, ct.Config.Enum, nameRegistry)
+		if err != nil {
+			return err
+		}
+		ctx.Enums = append(ctx.Enums, *enum)
+		return nil
+	}
+
+	var aliased goType
+	switch ct.Type {
+	case plan.PrimitiveTypeObject:
+		if !isEmptySchema(ct.Schema) {
+			s, err := buildStruct(typeName, ct.Description, ct.Schema, ctx, nameRegistry)
+			if err != nil {
+				return err
+			}
+			ctx.Structs = append(ctx.Structs, *s)
+			return nil
+		}
+		aliased = emptyObjectType(ct.Schema)
+	case plan.PrimitiveTypeArray:
+		var itemTypes []plan.PropertyType
+		if ct.ItemType != nil {
+			itemTypes = []plan.PropertyType{ct.ItemType}
+		}
+		aliased, err = resolveType(plan.PrimitiveTypeArray, itemTypes, nameRegistry)
+	default:
+		aliased, err = mapPrimitiveType(ct.Type)
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx.TypeAliases = append(ctx.TypeAliases, GoTypeAlias{
+		Name:    typeName,
+		Type:    aliased.expr,
+		Comment: ct.Description,
+	})
+	return nil
+}
+
+// ========== Enum Processing ==========
+
+// processPropertyEnums declares an enum for every property of the plan with
+// an enum config.
+func processPropertyEnums(p *plan.TrackingPlan, ctx *GoContext, nameRegistry *core.NameRegistry) error {
+	properties := p.ExtractAllProperties()
+	for _, name := range slices.Sorted(maps.Keys(properties)) {
+		prop := properties[name]
+		if !hasEnumConfig(prop.Config) {
+			continue
+		}
+
+		typeName, err := getOrRegisterPropertyEnumName(prop, nameRegistry)
+		if err != nil {
+			return err
+		}
+		enum, err := buildEnum(typeName, prop.Description, prop.Config.Enum, nameRegistry)
+		if err != nil {
+			return fmt.Errorf("processing enum of property %q: %w", name, err)
+		}
+		ctx.Enums = append(ctx.Enums, *enum)
+	}
+	return nil
+}
+
+func buildEnum(typeName, comment string, values []any, nameRegistry *core.NameRegistry) (*GoEnum, error) {
+	enum := &GoEnum{
+		Name:       typeName,
+		Comment:    comment,
+		Underlying: enumUnderlyingType(values),
+	}
+
+	for _, value := range values {
+		name, err := getOrRegisterEnumValue(typeName, value, nameRegistry)
+		if err != nil {
+			return nil, err
+		}
+		enum.Values = append(enum.Values, GoEnumValue{Name: name, Value: value})
+	}
+	return enum, nil
+}
+
+// ========== Event Rule Processing ==========
+
+func processEventRules(p *plan.TrackingPlan, ctx *GoContext, nameRegistry *core.NameRegistry) error {
+	// Map rules by a unique composite key for deterministic processing
+	ruleMap := make(map[string]*plan.EventRule)
+	for _, rule := range p.Rules {
+		key := string(rule.Event.EventType) + ":" + rule.Event.Name + ":" + string(rule.Section)
+		ruleMap[key] = &rule
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(ruleMap)) {
+		rule := ruleMap[key]
+
+		if !validateEventRuleSection(rule) {
+			ui.PrintWarning(fmt.Sprintf("invalid section %q for event type %q, skipping", rule.Section, rule.Event.EventType))
+			continue
+		}
+
+		if err := processEventRule(rule, ctx, nameRegistry); err != nil {
+			return fmt.Errorf("processing %s event %q: %w", rule.Event.EventType, rule.Event.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func processEventRule(rule *plan.EventRule, ctx *GoContext, nameRegistry *core.NameRegistry) error {
+	typeName, err := getOrRegisterEventTypeName(rule, nameRegistry)
+	if err != nil {
+		return err
+	}
+
+	argumentType := typeName
+	switch {
+	case len(rule.Variants) > 0:
+		variant, err := buildVariant(typeName, rule.Event.Description, &rule.Schema, rule.Variants, ctx, nameRegistry)
+		if err != nil {
+			return err
+		}
+		ctx.Variants = append(ctx.Variants, *variant)
+
+	case isEmptySchema(&rule.Schema):
+		// Events without properties take none, unless any are allowed
+		if !rule.Schema.AdditionalProperties {
+			argumentType = ""
+			break
+		}
+		ctx.TypeAliases = append(ctx.TypeAliases, GoTypeAlias{
+			Name:    typeName,
+			Type:    openObjectType,
+			Comment: rule.Event.Description,
+		})
+
+	default:
+		s, err := buildStruct(typeName, rule.Event.Description, &rule.Schema, ctx, nameRegistry)
+		if err != nil {
+			return err
+		}
+		ctx.Structs = append(ctx.Structs, *s)
+	}
+
+	method, err := buildMethod(rule, argumentType, nameRegistry)
+	if err != nil {
+		return err
+	}
+	ctx.Methods = append(ctx.Methods, *method)
+	return nil
+}
+
+func validateEventRuleSection(rule *plan.EventRule) bool {
+	switch rule.Event.EventType {
+	case plan.EventTypeTrack, plan.EventTypePage, plan.EventTypeScreen:
+		return rule.Section == plan.IdentitySectionProperties
+	case plan.EventTypeIdentify, plan.EventTypeGroup:
+		return rule.Section == plan.IdentitySectionTraits || rule.Section == plan.IdentitySectionContextTraits
+	}
+	return false
+}
+
+// ========== RudderTyper Method Builder ==========
+
+func buildMethod(rule *plan.EventRule, argumentType string, nameRegistry *core.NameRegistry) (*GoMethod, error) {
+	name, err := getOrRegisterEventMethodName(rule, nameRegistry)
+	if err != nil {
+		return nil, err
+	}
+
+	method := &GoMethod{
+		Name:         name,
+		EventName:    rule.Event.Name,
+		MessageType:  messageType(rule.Event.EventType),
+		ArgumentType: argumentType,
+	}
+
+	var summary string
+	switch rule.Event.EventType {
+	case plan.EventTypeTrack:
+		method.SetsEvent = true
+		summary = fmt.Sprintf("%s tracks the %q event.", name, rule.Event.Name)
+	case plan.EventTypeIdentify:
+		summary = fmt.Sprintf("%s identifies a user.", name)
+	case plan.EventTypePage:
+		summary = fmt.Sprintf("%s tracks a page view.", name)
+	case plan.EventTypeScreen:
+		summary = fmt.Sprintf("%s tracks a screen view.", name)
+	case plan.EventTypeGroup:
+		summary = fmt.Sprintf("%s associates a user with a group.", name)
+	}
+
+	if argumentType != "" {
+		switch rule.Section {
+		case plan.IdentitySectionProperties:
+			method.ArgumentName = "properties"
+			method.Target = "Properties"
+		case plan.IdentitySectionTraits:
+			method.ArgumentName = "traits"
+			method.Target = "Traits"
+		case plan.IdentitySectionContextTraits:
+			method.ArgumentName = "traits"
+			method.Target = "Context.Traits"
+			summary += " The traits are sent in the context of the message."
+		}
+	}
+
+	method.Comment = summary
+	if description := strings.TrimSpace(rule.Event.Description); description != "" {
+		method.Comment += "\n\n" + description
+	}
+	return method, nil
+}
+
+func messageType(eventType plan.EventType) string {
+	switch eventType {
+	case plan.EventTypeTrack:
+		return "Track"
+	case plan.EventTypeIdentify:
+		return "Identify"
+	case plan.EventTypePage:
+		return "Page"
+	case plan.EventTypeScreen:
+		return "Screen"
+	case plan.EventTypeGroup:
+		return "Group"
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+package golang_test
+
+import (
+	_ "embed"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/golang"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan/testutils"
+)
+
+//go:embed testdata/ruddertyper.go
+var rudderTyperGo string
+
+func TestGenerate(t *testing.T) {
+	trackingPlan := testutils.GetReferenceTrackingPlan()
+
+	generator := &golang.Generator{}
+	files, err := generator.Generate(trackingPlan, core.GenerateOptions{
+		RudderCLIVersion: "1.0.0",
+	}, nil)
+
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "ruddertyper.go", files[0].Path)
+
+	if diff := cmp.Diff(rudderTyperGo, files[0].Content); diff != "" {
+		t.Errorf("generated content does not match testdata/ruddertyper.go (-want +got):\n%s\nRun 'make typer-go-update-testdata' to update the golden file.", diff)
+	}
+}
+
+// TestGeneratedCodeCompiles type-checks the golden file against the
+// analytics-go version this module depends on.
+func TestGeneratedCodeCompiles(t *testing.T) {
+	path, err := filepath.Abs(filepath.Join("testdata", "ruddertyper.go"))
+	require.NoError(t, err)
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, rudderTyperGo, parser.ParseComments)
+	require.NoError(t, err)
+	assert.True(t, ast.IsGenerated(file), "generated file must be marked as generated")
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("ruddertyper", fset, []*ast.File{file}, nil)
+	require.NoError(t, err)
+}
+
+func TestGenerateWithOptions(t *testing.T) {
+	trackingPlan := testutils.GetReferenceTrackingPlan()
+
+	generator := &golang.Generator{}
+	files, err := generator.Generate(trackingPlan, core.GenerateOptions{
+		RudderCLIVersion: "1.0.0",
+	}, golang.GoOptions{
+		PackageName:    "tracking",
+		OutputFileName: "events.go",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "events.go", files[0].Path)
+	assert.Contains(t, files[0].Content, "\npackage tracking\n")
+}
+
+func TestGenerateInvalidPackageName(t *testing.T) {
+	generator := &golang.Generator{}
+
+	for _, name := range []string{"Tracking", "my-events", "1events", "type"} {
+		_, err := generator.Generate(testutils.GetReferenceTrackingPlan(), core.GenerateOptions{}, golang.GoOptions{PackageName: name})
+		assert.Error(t, err, name)
+	}
+}
+
+func TestGenerateRejectsUnencodableKeys(t *testing.T) {
+	trackingPlan := &plan.TrackingPlan{
+		Name: "Test Plan",
+		Rules: []plan.EventRule{{
+			Event:   plan.Event{EventType: plan.EventTypeTrack, Name: "Quoted"},
+			Section: plan.IdentitySectionProperties,
+			Schema: plan.ObjectSchema{
+				Properties: map[string]plan.PropertySchema{
+					`say "hi"`: {Property: plan.Property{Name: `say "hi"`, Types: []plan.PropertyType{plan.PrimitiveTypeString}}},
+				},
+			},
+		}},
+	}
+
+	_, err := (&golang.Generator{}).Generate(trackingPlan, core.GenerateOptions{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `property "say \"hi\""`)
+}
@@ -0,0 +1,256 @@
+package golang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+)
+
+const (
+	globalTypeScope = "types"
+	methodScope     = "methods"
+)
+
+// exportedPrefix is prepended to identifiers that would otherwise not be
+// exported, e.g. ones starting with a digit or a caseless letter such as "用".
+const exportedPrefix = "X"
+
+// enumValuePlaceholder names enum values made only of symbols or emoji. The
+// collision handler numbers them.
+const enumValuePlaceholder = "Value"
+
+// FormatTypeName converts a name to an exported PascalCase identifier suitable
+// for Go type names. If prefix is provided, it's prepended to the formatted name.
+func FormatTypeName(prefix, name string) string {
+	formatted := sanitizeForIdentifier(strings.TrimSpace(name))
+	if prefix != "" {
+		formatted = prefix + " " + formatted
+	}
+	return ensureExported(core.ToPascalCase(formatted))
+}
+
+// FormatFieldName converts a name to an exported PascalCase identifier
+// suitable for Go struct fields. Returns empty string if the name holds no
+// letters or digits.
+func FormatFieldName(name string) string {
+	formatted := sanitizeForIdentifier(strings.TrimSpace(name))
+	return ensureExported(core.ToPascalCase(formatted))
+}
+
+// FormatEnumValueSuffix converts an enum value to the PascalCase suffix that is
+// appended to the enum type name to name the value's constant. Returns empty
+// string for values without letters or digits, such as emoji.
+//
+// Examples:
+//   - "smartTV" → "SmartTv"
+//   - 2.5 → "2_5"
+//   - -1 → "Minus1"
+//   - true → "True"
+func FormatEnumValueSuffix(value any) string {
+	var formatted string
+	switch v := value.(type) {
+	case string:
+		formatted = core.ToPascalCase(sanitizeForIdentifier(strings.TrimSpace(v)))
+	case bool:
+		formatted = core.ToPascalCase(strconv.FormatBool(v))
+	default:
+		formatted = fmt.Sprintf("%v", v)
+		formatted = strings.ReplaceAll(formatted, "-", "Minus")
+		formatted = strings.ReplaceAll(formatted, "+", "")
+		formatted = strings.ReplaceAll(formatted, ".", "_")
+	}
+	return formatted
+}
+
+// FormatMethodName converts a name to an exported PascalCase identifier
+// suitable for Go method names. If prefix is provided, it's prepended to the
+// formatted name.
+func FormatMethodName(prefix, name string) string {
+	return FormatTypeName(prefix, name)
+}
+
+// sanitizeForIdentifier replaces characters that are invalid in Go identifiers
+// with spaces, so they become word boundaries in PascalCase conversion.
+func sanitizeForIdentifier(s string) string {
+	var result strings.Builder
+	result.Grow(len(s))
+
+	for _, ch := range s {
+		if unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '_' || ch == '-' || ch == ' ' || ch == '.' {
+			result.WriteRune(ch)
+		} else {
+			result.WriteRune(' ')
+		}
+	}
+
+	return result.String()
+}
+
+// ensureExported prefixes name when its first character is not an upper case
+// letter, which Go requires of exported identifiers.
+func ensureExported(name string) string {
+	for _, r := range name {
+		if unicode.IsUpper(r) {
+			return name
+		}
+		return exportedPrefix + name
+	}
+	return name
+}
+
+// enumValueKey identifies an enum value, keeping values of different types
+// such as 1 and "1" apart.
+func enumValueKey(value any) string {
+	return fmt.Sprintf("%T:%v", value, value)
+}
+
+// getOrRegisterCustomTypeName returns the registered type name for a custom type.
+func getOrRegisterCustomTypeName(customType *plan.CustomType, nameRegistry *core.NameRegistry) (string, error) {
+	typeName := FormatTypeName("CustomType", customType.Name)
+	return nameRegistry.RegisterName("customtype:"+customType.Name, globalTypeScope, typeName)
+}
+
+// getOrRegisterPropertyEnumName returns the registered type name for the enum
+// of a property.
+func getOrRegisterPropertyEnumName(property *plan.Property, nameRegistry *core.NameRegistry) (string, error) {
+	typeName := FormatTypeName("Property", property.Name)
+	return nameRegistry.RegisterName("propertyenum:"+property.Name, globalTypeScope, typeName)
+}
+
+// getOrRegisterNestedStructName returns the registered type name for an
+// inline object schema of a property, named after the struct holding it.
+func getOrRegisterNestedStructName(parentName, propName string, nameRegistry *core.NameRegistry) (string, error) {
+	typeName := parentName + FormatFieldName(propName)
+	if typeName == parentName {
+		typeName = parentName + "Object"
+	}
+	return nameRegistry.RegisterName("nested:"+parentName+":"+propName, globalTypeScope, typeName)
+}
+
+// getOrRegisterFieldName registers a field name within a struct scope and
+// returns a collision-free identifier, so that keys which sanitize to the same
+// identifier get unique names.
+func getOrRegisterFieldName(structName, propName string, nameRegistry *core.NameRegistry) (string, error) {
+	scope := fmt.Sprintf("struct:%s:fields", structName)
+	formatted := FormatFieldName(propName)
+	if formatted == "" {
+		formatted = "Field"
+	}
+	return nameRegistry.RegisterName(propName, scope, formatted)
+}
+
+// reserveFieldNames keeps names from being used as fields of a struct.
+func reserveFieldNames(structName string, names []string, nameRegistry *core.NameRegistry) error {
+	scope := fmt.Sprintf("struct:%s:fields", structName)
+	for _, name := range names {
+		if _, err := nameRegistry.RegisterName("reserved:"+name, scope, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getOrRegisterEnumValue returns the registered constant name for an enum
+// value. Constants are package-level identifiers, so they share the global
+// type scope.
+func getOrRegisterEnumValue(typeName string, value any, nameRegistry *core.NameRegistry) (string, error) {
+	suffix := FormatEnumValueSuffix(value)
+	if suffix == "" {
+		// Register the bare placeholder first so that every value using it
+		// gets a number, rather than the first one going without.
+		placeholder := typeName + enumValuePlaceholder
+		if _, err := nameRegistry.RegisterName("enum:placeholder:"+typeName, globalTypeScope, placeholder); err != nil {
+			return "", err
+		}
+		suffix = enumValuePlaceholder
+	}
+
+	name, err := nameRegistry.RegisterName("enum:"+typeName+":"+enumValueKey(value), globalTypeScope, typeName+suffix)
+	if err != nil {
+		return "", fmt.Errorf("failed to register name for enum %q: %w", typeName, err)
+	}
+	return name, nil
+}
+
+// getOrRegisterVariantCaseName returns the registered type name of the struct
+// for one match value of a variant. A nil value names the default case.
+func getOrRegisterVariantCaseName(variantName string, matchValue any, nameRegistry *core.NameRegistry) (string, error) {
+	if matchValue == nil {
+		return nameRegistry.RegisterName("variant:"+variantName+":default", globalTypeScope, variantName+"Default")
+	}
+	typeName := variantName + "Case" + FormatEnumValueSuffix(matchValue)
+	return nameRegistry.RegisterName("variant:"+variantName+":"+enumValueKey(matchValue), globalTypeScope, typeName)
+}
+
+// getOrRegisterEventTypeName returns the registered type name of the
+// properties or traits of an event rule.
+func getOrRegisterEventTypeName(rule *plan.EventRule, nameRegistry *core.NameRegistry) (string, error) {
+	var prefix, baseName string
+
+	switch rule.Event.EventType {
+	case plan.EventTypeTrack:
+		prefix = "Track"
+		baseName = rule.Event.Name
+	case plan.EventTypeIdentify:
+		prefix = "Identify"
+	case plan.EventTypePage:
+		prefix = "Page"
+	case plan.EventTypeScreen:
+		prefix = "Screen"
+	case plan.EventTypeGroup:
+		prefix = "Group"
+	default:
+		return "", fmt.Errorf("unsupported event type: %s", rule.Event.EventType)
+	}
+
+	var suffix string
+	switch rule.Section {
+	case plan.IdentitySectionProperties:
+		suffix = "Properties"
+	case plan.IdentitySectionTraits, plan.IdentitySectionContextTraits:
+		suffix = "Traits"
+	default:
+		return "", fmt.Errorf("unsupported event rule section: %s", rule.Section)
+	}
+
+	typeName := FormatTypeName(prefix, baseName+" "+suffix)
+	key := "event:" + string(rule.Event.EventType) + ":" + rule.Event.Name + ":" + string(rule.Section)
+	return nameRegistry.RegisterName(key, globalTypeScope, typeName)
+}
+
+// getOrRegisterEventMethodName returns the registered method name for an
+// event. Events with names that sanitize to the same method name are assigned
+// unique names (e.g., TrackEventName, TrackEventName1).
+func getOrRegisterEventMethodName(rule *plan.EventRule, nameRegistry *core.NameRegistry) (string, error) {
+	var prefix, name string
+
+	switch rule.Event.EventType {
+	case plan.EventTypeTrack:
+		prefix = "Track"
+		name = rule.Event.Name
+	case plan.EventTypeIdentify:
+		prefix = "Identify"
+	case plan.EventTypePage:
+		prefix = "Page"
+	case plan.EventTypeScreen:
+		prefix = "Screen"
+	case plan.EventTypeGroup:
+		prefix = "Group"
+	default:
+		return "", fmt.Errorf("unsupported event type: %s", rule.Event.EventType)
+	}
+
+	// Identify and group rules for traits and context traits are sent by
+	// separate methods, as Go has no overloading.
+	key := "method:" + string(rule.Event.EventType) + ":" + rule.Event.Name + ":" + string(rule.Section)
+	return nameRegistry.RegisterName(key, methodScope, FormatMethodName(prefix, name))
+}
+
+// GoCollisionHandler provides a Go-specific collision handler for the NameRegistry
+func GoCollisionHandler(name string, existingNames []string) string {
+	return core.DefaultCollisionHandler(name, existingNames)
+}
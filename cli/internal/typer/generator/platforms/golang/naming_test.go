@@ -0,0 +1,81 @@
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/golang"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatTypeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		input    string
+		expected string
+	}{
+		{"snake_case", "", "user_id", "UserId"},
+		{"kebab-case", "", "email-address", "EmailAddress"},
+		{"space separated", "", "first name", "FirstName"},
+		{"camelCase", "", "firstName", "FirstName"},
+		{"special characters", "", `Product "Premium" Clicked`, "ProductPremiumClicked"},
+		{"leading number", "", "123user", "X123user"},
+		{"keyword", "", "type", "Type"},
+		{"empty string", "", "", ""},
+		{"with prefix", "Track", "User Signed Up", "TrackUserSignedUp"},
+		{"prefix with empty name", "Identify", "", "Identify"},
+		{"cyrillic", "CustomType", "типы_данных", "CustomTypeТипыДанных"},
+		{"chinese", "", "用户名", "X用户名"},
+		{"emoji", "", "🎯", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, golang.FormatTypeName(tt.prefix, tt.input))
+		})
+	}
+}
+
+func TestFormatFieldName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"snake_case", "first_name", "FirstName"},
+		{"camelCase", "ipAddress", "IpAddress"},
+		{"leading number", "1st_place", "X1stPlace"},
+		{"latin with diacritics", "café", "Café"},
+		{"chinese", "用户名", "X用户名"},
+		{"symbols only", "!!!", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, golang.FormatFieldName(tt.input))
+		})
+	}
+}
+
+func TestFormatEnumValueSuffix(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected string
+	}{
+		{"string", "smartTV", "SmartTv"},
+		{"string with symbols", "200: OK", "200Ok"},
+		{"integer", 200, "200"},
+		{"negative integer", -1, "Minus1"},
+		{"float", 2.5, "2_5"},
+		{"boolean", true, "True"},
+		{"cyrillic", "активный", "Активный"},
+		{"emoji", "🎯", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, golang.FormatEnumValueSuffix(tt.input))
+		})
+	}
+}
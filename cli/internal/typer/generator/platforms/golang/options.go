@@ -0,0 +1,41 @@
+package golang
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// GoOptions holds platform-specific options for Go code generation.
+// These can be passed via --option flags in the CLI, e.g.:
+//
+//	--option packageName=tracking --option outputFileName=tracking.go
+type GoOptions struct {
+	PackageName    string `mapstructure:"packageName" description:"Name of the generated Go package. Defaults to ruddertyper"`
+	OutputFileName string `mapstructure:"outputFileName" description:"Name of the generated Go file. Defaults to ruddertyper.go"`
+}
+
+// DefaultOptions returns the default Go generation options.
+func (g *Generator) DefaultOptions() any {
+	return GoOptions{
+		PackageName:    "ruddertyper",
+		OutputFileName: "ruddertyper.go",
+	}
+}
+
+// packageNameRegex validates Go package names: a lowercase letter followed by
+// lowercase letters, digits or underscores.
+var packageNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Validate validates Go-specific options
+func (o *GoOptions) Validate() error {
+	if o.PackageName == "" {
+		return nil
+	}
+	if !packageNameRegex.MatchString(o.PackageName) || GoKeywords[o.PackageName] {
+		return fmt.Errorf(
+			"invalid package name %q: must be a lowercase Go identifier that is not a keyword (e.g., analytics)",
+			o.PackageName,
+		)
+	}
+	return nil
+}
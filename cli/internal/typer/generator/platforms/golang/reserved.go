@@ -0,0 +1,46 @@
+package golang
+
+// Source: https://go.dev/ref/spec#Keywords
+//
+// GoKeywords cannot be used as package names. Generated identifiers are all
+// exported, so they never clash with a keyword.
+var GoKeywords = map[string]bool{
+	"break":       true,
+	"case":        true,
+	"chan":        true,
+	"const":       true,
+	"continue":    true,
+	"default":     true,
+	"defer":       true,
+	"else":        true,
+	"fallthrough": true,
+	"for":         true,
+	"func":        true,
+	"go":          true,
+	"goto":        true,
+	"if":          true,
+	"import":      true,
+	"interface":   true,
+	"map":         true,
+	"package":     true,
+	"range":       true,
+	"return":      true,
+	"select":      true,
+	"struct":      true,
+	"switch":      true,
+	"type":        true,
+	"var":         true,
+}
+
+// reservedTypeNames are declared by the generated file itself, so types
+// generated from the tracking plan must not use them.
+var reservedTypeNames = []string{
+	"RudderTyper",
+	"New",
+}
+
+// reservedVariantMethodNames are methods declared on every variant case
+// struct, so none of its fields may use them.
+var reservedVariantMethodNames = []string{
+	"MarshalJSON",
+}
@@ -0,0 +1,79 @@
+package golang
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+)
+
+//go:embed templates/ruddertyper.go.tmpl
+var goTemplate string
+
+//go:embed templates/disclaimer.tmpl
+var disclaimerTemplate string
+
+//go:embed templates/typealias.tmpl
+var typealiasTemplate string
+
+//go:embed templates/enum.tmpl
+var enumTemplate string
+
+//go:embed templates/struct.tmpl
+var structTemplate string
+
+//go:embed templates/variant.tmpl
+var variantTemplate string
+
+//go:embed templates/ruddertyper.tmpl
+var ruddertyperTemplate string
+
+// GenerateFile renders ctx and formats the result with gofmt, so templates
+// don't need to care about alignment or blank lines.
+func GenerateFile(path string, ctx *GoContext) (*core.File, error) {
+	funcMap := template.FuncMap{
+		"comment": FormatGoComment,
+		"literal": FormatGoLiteral,
+		"jsonTag": FormatJSONTag,
+		"oneLine": func(s string) string {
+			return strings.Join(strings.Fields(s), " ")
+		},
+	}
+
+	tmpl, err := template.New("go").Funcs(funcMap).Parse(goTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, src := range map[string]string{
+		"disclaimer.tmpl":  disclaimerTemplate,
+		"typealias.tmpl":   typealiasTemplate,
+		"enum.tmpl":        enumTemplate,
+		"struct.tmpl":      structTemplate,
+		"variant.tmpl":     variantTemplate,
+		"ruddertyper.tmpl": ruddertyperTemplate,
+	} {
+		if _, err = tmpl.New(name).Parse(src); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, ctx); err != nil {
+		return nil, err
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated Go code: %w", err)
+	}
+
+	return &core.File{
+		Path:    path,
+		Content: string(formatted),
+	}, nil
+}
@@ -0,0 +1,786 @@
+// Code generated by Rudder CLI 1.0.0. DO NOT EDIT.
+
+// Package ruddertyper provides type-safe analytics calls for the
+// "Test Plan" tracking plan.
+//
+// Tracking plan ID: plan_12345, version 13
+//
+// See https://app.rudderstack.com/trackingPlans/plan_12345
+package ruddertyper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	analytics "github.com/rudderlabs/analytics-go/v4"
+)
+
+// Whether user is active
+type CustomTypeActive = bool
+
+// List of addresses
+type CustomTypeAddressList = []CustomTypeAddressDetails
+
+// User's age in years
+type CustomTypeAge = float64
+
+// Custom type for Colors
+type CustomTypeColor = string
+
+// Custom type for email validation
+type CustomTypeEmail = string
+
+// List of email addresses
+type CustomTypeEmailList = []CustomTypeEmail
+
+// Empty object that does not allow additional properties
+type CustomTypeEmptyObjectNoAdditionalProps = struct{}
+
+// Empty object that allows additional properties
+type CustomTypeEmptyObjectWithAdditionalProps = map[string]any
+
+// Custom type representing a null value
+type CustomTypeNullType = any
+
+// Custom type for phone numbers
+type CustomTypePhoneNumber = string
+
+// List of user profiles
+type CustomTypeProfileList = []CustomTypeUserProfile
+
+// Empty event schema with additionalProperties true
+type TrackEmptyEventWithAdditionalPropsProperties = map[string]any
+
+// User status enum
+type CustomTypeStatus string
+
+const (
+	CustomTypeStatusPending   CustomTypeStatus = "pending"
+	CustomTypeStatusActive    CustomTypeStatus = "active"
+	CustomTypeStatusSuspended CustomTypeStatus = "suspended"
+	CustomTypeStatusDeleted   CustomTypeStatus = "deleted"
+)
+
+// Custom type with Cyrillic name
+type CustomTypeТипыДанных string
+
+const (
+	CustomTypeТипыДанныхАктивный   CustomTypeТипыДанных = "активный"
+	CustomTypeТипыДанныхНеактивный CustomTypeТипыДанных = "неактивный"
+	CustomTypeТипыДанныхPending    CustomTypeТипыДанных = "pending"
+)
+
+// Type of device
+type PropertyDeviceType string
+
+const (
+	PropertyDeviceTypeMobile    PropertyDeviceType = "mobile"
+	PropertyDeviceTypeTablet    PropertyDeviceType = "tablet"
+	PropertyDeviceTypeDesktop   PropertyDeviceType = "desktop"
+	PropertyDeviceTypeSmartTv   PropertyDeviceType = "smartTV"
+	PropertyDeviceTypeIoTDevice PropertyDeviceType = "IoT-Device"
+)
+
+// Field with $ for testing string interpolation: $variable and ${expression}
+type PropertyDollarField string
+
+const (
+	PropertyDollarFieldUsd          PropertyDollarField = "$USD"
+	PropertyDollarField100          PropertyDollarField = "$100"
+	PropertyDollarFieldPrice9999    PropertyDollarField = "Price: $99.99"
+	PropertyDollarFieldVariableName PropertyDollarField = "$variable_name"
+)
+
+// Feature enabled flag
+type PropertyEnabled bool
+
+const (
+	PropertyEnabledTrue  PropertyEnabled = true
+	PropertyEnabledFalse PropertyEnabled = false
+)
+
+// Mixed type enum
+type PropertyMixedValue any
+
+var (
+	PropertyMixedValueActive PropertyMixedValue = "active"
+	PropertyMixedValue1      PropertyMixedValue = 1
+	PropertyMixedValueTrue   PropertyMixedValue = true
+	PropertyMixedValue2_5    PropertyMixedValue = 2.5
+)
+
+// Priority level
+type PropertyPriority int64
+
+const (
+	PropertyPriority1 PropertyPriority = 1
+	PropertyPriority2 PropertyPriority = 2
+	PropertyPriority3 PropertyPriority = 3
+)
+
+// Rating value
+type PropertyRating float64
+
+const (
+	PropertyRating1_5 PropertyRating = 1.5
+	PropertyRating2_5 PropertyRating = 2.5
+	PropertyRating3_5 PropertyRating = 3.5
+	PropertyRating4_5 PropertyRating = 4.5
+	PropertyRating5   PropertyRating = 5.0
+)
+
+// HTTP status with special characters
+type PropertyStatusCode string
+
+const (
+	PropertyStatusCode200Ok                  PropertyStatusCode = "200: OK"
+	PropertyStatusCode404NotFound            PropertyStatusCode = "404: Not Found"
+	PropertyStatusCode500InternalServerError PropertyStatusCode = "500: Internal \"Server\" Error"
+)
+
+// Field demonstrating various Unicode characters in enum values
+type PropertyUnicodeEnumField string
+
+const (
+	PropertyUnicodeEnumFieldValue1   PropertyUnicodeEnumField = "🎯"
+	PropertyUnicodeEnumFieldValue2   PropertyUnicodeEnumField = "✅"
+	PropertyUnicodeEnumFieldАктивный PropertyUnicodeEnumField = "активный"
+	PropertyUnicodeEnumField已完成      PropertyUnicodeEnumField = "已完成"
+	PropertyUnicodeEnumFieldΕνεργός  PropertyUnicodeEnumField = "ενεργός"
+	PropertyUnicodeEnumFieldCafé     PropertyUnicodeEnumField = "café"
+	PropertyUnicodeEnumFieldValue3   PropertyUnicodeEnumField = "!!!"
+)
+
+// Address details object
+type CustomTypeAddressDetails struct {
+	// City name
+	City string `json:"city"`
+	// Postal code
+	PostalCode *string `json:"postal_code,omitempty"`
+	// Street address
+	Street string `json:"street"`
+}
+
+// User profile information
+type CustomTypeUserProfile struct {
+	// User's email address
+	Email CustomTypeEmail `json:"email"`
+	// User's first name
+	FirstName string `json:"first_name"`
+	// User's last name
+	LastName *string `json:"last_name,omitempty"`
+}
+
+// Group association event
+type GroupTraits struct {
+	// User active status
+	Active CustomTypeActive `json:"active"`
+	// User account status
+	Status *CustomTypeStatus `json:"status,omitempty"`
+}
+
+// User identification event
+type IdentifyTraits struct {
+	// User active status
+	Active *CustomTypeActive `json:"active,omitempty"`
+	// User's email address
+	Email CustomTypeEmail `json:"email"`
+}
+
+// Page view event
+type PageProperties struct {
+	// User profile data
+	Profile CustomTypeUserProfile `json:"profile"`
+}
+
+// Screen view event
+type ScreenProperties struct {
+	// User profile data
+	Profile *CustomTypeUserProfile `json:"profile,omitempty"`
+}
+
+// Event with dollar signs to test string interpolation escaping
+type TrackVariableStringProperties struct {
+	// Field with $ for testing string interpolation: $variable and ${expression}
+	DollarField PropertyDollarField `json:"dollar_field"`
+}
+
+// Event with special characters that collide after sanitization
+type TrackEventWithNameCamelCaseProperties struct {
+	// User's email address
+	Email *CustomTypeEmail `json:"email,omitempty"`
+}
+
+// Triggered when user clicks on a "premium" product /* important */
+type TrackProductPremiumClickedProperties struct {
+	// Field with special chars: "quotes", backslash\path, and /* comment */
+	SpecialField string `json:"special_field"`
+	// HTTP status with special characters
+	StatusCode *PropertyStatusCode `json:"status_code,omitempty"`
+}
+
+// demonstrates multiple levels of nesting
+type TrackUserSignedUpPropertiesContextNestedContext struct {
+	// Array of favorite colors using custom type
+	FavoriteColors []CustomTypeColor `json:"favorite_colors,omitempty"`
+	// User profile data
+	Profile *CustomTypeUserProfile `json:"profile,omitempty"`
+}
+
+// example of object property
+type TrackUserSignedUpPropertiesContext struct {
+	// IP address of the user
+	IpAddress string `json:"ip_address"`
+	// demonstrates multiple levels of nesting
+	NestedContext TrackUserSignedUpPropertiesContextNestedContext `json:"nested_context"`
+}
+
+// Triggered when a user signs up
+type TrackUserSignedUpProperties struct {
+	// User active status
+	Active CustomTypeActive `json:"active"`
+	// User's addresses
+	Addresses CustomTypeAddressList `json:"addresses,omitempty"`
+	// User's age
+	Age *CustomTypeAge `json:"age,omitempty"`
+	// An array that can contain any type of items
+	ArrayOfAny []any `json:"array_of_any,omitempty"`
+	// Array with items that can be string or null
+	ArrayWithNullItems []*string `json:"array_with_null_items,omitempty"`
+	// Array of user contacts
+	Contacts []CustomTypeEmail `json:"contacts,omitempty"`
+	// example of object property
+	Context *TrackUserSignedUpPropertiesContext `json:"context,omitempty"`
+	// Property using custom null type
+	CustomNullField CustomTypeNullType `json:"custom_null_field,omitempty"`
+	// Type of device
+	DeviceType *PropertyDeviceType `json:"device_type,omitempty"`
+	// User's email addresses
+	EmailList CustomTypeEmailList `json:"email_list,omitempty"`
+	// Property with empty object not allowing additional properties
+	EmptyObjectNoAdditionalProps *CustomTypeEmptyObjectNoAdditionalProps `json:"empty_object_no_additional_props,omitempty"`
+	// Property with empty object allowing additional properties
+	EmptyObjectWithAdditionalProps CustomTypeEmptyObjectWithAdditionalProps `json:"empty_object_with_additional_props,omitempty"`
+	// Feature enabled flag
+	Enabled *PropertyEnabled `json:"enabled,omitempty"`
+	// Feature configuration information
+	FeatureConfig CustomTypeFeatureConfig `json:"feature_config,omitempty"`
+	// Property with mixed unicode: café, naïve, 日本語
+	MixedUnicode *string `json:"mixed_unicode,omitempty"`
+	// Mixed type enum
+	MixedValue PropertyMixedValue `json:"mixed_value,omitempty"`
+	// An array with items that can be string or integer
+	MultiTypeArray []any `json:"multi_type_array,omitempty"`
+	// A field that can be string, integer, or boolean
+	MultiTypeField any `json:"multi_type_field,omitempty"`
+	// Property that can be string, integer, or null
+	MultiTypeWithNull any `json:"multi_type_with_null,omitempty"`
+	// Nested property with empty object allowing additional properties
+	NestedEmptyObject map[string]any `json:"nested_empty_object,omitempty"`
+	// Nested property with empty object not allowing additional properties
+	NestedEmptyObjectNoAdditionalProps *struct{} `json:"nested_empty_object_no_additional_props,omitempty"`
+	// Property that is always null
+	NullField any `json:"null_field,omitempty"`
+	// Property that can be number or null
+	NumberOrNull *float64 `json:"number_or_null,omitempty"`
+	// An object field with no defined structure
+	ObjectProperty map[string]any `json:"object_property,omitempty"`
+	// Array of phone numbers using custom type
+	PhoneNumbers []CustomTypePhoneNumber `json:"phone_numbers,omitempty"`
+	// Priority level
+	Priority *PropertyPriority `json:"priority,omitempty"`
+	// User profile data
+	Profile CustomTypeUserProfile `json:"profile"`
+	// List of related user profiles
+	ProfileList CustomTypeProfileList `json:"profile_list,omitempty"`
+	// A field that can contain any type of value
+	PropertyOfAny any `json:"property_of_any,omitempty"`
+	// Rating value
+	Rating *PropertyRating `json:"rating,omitempty"`
+	// User account status
+	Status *CustomTypeStatus `json:"status,omitempty"`
+	// Property that can be string or null
+	StringOrNull *string `json:"string_or_null,omitempty"`
+	// User tags as array of strings
+	Tags []string `json:"tags,omitempty"`
+	// Property using custom type with Unicode
+	UnicodeCustomType *CustomTypeТипыДанных `json:"unicode_custom_type,omitempty"`
+	// Field demonstrating various Unicode characters in enum values
+	UnicodeEnumField *PropertyUnicodeEnumField `json:"unicode_enum_field,omitempty"`
+	// An array with no explicit item type (treated as any)
+	UntypedArray []any `json:"untyped_array,omitempty"`
+	// A field with no explicit type (treated as any)
+	UntypedField any `json:"untyped_field,omitempty"`
+	// User access information
+	UserAccess CustomTypeUserAccess `json:"user_access,omitempty"`
+	// Username in Chinese characters
+	X用户名 *string `json:"用户名,omitempty"`
+}
+
+// Event with camel case name
+type TrackEventWithNameCamelCaseProperties1 struct {
+	// User active status
+	Active *CustomTypeActive `json:"active,omitempty"`
+}
+
+// Feature configuration with variants based on multi-type flag
+//
+// CustomTypeFeatureConfig is implemented by:
+//   - [CustomTypeFeatureConfigCaseTrue]
+//   - [CustomTypeFeatureConfigCaseFalse]
+//   - [CustomTypeFeatureConfigCaseBeta]
+//   - [CustomTypeFeatureConfigDefault]
+type CustomTypeFeatureConfig interface {
+	isCustomTypeFeatureConfig()
+}
+
+// Feature enabled (boolean true)
+type CustomTypeFeatureConfigCaseTrue struct {
+	// User's age
+	Age *CustomTypeAge `json:"age,omitempty"`
+}
+
+func (CustomTypeFeatureConfigCaseTrue) isCustomTypeFeatureConfig() {}
+
+// MarshalJSON encodes CustomTypeFeatureConfigCaseTrue with feature_flag set to true.
+func (v CustomTypeFeatureConfigCaseTrue) MarshalJSON() ([]byte, error) {
+	type fields CustomTypeFeatureConfigCaseTrue
+	return marshalWithDiscriminator(fields(v), "feature_flag", true)
+}
+
+// Feature disabled (boolean false)
+type CustomTypeFeatureConfigCaseFalse struct {
+	// User's first name
+	FirstName *string `json:"first_name,omitempty"`
+}
+
+func (CustomTypeFeatureConfigCaseFalse) isCustomTypeFeatureConfig() {}
+
+// MarshalJSON encodes CustomTypeFeatureConfigCaseFalse with feature_flag set to false.
+func (v CustomTypeFeatureConfigCaseFalse) MarshalJSON() ([]byte, error) {
+	type fields CustomTypeFeatureConfigCaseFalse
+	return marshalWithDiscriminator(fields(v), "feature_flag", false)
+}
+
+// Feature in beta (string 'beta')
+type CustomTypeFeatureConfigCaseBeta struct {
+	// User tags as array of strings
+	Tags []string `json:"tags,omitempty"`
+}
+
+func (CustomTypeFeatureConfigCaseBeta) isCustomTypeFeatureConfig() {}
+
+// MarshalJSON encodes CustomTypeFeatureConfigCaseBeta with feature_flag set to "beta".
+func (v CustomTypeFeatureConfigCaseBeta) MarshalJSON() ([]byte, error) {
+	type fields CustomTypeFeatureConfigCaseBeta
+	return marshalWithDiscriminator(fields(v), "feature_flag", "beta")
+}
+
+// Default case, used when feature_flag matches none of the other cases
+type CustomTypeFeatureConfigDefault struct {
+	// Feature flag that can be boolean or string
+	FeatureFlag any `json:"feature_flag"`
+}
+
+func (CustomTypeFeatureConfigDefault) isCustomTypeFeatureConfig() {}
+
+// Page context with variants based on page type
+//
+// CustomTypePageContext is implemented by:
+//   - [CustomTypePageContextCaseSearch]
+//   - [CustomTypePageContextCaseProduct]
+//   - [CustomTypePageContextCaseHome]
+//   - [CustomTypePageContextDefault]
+type CustomTypePageContext interface {
+	isCustomTypePageContext()
+}
+
+// Search page variant
+type CustomTypePageContextCaseSearch struct {
+	// Search query
+	Query string `json:"query"`
+}
+
+func (CustomTypePageContextCaseSearch) isCustomTypePageContext() {}
+
+// MarshalJSON encodes CustomTypePageContextCaseSearch with page_type set to "search".
+func (v CustomTypePageContextCaseSearch) MarshalJSON() ([]byte, error) {
+	type fields CustomTypePageContextCaseSearch
+	return marshalWithDiscriminator(fields(v), "page_type", "search")
+}
+
+// Product page variant
+type CustomTypePageContextCaseProduct struct {
+	// Product identifier
+	ProductId string `json:"product_id"`
+}
+
+func (CustomTypePageContextCaseProduct) isCustomTypePageContext() {}
+
+// MarshalJSON encodes CustomTypePageContextCaseProduct with page_type set to "product".
+func (v CustomTypePageContextCaseProduct) MarshalJSON() ([]byte, error) {
+	type fields CustomTypePageContextCaseProduct
+	return marshalWithDiscriminator(fields(v), "page_type", "product")
+}
+
+// Home page variant with no additional properties
+type CustomTypePageContextCaseHome struct{}
+
+func (CustomTypePageContextCaseHome) isCustomTypePageContext() {}
+
+// MarshalJSON encodes CustomTypePageContextCaseHome with page_type set to "home".
+func (v CustomTypePageContextCaseHome) MarshalJSON() ([]byte, error) {
+	type fields CustomTypePageContextCaseHome
+	return marshalWithDiscriminator(fields(v), "page_type", "home")
+}
+
+// Default case, used when page_type matches none of the other cases
+type CustomTypePageContextDefault struct {
+	// Additional page data
+	PageData map[string]any `json:"page_data,omitempty"`
+	// Type of page
+	PageType string `json:"page_type"`
+}
+
+func (CustomTypePageContextDefault) isCustomTypePageContext() {}
+
+// User access with variants based on active status
+//
+// CustomTypeUserAccess is implemented by:
+//   - [CustomTypeUserAccessCaseTrue]
+//   - [CustomTypeUserAccessCaseFalse]
+//   - [CustomTypeUserAccessDefault]
+type CustomTypeUserAccess interface {
+	isCustomTypeUserAccess()
+}
+
+// Active user access
+type CustomTypeUserAccessCaseTrue struct {
+	// User's email address
+	Email CustomTypeEmail `json:"email"`
+}
+
+func (CustomTypeUserAccessCaseTrue) isCustomTypeUserAccess() {}
+
+// MarshalJSON encodes CustomTypeUserAccessCaseTrue with active set to true.
+func (v CustomTypeUserAccessCaseTrue) MarshalJSON() ([]byte, error) {
+	type fields CustomTypeUserAccessCaseTrue
+	return marshalWithDiscriminator(fields(v), "active", true)
+}
+
+// Inactive user access
+type CustomTypeUserAccessCaseFalse struct {
+	// User account status
+	Status CustomTypeStatus `json:"status"`
+}
+
+func (CustomTypeUserAccessCaseFalse) isCustomTypeUserAccess() {}
+
+// MarshalJSON encodes CustomTypeUserAccessCaseFalse with active set to false.
+func (v CustomTypeUserAccessCaseFalse) MarshalJSON() ([]byte, error) {
+	type fields CustomTypeUserAccessCaseFalse
+	return marshalWithDiscriminator(fields(v), "active", false)
+}
+
+// Default case, used when active matches none of the other cases
+type CustomTypeUserAccessDefault struct {
+	// User active status
+	Active CustomTypeActive `json:"active"`
+}
+
+func (CustomTypeUserAccessDefault) isCustomTypeUserAccess() {}
+
+// Example event to demonstrate variants
+//
+// TrackEventWithVariantsProperties is implemented by:
+//   - [TrackEventWithVariantsPropertiesCaseMobile]
+//   - [TrackEventWithVariantsPropertiesCaseDesktop]
+//   - [TrackEventWithVariantsPropertiesDefault]
+type TrackEventWithVariantsProperties interface {
+	isTrackEventWithVariantsProperties()
+}
+
+// Mobile device page view
+type TrackEventWithVariantsPropertiesCaseMobile struct {
+	// Page context information
+	PageContext CustomTypePageContext `json:"page_context,omitempty"`
+	// User profile data
+	Profile CustomTypeUserProfile `json:"profile"`
+	// User tags as array of strings
+	Tags []string `json:"tags,omitempty"`
+}
+
+func (TrackEventWithVariantsPropertiesCaseMobile) isTrackEventWithVariantsProperties() {}
+
+// MarshalJSON encodes TrackEventWithVariantsPropertiesCaseMobile with device_type set to PropertyDeviceTypeMobile.
+func (v TrackEventWithVariantsPropertiesCaseMobile) MarshalJSON() ([]byte, error) {
+	type fields TrackEventWithVariantsPropertiesCaseMobile
+	return marshalWithDiscriminator(fields(v), "device_type", PropertyDeviceTypeMobile)
+}
+
+// Desktop page view
+type TrackEventWithVariantsPropertiesCaseDesktop struct {
+	// User's first name
+	FirstName string `json:"first_name"`
+	// User's last name
+	LastName *string `json:"last_name,omitempty"`
+	// Page context information
+	PageContext CustomTypePageContext `json:"page_context,omitempty"`
+	// User profile data
+	Profile CustomTypeUserProfile `json:"profile"`
+}
+
+func (TrackEventWithVariantsPropertiesCaseDesktop) isTrackEventWithVariantsProperties() {}
+
+// MarshalJSON encodes TrackEventWithVariantsPropertiesCaseDesktop with device_type set to PropertyDeviceTypeDesktop.
+func (v TrackEventWithVariantsPropertiesCaseDesktop) MarshalJSON() ([]byte, error) {
+	type fields TrackEventWithVariantsPropertiesCaseDesktop
+	return marshalWithDiscriminator(fields(v), "device_type", PropertyDeviceTypeDesktop)
+}
+
+// Default case, used when device_type matches none of the other cases
+type TrackEventWithVariantsPropertiesDefault struct {
+	// Type of device
+	DeviceType PropertyDeviceType `json:"device_type"`
+	// Page context information
+	PageContext CustomTypePageContext `json:"page_context,omitempty"`
+	// User profile data
+	Profile CustomTypeUserProfile `json:"profile"`
+	// A field with no explicit type (treated as any)
+	UntypedField any `json:"untyped_field,omitempty"`
+}
+
+func (TrackEventWithVariantsPropertiesDefault) isTrackEventWithVariantsProperties() {}
+
+// rudderTyperContext is added to the context of every message.
+var rudderTyperContext = map[string]any{
+	"platform":            "go",
+	"rudderCLIVersion":    "1.0.0",
+	"trackingPlanId":      "plan_12345",
+	"trackingPlanVersion": 13,
+}
+
+// RudderTyper sends the events of the tracking plan through an analytics
+// client, checking their properties and traits at compile time.
+type RudderTyper struct {
+	client analytics.Client
+}
+
+// New returns a RudderTyper sending events through client.
+func New(client analytics.Client) *RudderTyper {
+	return &RudderTyper{client: client}
+}
+
+// Group associates a user with a group. The traits are sent in the context of the message.
+//
+// Group association event
+func (r *RudderTyper) Group(msg analytics.Group, traits GroupTraits) error {
+	data, err := toMap(traits)
+	if err != nil {
+		return err
+	}
+	msg.Context = withRudderTyperContext(msg.Context)
+	msg.Context.Traits = data
+	return r.client.Enqueue(msg)
+}
+
+// Identify identifies a user.
+//
+// User identification event
+func (r *RudderTyper) Identify(msg analytics.Identify, traits IdentifyTraits) error {
+	data, err := toMap(traits)
+	if err != nil {
+		return err
+	}
+	msg.Context = withRudderTyperContext(msg.Context)
+	msg.Traits = data
+	return r.client.Enqueue(msg)
+}
+
+// Page tracks a page view.
+//
+// Page view event
+func (r *RudderTyper) Page(msg analytics.Page, properties PageProperties) error {
+	data, err := toMap(properties)
+	if err != nil {
+		return err
+	}
+	msg.Context = withRudderTyperContext(msg.Context)
+	msg.Properties = data
+	return r.client.Enqueue(msg)
+}
+
+// Screen tracks a screen view.
+//
+// Screen view event
+func (r *RudderTyper) Screen(msg analytics.Screen, properties ScreenProperties) error {
+	data, err := toMap(properties)
+	if err != nil {
+		return err
+	}
+	msg.Context = withRudderTyperContext(msg.Context)
+	msg.Properties = data
+	return r.client.Enqueue(msg)
+}
+
+// TrackVariableString tracks the "$Variable$String" event.
+//
+// Event with dollar signs to test string interpolation escaping
+func (r *RudderTyper) TrackVariableString(msg analytics.Track, properties TrackVariableStringProperties) error {
+	data, err := toMap(properties)
+	if err != nil {
+		return err
+	}
+	msg.Event = "$Variable$String"
+	msg.Context = withRudderTyperContext(msg.Context)
+	msg.Properties = data
+	return r.client.Enqueue(msg)
+}
+
+// TrackEventWithNameCamelCase tracks the "$eventWithNameCamelCase$!" event.
+//
+// Event with special characters that collide after sanitization
+func (r *RudderTyper) TrackEventWithNameCamelCase(msg analytics.Track, properties TrackEventWithNameCamelCaseProperties) error {
+	data, err := toMap(properties)
+	if err != nil {
+		return err
+	}
+	msg.Event = "$eventWithNameCamelCase$!"
+	msg.Context = withRudderTyperContext(msg.Context)
+	msg.Properties = data
+	return r.client.Enqueue(msg)
+}
+
+// TrackEmptyEventNoAdditionalProps tracks the "Empty Event No Additional Props" event.
+//
+// Empty event schema with additionalProperties false
+func (r *RudderTyper) TrackEmptyEventNoAdditionalProps(msg analytics.Track) error {
+	msg.Event = "Empty Event No Additional Props"
+	msg.Context = withRudderTyperContext(msg.Context)
+	return r.client.Enqueue(msg)
+}
+
+// TrackEmptyEventWithAdditionalProps tracks the "Empty Event With Additional Props" event.
+//
+// Empty event schema with additionalProperties true
+func (r *RudderTyper) TrackEmptyEventWithAdditionalProps(msg analytics.Track, properties TrackEmptyEventWithAdditionalPropsProperties) error {
+	data, err := toMap(properties)
+	if err != nil {
+		return err
+	}
+	msg.Event = "Empty Event With Additional Props"
+	msg.Context = withRudderTyperContext(msg.Context)
+	msg.Properties = data
+	return r.client.Enqueue(msg)
+}
+
+// TrackEventWithVariants tracks the "Event With Variants" event.
+//
+// Example event to demonstrate variants
+func (r *RudderTyper) TrackEventWithVariants(msg analytics.Track, properties TrackEventWithVariantsProperties) error {
+	data, err := toMap(properties)
+	if err != nil {
+		return err
+	}
+	msg.Event = "Event With Variants"
+	msg.Context = withRudderTyperContext(msg.Context)
+	msg.Properties = data
+	return r.client.Enqueue(msg)
+}
+
+// TrackProductPremiumClicked tracks the "Product \"Premium\" Clicked" event.
+//
+// Triggered when user clicks on a "premium" product /* important */
+func (r *RudderTyper) TrackProductPremiumClicked(msg analytics.Track, properties TrackProductPremiumClickedProperties) error {
+	data, err := toMap(properties)
+	if err != nil {
+		return err
+	}
+	msg.Event = "Product \"Premium\" Clicked"
+	msg.Context = withRudderTyperContext(msg.Context)
+	msg.Properties = data
+	return r.client.Enqueue(msg)
+}
+
+// TrackUserSignedUp tracks the "User Signed Up" event.
+//
+// Triggered when a user signs up
+func (r *RudderTyper) TrackUserSignedUp(msg analytics.Track, properties TrackUserSignedUpProperties) error {
+	data, err := toMap(properties)
+	if err != nil {
+		return err
+	}
+	msg.Event = "User Signed Up"
+	msg.Context = withRudderTyperContext(msg.Context)
+	msg.Properties = data
+	return r.client.Enqueue(msg)
+}
+
+// TrackEventWithNameCamelCase1 tracks the "eventWithNameCamelCase" event.
+//
+// Event with camel case name
+func (r *RudderTyper) TrackEventWithNameCamelCase1(msg analytics.Track, properties TrackEventWithNameCamelCaseProperties1) error {
+	data, err := toMap(properties)
+	if err != nil {
+		return err
+	}
+	msg.Event = "eventWithNameCamelCase"
+	msg.Context = withRudderTyperContext(msg.Context)
+	msg.Properties = data
+	return r.client.Enqueue(msg)
+}
+
+// withRudderTyperContext returns a copy of ctx with the ruddertyper context
+// added.
+func withRudderTyperContext(ctx *analytics.Context) *analytics.Context {
+	c := analytics.Context{}
+	if ctx != nil {
+		c = *ctx
+	}
+
+	extra := make(map[string]any, len(c.Extra)+1)
+	for k, v := range c.Extra {
+		extra[k] = v
+	}
+	extra["ruddertyper"] = rudderTyperContext
+	c.Extra = extra
+
+	return &c
+}
+
+// toMap converts typed properties or traits to the map sent in a message.
+// Numbers are decoded as json.Number, keeping large integers exact.
+func toMap(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("ruddertyper: encoding %T: %w", v, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var m map[string]any
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("ruddertyper: encoding %T: %w", v, err)
+	}
+	return m, nil
+}
+
+// marshalWithDiscriminator encodes v, the fields of a variant case, with the
+// discriminator set to value.
+func marshalWithDiscriminator(v any, discriminator string, value any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields[discriminator], err = json.Marshal(value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
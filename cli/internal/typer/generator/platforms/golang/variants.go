@@ -0,0 +1,139 @@
+package golang
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+)
+
+// buildVariant builds the interface of a variant type and a struct per match
+// value of its cases, plus a default case. Case structs leave the
+// discriminator out and marshal it with their match value, while the default
+// case holds it as a regular field.
+func buildVariant(
+	name string,
+	comment string,
+	baseSchema *plan.ObjectSchema,
+	variants []plan.Variant,
+	ctx *GoContext,
+	nameRegistry *core.NameRegistry,
+) (*GoVariant, error) {
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("no variants provided")
+	}
+
+	// We currently support only one variant per type
+	if len(variants) > 1 {
+		return nil, fmt.Errorf("multiple variants per type are not supported; found %d variants", len(variants))
+	}
+
+	variant := variants[0]
+	goVariant := &GoVariant{
+		Name:          name,
+		Comment:       comment,
+		Discriminator: variant.Discriminator,
+	}
+
+	for _, variantCase := range variant.Cases {
+		for _, matchValue := range variantCase.Match {
+			caseName, err := getOrRegisterVariantCaseName(name, matchValue, nameRegistry)
+			if err != nil {
+				return nil, err
+			}
+			if err := reserveFieldNames(caseName, reservedVariantMethodNames, nameRegistry); err != nil {
+				return nil, err
+			}
+
+			merged := mergeVariantSchemaProperties(baseSchema, &variantCase.Schema)
+			fields, err := buildFields(caseName, merged, variant.Discriminator, ctx, nameRegistry)
+			if err != nil {
+				return nil, err
+			}
+
+			discriminator, err := formatDiscriminatorValue(baseSchema, variant.Discriminator, matchValue, nameRegistry)
+			if err != nil {
+				return nil, err
+			}
+
+			goVariant.Cases = append(goVariant.Cases, GoVariantCase{
+				Struct: GoStruct{
+					Name:    caseName,
+					Comment: variantCase.Description,
+					Fields:  fields,
+				},
+				Discriminator: discriminator,
+			})
+		}
+	}
+
+	// Always create a default case. If DefaultSchema is explicitly provided,
+	// it adds to the base properties.
+	defaultName, err := getOrRegisterVariantCaseName(name, nil, nameRegistry)
+	if err != nil {
+		return nil, err
+	}
+	merged := mergeVariantSchemaProperties(baseSchema, variant.DefaultSchema)
+	fields, err := buildFields(defaultName, merged, "", ctx, nameRegistry)
+	if err != nil {
+		return nil, err
+	}
+	goVariant.Cases = append(goVariant.Cases, GoVariantCase{
+		Struct: GoStruct{
+			Name:    defaultName,
+			Comment: fmt.Sprintf("Default case, used when %s matches none of the other cases", variant.Discriminator),
+			Fields:  fields,
+		},
+	})
+
+	return goVariant, nil
+}
+
+// mergeVariantSchemaProperties merges the properties of the base schema of a
+// variant with those of one of its cases. Properties in both are required if
+// either requires them.
+func mergeVariantSchemaProperties(baseSchema, caseSchema *plan.ObjectSchema) map[string]plan.PropertySchema {
+	merged := make(map[string]plan.PropertySchema)
+	if baseSchema != nil {
+		maps.Copy(merged, baseSchema.Properties)
+	}
+	if caseSchema == nil {
+		return merged
+	}
+
+	for name, casePropSchema := range caseSchema.Properties {
+		if existing, exists := merged[name]; exists {
+			existing.Required = existing.Required || casePropSchema.Required
+			merged[name] = existing
+		} else {
+			merged[name] = casePropSchema
+		}
+	}
+	return merged
+}
+
+// formatDiscriminatorValue returns the Go expression of a match value: the
+// enum constant when the discriminator property has an enum holding the value,
+// a literal otherwise.
+func formatDiscriminatorValue(baseSchema *plan.ObjectSchema, discriminator string, value any, nameRegistry *core.NameRegistry) (string, error) {
+	propSchema, exists := baseSchema.Properties[discriminator]
+	if !exists || !hasEnumConfig(propSchema.Property.Config) || !enumContains(propSchema.Property.Config.Enum, value) {
+		return FormatGoLiteral(value), nil
+	}
+
+	enumName, err := getOrRegisterPropertyEnumName(&propSchema.Property, nameRegistry)
+	if err != nil {
+		return "", err
+	}
+	return getOrRegisterEnumValue(enumName, value, nameRegistry)
+}
+
+func enumContains(values []any, value any) bool {
+	for _, v := range values {
+		if enumValueKey(v) == enumValueKey(value) {
+			return true
+		}
+	}
+	return false
+}
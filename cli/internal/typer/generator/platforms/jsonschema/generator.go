@@ -0,0 +1,409 @@
+package jsonschema
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+	"github.com/rudderlabs/rudder-iac/cli/internal/ui"
+)
+
+const Platform = "jsonschema"
+
+// Generator implements core.Generator for JSON Schema. It generates a schema
+// per event rule, validating whole messages, and a schema holding the custom
+// types they reference under $defs.
+type Generator struct{}
+
+// eventSchema is the schema of the messages of an event rule.
+type eventSchema struct {
+	name     string
+	fileName string
+	rule     *plan.EventRule
+}
+
+// ========== Main Entry Point ==========
+
+// Generate produces JSON Schema documents from a tracking plan
+func (g *Generator) Generate(p *plan.TrackingPlan, options core.GenerateOptions, platformOptions any) ([]*core.File, error) {
+	defaults := g.DefaultOptions().(JSONSchemaOptions)
+	jsonSchemaOptions := defaults
+	if platformOptions != nil {
+		jsonSchemaOptions = platformOptions.(JSONSchemaOptions)
+	}
+
+	if err := jsonSchemaOptions.Validate(); err != nil {
+		return nil, err
+	}
+
+	openAPIFileName := jsonSchemaOptions.OpenAPIFileName
+	if openAPIFileName == "" {
+		openAPIFileName = defaults.OpenAPIFileName
+	}
+
+	nameRegistry := core.NewNameRegistry(JSONSchemaCollisionHandler)
+
+	customTypes, err := registerCustomTypes(p, nameRegistry)
+	if err != nil {
+		return nil, err
+	}
+	events, err := registerEventRules(p, nameRegistry)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := fmt.Sprintf("Code generated by Rudder CLI %s. DO NOT EDIT.", options.RudderCLIVersion)
+	var files []*core.File
+
+	if len(customTypes) > 0 {
+		c := &converter{refPrefix: "#/$defs/", nameRegistry: nameRegistry}
+		defs, err := c.customTypeSchemas(customTypes)
+		if err != nil {
+			return nil, err
+		}
+
+		file, err := schemaFile(defsFileName, &Schema{
+			Schema:      Draft,
+			ID:          defsFileName,
+			Comment:     comment,
+			Title:       p.Name,
+			Description: fmt.Sprintf("Custom types of the %q tracking plan", p.Name),
+			Defs:        defs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	c := &converter{refPrefix: defsFileName + "#/$defs/", nameRegistry: nameRegistry}
+	for _, event := range events {
+		schema, err := c.messageSchema(event.rule)
+		if err != nil {
+			return nil, fmt.Errorf("processing %s event %q: %w", event.rule.Event.EventType, event.rule.Event.Name, err)
+		}
+		schema.Schema = Draft
+		schema.ID = event.fileName
+		schema.Comment = comment
+
+		file, err := schemaFile(event.fileName, schema)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	if jsonSchemaOptions.OpenAPI {
+		file, err := openAPIFile(openAPIFileName, p, options.RudderCLIVersion, customTypes, events, nameRegistry)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+func schemaFile(path string, schema *Schema) (*core.File, error) {
+	content, err := marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s: %w", path, err)
+	}
+	return &core.File{Path: path, Content: content}, nil
+}
+
+// registerCustomTypes registers the names of all custom types of the plan,
+// returning them sorted by name.
+func registerCustomTypes(p *plan.TrackingPlan, nameRegistry *core.NameRegistry) ([]*plan.CustomType, error) {
+	customTypes := p.ExtractAllCustomTypes()
+	sorted := make([]*plan.CustomType, 0, len(customTypes))
+	for _, name := range slices.Sorted(maps.Keys(customTypes)) {
+		if _, err := getOrRegisterCustomTypeName(customTypes[name], nameRegistry); err != nil {
+			return nil, err
+		}
+		sorted = append(sorted, customTypes[name])
+	}
+	return sorted, nil
+}
+
+// registerEventRules registers the schema and file names of the event rules
+// of the plan, in a deterministic order.
+func registerEventRules(p *plan.TrackingPlan, nameRegistry *core.NameRegistry) ([]eventSchema, error) {
+	// Map rules by a unique composite key for deterministic processing
+	ruleMap := make(map[string]*plan.EventRule)
+	for _, rule := range p.Rules {
+		key := string(rule.Event.EventType) + ":" + rule.Event.Name + ":" + string(rule.Section)
+		ruleMap[key] = &rule
+	}
+
+	var events []eventSchema
+	for _, key := range slices.Sorted(maps.Keys(ruleMap)) {
+		rule := ruleMap[key]
+
+		if !validateEventRuleSection(rule) {
+			ui.PrintWarning(fmt.Sprintf("invalid section %q for event type %q, skipping", rule.Section, rule.Event.EventType))
+			continue
+		}
+
+		name, err := getOrRegisterEventSchemaName(rule, nameRegistry)
+		if err != nil {
+			return nil, err
+		}
+		fileName, err := getOrRegisterEventFileName(rule, nameRegistry)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, eventSchema{name: name, fileName: fileName, rule: rule})
+	}
+	return events, nil
+}
+
+func validateEventRuleSection(rule *plan.EventRule) bool {
+	switch rule.Event.EventType {
+	case plan.EventTypeTrack, plan.EventTypePage, plan.EventTypeScreen:
+		return rule.Section == plan.IdentitySectionProperties
+	case plan.EventTypeIdentify, plan.EventTypeGroup:
+		return rule.Section == plan.IdentitySectionTraits || rule.Section == plan.IdentitySectionContextTraits
+	}
+	return false
+}
+
+// ========== Schema Conversion ==========
+
+// converter converts tracking plan definitions to schemas, referencing
+// custom types by refPrefix followed by their registered name.
+type converter struct {
+	refPrefix    string
+	nameRegistry *core.NameRegistry
+}
+
+// customTypeSchemas returns the schemas of customTypes by registered name.
+func (c *converter) customTypeSchemas(customTypes []*plan.CustomType) (map[string]*Schema, error) {
+	schemas := make(map[string]*Schema, len(customTypes))
+	for _, ct := range customTypes {
+		name, err := getOrRegisterCustomTypeName(ct, c.nameRegistry)
+		if err != nil {
+			return nil, err
+		}
+		schema, err := c.customTypeSchema(ct)
+		if err != nil {
+			return nil, fmt.Errorf("processing custom type %q: %w", ct.Name, err)
+		}
+		schemas[name] = schema
+	}
+	return schemas, nil
+}
+
+// customTypeSchema returns the schema of a custom type, titled with its name
+// in the tracking plan.
+func (c *converter) customTypeSchema(ct *plan.CustomType) (*Schema, error) {
+	var schema *Schema
+	switch {
+	case ct.Type == plan.PrimitiveTypeObject && (ct.Schema != nil || len(ct.Variants) > 0):
+		objectSchema := ct.Schema
+		if objectSchema == nil {
+			objectSchema = &plan.ObjectSchema{AdditionalProperties: true}
+		}
+		var err error
+		if schema, err = c.objectSchema(objectSchema, ct.Variants); err != nil {
+			return nil, err
+		}
+	default:
+		schema = &Schema{Type: string(ct.Type)}
+		if ct.Type == plan.PrimitiveTypeArray && ct.ItemType != nil {
+			items, err := c.typesSchema([]plan.PropertyType{ct.ItemType})
+			if err != nil {
+				return nil, err
+			}
+			schema.Items = items
+		}
+	}
+
+	schema.Title = ct.Name
+	schema.Description = ct.Description
+	if ct.Config != nil && len(ct.Config.Enum) > 0 {
+		schema.Enum = ct.Config.Enum
+	}
+	return schema, nil
+}
+
+// messageSchema returns the schema of the messages of an event rule: their
+// type, the event name for track events, and the section of the rule.
+func (c *converter) messageSchema(rule *plan.EventRule) (*Schema, error) {
+	section, err := c.objectSchema(&rule.Schema, rule.Variants)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &Schema{
+		Description: rule.Event.Description,
+		Type:        string(plan.PrimitiveTypeObject),
+		Properties: map[string]*Schema{
+			"type": {Const: string(rule.Event.EventType)},
+		},
+		Required: []string{"type"},
+	}
+	if rule.Event.EventType == plan.EventTypeTrack {
+		message.Title = rule.Event.Name
+		message.Properties["event"] = &Schema{Const: rule.Event.Name}
+		message.Required = append(message.Required, "event")
+	}
+
+	// The section itself is only required when some of its keys are
+	sectionRequired := len(section.Required) > 0
+	key := string(rule.Section)
+	if rule.Section == plan.IdentitySectionContextTraits {
+		key = "context"
+		section = &Schema{
+			Type:       string(plan.PrimitiveTypeObject),
+			Properties: map[string]*Schema{"traits": section},
+		}
+		if sectionRequired {
+			section.Required = []string{"traits"}
+		}
+	}
+
+	message.Properties[key] = section
+	if sectionRequired {
+		message.Required = append(message.Required, key)
+	}
+	return message, nil
+}
+
+// objectSchema returns the schema of objects following schema and, if any,
+// its variant.
+func (c *converter) objectSchema(schema *plan.ObjectSchema, variants []plan.Variant) (*Schema, error) {
+	result, err := c.propertiesSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	result.Type = string(plan.PrimitiveTypeObject)
+
+	if len(variants) > 0 {
+		if err := c.applyVariants(result, variants); err != nil {
+			return nil, err
+		}
+		// additionalProperties doesn't see the properties of the variant
+		// cases, unevaluatedProperties does
+		if !schema.AdditionalProperties {
+			result.UnevaluatedProperties = boolPtr(false)
+		}
+		return result, nil
+	}
+
+	if !schema.AdditionalProperties {
+		result.AdditionalProperties = boolPtr(false)
+	}
+	return result, nil
+}
+
+// propertiesSchema returns a schema with the properties and required keys
+// of schema, and no type, as applied by variant cases.
+func (c *converter) propertiesSchema(schema *plan.ObjectSchema) (*Schema, error) {
+	result := &Schema{}
+	if schema == nil || len(schema.Properties) == 0 {
+		return result, nil
+	}
+
+	result.Properties = make(map[string]*Schema, len(schema.Properties))
+	for _, key := range slices.Sorted(maps.Keys(schema.Properties)) {
+		propSchema := schema.Properties[key]
+		s, err := c.propertySchema(&propSchema)
+		if err != nil {
+			return nil, fmt.Errorf("property %q: %w", key, err)
+		}
+		result.Properties[key] = s
+		if propSchema.Required {
+			result.Required = append(result.Required, key)
+		}
+	}
+	return result, nil
+}
+
+// propertySchema returns the schema of the values of a property.
+func (c *converter) propertySchema(propSchema *plan.PropertySchema) (*Schema, error) {
+	prop := &propSchema.Property
+
+	schema, err := c.typesSchema(prop.Types)
+	if err != nil {
+		return nil, err
+	}
+
+	if propSchema.Schema != nil {
+		nested, err := c.objectSchema(propSchema.Schema, nil)
+		if err != nil {
+			return nil, err
+		}
+		schema.Properties = nested.Properties
+		schema.Required = nested.Required
+		schema.AdditionalProperties = nested.AdditionalProperties
+		if schema.Type == nil && schema.AnyOf == nil {
+			schema.Type = nested.Type
+		}
+	}
+
+	if len(prop.ItemTypes) > 0 {
+		items, err := c.typesSchema(prop.ItemTypes)
+		if err != nil {
+			return nil, err
+		}
+		schema.Items = items
+	}
+
+	if prop.Config != nil && len(prop.Config.Enum) > 0 {
+		schema.Enum = prop.Config.Enum
+	}
+
+	schema.Description = prop.Description
+	return schema, nil
+}
+
+// typesSchema returns the schema of values of any of types: the empty schema
+// without types, a type keyword for primitive types, a reference for a
+// single custom type, and anyOf otherwise.
+func (c *converter) typesSchema(types []plan.PropertyType) (*Schema, error) {
+	var primitives []string
+	var refs []*Schema
+	for _, t := range types {
+		if plan.IsCustomType(t) {
+			name, err := getOrRegisterCustomTypeName(plan.AsCustomType(t), c.nameRegistry)
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, &Schema{Ref: c.refPrefix + name})
+			continue
+		}
+
+		primitive := plan.AsPrimitiveType(t)
+		if primitive == nil {
+			return nil, fmt.Errorf("unsupported property type: %T", t)
+		}
+		primitives = append(primitives, string(*primitive))
+	}
+
+	var primitive *Schema
+	switch len(primitives) {
+	case 0:
+	case 1:
+		primitive = &Schema{Type: primitives[0]}
+	default:
+		primitive = &Schema{Type: primitives}
+	}
+
+	switch {
+	case len(refs) == 0 && primitive == nil:
+		return &Schema{}, nil
+	case len(refs) == 0:
+		return primitive, nil
+	case len(refs) == 1 && primitive == nil:
+		return refs[0], nil
+	}
+
+	anyOf := refs
+	if primitive != nil {
+		anyOf = append([]*Schema{primitive}, refs...)
+	}
+	return &Schema{AnyOf: anyOf}, nil
+}
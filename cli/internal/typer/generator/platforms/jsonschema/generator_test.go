@@ -0,0 +1,178 @@
+package jsonschema_test
+
+import (
+	"embed"
+	"encoding/json"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/jsonschema"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan/testutils"
+)
+
+//go:embed testdata/*.json
+var testdata embed.FS
+
+func generateReferencePlan(t *testing.T, options jsonschema.JSONSchemaOptions) map[string]string {
+	t.Helper()
+
+	files, err := (&jsonschema.Generator{}).Generate(testutils.GetReferenceTrackingPlan(), core.GenerateOptions{
+		RudderCLIVersion: "1.0.0",
+	}, options)
+	require.NoError(t, err)
+
+	contents := make(map[string]string, len(files))
+	for _, file := range files {
+		contents[file.Path] = file.Content
+	}
+	return contents
+}
+
+func TestGenerate(t *testing.T) {
+	got := generateReferencePlan(t, jsonschema.JSONSchemaOptions{OpenAPI: true})
+
+	entries, err := testdata.ReadDir("testdata")
+	require.NoError(t, err)
+
+	want := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		content, err := testdata.ReadFile(path.Join("testdata", entry.Name()))
+		require.NoError(t, err)
+		want[entry.Name()] = string(content)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("generated files do not match testdata (-want +got):\n%s\nRun 'make typer-jsonschema-update-testdata' to update the golden files.", diff)
+	}
+}
+
+func TestGenerateWithoutOpenAPI(t *testing.T) {
+	got := generateReferencePlan(t, jsonschema.JSONSchemaOptions{})
+
+	assert.NotContains(t, got, "openapi.json")
+	assert.Contains(t, got, "defs.schema.json")
+	assert.Contains(t, got, "track-user-signed-up.schema.json")
+}
+
+func TestGenerateWithOpenAPIFileName(t *testing.T) {
+	got := generateReferencePlan(t, jsonschema.JSONSchemaOptions{OpenAPI: true, OpenAPIFileName: "events.openapi.json"})
+
+	assert.Contains(t, got, "events.openapi.json")
+	assert.NotContains(t, got, "openapi.json")
+}
+
+func TestGenerateInvalidOpenAPIFileName(t *testing.T) {
+	generator := &jsonschema.Generator{}
+
+	for _, name := range []string{"openapi", "openapi.yaml", "docs/openapi.json", "defs.schema.json"} {
+		_, err := generator.Generate(testutils.GetReferenceTrackingPlan(), core.GenerateOptions{}, jsonschema.JSONSchemaOptions{OpenAPI: true, OpenAPIFileName: name})
+		assert.Error(t, err, name)
+	}
+}
+
+// TestReferencesResolve checks that every $ref of the generated documents
+// points to a schema they define.
+func TestReferencesResolve(t *testing.T) {
+	got := generateReferencePlan(t, jsonschema.JSONSchemaOptions{OpenAPI: true})
+
+	var defs struct {
+		Defs map[string]any `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(got["defs.schema.json"]), &defs))
+
+	var openAPI struct {
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(got["openapi.json"]), &openAPI))
+
+	for fileName, content := range got {
+		var document any
+		require.NoError(t, json.Unmarshal([]byte(content), &document))
+
+		for _, ref := range collectRefs(document) {
+			switch {
+			case strings.HasPrefix(ref, "#/components/schemas/"):
+				assert.Contains(t, openAPI.Components.Schemas, strings.TrimPrefix(ref, "#/components/schemas/"), fileName)
+			case strings.HasPrefix(ref, "#/$defs/"):
+				assert.Equal(t, "defs.schema.json", fileName)
+				assert.Contains(t, defs.Defs, strings.TrimPrefix(ref, "#/$defs/"), fileName)
+			default:
+				require.True(t, strings.HasPrefix(ref, "defs.schema.json#/$defs/"), "%s: unexpected $ref %q", fileName, ref)
+				assert.Contains(t, defs.Defs, strings.TrimPrefix(ref, "defs.schema.json#/$defs/"), fileName)
+			}
+		}
+	}
+}
+
+func collectRefs(value any) []string {
+	var refs []string
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if ref, ok := item.(string); ok && key == "$ref" {
+				refs = append(refs, ref)
+				continue
+			}
+			refs = append(refs, collectRefs(item)...)
+		}
+	case []any:
+		for _, item := range v {
+			refs = append(refs, collectRefs(item)...)
+		}
+	}
+	return refs
+}
+
+func TestGenerateMessageSchema(t *testing.T) {
+	trackingPlan := &plan.TrackingPlan{
+		Name: "Test Plan",
+		Rules: []plan.EventRule{{
+			Event:   plan.Event{EventType: plan.EventTypeIdentify},
+			Section: plan.IdentitySectionContextTraits,
+			Schema: plan.ObjectSchema{
+				Properties: map[string]plan.PropertySchema{
+					"email": {Property: plan.Property{Name: "email", Types: []plan.PropertyType{plan.PrimitiveTypeString}}},
+				},
+				AdditionalProperties: true,
+			},
+		}},
+	}
+
+	files, err := (&jsonschema.Generator{}).Generate(trackingPlan, core.GenerateOptions{}, nil)
+	require.NoError(t, err)
+	require.Len(t, files, 1, "no defs file without custom types")
+	assert.Equal(t, "identify-context-traits.schema.json", files[0].Path)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal([]byte(files[0].Content), &schema))
+	assert.Equal(t, map[string]any{
+		"$schema":  "https://json-schema.org/draft/2020-12/schema",
+		"$id":      "identify-context-traits.schema.json",
+		"$comment": "Code generated by Rudder CLI . DO NOT EDIT.",
+		"type":     "object",
+		"properties": map[string]any{
+			"type": map[string]any{"const": "identify"},
+			"context": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"traits": map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"email": map[string]any{"type": "string"},
+						},
+					},
+				},
+			},
+		},
+		"required": []any{"type"},
+	}, schema)
+}
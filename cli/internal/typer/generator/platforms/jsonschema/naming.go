@@ -0,0 +1,146 @@
+package jsonschema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+)
+
+const (
+	// schemaScope holds the names of the $defs and OpenAPI components, which
+	// share a namespace in the OpenAPI document.
+	schemaScope = "schemas"
+	fileScope   = "files"
+)
+
+const (
+	// schemaFileSuffix ends the names of all generated schema files.
+	schemaFileSuffix = ".schema.json"
+	// defsFileName is the file holding the schemas of the custom types,
+	// referenced by the schemas of the event rules.
+	defsFileName = "defs" + schemaFileSuffix
+)
+
+// FormatSchemaName converts a name to a PascalCase name suitable for $defs
+// and OpenAPI components, which only allow ASCII letters, digits, ".", "-"
+// and "_". If prefix is provided, it's prepended to the formatted name.
+func FormatSchemaName(prefix, name string) string {
+	formatted := sanitize(strings.TrimSpace(name))
+	if prefix != "" {
+		formatted = prefix + " " + formatted
+	}
+	return core.ToPascalCase(formatted)
+}
+
+// FormatFileName converts a name to a lowercase, hyphen separated file name,
+// without extension.
+//
+// Examples:
+//   - "Track User Signed Up" → "track-user-signed-up"
+//   - "Track $eventWithNameCamelCase$!" → "track-event-with-name-camel-case"
+func FormatFileName(name string) string {
+	words := core.SplitIntoWords(sanitize(name))
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, "-")
+}
+
+// sanitize replaces characters other than ASCII letters and digits with
+// spaces, so they become word boundaries in case conversion.
+func sanitize(s string) string {
+	var result strings.Builder
+	result.Grow(len(s))
+
+	for _, ch := range s {
+		if ch < unicode.MaxASCII && (unicode.IsLetter(ch) || unicode.IsDigit(ch)) {
+			result.WriteRune(ch)
+		} else {
+			result.WriteRune(' ')
+		}
+	}
+
+	return result.String()
+}
+
+// getOrRegisterCustomTypeName returns the registered schema name for a custom type.
+func getOrRegisterCustomTypeName(customType *plan.CustomType, nameRegistry *core.NameRegistry) (string, error) {
+	return nameRegistry.RegisterName("customType:"+customType.Name, schemaScope, FormatSchemaName("CustomType", customType.Name))
+}
+
+// getOrRegisterEventSchemaName returns the registered schema name for the
+// messages of an event rule, e.g. TrackUserSignedUpProperties or
+// IdentifyContextTraits.
+func getOrRegisterEventSchemaName(rule *plan.EventRule, nameRegistry *core.NameRegistry) (string, error) {
+	prefix, name := eventNameParts(rule)
+	suffix, err := sectionName(rule.Section)
+	if err != nil {
+		return "", err
+	}
+	return nameRegistry.RegisterName(eventRuleKey(rule), schemaScope, FormatSchemaName(prefix, name+" "+suffix))
+}
+
+// getOrRegisterEventFileName returns the registered name of the schema file of
+// an event rule, e.g. track-user-signed-up.schema.json. Rules of the traits
+// and properties sections are named after their event alone, as events have
+// only one of them.
+func getOrRegisterEventFileName(rule *plan.EventRule, nameRegistry *core.NameRegistry) (string, error) {
+	prefix, name := eventNameParts(rule)
+	if rule.Section == plan.IdentitySectionContextTraits {
+		name += " Context Traits"
+	}
+	fileName := FormatFileName(prefix + " " + name)
+	if fileName == "" {
+		return "", fmt.Errorf("event %q has no usable file name", rule.Event.Name)
+	}
+	registered, err := nameRegistry.RegisterName(eventRuleKey(rule), fileScope, fileName)
+	if err != nil {
+		return "", err
+	}
+	return registered + schemaFileSuffix, nil
+}
+
+func eventRuleKey(rule *plan.EventRule) string {
+	return "event:" + string(rule.Event.EventType) + ":" + rule.Event.Name + ":" + string(rule.Section)
+}
+
+// eventNameParts returns the prefix naming the event type of a rule and, for
+// track events, the event name. Other event types are named by type alone.
+func eventNameParts(rule *plan.EventRule) (string, string) {
+	prefix := core.ToPascalCase(string(rule.Event.EventType))
+	if rule.Event.EventType == plan.EventTypeTrack {
+		return prefix, rule.Event.Name
+	}
+	return prefix, ""
+}
+
+func sectionName(section plan.IdentitySection) (string, error) {
+	switch section {
+	case plan.IdentitySectionProperties:
+		return "Properties", nil
+	case plan.IdentitySectionTraits:
+		return "Traits", nil
+	case plan.IdentitySectionContextTraits:
+		return "Context Traits", nil
+	default:
+		return "", fmt.Errorf("unsupported event rule section: %s", section)
+	}
+}
+
+// JSONSchemaCollisionHandler numbers colliding names, separating file names
+// from their number with a hyphen.
+func JSONSchemaCollisionHandler(name string, existingNames []string) string {
+	if strings.ToLower(name) != name {
+		return core.DefaultCollisionHandler(name, existingNames)
+	}
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", name, i)
+		if !slices.Contains(existingNames, candidate) {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/jsonschema"
+)
+
+func TestFormatSchemaName(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		input    string
+		expected string
+	}{
+		{"snake_case", "CustomType", "user_profile", "CustomTypeUserProfile"},
+		{"special characters", "Track", `Product "Premium" Clicked`, "TrackProductPremiumClicked"},
+		{"cyrillic", "CustomType", "типы_данных", "CustomType"},
+		{"prefix only", "Identify", "", "Identify"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, jsonschema.FormatSchemaName(tt.prefix, tt.input))
+		})
+	}
+}
+
+func TestFormatFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"words", "Track User Signed Up", "track-user-signed-up"},
+		{"camelCase", "Track eventWithNameCamelCase", "track-event-with-name-camel-case"},
+		{"special characters", "Track $Variable$String", "track-variable-string"},
+		{"symbols only", "!!!", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, jsonschema.FormatFileName(tt.input))
+		})
+	}
+}
+
+func TestJSONSchemaCollisionHandler(t *testing.T) {
+	assert.Equal(t, "track-event-1", jsonschema.JSONSchemaCollisionHandler("track-event", []string{"track-event"}))
+	assert.Equal(t, "TrackEventProperties1", jsonschema.JSONSchemaCollisionHandler("TrackEventProperties", []string{"TrackEventProperties"}))
+}
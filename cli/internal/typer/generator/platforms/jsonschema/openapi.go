@@ -0,0 +1,77 @@
+package jsonschema
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+)
+
+// openAPIVersion is the version of the OpenAPI document, the first whose
+// schemas are JSON Schema draft 2020-12.
+const openAPIVersion = "3.1.0"
+
+// openAPIDocument is an OpenAPI document holding schemas as components only.
+type openAPIDocument struct {
+	OpenAPI    string            `json:"openapi"`
+	Info       openAPIInfo       `json:"info"`
+	Components openAPIComponents `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// openAPIFile returns an OpenAPI document holding the schemas of the custom
+// types and event rules as components, under the same names as in $defs.
+func openAPIFile(
+	path string,
+	p *plan.TrackingPlan,
+	rudderCLIVersion string,
+	customTypes []*plan.CustomType,
+	events []eventSchema,
+	nameRegistry *core.NameRegistry,
+) (*core.File, error) {
+	c := &converter{refPrefix: "#/components/schemas/", nameRegistry: nameRegistry}
+
+	schemas, err := c.customTypeSchemas(customTypes)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		schema, err := c.messageSchema(event.rule)
+		if err != nil {
+			return nil, fmt.Errorf("processing %s event %q: %w", event.rule.Event.EventType, event.rule.Event.Name, err)
+		}
+		schemas[event.name] = schema
+	}
+
+	description := fmt.Sprintf("Code generated by Rudder CLI %s. DO NOT EDIT.", rudderCLIVersion)
+	if p.Metadata.TrackingPlanID != "" {
+		description += fmt.Sprintf("\n\nTracking plan ID: %s", p.Metadata.TrackingPlanID)
+	}
+	if p.Metadata.URL != "" {
+		description += fmt.Sprintf("\n\nSee %s", p.Metadata.URL)
+	}
+
+	content, err := marshal(openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info: openAPIInfo{
+			Title:       p.Name,
+			Description: description,
+			Version:     strconv.Itoa(p.Metadata.TrackingPlanVersion),
+		},
+		Components: openAPIComponents{Schemas: schemas},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s: %w", path, err)
+	}
+	return &core.File{Path: path, Content: content}, nil
+}
@@ -0,0 +1,44 @@
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// JSONSchemaOptions holds platform-specific options for JSON Schema
+// generation. These can be passed via --option flags in the CLI, e.g.:
+//
+//	--option openapi=true --option openapiFileName=events.openapi.json
+type JSONSchemaOptions struct {
+	OpenAPI         bool   `mapstructure:"openapi" description:"Also generate an OpenAPI 3.1 document holding every schema as a component. Defaults to false"`
+	OpenAPIFileName string `mapstructure:"openapiFileName" description:"Name of the generated OpenAPI document. Defaults to openapi.json"`
+}
+
+// DefaultOptions returns the default JSON Schema generation options.
+func (g *Generator) DefaultOptions() any {
+	return JSONSchemaOptions{
+		OpenAPI:         false,
+		OpenAPIFileName: "openapi.json",
+	}
+}
+
+// openAPIFileNameRegex validates the OpenAPI document file name: a plain file
+// name with the .json extension. Names ending in .schema.json are left to
+// the schemas of event rules.
+var openAPIFileNameRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]*\.json$`)
+
+// Validate validates JSON Schema-specific options
+func (o *JSONSchemaOptions) Validate() error {
+	if o.OpenAPIFileName == "" {
+		return nil
+	}
+	if !openAPIFileNameRegex.MatchString(o.OpenAPIFileName) || strings.HasSuffix(o.OpenAPIFileName, schemaFileSuffix) {
+		return fmt.Errorf(
+			"invalid OpenAPI file name %q: must be a file name with the .json extension not ending in %s (e.g., openapi.json)",
+			o.OpenAPIFileName,
+			schemaFileSuffix,
+		)
+	}
+	return nil
+}
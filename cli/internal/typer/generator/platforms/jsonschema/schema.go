@@ -0,0 +1,58 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Draft is the URI of the JSON Schema dialect of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema (draft 2020-12) object, holding the keywords the
+// generator writes. Keywords are written in the order of the fields.
+type Schema struct {
+	Schema  string `json:"$schema,omitempty"`
+	ID      string `json:"$id,omitempty"`
+	Comment string `json:"$comment,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Type is a single type name, or a list of them.
+	Type  any       `json:"type,omitempty"`
+	Const any       `json:"const,omitempty"`
+	Enum  []any     `json:"enum,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+
+	Items *Schema `json:"items,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+
+	If   *Schema `json:"if,omitempty"`
+	Then *Schema `json:"then,omitempty"`
+	Else *Schema `json:"else,omitempty"`
+
+	UnevaluatedProperties *bool `json:"unevaluatedProperties,omitempty"`
+
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// marshal encodes v as indented JSON, leaving characters such as < and &
+// as they are.
+func marshal(v any) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/jsonschema"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan/testutils"
+	"github.com/rudderlabs/rudder-iac/cli/internal/ui"
+)
+
+// The JSON Schema generator writes several files, so they are written to the
+// directory given as argument rather than to stdout.
+func main() {
+	ui.SetWriter(os.Stderr)
+
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "Usage: generate_reference_plan <output directory>")
+		os.Exit(1)
+	}
+	outputDir := os.Args[1]
+
+	trackingPlan := testutils.GetReferenceTrackingPlan()
+	gen := &jsonschema.Generator{}
+
+	files, err := gen.Generate(trackingPlan, core.GenerateOptions{RudderCLIVersion: "1.0.0"}, jsonschema.JSONSchemaOptions{OpenAPI: true})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Remove files of rules no longer in the reference plan
+	stale, err := filepath.Glob(filepath.Join(outputDir, "*.json"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if err := core.NewFileManager(outputDir).WriteFiles(files); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
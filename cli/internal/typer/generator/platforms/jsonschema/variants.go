@@ -0,0 +1,65 @@
+package jsonschema
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+)
+
+// applyVariants adds the cases of a variant to the schema of its base
+// object, as a chain of if/then/else conditions on the discriminator. Each
+// case applies the properties of the first case matching the discriminator,
+// and the default schema applies when none does.
+func (c *converter) applyVariants(base *Schema, variants []plan.Variant) error {
+	// We currently support only one variant per type
+	if len(variants) > 1 {
+		return fmt.Errorf("multiple variants per type are not supported; found %d variants", len(variants))
+	}
+	variant := variants[0]
+
+	var next *Schema
+	if variant.DefaultSchema != nil && len(variant.DefaultSchema.Properties) > 0 {
+		defaultSchema, err := c.propertiesSchema(variant.DefaultSchema)
+		if err != nil {
+			return fmt.Errorf("default case: %w", err)
+		}
+		next = defaultSchema
+	}
+
+	for i := len(variant.Cases) - 1; i >= 0; i-- {
+		variantCase := variant.Cases[i]
+
+		then, err := c.propertiesSchema(&variantCase.Schema)
+		if err != nil {
+			return fmt.Errorf("case %v: %w", variantCase.Match, err)
+		}
+		then.Title = variantCase.DisplayName
+		then.Description = variantCase.Description
+
+		next = &Schema{
+			If: &Schema{
+				Properties: map[string]*Schema{
+					variant.Discriminator: {Enum: variantCase.Match},
+				},
+				Required: []string{variant.Discriminator},
+			},
+			Then: then,
+			Else: next,
+		}
+	}
+
+	switch {
+	case next == nil:
+	case next.If == nil:
+		// Without cases, the default case always applies
+		if base.Properties == nil {
+			base.Properties = make(map[string]*Schema, len(next.Properties))
+		}
+		maps.Copy(base.Properties, next.Properties)
+		base.Required = append(base.Required, next.Required...)
+	default:
+		base.If, base.Then, base.Else = next.If, next.Then, next.Else
+	}
+	return nil
+}
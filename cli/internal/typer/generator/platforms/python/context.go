@@ -0,0 +1,75 @@
+package python
+
+// PythonTypeAlias → X = Y
+type PythonTypeAlias struct {
+	Name    string
+	Type    string
+	Comment string
+}
+
+// PythonField is one field of a dataclass.
+type PythonField struct {
+	Name       string // snake_case Python identifier
+	SerialName string // original key in the tracking plan
+	Type       string
+	Comment    string
+	// Optional fields default to None and are left out of the payload when
+	// None. Required fields are always sent, even when nullable.
+	Optional bool
+}
+
+// PythonClass → @dataclass class X: ...
+type PythonClass struct {
+	Name    string
+	Comment string
+	Fields  []PythonField // required fields first, as dataclasses require
+	// Discriminator and DiscriminatorValue are set for variant cases, which
+	// always send the discriminator key with their match value, a Python
+	// literal.
+	Discriminator      string
+	DiscriminatorValue string
+}
+
+// PythonVariant → X = Union[XCaseA, XCaseB, XDefault], with one dataclass per
+// case
+type PythonVariant struct {
+	Name          string
+	Comment       string
+	Discriminator string // key of the discriminator property
+	Cases         []PythonClass
+}
+
+// PythonMethod is one method of the generated RudderTyper class.
+type PythonMethod struct {
+	Name      string
+	Comment   string
+	EventName string
+	// Call is the SDK function the method calls: "track", "identify",
+	// "page", "screen" or "group".
+	Call string
+	// ArgumentName and ArgumentType describe the typed properties or traits
+	// argument. Both are empty for events without any.
+	ArgumentName string
+	ArgumentType string
+	// ContextTraits is true when the traits are sent in the context of the
+	// message rather than as its traits.
+	ContextTraits bool
+}
+
+// PythonContext is the root data object passed to the templates.
+type PythonContext struct {
+	// TypeAliases only refer to builtin and typing types, so they come first.
+	TypeAliases []PythonTypeAlias
+	Classes     []PythonClass
+	Variants    []PythonVariant
+	// DependentTypeAliases refer to other generated types. Aliases are
+	// evaluated at import time, so they follow the types they refer to.
+	DependentTypeAliases []PythonTypeAlias
+	Methods              []PythonMethod
+	EventContext         map[string]string // injected into every event, values are Python literals
+	RudderCLIVersion     string
+	TrackingPlanName     string
+	TrackingPlanID       string
+	TrackingPlanVersion  int
+	TrackingPlanURL      string
+}
@@ -0,0 +1,129 @@
+package python
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatPythonComment turns s into comments, one per line of s.
+//
+// Examples:
+//   - `User's email` → `# User's email`
+//   - "Line 1\n\nLine 2" → "# Line 1\n#\n# Line 2"
+func FormatPythonComment(s string) string {
+	lines := splitLines(s)
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = "#"
+			continue
+		}
+		lines[i] = "# " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FormatDocstring turns s into a docstring whose lines after the first are
+// indented by indent, following PEP 257.
+//
+// Examples:
+//   - `User's email` → `"""User's email"""`
+//   - "Summary\n\nDetails" → "\"\"\"Summary\n\n<indent>Details\n<indent>\"\"\""
+func FormatDocstring(s, indent string) string {
+	lines := splitLines(s)
+	if len(lines) == 0 {
+		return ""
+	}
+
+	for i, line := range lines {
+		line = escapeDocstring(line)
+		if i > 0 && line != "" {
+			line = indent + line
+		}
+		lines[i] = line
+	}
+
+	if len(lines) == 1 {
+		return `"""` + lines[0] + `"""`
+	}
+	return `"""` + strings.Join(lines, "\n") + "\n" + indent + `"""`
+}
+
+// escapeDocstring escapes backslashes and quotes that would end a docstring
+// early, including a quote ending the line right before the closing quotes.
+func escapeDocstring(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"""`, `\"\"\"`)
+	if strings.HasSuffix(s, `"`) && !isEscaped(s, len(s)-1) {
+		s = strings.TrimSuffix(s, `"`) + `\"`
+	}
+	return s
+}
+
+// isEscaped reports whether the character at i is preceded by an odd number
+// of backslashes.
+func isEscaped(s string, i int) bool {
+	backslashes := 0
+	for j := i - 1; j >= 0 && s[j] == '\\'; j-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
+}
+
+// splitLines splits s into lines without trailing whitespace, dropping
+// leading and trailing blank lines.
+func splitLines(s string) []string {
+	s = strings.TrimSpace(strings.ReplaceAll(s, "\r\n", "\n"))
+	if s == "" {
+		return nil
+	}
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return lines
+}
+
+// FormatPythonLiteral formats a value from the tracking plan as a Python
+// literal. Go string escapes are all valid in Python string literals.
+func FormatPythonLiteral(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "None"
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		if v {
+			return "True"
+		}
+		return "False"
+	case float32:
+		return formatFloat(float64(v))
+	case float64:
+		return formatFloat(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// formatFloat keeps a decimal point on whole floats so that they stay floats
+// in Python.
+func formatFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eEIN") {
+		s += ".0"
+	}
+	return s
+}
+
+// indent prefixes every non-empty line of s with prefix.
+func indent(prefix, s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
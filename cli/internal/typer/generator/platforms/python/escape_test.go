@@ -0,0 +1,58 @@
+package python_test
+
+import (
+	"testing"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/python"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatPythonComment(t *testing.T) {
+	assert.Equal(t, "# User's email", python.FormatPythonComment("User's email"))
+	assert.Equal(t, "# Line 1\n#\n# Line 2", python.FormatPythonComment("Line 1\n\nLine 2\n"))
+	assert.Equal(t, "", python.FormatPythonComment("  "))
+}
+
+func TestFormatDocstring(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"single line", "User's email", `"""User's email"""`},
+		{"multiple lines", "Summary\n\nDetails", "\"\"\"Summary\n\n    Details\n    \"\"\""},
+		{"backslash", `C:\path`, `"""C:\\path"""`},
+		{"triple quotes", `say """hi"""`, `"""say \"\"\"hi\"\"\""""`},
+		{"trailing quote", `click "premium"`, `"""click "premium\""""`},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, python.FormatDocstring(tt.input, "    "))
+		})
+	}
+}
+
+func TestFormatPythonLiteral(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected string
+	}{
+		{"string", `say "hi"`, `"say \"hi\""`},
+		{"unicode string", "已完成", `"已完成"`},
+		{"integer", 200, "200"},
+		{"float", 2.5, "2.5"},
+		{"whole float", 2.0, "2.0"},
+		{"true", true, "True"},
+		{"false", false, "False"},
+		{"nil", nil, "None"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, python.FormatPythonLiteral(tt.input))
+		})
+	}
+}
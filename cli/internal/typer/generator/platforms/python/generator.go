@@ -0,0 +1,644 @@
+package python
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+	"github.com/rudderlabs/rudder-iac/cli/internal/ui"
+)
+
+const Platform = "python"
+
+// Generator implements core.Generator for the Python platform.
+type Generator struct{}
+
+const (
+	objectType = "Dict[str, Any]"
+	anyType    = "Any"
+	noneType   = "None"
+)
+
+// pyType is a resolved Python type annotation.
+type pyType struct {
+	expr string
+	// nullable is true when None is a valid value of the type, so that
+	// optional and nullable values of it need no Optional.
+	nullable bool
+}
+
+// optional returns the type of optional or nullable values of t.
+func (t pyType) optional() pyType {
+	if t.nullable {
+		return t
+	}
+	return pyType{expr: "Optional[" + t.expr + "]", nullable: true}
+}
+
+// ========== Main Entry Point ==========
+
+// Generate produces a Python module from a tracking plan
+func (g *Generator) Generate(p *plan.TrackingPlan, options core.GenerateOptions, platformOptions any) ([]*core.File, error) {
+	defaults := g.DefaultOptions().(PythonOptions)
+	pythonOptions := defaults
+	if platformOptions != nil {
+		pythonOptions = platformOptions.(PythonOptions)
+	}
+
+	if err := pythonOptions.Validate(); err != nil {
+		return nil, err
+	}
+
+	outputFileName := pythonOptions.OutputFileName
+	if outputFileName == "" {
+		outputFileName = defaults.OutputFileName
+	}
+
+	ctx := &PythonContext{
+		RudderCLIVersion:    options.RudderCLIVersion,
+		TrackingPlanName:    p.Name,
+		TrackingPlanID:      p.Metadata.TrackingPlanID,
+		TrackingPlanVersion: p.Metadata.TrackingPlanVersion,
+		TrackingPlanURL:     p.Metadata.URL,
+		EventContext:        formatEventContext(p.Metadata, options.RudderCLIVersion),
+	}
+
+	nameRegistry := core.NewNameRegistry(PythonCollisionHandler)
+	for _, name := range reservedTypeNames {
+		if _, err := nameRegistry.RegisterName("reserved:"+name, globalTypeScope, name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Custom types and property enums are processed first so that event rules
+	// referring to them see their final names.
+	if err := processCustomTypes(p, ctx, nameRegistry); err != nil {
+		return nil, err
+	}
+	if err := processPropertyEnums(p, ctx, nameRegistry); err != nil {
+		return nil, err
+	}
+	if err := processEventRules(p, ctx, nameRegistry); err != nil {
+		return nil, err
+	}
+	orderTypeAliases(ctx)
+
+	file, err := GenerateFile(outputFileName, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*core.File{file}, nil
+}
+
+func formatEventContext(meta plan.PlanMetadata, rudderCLIVersion string) map[string]string {
+	return map[string]string{
+		"platform":            FormatPythonLiteral(Platform),
+		"rudderCLIVersion":    FormatPythonLiteral(rudderCLIVersion),
+		"trackingPlanId":      FormatPythonLiteral(meta.TrackingPlanID),
+		"trackingPlanVersion": fmt.Sprintf("%d", meta.TrackingPlanVersion),
+	}
+}
+
+// ========== Type Mapping ==========
+
+func mapPrimitiveType(t plan.PrimitiveType) (pyType, error) {
+	switch t {
+	case plan.PrimitiveTypeString:
+		return pyType{expr: "str"}, nil
+	case plan.PrimitiveTypeInteger:
+		return pyType{expr: "int"}, nil
+	case plan.PrimitiveTypeNumber:
+		return pyType{expr: "float"}, nil
+	case plan.PrimitiveTypeBoolean:
+		return pyType{expr: "bool"}, nil
+	case plan.PrimitiveTypeNull:
+		return pyType{expr: noneType, nullable: true}, nil
+	case plan.PrimitiveTypeArray:
+		return pyType{expr: "List[" + anyType + "]"}, nil
+	case plan.PrimitiveTypeObject:
+		return pyType{expr: objectType}, nil
+	default:
+		return pyType{}, fmt.Errorf("unsupported primitive type: %s", t)
+	}
+}
+
+func hasEnumConfig(config *plan.PropertyConfig) bool {
+	return config != nil && len(config.Enum) > 0
+}
+
+func isEmptySchema(schema *plan.ObjectSchema) bool {
+	return schema == nil || len(schema.Properties) == 0
+}
+
+// enumType returns the type of enum values. PEP 586 only allows strings,
+// integers, booleans and None in Literal types, so float values widen the
+// type to float.
+//
+// Examples:
+//   - ["a", "b"] → Literal["a", "b"]
+//   - [1, true, 2.5] → Union[Literal[1, True], float]
+//   - [1.5, 2.5] → float
+func enumType(values []any) pyType {
+	var literals []string
+	hasFloat, hasNone := false, false
+	for _, value := range values {
+		switch value.(type) {
+		case float32, float64:
+			hasFloat = true
+			continue
+		case nil:
+			hasNone = true
+		}
+		literals = append(literals, FormatPythonLiteral(value))
+	}
+
+	switch {
+	case len(literals) == 0:
+		return pyType{expr: "float"}
+	case hasFloat:
+		return pyType{expr: "Union[Literal[" + strings.Join(literals, ", ") + "], float]", nullable: hasNone}
+	default:
+		return pyType{expr: "Literal[" + strings.Join(literals, ", ") + "]", nullable: hasNone}
+	}
+}
+
+// splitNull separates the null type from the other types.
+func splitNull(types []plan.PropertyType) ([]plan.PropertyType, bool) {
+	var nonNull []plan.PropertyType
+	hasNull := false
+	for _, t := range types {
+		if pt := plan.AsPrimitiveType(t); pt != nil && *pt == plan.PrimitiveTypeNull {
+			hasNull = true
+			continue
+		}
+		nonNull = append(nonNull, t)
+	}
+	return nonNull, hasNull
+}
+
+// resolveTypes returns the Python type of a value having one of types: a
+// Union of them, Optional when null is one of them. Values without types
+// are Any.
+func resolveTypes(types []plan.PropertyType, itemTypes []plan.PropertyType, nameRegistry *core.NameRegistry) (pyType, error) {
+	nonNull, hasNull := splitNull(types)
+	if len(nonNull) == 0 {
+		if hasNull {
+			return pyType{expr: noneType, nullable: true}, nil
+		}
+		return pyType{expr: anyType, nullable: true}, nil
+	}
+
+	var resolved []pyType
+	for _, t := range nonNull {
+		r, err := resolveType(t, itemTypes, nameRegistry)
+		if err != nil {
+			return pyType{}, err
+		}
+		if !slices.ContainsFunc(resolved, func(other pyType) bool { return other.expr == r.expr }) {
+			resolved = append(resolved, r)
+		}
+	}
+
+	t := resolved[0]
+	if len(resolved) > 1 {
+		exprs := make([]string, len(resolved))
+		for i, r := range resolved {
+			exprs[i] = r.expr
+		}
+		t = pyType{expr: "Union[" + strings.Join(exprs, ", ") + "]"}
+	}
+	if hasNull {
+		return t.optional(), nil
+	}
+	return t, nil
+}
+
+// resolveType returns the Python type of a single primitive or custom type.
+// itemTypes narrow arrays.
+func resolveType(t plan.PropertyType, itemTypes []plan.PropertyType, nameRegistry *core.NameRegistry) (pyType, error) {
+	if plan.IsCustomType(t) {
+		ct := plan.AsCustomType(t)
+		name, err := getOrRegisterCustomTypeName(ct, nameRegistry)
+		if err != nil {
+			return pyType{}, err
+		}
+		return pyType{expr: name, nullable: isNullableCustomType(ct)}, nil
+	}
+
+	primitive := plan.AsPrimitiveType(t)
+	if primitive == nil {
+		return pyType{expr: anyType, nullable: true}, nil
+	}
+	if *primitive == plan.PrimitiveTypeArray && len(itemTypes) > 0 {
+		item, err := resolveTypes(itemTypes, nil, nameRegistry)
+		if err != nil {
+			return pyType{}, err
+		}
+		return pyType{expr: "List[" + item.expr + "]"}, nil
+	}
+	return mapPrimitiveType(*primitive)
+}
+
+func isNullableCustomType(ct *plan.CustomType) bool {
+	switch {
+	case len(ct.Variants) > 0:
+		return false
+	case hasEnumConfig(ct.Config):
+		return enumType(ct.Config.Enum).nullable
+	}
+	return ct.Type == plan.PrimitiveTypeNull
+}
+
+// resolvePropertyType returns the Python type of a property held by a field
+// of className. Properties with their own enum resolve to its Literal type,
+// and inline object schemas to a dataclass named after the field.
+func resolvePropertyType(className string, propSchema *plan.PropertySchema, ctx *PythonContext, nameRegistry *core.NameRegistry) (pyType, error) {
+	prop := &propSchema.Property
+
+	if hasEnumConfig(prop.Config) {
+		name, err := getOrRegisterPropertyEnumName(prop, nameRegistry)
+		if err != nil {
+			return pyType{}, err
+		}
+		t := pyType{expr: name, nullable: enumType(prop.Config.Enum).nullable}
+		if _, hasNull := splitNull(prop.Types); hasNull {
+			return t.optional(), nil
+		}
+		return t, nil
+	}
+
+	if propSchema.Schema != nil {
+		if isEmptySchema(propSchema.Schema) {
+			return pyType{expr: objectType}, nil
+		}
+
+		name, err := getOrRegisterNestedClassName(className, prop.Name, nameRegistry)
+		if err != nil {
+			return pyType{}, err
+		}
+		nested, err := buildClass(name, prop.Description, propSchema.Schema, ctx, nameRegistry)
+		if err != nil {
+			return pyType{}, err
+		}
+		ctx.Classes = append(ctx.Classes, *nested)
+		return pyType{expr: name}, nil
+	}
+
+	return resolveTypes(prop.Types, prop.ItemTypes, nameRegistry)
+}
+
+// ========== Class Builders ==========
+
+// buildClass builds a dataclass with a field per property of schema. Classes
+// of inline object schemas are added to ctx as they are found.
+func buildClass(name, comment string, schema *plan.ObjectSchema, ctx *PythonContext, nameRegistry *core.NameRegistry) (*PythonClass, error) {
+	fields, err := buildFields(name, schema.Properties, "", ctx, nameRegistry)
+	if err != nil {
+		return nil, err
+	}
+	return &PythonClass{
+		Name:    name,
+		Comment: comment,
+		Fields:  fields,
+	}, nil
+}
+
+// buildFields builds the fields of className for properties, leaving out the
+// property named skip. Dataclass fields with a default must follow those
+// without, so required fields come first, each group sorted by key. Optional
+// fields default to None.
+func buildFields(className string, properties map[string]plan.PropertySchema, skip string, ctx *PythonContext, nameRegistry *core.NameRegistry) ([]PythonField, error) {
+	if err := reserveFieldNames(className, reservedClassMemberNames, nameRegistry); err != nil {
+		return nil, err
+	}
+
+	var required, optional []PythonField
+	for _, key := range slices.Sorted(maps.Keys(properties)) {
+		if key == skip {
+			continue
+		}
+
+		propSchema := properties[key]
+		fieldName, err := getOrRegisterFieldName(className, key, nameRegistry)
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := resolvePropertyType(className, &propSchema, ctx, nameRegistry)
+		if err != nil {
+			return nil, fmt.Errorf("resolving type of property %q of %s: %w", key, className, err)
+		}
+
+		field := PythonField{
+			Name:       fieldName,
+			SerialName: key,
+			Type:       t.expr,
+			Comment:    propSchema.Property.Description,
+		}
+		if propSchema.Required {
+			required = append(required, field)
+			continue
+		}
+		field.Type = t.optional().expr
+		field.Optional = true
+		optional = append(optional, field)
+	}
+
+	return append(required, optional...), nil
+}
+
+// ========== Custom Type Processing ==========
+
+// processCustomTypes declares every custom type of the plan:
+//   - variants → Union of a dataclass per case
+//   - enum → Literal alias
+//   - object → dataclass, or a dict alias without properties
+//   - array → alias of a list of the item type
+//   - primitive → alias of the primitive type
+func processCustomTypes(p *plan.TrackingPlan, ctx *PythonContext, nameRegistry *core.NameRegistry) error {
+	customTypes := p.ExtractAllCustomTypes()
+	for _, name := range slices.Sorted(maps.Keys(customTypes)) {
+		if err := processCustomType(customTypes[name], ctx, nameRegistry); err != nil {
+			return fmt.Errorf("processing custom type %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func processCustomType(ct *plan.CustomType, ctx *PythonContext, nameRegistry *core.NameRegistry) error {
+	typeName, err := getOrRegisterCustomTypeName(ct, nameRegistry)
+	if err != nil {
+		return err
+	}
+
+	if len(ct.Variants) > 0 {
+		baseSchema := ct.Schema
+		if baseSchema == nil {
+			baseSchema = &plan.ObjectSchema{}
+		}
+		variant, err := buildVariant(typeName, ct.Description, baseSchema, ct.Variants, ctx, nameRegistry)
+		if err != nil {
+			return err
+		}
+		ctx.Variants = append(ctx.Variants, *variant)
+		return nil
+	}
+
+	var aliased pyType
+	switch {
+	case hasEnumConfig(ct.Config):
+		aliased = enumType(ct.Config.Enum)
+	case ct.Type == plan.PrimitiveTypeObject:
+		if !isEmptySchema(ct.Schema) {
+			c, err := buildClass(typeName, ct.Description, ct.Schema, ctx, nameRegistry)
+			if err != nil {
+				return err
+			}
+			ctx.Classes = append(ctx.Classes, *c)
+			return nil
+		}
+		aliased = pyType{expr: objectType}
+	case ct.Type == plan.PrimitiveTypeArray:
+		var itemTypes []plan.PropertyType
+		if ct.ItemType != nil {
+			itemTypes = []plan.PropertyType{ct.ItemType}
+		}
+		aliased, err = resolveType(plan.PrimitiveTypeArray, itemTypes, nameRegistry)
+	default:
+		aliased, err = mapPrimitiveType(ct.Type)
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx.TypeAliases = append(ctx.TypeAliases, PythonTypeAlias{
+		Name:    typeName,
+		Type:    aliased.expr,
+		Comment: ct.Description,
+	})
+	return nil
+}
+
+// ========== Enum Processing ==========
+
+// processPropertyEnums declares a Literal alias for every property of the
+// plan with an enum config.
+func processPropertyEnums(p *plan.TrackingPlan, ctx *PythonContext, nameRegistry *core.NameRegistry) error {
+	properties := p.ExtractAllProperties()
+	for _, name := range slices.Sorted(maps.Keys(properties)) {
+		prop := properties[name]
+		if !hasEnumConfig(prop.Config) {
+			continue
+		}
+
+		typeName, err := getOrRegisterPropertyEnumName(prop, nameRegistry)
+		if err != nil {
+			return fmt.Errorf("processing enum of property %q: %w", name, err)
+		}
+		ctx.TypeAliases = append(ctx.TypeAliases, PythonTypeAlias{
+			Name:    typeName,
+			Type:    enumType(prop.Config.Enum).expr,
+			Comment: prop.Description,
+		})
+	}
+	return nil
+}
+
+// ========== Type Alias Ordering ==========
+
+// identifierRegex matches the identifiers of a type expression.
+var identifierRegex = regexp.MustCompile(`[\pL\pN_]+`)
+
+// orderTypeAliases moves aliases referring to generated types after the
+// classes, ordered so that every alias follows the aliases it refers to.
+// Unlike annotations, the right-hand side of an alias is evaluated when the
+// module is imported.
+func orderTypeAliases(ctx *PythonContext) {
+	aliases := make(map[string]PythonTypeAlias, len(ctx.TypeAliases))
+	generated := make(map[string]bool)
+	for _, alias := range ctx.TypeAliases {
+		aliases[alias.Name] = alias
+		generated[alias.Name] = true
+	}
+	for _, c := range ctx.Classes {
+		generated[c.Name] = true
+	}
+	for _, v := range ctx.Variants {
+		generated[v.Name] = true
+		for _, c := range v.Cases {
+			generated[c.Name] = true
+		}
+	}
+
+	refs := func(alias PythonTypeAlias) []string {
+		var names []string
+		for _, id := range identifierRegex.FindAllString(alias.Type, -1) {
+			if generated[id] {
+				names = append(names, id)
+			}
+		}
+		return names
+	}
+
+	var independent, dependent []PythonTypeAlias
+	visited := make(map[string]bool)
+	var visit func(alias PythonTypeAlias)
+	visit = func(alias PythonTypeAlias) {
+		if visited[alias.Name] {
+			return
+		}
+		visited[alias.Name] = true
+		for _, ref := range refs(alias) {
+			if dep, ok := aliases[ref]; ok {
+				visit(dep)
+			}
+		}
+		dependent = append(dependent, alias)
+	}
+
+	for _, alias := range ctx.TypeAliases {
+		if len(refs(alias)) == 0 {
+			independent = append(independent, alias)
+			visited[alias.Name] = true
+		}
+	}
+	for _, alias := range ctx.TypeAliases {
+		visit(alias)
+	}
+
+	ctx.TypeAliases = independent
+	ctx.DependentTypeAliases = dependent
+}
+
+// ========== Event Rule Processing ==========
+
+func processEventRules(p *plan.TrackingPlan, ctx *PythonContext, nameRegistry *core.NameRegistry) error {
+	// Map rules by a unique composite key for deterministic processing
+	ruleMap := make(map[string]*plan.EventRule)
+	for _, rule := range p.Rules {
+		key := string(rule.Event.EventType) + ":" + rule.Event.Name + ":" + string(rule.Section)
+		ruleMap[key] = &rule
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(ruleMap)) {
+		rule := ruleMap[key]
+
+		if !validateEventRuleSection(rule) {
+			ui.PrintWarning(fmt.Sprintf("invalid section %q for event type %q, skipping", rule.Section, rule.Event.EventType))
+			continue
+		}
+
+		if err := processEventRule(rule, ctx, nameRegistry); err != nil {
+			return fmt.Errorf("processing %s event %q: %w", rule.Event.EventType, rule.Event.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func processEventRule(rule *plan.EventRule, ctx *PythonContext, nameRegistry *core.NameRegistry) error {
+	typeName, err := getOrRegisterEventTypeName(rule, nameRegistry)
+	if err != nil {
+		return err
+	}
+
+	argumentType := typeName
+	switch {
+	case len(rule.Variants) > 0:
+		variant, err := buildVariant(typeName, rule.Event.Description, &rule.Schema, rule.Variants, ctx, nameRegistry)
+		if err != nil {
+			return err
+		}
+		ctx.Variants = append(ctx.Variants, *variant)
+
+	case isEmptySchema(&rule.Schema):
+		// Events without properties take none, unless any are allowed
+		if !rule.Schema.AdditionalProperties {
+			argumentType = ""
+			break
+		}
+		ctx.TypeAliases = append(ctx.TypeAliases, PythonTypeAlias{
+			Name:    typeName,
+			Type:    objectType,
+			Comment: rule.Event.Description,
+		})
+
+	default:
+		c, err := buildClass(typeName, rule.Event.Description, &rule.Schema, ctx, nameRegistry)
+		if err != nil {
+			return err
+		}
+		ctx.Classes = append(ctx.Classes, *c)
+	}
+
+	method, err := buildMethod(rule, argumentType, nameRegistry)
+	if err != nil {
+		return err
+	}
+	ctx.Methods = append(ctx.Methods, *method)
+	return nil
+}
+
+func validateEventRuleSection(rule *plan.EventRule) bool {
+	switch rule.Event.EventType {
+	case plan.EventTypeTrack, plan.EventTypePage, plan.EventTypeScreen:
+		return rule.Section == plan.IdentitySectionProperties
+	case plan.EventTypeIdentify, plan.EventTypeGroup:
+		return rule.Section == plan.IdentitySectionTraits || rule.Section == plan.IdentitySectionContextTraits
+	}
+	return false
+}
+
+// ========== RudderTyper Method Builder ==========
+
+func buildMethod(rule *plan.EventRule, argumentType string, nameRegistry *core.NameRegistry) (*PythonMethod, error) {
+	name, err := getOrRegisterEventMethodName(rule, nameRegistry)
+	if err != nil {
+		return nil, err
+	}
+
+	method := &PythonMethod{
+		Name:         name,
+		EventName:    rule.Event.Name,
+		Call:         string(rule.Event.EventType),
+		ArgumentType: argumentType,
+	}
+
+	var summary string
+	switch rule.Event.EventType {
+	case plan.EventTypeTrack:
+		summary = fmt.Sprintf(`Tracks the "%s" event.`, rule.Event.Name)
+	case plan.EventTypeIdentify:
+		summary = "Identifies a user."
+	case plan.EventTypePage:
+		summary = "Tracks a page view."
+	case plan.EventTypeScreen:
+		summary = "Tracks a screen view."
+	case plan.EventTypeGroup:
+		summary = "Associates a user with a group."
+	}
+
+	if argumentType != "" {
+		switch rule.Section {
+		case plan.IdentitySectionProperties:
+			method.ArgumentName = "properties"
+		case plan.IdentitySectionTraits:
+			method.ArgumentName = "traits"
+		case plan.IdentitySectionContextTraits:
+			method.ArgumentName = "traits"
+			method.ContextTraits = true
+			summary += " The traits are sent in the context of the message."
+		}
+	}
+
+	method.Comment = summary
+	if description := strings.TrimSpace(rule.Event.Description); description != "" {
+		method.Comment += "\n\n" + description
+	}
+	return method, nil
+}
@@ -0,0 +1,84 @@
+package python_test
+
+import (
+	_ "embed"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/python"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan/testutils"
+)
+
+//go:embed testdata/ruddertyper.py
+var rudderTyperPy string
+
+func TestGenerate(t *testing.T) {
+	trackingPlan := testutils.GetReferenceTrackingPlan()
+
+	generator := &python.Generator{}
+	files, err := generator.Generate(trackingPlan, core.GenerateOptions{
+		RudderCLIVersion: "1.0.0",
+	}, nil)
+
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "ruddertyper.py", files[0].Path)
+
+	if diff := cmp.Diff(rudderTyperPy, files[0].Content); diff != "" {
+		t.Errorf("generated content does not match testdata/ruddertyper.py (-want +got):\n%s\nRun 'make typer-python-update-testdata' to update the golden file.", diff)
+	}
+}
+
+func TestGenerateWithOptions(t *testing.T) {
+	trackingPlan := testutils.GetReferenceTrackingPlan()
+
+	generator := &python.Generator{}
+	files, err := generator.Generate(trackingPlan, core.GenerateOptions{
+		RudderCLIVersion: "1.0.0",
+	}, python.PythonOptions{
+		OutputFileName: "analytics_events.py",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "analytics_events.py", files[0].Path)
+}
+
+func TestGenerateInvalidOutputFileName(t *testing.T) {
+	generator := &python.Generator{}
+
+	for _, name := range []string{"events", "my-events.py", "1events.py", "class.py"} {
+		_, err := generator.Generate(testutils.GetReferenceTrackingPlan(), core.GenerateOptions{}, python.PythonOptions{OutputFileName: name})
+		assert.Error(t, err, name)
+	}
+}
+
+func TestGenerateEscapesKeywords(t *testing.T) {
+	trackingPlan := &plan.TrackingPlan{
+		Name: "Test Plan",
+		Rules: []plan.EventRule{{
+			Event:   plan.Event{EventType: plan.EventTypeTrack, Name: "Keywords"},
+			Section: plan.IdentitySectionProperties,
+			Schema: plan.ObjectSchema{
+				Properties: map[string]plan.PropertySchema{
+					"class":   {Property: plan.Property{Name: "class", Types: []plan.PropertyType{plan.PrimitiveTypeString}}, Required: true},
+					"to_dict": {Property: plan.Property{Name: "to_dict", Types: []plan.PropertyType{plan.PrimitiveTypeString}}, Required: true},
+				},
+			},
+		}},
+	}
+
+	files, err := (&python.Generator{}).Generate(trackingPlan, core.GenerateOptions{}, nil)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	assert.Contains(t, files[0].Content, "    class_: str\n")
+	assert.Contains(t, files[0].Content, `data["class"] = _serialize(self.class_)`)
+	assert.Contains(t, files[0].Content, "    to_dict_1: str\n")
+	assert.Contains(t, files[0].Content, `data["to_dict"] = _serialize(self.to_dict_1)`)
+}
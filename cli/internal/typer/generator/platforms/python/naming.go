@@ -0,0 +1,246 @@
+package python
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+)
+
+const (
+	globalTypeScope = "types"
+	methodScope     = "methods"
+)
+
+const (
+	// classNamePrefix and fieldNamePrefix are prepended to names starting
+	// with a digit, which cannot start a Python identifier.
+	classNamePrefix = "Type"
+	fieldNamePrefix = "field_"
+)
+
+// FormatClassName converts a name to a CapWords identifier suitable for
+// Python class and type alias names, following PEP 8. If prefix is provided,
+// it's prepended to the formatted name.
+func FormatClassName(prefix, name string) string {
+	formatted := sanitizeForIdentifier(strings.TrimSpace(name))
+	if prefix != "" {
+		formatted = prefix + " " + formatted
+	}
+	return escapeIdentifier(core.ToPascalCase(formatted), classNamePrefix)
+}
+
+// FormatFieldName converts a name to a snake_case identifier suitable for
+// dataclass fields, following PEP 8. Returns empty string if the name holds
+// no letters or digits.
+func FormatFieldName(name string) string {
+	return escapeIdentifier(toSnakeCase(sanitizeForIdentifier(strings.TrimSpace(name))), fieldNamePrefix)
+}
+
+// FormatMethodName converts a name to a snake_case identifier suitable for
+// Python method names. If prefix is provided, it's prepended to the formatted
+// name.
+func FormatMethodName(prefix, name string) string {
+	formatted := sanitizeForIdentifier(strings.TrimSpace(name))
+	if prefix != "" {
+		formatted = prefix + " " + formatted
+	}
+	return escapeIdentifier(toSnakeCase(formatted), fieldNamePrefix)
+}
+
+// FormatCaseSuffix converts a match value of a variant to the CapWords suffix
+// naming the dataclass of its case. Returns empty string for values without
+// letters or digits, such as emoji.
+//
+// Examples:
+//   - "smartTV" → "SmartTv"
+//   - 2.5 → "2_5"
+//   - -1 → "Minus1"
+//   - true → "True"
+func FormatCaseSuffix(value any) string {
+	switch v := value.(type) {
+	case string:
+		return core.ToPascalCase(sanitizeForIdentifier(strings.TrimSpace(v)))
+	case bool:
+		return core.ToPascalCase(strconv.FormatBool(v))
+	default:
+		formatted := fmt.Sprintf("%v", v)
+		formatted = strings.ReplaceAll(formatted, "-", "Minus")
+		formatted = strings.ReplaceAll(formatted, "+", "")
+		return strings.ReplaceAll(formatted, ".", "_")
+	}
+}
+
+// toSnakeCase joins the lower-cased words of s with underscores.
+func toSnakeCase(s string) string {
+	words := core.SplitIntoWords(s)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, "_")
+}
+
+// sanitizeForIdentifier replaces characters that are invalid in Python
+// identifiers with spaces, so they become word boundaries in case conversion.
+func sanitizeForIdentifier(s string) string {
+	var result strings.Builder
+	result.Grow(len(s))
+
+	for _, ch := range s {
+		if unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '_' || ch == '-' || ch == ' ' || ch == '.' {
+			result.WriteRune(ch)
+		} else {
+			result.WriteRune(' ')
+		}
+	}
+
+	return result.String()
+}
+
+// escapeIdentifier prefixes names starting with a digit and appends an
+// underscore to keywords, as PEP 8 recommends.
+func escapeIdentifier(name, digitPrefix string) string {
+	if name == "" {
+		return ""
+	}
+	if unicode.IsDigit([]rune(name)[0]) {
+		name = digitPrefix + name
+	}
+	if PythonKeywords[name] {
+		name += "_"
+	}
+	return name
+}
+
+// enumValueKey identifies an enum value, keeping values of different types
+// such as 1 and "1" apart.
+func enumValueKey(value any) string {
+	return fmt.Sprintf("%T:%v", value, value)
+}
+
+// getOrRegisterCustomTypeName returns the registered type name for a custom type.
+func getOrRegisterCustomTypeName(customType *plan.CustomType, nameRegistry *core.NameRegistry) (string, error) {
+	typeName := FormatClassName("CustomType", customType.Name)
+	return nameRegistry.RegisterName("customtype:"+customType.Name, globalTypeScope, typeName)
+}
+
+// getOrRegisterPropertyEnumName returns the registered type name for the
+// Literal type of a property enum.
+func getOrRegisterPropertyEnumName(property *plan.Property, nameRegistry *core.NameRegistry) (string, error) {
+	typeName := FormatClassName("Property", property.Name)
+	return nameRegistry.RegisterName("propertyenum:"+property.Name, globalTypeScope, typeName)
+}
+
+// getOrRegisterNestedClassName returns the registered class name for an
+// inline object schema of a property, named after the class holding it.
+func getOrRegisterNestedClassName(parentName, propName string, nameRegistry *core.NameRegistry) (string, error) {
+	typeName := parentName + core.ToPascalCase(sanitizeForIdentifier(propName))
+	if typeName == parentName {
+		typeName = parentName + "Object"
+	}
+	return nameRegistry.RegisterName("nested:"+parentName+":"+propName, globalTypeScope, typeName)
+}
+
+// getOrRegisterFieldName registers a field name within a class scope and
+// returns a collision-free identifier, so that keys which sanitize to the same
+// identifier get unique names.
+func getOrRegisterFieldName(className, propName string, nameRegistry *core.NameRegistry) (string, error) {
+	scope := fmt.Sprintf("class:%s:fields", className)
+	formatted := FormatFieldName(propName)
+	if formatted == "" {
+		formatted = "field"
+	}
+	return nameRegistry.RegisterName(propName, scope, formatted)
+}
+
+// reserveFieldNames keeps names from being used as fields of a class.
+func reserveFieldNames(className string, names []string, nameRegistry *core.NameRegistry) error {
+	scope := fmt.Sprintf("class:%s:fields", className)
+	for _, name := range names {
+		if _, err := nameRegistry.RegisterName("reserved:"+name, scope, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getOrRegisterVariantCaseName returns the registered class name of the
+// dataclass for one match value of a variant. A nil value names the default
+// case.
+func getOrRegisterVariantCaseName(variantName string, matchValue any, nameRegistry *core.NameRegistry) (string, error) {
+	if matchValue == nil {
+		return nameRegistry.RegisterName("variant:"+variantName+":default", globalTypeScope, variantName+"Default")
+	}
+	typeName := variantName + "Case" + FormatCaseSuffix(matchValue)
+	return nameRegistry.RegisterName("variant:"+variantName+":"+enumValueKey(matchValue), globalTypeScope, typeName)
+}
+
+// getOrRegisterEventTypeName returns the registered type name of the
+// properties or traits of an event rule.
+func getOrRegisterEventTypeName(rule *plan.EventRule, nameRegistry *core.NameRegistry) (string, error) {
+	prefix, baseName, err := eventNameParts(rule)
+	if err != nil {
+		return "", err
+	}
+
+	var suffix string
+	switch rule.Section {
+	case plan.IdentitySectionProperties:
+		suffix = "Properties"
+	case plan.IdentitySectionTraits, plan.IdentitySectionContextTraits:
+		suffix = "Traits"
+	default:
+		return "", fmt.Errorf("unsupported event rule section: %s", rule.Section)
+	}
+
+	typeName := FormatClassName(prefix, baseName+" "+suffix)
+	key := "event:" + string(rule.Event.EventType) + ":" + rule.Event.Name + ":" + string(rule.Section)
+	return nameRegistry.RegisterName(key, globalTypeScope, typeName)
+}
+
+// getOrRegisterEventMethodName returns the registered method name for an
+// event. Events with names that sanitize to the same method name are assigned
+// unique names (e.g., track_event_name, track_event_name_1).
+func getOrRegisterEventMethodName(rule *plan.EventRule, nameRegistry *core.NameRegistry) (string, error) {
+	prefix, name, err := eventNameParts(rule)
+	if err != nil {
+		return "", err
+	}
+
+	// Identify and group rules for traits and context traits are sent by
+	// separate methods, as they take different arguments.
+	key := "method:" + string(rule.Event.EventType) + ":" + rule.Event.Name + ":" + string(rule.Section)
+	return nameRegistry.RegisterName(key, methodScope, FormatMethodName(prefix, name))
+}
+
+// eventNameParts returns the prefix naming the event type of a rule and, for
+// track events, the event name. Other event types are named by type alone.
+func eventNameParts(rule *plan.EventRule) (string, string, error) {
+	switch rule.Event.EventType {
+	case plan.EventTypeTrack:
+		return "Track", rule.Event.Name, nil
+	case plan.EventTypeIdentify:
+		return "Identify", "", nil
+	case plan.EventTypePage:
+		return "Page", "", nil
+	case plan.EventTypeScreen:
+		return "Screen", "", nil
+	case plan.EventTypeGroup:
+		return "Group", "", nil
+	default:
+		return "", "", fmt.Errorf("unsupported event type: %s", rule.Event.EventType)
+	}
+}
+
+// PythonCollisionHandler provides a Python-specific collision handler for the
+// NameRegistry. snake_case names get an underscore before their number, as in
+// track_event_name_1, while CapWords names get the number appended.
+func PythonCollisionHandler(name string, existingNames []string) string {
+	if name != strings.ToLower(name) {
+		return core.DefaultCollisionHandler(name, existingNames)
+	}
+	return core.DefaultCollisionHandler(name+"_", existingNames)
+}
@@ -0,0 +1,81 @@
+package python_test
+
+import (
+	"testing"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/platforms/python"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatClassName(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		input    string
+		expected string
+	}{
+		{"snake_case", "", "user_id", "UserId"},
+		{"kebab-case", "", "email-address", "EmailAddress"},
+		{"camelCase", "", "firstName", "FirstName"},
+		{"special characters", "", `Product "Premium" Clicked`, "ProductPremiumClicked"},
+		{"leading number", "", "123user", "Type123user"},
+		{"keyword", "", "none", "None_"},
+		{"empty string", "", "", ""},
+		{"with prefix", "Track", "User Signed Up", "TrackUserSignedUp"},
+		{"cyrillic", "CustomType", "типы_данных", "CustomTypeТипыДанных"},
+		{"emoji", "", "🎯", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, python.FormatClassName(tt.prefix, tt.input))
+		})
+	}
+}
+
+func TestFormatFieldName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"snake_case", "first_name", "first_name"},
+		{"camelCase", "ipAddress", "ip_address"},
+		{"acronym", "smartTV", "smart_tv"},
+		{"space separated", "First Name", "first_name"},
+		{"leading number", "1st_place", "field_1st_place"},
+		{"keyword", "class", "class_"},
+		{"chinese", "用户名", "用户名"},
+		{"symbols only", "!!!", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, python.FormatFieldName(tt.input))
+		})
+	}
+}
+
+func TestFormatMethodName(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		input    string
+		expected string
+	}{
+		{"track", "Track", "User Signed Up", "track_user_signed_up"},
+		{"special characters", "Track", "$eventWithNameCamelCase$!", "track_event_with_name_camel_case"},
+		{"prefix only", "Identify", "", "identify"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, python.FormatMethodName(tt.prefix, tt.input))
+		})
+	}
+}
+
+func TestPythonCollisionHandler(t *testing.T) {
+	assert.Equal(t, "track_event_1", python.PythonCollisionHandler("track_event", []string{"track_event"}))
+	assert.Equal(t, "TrackEventProperties1", python.PythonCollisionHandler("TrackEventProperties", []string{"TrackEventProperties"}))
+}
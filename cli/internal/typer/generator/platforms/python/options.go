@@ -0,0 +1,40 @@
+package python
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PythonOptions holds platform-specific options for Python code generation.
+// These can be passed via --option flags in the CLI, e.g.:
+//
+//	--option outputFileName=tracking.py
+type PythonOptions struct {
+	OutputFileName string `mapstructure:"outputFileName" description:"Name of the generated Python module file. Defaults to ruddertyper.py"`
+}
+
+// DefaultOptions returns the default Python generation options.
+func (g *Generator) DefaultOptions() any {
+	return PythonOptions{
+		OutputFileName: "ruddertyper.py",
+	}
+}
+
+// moduleFileNameRegex validates module file names: an ASCII identifier with
+// the .py extension, so that the module can be imported.
+var moduleFileNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*\.py$`)
+
+// Validate validates Python-specific options
+func (o *PythonOptions) Validate() error {
+	if o.OutputFileName == "" {
+		return nil
+	}
+	if !moduleFileNameRegex.MatchString(o.OutputFileName) || PythonKeywords[strings.TrimSuffix(o.OutputFileName, ".py")] {
+		return fmt.Errorf(
+			"invalid output file name %q: must be an importable module name with the .py extension (e.g., analytics_events.py)",
+			o.OutputFileName,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package python
+
+// Source: https://docs.python.org/3/reference/lexical_analysis.html#keywords
+//
+// PythonKeywords cannot be used as identifiers. Generated names matching one
+// get a trailing underscore, as PEP 8 recommends.
+var PythonKeywords = map[string]bool{
+	"False":    true,
+	"None":     true,
+	"True":     true,
+	"and":      true,
+	"as":       true,
+	"assert":   true,
+	"async":    true,
+	"await":    true,
+	"break":    true,
+	"class":    true,
+	"continue": true,
+	"def":      true,
+	"del":      true,
+	"elif":     true,
+	"else":     true,
+	"except":   true,
+	"finally":  true,
+	"for":      true,
+	"from":     true,
+	"global":   true,
+	"if":       true,
+	"import":   true,
+	"in":       true,
+	"is":       true,
+	"lambda":   true,
+	"nonlocal": true,
+	"not":      true,
+	"or":       true,
+	"pass":     true,
+	"raise":    true,
+	"return":   true,
+	"try":      true,
+	"while":    true,
+	"with":     true,
+	"yield":    true,
+}
+
+// reservedTypeNames are declared or imported by the generated module itself,
+// so types generated from the tracking plan must not use them.
+var reservedTypeNames = []string{
+	"RudderTyper",
+	"Any",
+	"Dict",
+	"List",
+	"Literal",
+	"Optional",
+	"Union",
+}
+
+// reservedClassMemberNames are methods declared on every generated dataclass,
+// so none of its fields may use them.
+var reservedClassMemberNames = []string{
+	"to_dict",
+}
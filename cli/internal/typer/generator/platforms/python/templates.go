@@ -0,0 +1,109 @@
+package python
+
+import (
+	"bytes"
+	_ "embed"
+	"strings"
+	"text/template"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+)
+
+//go:embed templates/disclaimer.tmpl
+var disclaimerTemplate string
+
+//go:embed templates/header.tmpl
+var headerTemplate string
+
+//go:embed templates/typealias.tmpl
+var typealiasTemplate string
+
+//go:embed templates/class.tmpl
+var classTemplate string
+
+//go:embed templates/variant.tmpl
+var variantTemplate string
+
+//go:embed templates/ruddertyper.tmpl
+var ruddertyperTemplate string
+
+// topLevelSeparator is the two blank lines PEP 8 puts between top-level
+// definitions.
+const topLevelSeparator = "\n\n\n"
+
+// GenerateFile renders ctx. Python has no standard formatter to run on the
+// result, so each top-level definition is rendered on its own and joined with
+// the blank lines PEP 8 asks for.
+func GenerateFile(path string, ctx *PythonContext) (*core.File, error) {
+	funcMap := template.FuncMap{
+		"comment":   FormatPythonComment,
+		"docstring": FormatDocstring,
+		"docEscape": escapeDocstring,
+		"literal":   FormatPythonLiteral,
+		"indent":    indent,
+		"oneLine": func(s string) string {
+			return strings.Join(strings.Fields(s), " ")
+		},
+	}
+
+	tmpl := template.New("python").Funcs(funcMap)
+	for name, src := range map[string]string{
+		"disclaimer.tmpl":  disclaimerTemplate,
+		"header.tmpl":      headerTemplate,
+		"typealias.tmpl":   typealiasTemplate,
+		"class.tmpl":       classTemplate,
+		"variant.tmpl":     variantTemplate,
+		"ruddertyper.tmpl": ruddertyperTemplate,
+	} {
+		if _, err := tmpl.New(name).Parse(src); err != nil {
+			return nil, err
+		}
+	}
+
+	var blocks []string
+	render := func(name string, data any) error {
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+			return err
+		}
+		blocks = append(blocks, strings.TrimSpace(buf.String()))
+		return nil
+	}
+
+	if err := render("header.tmpl", ctx); err != nil {
+		return nil, err
+	}
+	for _, alias := range ctx.TypeAliases {
+		if err := render("typealias.tmpl", alias); err != nil {
+			return nil, err
+		}
+	}
+	for _, class := range ctx.Classes {
+		if err := render("class.tmpl", class); err != nil {
+			return nil, err
+		}
+	}
+	for _, variant := range ctx.Variants {
+		for _, class := range variant.Cases {
+			if err := render("class.tmpl", class); err != nil {
+				return nil, err
+			}
+		}
+		if err := render("variant.tmpl", variant); err != nil {
+			return nil, err
+		}
+	}
+	for _, alias := range ctx.DependentTypeAliases {
+		if err := render("typealias.tmpl", alias); err != nil {
+			return nil, err
+		}
+	}
+	if err := render("ruddertyper.tmpl", ctx); err != nil {
+		return nil, err
+	}
+
+	return &core.File{
+		Path:    path,
+		Content: strings.Join(blocks, topLevelSeparator) + "\n",
+	}, nil
+}
@@ -0,0 +1,103 @@
+package python
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/generator/core"
+	"github.com/rudderlabs/rudder-iac/cli/internal/typer/plan"
+)
+
+// buildVariant builds a dataclass per match value of the cases of a variant
+// type, plus a default case, joined by a Union alias. Case dataclasses leave
+// the discriminator out and send it with their match value, while the
+// default case holds it as a regular field.
+func buildVariant(
+	name string,
+	comment string,
+	baseSchema *plan.ObjectSchema,
+	variants []plan.Variant,
+	ctx *PythonContext,
+	nameRegistry *core.NameRegistry,
+) (*PythonVariant, error) {
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("no variants provided")
+	}
+
+	// We currently support only one variant per type
+	if len(variants) > 1 {
+		return nil, fmt.Errorf("multiple variants per type are not supported; found %d variants", len(variants))
+	}
+
+	variant := variants[0]
+	pythonVariant := &PythonVariant{
+		Name:          name,
+		Comment:       comment,
+		Discriminator: variant.Discriminator,
+	}
+
+	for _, variantCase := range variant.Cases {
+		for _, matchValue := range variantCase.Match {
+			caseName, err := getOrRegisterVariantCaseName(name, matchValue, nameRegistry)
+			if err != nil {
+				return nil, err
+			}
+
+			merged := mergeVariantSchemaProperties(baseSchema, &variantCase.Schema)
+			fields, err := buildFields(caseName, merged, variant.Discriminator, ctx, nameRegistry)
+			if err != nil {
+				return nil, err
+			}
+
+			pythonVariant.Cases = append(pythonVariant.Cases, PythonClass{
+				Name:               caseName,
+				Comment:            variantCase.Description,
+				Fields:             fields,
+				Discriminator:      variant.Discriminator,
+				DiscriminatorValue: FormatPythonLiteral(matchValue),
+			})
+		}
+	}
+
+	// Always create a default case. If DefaultSchema is explicitly provided,
+	// it adds to the base properties.
+	defaultName, err := getOrRegisterVariantCaseName(name, nil, nameRegistry)
+	if err != nil {
+		return nil, err
+	}
+	merged := mergeVariantSchemaProperties(baseSchema, variant.DefaultSchema)
+	fields, err := buildFields(defaultName, merged, "", ctx, nameRegistry)
+	if err != nil {
+		return nil, err
+	}
+	pythonVariant.Cases = append(pythonVariant.Cases, PythonClass{
+		Name:    defaultName,
+		Comment: fmt.Sprintf("Default case, used when %s matches none of the other cases", variant.Discriminator),
+		Fields:  fields,
+	})
+
+	return pythonVariant, nil
+}
+
+// mergeVariantSchemaProperties merges the properties of the base schema of a
+// variant with those of one of its cases. Properties in both are required if
+// either requires them.
+func mergeVariantSchemaProperties(baseSchema, caseSchema *plan.ObjectSchema) map[string]plan.PropertySchema {
+	merged := make(map[string]plan.PropertySchema)
+	if baseSchema != nil {
+		maps.Copy(merged, baseSchema.Properties)
+	}
+	if caseSchema == nil {
+		return merged
+	}
+
+	for name, casePropSchema := range caseSchema.Properties {
+		if existing, exists := merged[name]; exists {
+			existing.Required = existing.Required || casePropSchema.Required
+			merged[name] = existing
+		} else {
+			merged[name] = casePropSchema
+		}
+	}
+	return merged
+}
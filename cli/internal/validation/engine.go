@@ -108,7 +108,7 @@ func (e *validationEngine) runProjectValidationRules(rawSpecs map[string]*specs.
 	for path, rawSpec := range rawSpecs {
 		contexts[path] = &rules.ValidationContext{
 			FilePath: path,
-			FileName: filepath.Base(path),
+			FileName: filepath.Base(specs.DocumentFile(path)),
 			Spec:     rawSpec.Parsed().Spec,
 			Kind:     rawSpec.Parsed().Kind,
 			Version:  rawSpec.Parsed().Version,
@@ -146,7 +146,7 @@ func (e *validationEngine) runProjectValidationRules(rawSpecs map[string]*specs.
 					RuleID:   rule.ID(),
 					Severity: rule.Severity(),
 					Message:  result.Message,
-					File:     filePath,
+					File:     specs.DocumentFile(filePath),
 					Position: *position,
 					Examples: rule.Examples(),
 				})
@@ -219,7 +219,7 @@ func (e *validationEngine) runValidationRules(
 	for _, rule := range toValidateAgainst {
 		results := rule.Validate(&rules.ValidationContext{
 			FilePath:    path,
-			FileName:    filepath.Base(path),
+			FileName:    filepath.Base(specs.DocumentFile(path)),
 			Spec:        rawSpec.Parsed().Spec,
 			Kind:        rawSpec.Parsed().Kind,
 			Version:     rawSpec.Parsed().Version,
@@ -246,7 +246,7 @@ func (e *validationEngine) runValidationRules(
 				RuleID:   rule.ID(),
 				Severity: rule.Severity(),
 				Message:  result.Message,
-				File:     path,
+				File:     specs.DocumentFile(path),
 				Position: *position,
 				Examples: rule.Examples(),
 			})
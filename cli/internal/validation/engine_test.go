@@ -179,6 +179,41 @@ func TestValidationEngine_ValidateSyntax(t *testing.T) {
 			assert.Equal(t, "test.yaml", receivedFileName)
 		})
 
+		t.Run("diagnostics of multi-document files point into the file", func(t *testing.T) {
+			t.Parallel()
+
+			rawSpecs := specs.SplitDocuments("/path/to/test.yaml", []byte(validEventsYAML+"---\n"+validPropertiesYAML))
+			for _, rawSpec := range rawSpecs {
+				_, err := rawSpec.Parse()
+				require.NoError(t, err)
+			}
+
+			var receivedFileName string
+			rule := &mockRule{
+				id:        "group-rule",
+				severity:  rules.Error,
+				appliesTo: []rules.MatchPattern{rules.MatchKind("properties")},
+				validateFn: func(ctx *rules.ValidationContext) []rules.ValidationResult {
+					receivedFileName = ctx.FileName
+					return []rules.ValidationResult{{Reference: "/spec/group", Message: "bad group"}}
+				},
+			}
+
+			registry := rules.NewRegistry(engineTestSupportedPatterns)
+			require.NoError(t, registry.RegisterSyntactic(rule))
+
+			engine, err := NewValidationEngine(registry, nil)
+			require.NoError(t, err)
+
+			diagnostics, err := engine.ValidateSyntax(context.Background(), rawSpecs)
+			require.NoError(t, err)
+
+			require.Len(t, diagnostics, 1)
+			assert.Equal(t, "/path/to/test.yaml", diagnostics[0].File)
+			assert.Equal(t, 16, diagnostics[0].Position.Line)
+			assert.Equal(t, "test.yaml", receivedFileName)
+		})
+
 		t.Run("multiple specs with multiple errors each", func(t *testing.T) {
 			t.Parallel()
 
@@ -24,7 +24,20 @@ type PathIndexer interface {
 
 // PathIndex stores mapping from JSON Pointer paths to file positions
 type PathIndex struct {
-	positions map[string]Position
+	positions  map[string]Position
+	lineOffset int
+}
+
+// Option configures a PathIndex.
+type Option func(*PathIndex)
+
+// WithLineOffset shifts every recorded line by offset, for content that
+// starts partway into a file such as the second document of a multi-document
+// YAML file.
+func WithLineOffset(offset int) Option {
+	return func(pi *PathIndex) {
+		pi.lineOffset = offset
+	}
 }
 
 var StartingPosition = Position{
@@ -41,7 +54,7 @@ type Position struct {
 }
 
 // NewPathIndex creates a new PathIndex by parsing YAML content and building the position map
-func NewPathIndexer(content []byte) (PathIndexer, error) {
+func NewPathIndexer(content []byte, opts ...Option) (PathIndexer, error) {
 	var node yaml.Node
 	if err := yaml.Unmarshal(content, &node); err != nil {
 		return nil, fmt.Errorf("parsing YAML content: %w", err)
@@ -50,10 +63,15 @@ func NewPathIndexer(content []byte) (PathIndexer, error) {
 	pi := &PathIndex{
 		positions: make(map[string]Position),
 	}
+	for _, opt := range opts {
+		opt(pi)
+	}
 
-	// Record root position "/" at line 1, column 1
+	// Record root position "/" at line 1, column 1 (of the content)
 	// This serves as the ultimate fallback for NearestPosition
-	pi.positions["/"] = StartingPosition
+	root := StartingPosition
+	root.Line += pi.lineOffset
+	pi.positions["/"] = root
 
 	// Walk the YAML tree and build the index
 	pi.walkNode(&node, "", nil)
@@ -194,7 +212,7 @@ func (pi *PathIndex) recordPosition(
 	}
 
 	pos := Position{
-		Line:     line,
+		Line:     line + pi.lineOffset,
 		Column:   column,
 		LineText: lineText,
 	}
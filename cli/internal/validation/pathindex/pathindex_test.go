@@ -441,3 +441,14 @@ spec:
 		}
 	})
 }
+
+func TestPathIndexer_WithLineOffset(t *testing.T) {
+	pi, err := NewPathIndexer([]byte("version: rudder/v1\nspec:\n  name: test\n"), WithLineOffset(10))
+	require.NoError(t, err)
+
+	pos, err := pi.PositionLookup("/spec/name")
+	require.NoError(t, err)
+	assert.Equal(t, Position{Line: 13, Column: 3, LineText: "name: test"}, *pos)
+
+	assert.Equal(t, 11, pi.NearestPosition("/missing").Line)
+}
@@ -0,0 +1,98 @@
+package renderer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+)
+
+// JSONRenderer renders diagnostics as a single JSON document, for tooling
+// that consumes validation results programmatically.
+type JSONRenderer struct {
+	w        io.Writer
+	rendered bool
+}
+
+func NewJSONRenderer(w io.Writer) Renderer {
+	return &JSONRenderer{
+		w: w,
+	}
+}
+
+type jsonReport struct {
+	Diagnostics []jsonDiagnostic `json:"diagnostics"`
+	Summary     jsonSummary      `json:"summary"`
+	// Error is set when validation stopped before producing diagnostics,
+	// e.g. because a spec could not be loaded.
+	Error string `json:"error,omitempty"`
+}
+
+type jsonDiagnostic struct {
+	RuleID   string `json:"ruleId"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+}
+
+type jsonSummary struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+}
+
+// Render writes every diagnostic along with an error/warning summary. An
+// empty diagnostics list still produces a document, so consumers can tell a
+// clean run apart from no output at all.
+func (r *JSONRenderer) Render(diagnostics validation.Diagnostics) error {
+	report := jsonReport{
+		Diagnostics: make([]jsonDiagnostic, 0, len(diagnostics)),
+	}
+
+	for _, d := range diagnostics {
+		switch d.Severity {
+		case rules.Error:
+			report.Summary.Errors += 1
+
+		case rules.Warning:
+			report.Summary.Warnings += 1
+		}
+
+		report.Diagnostics = append(report.Diagnostics, jsonDiagnostic{
+			RuleID:   d.RuleID,
+			Severity: d.Severity.String(),
+			Message:  d.Message,
+			File:     d.File,
+			Line:     d.Position.Line,
+			Column:   d.Position.Column,
+		})
+	}
+
+	return r.write(report)
+}
+
+func (r *JSONRenderer) Rendered() bool {
+	return r.rendered
+}
+
+// RenderError writes a report with no diagnostics and err in its error field.
+func (r *JSONRenderer) RenderError(err error) error {
+	return r.write(jsonReport{
+		Diagnostics: []jsonDiagnostic{},
+		Error:       err.Error(),
+	})
+}
+
+func (r *JSONRenderer) write(report jsonReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling diagnostics: %w", err)
+	}
+
+	r.rendered = true
+	_, err = fmt.Fprintln(r.w, string(data))
+	return err
+}
@@ -8,3 +8,15 @@ type Renderer interface {
 	// Render outputs diagnostics in the renderer's specific format.
 	Render(diagnostics validation.Diagnostics) error
 }
+
+// ErrorRenderer is implemented by renderers of machine-readable formats. They
+// must write a well-formed document even when validation stops with an error
+// before any diagnostics are rendered, so that consumers can always parse
+// the output.
+type ErrorRenderer interface {
+	Renderer
+	// Rendered reports whether a document has been written.
+	Rendered() bool
+	// RenderError writes a document reporting err in place of diagnostics.
+	RenderError(err error) error
+}
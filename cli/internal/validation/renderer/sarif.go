@@ -0,0 +1,311 @@
+package renderer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/docs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	sarifToolName = "rudder-cli"
+	sarifToolURI  = "https://github.com/rudderlabs/rudder-iac"
+)
+
+// SARIFRenderer renders diagnostics as a SARIF 2.1.0 log, the format GitHub
+// code scanning ingests to annotate pull requests. Rules referenced by the
+// diagnostics are described in the tool driver using the documented rule
+// catalog, so their authored examples show up as rule help.
+type SARIFRenderer struct {
+	w           io.Writer
+	toolVersion string
+	rules       map[string]docs.DocumentedRule
+	rendered    bool
+}
+
+// NewSARIFRenderer returns a renderer writing to w. catalog supplies the
+// description and help text for each rule; diagnostics from rules missing in
+// the catalog are still reported, with a rule entry carrying only their ID.
+func NewSARIFRenderer(w io.Writer, toolVersion string, catalog []docs.DocumentedRule) Renderer {
+	byID := make(map[string]docs.DocumentedRule, len(catalog))
+	for _, rule := range catalog {
+		byID[rule.RuleID] = rule
+	}
+
+	return &SARIFRenderer{
+		w:           w,
+		toolVersion: toolVersion,
+		rules:       byID,
+	}
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations,omitempty"`
+	Results     []sarifResult     `json:"results"`
+}
+
+// sarifInvocation reports a run that failed before producing results.
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level   string       `json:"level"`
+	Message sarifMessage `json:"message"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string              `json:"id"`
+	ShortDescription     *sarifMessage       `json:"shortDescription,omitempty"`
+	Help                 *sarifHelp          `json:"help,omitempty"`
+	DefaultConfiguration *sarifConfiguration `json:"defaultConfiguration,omitempty"`
+}
+
+type sarifHelp struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func (r *SARIFRenderer) Render(diagnostics validation.Diagnostics) error {
+	run := r.newRun(len(diagnostics))
+
+	ruleIndex := make(map[string]int)
+	for _, d := range diagnostics {
+		idx, ok := ruleIndex[d.RuleID]
+		if !ok {
+			idx = len(run.Tool.Driver.Rules)
+			ruleIndex[d.RuleID] = idx
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, r.sarifRule(d.RuleID))
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    d.RuleID,
+			RuleIndex: idx,
+			Level:     sarifLevel(d.Severity),
+			Message:   sarifMessage{Text: d.Message},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: artifactURI(d.File)},
+				Region:           sarifRegionFor(d),
+			}}},
+		})
+	}
+
+	return r.write(run)
+}
+
+func (r *SARIFRenderer) Rendered() bool {
+	return r.rendered
+}
+
+// RenderError writes a log whose run has no results and an unsuccessful
+// invocation carrying err as an error notification.
+func (r *SARIFRenderer) RenderError(err error) error {
+	run := r.newRun(0)
+	run.Invocations = []sarifInvocation{{
+		ExecutionSuccessful: false,
+		ToolExecutionNotifications: []sarifNotification{{
+			Level:   "error",
+			Message: sarifMessage{Text: err.Error()},
+		}},
+	}}
+	return r.write(run)
+}
+
+func (r *SARIFRenderer) newRun(results int) sarifRun {
+	return sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           sarifToolName,
+			Version:        r.toolVersion,
+			InformationURI: sarifToolURI,
+			Rules:          make([]sarifRule, 0),
+		}},
+		Results: make([]sarifResult, 0, results),
+	}
+}
+
+func (r *SARIFRenderer) write(run sarifRun) error {
+	data, err := json.MarshalIndent(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling sarif log: %w", err)
+	}
+
+	r.rendered = true
+	_, err = fmt.Fprintln(r.w, string(data))
+	return err
+}
+
+func (r *SARIFRenderer) sarifRule(ruleID string) sarifRule {
+	rule, ok := r.rules[ruleID]
+	if !ok {
+		return sarifRule{ID: ruleID}
+	}
+
+	level := "warning"
+	switch rule.Severity {
+	case rules.Error.String():
+		level = "error"
+	case rules.Info.String():
+		level = "note"
+	}
+
+	text, markdown := ruleHelp(rule)
+	return sarifRule{
+		ID:                   ruleID,
+		ShortDescription:     &sarifMessage{Text: rule.Description},
+		Help:                 &sarifHelp{Text: text, Markdown: markdown},
+		DefaultConfiguration: &sarifConfiguration{Level: level},
+	}
+}
+
+// ruleHelp renders the rule description and its authored examples as the
+// plain text and markdown help SARIF viewers show next to a result.
+func ruleHelp(rule docs.DocumentedRule) (string, string) {
+	var text, md strings.Builder
+
+	text.WriteString(rule.Description)
+	md.WriteString(rule.Description)
+
+	appliesTo := make([]string, 0, len(rule.AppliesTo))
+	for _, p := range rule.AppliesTo {
+		appliesTo = append(appliesTo, fmt.Sprintf("%s (%s)", p.Kind, p.Version))
+	}
+	if len(appliesTo) > 0 {
+		fmt.Fprintf(&text, "\n\nApplies to: %s", strings.Join(appliesTo, ", "))
+		fmt.Fprintf(&md, "\n\n**Applies to:** `%s`", strings.Join(appliesTo, "`, `"))
+	}
+
+	for _, mb := range rule.MatchBehavior {
+		for _, ex := range mb.Invalid {
+			writeExample(&text, &md, "Invalid", ex.Title, ex.Description, ex.Files)
+		}
+		for _, ex := range mb.Valid {
+			writeExample(&text, &md, "Valid", ex.Title, ex.Description, ex.Files)
+		}
+	}
+
+	return text.String(), md.String()
+}
+
+func writeExample(text, md *strings.Builder, label, title, description string, files map[string]string) {
+	fmt.Fprintf(text, "\n\n%s: %s", label, title)
+	fmt.Fprintf(md, "\n\n#### %s: %s", label, title)
+	if description = strings.TrimSpace(description); description != "" {
+		fmt.Fprintf(text, "\n%s", description)
+		fmt.Fprintf(md, "\n\n%s", description)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(md, "\n\n`%s`\n```yaml\n%s\n```", name, strings.TrimRight(files[name], "\n"))
+	}
+}
+
+func sarifLevel(s rules.Severity) string {
+	switch s {
+	case rules.Error:
+		return "error"
+	case rules.Warning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifRegionFor omits the region when no position could be resolved, since
+// SARIF lines and columns are 1-based.
+func sarifRegionFor(d validation.Diagnostic) *sarifRegion {
+	if d.Position.Line < 1 {
+		return nil
+	}
+	region := &sarifRegion{StartLine: d.Position.Line}
+	if d.Position.Column > 0 {
+		region.StartColumn = d.Position.Column
+	}
+	return region
+}
+
+// artifactURI returns file as a forward-slash URI. Code scanning resolves
+// URIs against the repository root, so absolute paths are made relative to
+// the working directory where possible.
+func artifactURI(file string) string {
+	if filepath.IsAbs(file) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+		}
+	}
+	return strings.TrimPrefix(filepath.ToSlash(file), "./")
+}
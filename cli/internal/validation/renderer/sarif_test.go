@@ -0,0 +1,128 @@
+package renderer
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/docs"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/pathindex"
+	"github.com/rudderlabs/rudder-iac/cli/internal/validation/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSARIFRenderer_Render(t *testing.T) {
+	catalog := []docs.DocumentedRule{
+		{
+			RuleID:      "project/version-valid",
+			Phase:       "syntactic",
+			Severity:    "error",
+			Description: "version must be supported",
+			AppliesTo:   []docs.MatchPatternDoc{{Kind: "*", Version: "*"}},
+			MatchBehavior: []docs.MatchBehaviorEntry{{
+				AppliesTo: []docs.MatchPatternDoc{{Kind: "*", Version: "*"}},
+				Invalid: []docs.InvalidExample{{
+					ExampleID: "bad-version",
+					Title:     "Unknown version",
+					Files:     map[string]string{"spec.yaml": "version: rudder/v9\n"},
+				}},
+			}},
+		},
+	}
+
+	diagnostics := validation.Diagnostics{
+		{
+			RuleID:   "project/version-valid",
+			Severity: rules.Error,
+			Message:  "version must be one of the supported versions",
+			File:     "./specs/malformed.yaml",
+			Position: pathindex.Position{Line: 1, Column: 1},
+		},
+		{
+			RuleID:   "project/spec-syntax-parse-valid",
+			Severity: rules.Warning,
+			Message:  "failed to parse spec",
+			File:     "specs/broken.yaml",
+		},
+		{
+			RuleID:   "project/version-valid",
+			Severity: rules.Error,
+			Message:  "version must be one of the supported versions",
+			File:     "specs/other.yaml",
+			Position: pathindex.Position{Line: 2, Column: 3},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewSARIFRenderer(&buf, "1.2.3", catalog).Render(diagnostics))
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": [{
+			"tool": {"driver": {
+				"name": "rudder-cli",
+				"version": "1.2.3",
+				"informationUri": "https://github.com/rudderlabs/rudder-iac",
+				"rules": [
+					{
+						"id": "project/version-valid",
+						"shortDescription": {"text": "version must be supported"},
+						"help": {
+							"text": "version must be supported\n\nApplies to: * (*)\n\nInvalid: Unknown version",
+							"markdown": "version must be supported\n\n**Applies to:** `+"`* (*)`"+`\n\n#### Invalid: Unknown version\n\n`+"`spec.yaml`"+`\n`+"```yaml\\nversion: rudder/v9\\n```"+`"
+						},
+						"defaultConfiguration": {"level": "error"}
+					},
+					{"id": "project/spec-syntax-parse-valid"}
+				]
+			}},
+			"results": [
+				{
+					"ruleId": "project/version-valid", "ruleIndex": 0, "level": "error",
+					"message": {"text": "version must be one of the supported versions"},
+					"locations": [{"physicalLocation": {"artifactLocation": {"uri": "specs/malformed.yaml"}, "region": {"startLine": 1, "startColumn": 1}}}]
+				},
+				{
+					"ruleId": "project/spec-syntax-parse-valid", "ruleIndex": 1, "level": "warning",
+					"message": {"text": "failed to parse spec"},
+					"locations": [{"physicalLocation": {"artifactLocation": {"uri": "specs/broken.yaml"}}}]
+				},
+				{
+					"ruleId": "project/version-valid", "ruleIndex": 0, "level": "error",
+					"message": {"text": "version must be one of the supported versions"},
+					"locations": [{"physicalLocation": {"artifactLocation": {"uri": "specs/other.yaml"}, "region": {"startLine": 2, "startColumn": 3}}}]
+				}
+			]
+		}]
+	}`, buf.String())
+}
+
+func TestSARIFRenderer_RenderError(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewSARIFRenderer(&buf, "1.2.3", nil).(ErrorRenderer)
+	assert.False(t, r.Rendered())
+
+	require.NoError(t, r.RenderError(errors.New("cycle detected in resource graph")))
+
+	assert.True(t, r.Rendered())
+	assert.JSONEq(t, `{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": [{
+			"tool": {"driver": {
+				"name": "rudder-cli",
+				"version": "1.2.3",
+				"informationUri": "https://github.com/rudderlabs/rudder-iac",
+				"rules": []
+			}},
+			"invocations": [{
+				"executionSuccessful": false,
+				"toolExecutionNotifications": [{"level": "error", "message": {"text": "cycle detected in resource graph"}}]
+			}],
+			"results": []
+		}]
+	}`, buf.String())
+}
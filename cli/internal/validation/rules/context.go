@@ -9,7 +9,9 @@ import (
 // Syntactic rules typically use FilePath, FileName, Spec, Kind, and Metadata fields,
 // while semantic rules additionally use the Graph field for cross-resource validation.
 type ValidationContext struct {
-	// FilePath is the absolute path to the spec file being validated
+	// FilePath is the absolute path to the spec file being validated. For
+	// files holding several YAML documents it carries the document suffix
+	// added by specs.DocumentKey.
 	FilePath string
 
 	// FileName is just the name of the file (without directory path)
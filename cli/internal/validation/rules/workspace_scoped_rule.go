@@ -0,0 +1,17 @@
+package rules
+
+// WorkspaceScopedRule is an optional interface for rules whose verdict depends
+// on the workspace a run targets (e.g. the import-manifest orphaned-urn check,
+// which only considers the active workspace's entries). Rules implementing it
+// and returning true are skipped when validation runs offline without a
+// workspace ID, since there is no workspace to scope them to.
+type WorkspaceScopedRule interface {
+	WorkspaceScoped() bool
+}
+
+// IsWorkspaceScoped reports whether rule implements WorkspaceScopedRule and
+// declares itself workspace-scoped.
+func IsWorkspaceScoped(rule Rule) bool {
+	ws, ok := rule.(WorkspaceScopedRule)
+	return ok && ws.WorkspaceScoped()
+}
@@ -46,6 +46,10 @@ func IsValidVariableName(name string) bool {
 //     `flag: "{{ .FLAG }}"`.
 type Substitutor interface {
 	SubstituteBytes(data []byte) ([]byte, []SubstitutionError)
+	// Resolve looks name up through the resolver chain without substituting
+	// anything. It lets content the substitutor never sees, such as SQL model
+	// parameters, honour the same env vars and variable files as the specs.
+	Resolve(name string) (value string, found bool)
 }
 
 type substitutor struct {
@@ -107,16 +111,7 @@ func (s *substitutor) SubstituteBytes(data []byte) ([]byte, []SubstitutionError)
 			defaultVal = strings.TrimRight(string(data[match[4]:match[5]]), " \t")
 		}
 
-		var (
-			resolved string
-			found    bool
-		)
-		for _, r := range s.resolvers {
-			resolved, found = r.Resolve(varName)
-			if found {
-				break
-			}
-		}
+		resolved, found := s.Resolve(varName)
 
 		if !found {
 			if hasDefault {
@@ -138,6 +133,16 @@ func (s *substitutor) SubstituteBytes(data []byte) ([]byte, []SubstitutionError)
 	return data, errs
 }
 
+// Resolve returns the value of the first resolver in the chain that knows name.
+func (s *substitutor) Resolve(name string) (string, bool) {
+	for _, r := range s.resolvers {
+		if value, found := r.Resolve(name); found {
+			return value, true
+		}
+	}
+	return "", false
+}
+
 func parseVarName(token string) (string, error) {
 	if token[0] != '.' {
 		return token, ErrInvalidVarSyntax
@@ -292,3 +292,21 @@ func TestSubstituteBytes_ErrorPositions(t *testing.T) {
 	}
 }
 
+
+func TestSubstitutorResolve(t *testing.T) {
+	sub := NewSubstitutor(
+		mapResolver{"SCHEMA": "analytics_prod"},
+		mapResolver{"SCHEMA": "analytics", "DAYS": "30"},
+	)
+
+	value, found := sub.Resolve("SCHEMA")
+	assert.True(t, found)
+	assert.Equal(t, "analytics_prod", value)
+
+	value, found = sub.Resolve("DAYS")
+	assert.True(t, found)
+	assert.Equal(t, "30", value)
+
+	_, found = sub.Resolve("MISSING")
+	assert.False(t, found)
+}